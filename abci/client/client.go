@@ -46,6 +46,7 @@ type Client interface {
 	OfferSnapshotAsync(context.Context, types.RequestOfferSnapshot) (*ReqRes, error)
 	LoadSnapshotChunkAsync(context.Context, types.RequestLoadSnapshotChunk) (*ReqRes, error)
 	ApplySnapshotChunkAsync(context.Context, types.RequestApplySnapshotChunk) (*ReqRes, error)
+	FinalizeBlockAsync(context.Context, types.RequestFinalizeBlock) (*ReqRes, error)
 
 	// Synchronous requests
 	FlushSync(context.Context) error
@@ -62,16 +63,20 @@ type Client interface {
 	OfferSnapshotSync(context.Context, types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
 	LoadSnapshotChunkSync(context.Context, types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunkSync(context.Context, types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
+	FinalizeBlockSync(context.Context, types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error)
 }
 
 //----------------------------------------
 
 // NewClient returns a new ABCI client of the specified transport type.
-// It returns an error if the transport is not "socket" or "grpc"
+// It returns an error if the transport is not "socket", "socket-framed" or
+// "grpc"
 func NewClient(addr, transport string, mustConnect bool) (client Client, err error) {
 	switch transport {
 	case "socket":
 		client = NewSocketClient(addr, mustConnect)
+	case "socket-framed":
+		client = NewFramedSocketClient(addr, mustConnect)
 	case "grpc":
 		client = NewGRPCClient(addr, mustConnect)
 	default:
@@ -120,11 +125,13 @@ func (r *ReqRes) SetCallback(cb func(res *types.Response)) {
 }
 
 // InvokeCallback invokes a thread-safe execution of the configured callback
-// if non-nil.
+// if non-nil, and marks the ReqRes object as done, so that a callback set
+// afterwards is invoked by SetCallback.
 func (r *ReqRes) InvokeCallback() {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 
+	r.done = true
 	if r.cb != nil {
 		r.cb(r.Response)
 	}
@@ -149,6 +156,22 @@ func (r *ReqRes) SetDone() {
 	r.mtx.Unlock()
 }
 
+// Complete sets the response, marks the ReqRes object as done, releases
+// waiters and invokes the callback if one is set. It is used by wrappers that
+// hand out their own ReqRes objects, such as a pool of connections.
+func (r *ReqRes) Complete(res *types.Response) {
+	r.mtx.Lock()
+	r.Response = res
+	r.done = true
+	cb := r.cb
+	r.mtx.Unlock()
+
+	r.Done()
+	if cb != nil {
+		cb(res)
+	}
+}
+
 func waitGroup1() (wg *sync.WaitGroup) {
 	wg = &sync.WaitGroup{}
 	wg.Add(1)
@@ -24,8 +24,12 @@ const (
 	reqQueueSize = 256
 	// Don't wait longer than...
 	flushThrottleMS = 20
+	// negotiationTimeout is the max time to negotiate the framed protocol.
+	negotiationTimeout = 10 * time.Second
 )
 
+var errClientNotRunning = errors.New("client is not running")
+
 type reqResWithContext struct {
 	R *ReqRes
 	C context.Context // if context.Err is not nil, reqRes will be thrown away (ignored)
@@ -39,6 +43,10 @@ type socketClient struct {
 	addr        string
 	mustConnect bool
 	conn        net.Conn
+	// tryFramed is set if the client negotiates the framed protocol, and
+	// framed if the server agreed.
+	tryFramed bool
+	framed    bool
 
 	reqQueue   chan *reqResWithContext
 	flushTimer *timer.ThrottleTimer
@@ -47,6 +55,11 @@ type socketClient struct {
 	err     error
 	reqSent *list.List                            // list of requests sent, waiting for response
 	resCb   func(*types.Request, *types.Response) // called on all requests, if set.
+
+	// nextReqID is the ID of the next request sent, and sentByID the requests
+	// sent by ID, when using the framed protocol.
+	nextReqID uint64
+	sentByID  map[uint64]*list.Element
 }
 
 var _ Client = (*socketClient)(nil)
@@ -55,14 +68,30 @@ var _ Client = (*socketClient)(nil)
 // address. If mustConnect is true, the client will return an error upon start
 // if it fails to connect.
 func NewSocketClient(addr string, mustConnect bool) Client {
+	return newSocketClient(addr, mustConnect, false)
+}
+
+// NewFramedSocketClient creates a new socket client like NewSocketClient,
+// which switches to the framed protocol if the server supports it. Requests
+// are then pipelined and written without a flush per request, and the
+// responses are matched to requests by ID, so that the server can handle
+// several CheckTx requests at once. The callbacks are still invoked in the
+// order the requests were sent.
+func NewFramedSocketClient(addr string, mustConnect bool) Client {
+	return newSocketClient(addr, mustConnect, true)
+}
+
+func newSocketClient(addr string, mustConnect, tryFramed bool) *socketClient {
 	cli := &socketClient{
 		reqQueue:    make(chan *reqResWithContext, reqQueueSize),
 		flushTimer:  timer.NewThrottleTimer("socketClient", flushThrottleMS),
 		mustConnect: mustConnect,
+		tryFramed:   tryFramed,
 
-		addr:    addr,
-		reqSent: list.New(),
-		resCb:   nil,
+		addr:     addr,
+		reqSent:  list.New(),
+		resCb:    nil,
+		sentByID: make(map[uint64]*list.Element),
 	}
 	cli.BaseService = *service.NewBaseService(nil, "socketClient", cli)
 	return cli
@@ -87,15 +116,61 @@ func (cli *socketClient) OnStart() error {
 			time.Sleep(time.Second * dialRetryIntervalSeconds)
 			continue
 		}
+		r := bufio.NewReader(conn)
+		if cli.tryFramed {
+			cli.framed, err = negotiateFramed(conn, r)
+			if err != nil {
+				conn.Close()
+				if cli.mustConnect {
+					return err
+				}
+				cli.Logger.Error(fmt.Sprintf("abci.socketClient failed to negotiate the protocol with %v.  Retrying after %vs...",
+					cli.addr, dialRetryIntervalSeconds), "err", err)
+				time.Sleep(time.Second * dialRetryIntervalSeconds)
+				continue
+			}
+			if !cli.framed {
+				cli.Logger.Info("Server doesn't support the framed protocol, using varint-delimited messages")
+			}
+		}
 		cli.conn = conn
 
 		go cli.sendRequestsRoutine(conn)
-		go cli.recvResponseRoutine(conn)
+		go cli.recvResponseRoutine(r)
 
 		return nil
 	}
 }
 
+// negotiateFramed asks the server to switch the connection to the framed
+// protocol, and returns whether it did.
+func negotiateFramed(conn net.Conn, r io.Reader) (bool, error) {
+	if err := conn.SetDeadline(time.Now().Add(negotiationTimeout)); err != nil {
+		return false, err
+	}
+	w := bufio.NewWriter(conn)
+	for _, req := range []*types.Request{types.ToRequestEcho(types.EchoFramedProtocol), types.ToRequestFlush()} {
+		if err := types.WriteMessage(req, w); err != nil {
+			return false, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return false, err
+	}
+
+	echo, flush := &types.Response{}, &types.Response{}
+	if err := types.ReadMessage(r, echo); err != nil {
+		return false, err
+	}
+	if err := types.ReadMessage(r, flush); err != nil {
+		return false, err
+	}
+	if echo.GetEcho() == nil || flush.GetFlush() == nil {
+		return false, fmt.Errorf("unexpected responses %v and %v to the negotiation", echo, flush)
+	}
+	return echo.GetEcho().Message == types.EchoFramedProtocolAck, conn.SetDeadline(time.Time{})
+}
+
 // OnStop implements Service by closing connection and flushing all queues.
 func (cli *socketClient) OnStop() {
 	if cli.conn != nil {
@@ -114,7 +189,8 @@ func (cli *socketClient) Error() error {
 }
 
 // SetResponseCallback sets a callback, which will be executed for each
-// non-error & non-empty response from the server.
+// non-error & non-empty response from the server, and with an exception for
+// each pending request when the client stops.
 //
 // NOTE: callback may get internally generated flush responses.
 func (cli *socketClient) SetResponseCallback(resCb Callback) {
@@ -137,15 +213,25 @@ func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
 				continue
 			}
 
-			cli.willSendReq(reqres.R)
-			err := types.WriteMessage(reqres.R.Request, w)
+			id, ok := cli.willSendReq(reqres.R)
+			if !ok {
+				continue
+			}
+			var err error
+			if cli.framed {
+				err = types.WriteFrame(w, id, reqres.R.Request)
+			} else {
+				err = types.WriteMessage(reqres.R.Request, w)
+			}
 			if err != nil {
 				cli.stopForError(fmt.Errorf("write to buffer: %w", err))
 				return
 			}
 
-			// If it's a flush request, flush the current buffer.
-			if _, ok := reqres.R.Request.Value.(*types.Request_Flush); ok {
+			// If it's a flush request, or with the framed protocol if no other
+			// request is queued, flush the current buffer.
+			_, isFlush := reqres.R.Request.Value.(*types.Request_Flush)
+			if isFlush || (cli.framed && len(cli.reqQueue) == 0) {
 				err = w.Flush()
 				if err != nil {
 					cli.stopForError(fmt.Errorf("flush buffer: %w", err))
@@ -164,11 +250,17 @@ func (cli *socketClient) sendRequestsRoutine(conn io.Writer) {
 	}
 }
 
-func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
-	r := bufio.NewReader(conn)
+func (cli *socketClient) recvResponseRoutine(r io.Reader) {
+	frameReader := types.NewFrameReader(r)
 	for {
 		var res = &types.Response{}
-		err := types.ReadMessage(r, res)
+		var id uint64
+		var err error
+		if cli.framed {
+			id, err = frameReader.ReadFrame(res)
+		} else {
+			err = types.ReadMessage(r, res)
+		}
 		if err != nil {
 			cli.stopForError(fmt.Errorf("read message: %w", err))
 			return
@@ -178,11 +270,10 @@ func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
 
 		switch r := res.Value.(type) {
 		case *types.Response_Exception: // app responded with error
-			// XXX After setting cli.err, release waiters (e.g. reqres.Done())
 			cli.stopForError(errors.New(r.Exception.Error))
 			return
 		default:
-			err := cli.didRecvResponse(res)
+			err := cli.didRecvResponse(id, res)
 			if err != nil {
 				cli.stopForError(err)
 				return
@@ -191,18 +282,37 @@ func (cli *socketClient) recvResponseRoutine(conn io.Reader) {
 	}
 }
 
-func (cli *socketClient) willSendReq(reqres *ReqRes) {
+// willSendReq records reqres as sent, and returns its request ID. It returns
+// false if the client is stopped, in which case reqres is resolved with an
+// exception.
+func (cli *socketClient) willSendReq(reqres *ReqRes) (uint64, bool) {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
-	cli.reqSent.PushBack(reqres)
+	if !cli.IsRunning() {
+		cli.resolveWithException(reqres)
+		return 0, false
+	}
+	id := cli.nextReqID
+	cli.nextReqID++
+	elem := cli.reqSent.PushBack(reqres)
+	if cli.framed {
+		cli.sentByID[id] = elem
+	}
+	return id, true
 }
 
-func (cli *socketClient) didRecvResponse(res *types.Response) error {
+func (cli *socketClient) didRecvResponse(id uint64, res *types.Response) error {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
 
-	// Get the first ReqRes.
-	next := cli.reqSent.Front()
+	// Get the ReqRes with the given ID, or else the first one.
+	var next *list.Element
+	if cli.framed {
+		next = cli.sentByID[id]
+		delete(cli.sentByID, id)
+	} else {
+		next = cli.reqSent.Front()
+	}
 	if next == nil {
 		return fmt.Errorf("unexpected %v when nothing expected", reflect.TypeOf(res.Value))
 	}
@@ -212,23 +322,36 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 		return fmt.Errorf("unexpected %v when response to %v expected",
 			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))
 	}
-
 	reqres.Response = res
-	reqres.Done()            // release waiters
-	cli.reqSent.Remove(next) // pop first item from linked list
+
+	// Deliver the responses received in the order the requests were sent.
+	for next = cli.reqSent.Front(); next != nil; next = cli.reqSent.Front() {
+		reqres := next.Value.(*ReqRes)
+		if reqres.Response == nil {
+			break
+		}
+		cli.reqSent.Remove(next)
+		cli.deliver(reqres)
+	}
+	return nil
+}
+
+// deliver releases the waiters of reqres, and invokes the callbacks. The
+// caller must hold cli.mtx.
+func (cli *socketClient) deliver(reqres *ReqRes) {
+	reqres.Done() // release waiters
 
 	// Notify client listener if set (global callback).
 	if cli.resCb != nil {
-		cli.resCb(reqres.Request, res)
+		cli.resCb(reqres.Request, reqres.Response)
 	}
 
 	// Notify reqRes listener if set (request specific callback).
 	//
 	// NOTE: It is possible this callback isn't set on the reqres object. At this
-	// point, in which case it will be called after, when it is set.
+	// point, in which case it will be called after, when it is set, since
+	// InvokeCallback marks it done.
 	reqres.InvokeCallback()
-
-	return nil
 }
 
 //----------------------------------------
@@ -295,6 +418,10 @@ func (cli *socketClient) ApplySnapshotChunkAsync(
 	return cli.queueRequestAsync(ctx, types.ToRequestApplySnapshotChunk(req))
 }
 
+func (cli *socketClient) FinalizeBlockAsync(ctx context.Context, req types.RequestFinalizeBlock) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestFinalizeBlock(req))
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync(ctx context.Context) error {
@@ -465,6 +592,17 @@ func (cli *socketClient) ApplySnapshotChunkSync(
 	return reqres.Response.GetApplySnapshotChunk(), nil
 }
 
+func (cli *socketClient) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
+
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestFinalizeBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetFinalizeBlock(), nil
+}
+
 //----------------------------------------
 
 // queueRequest enqueues req onto the queue. If the queue is full, it ether
@@ -474,8 +612,14 @@ func (cli *socketClient) ApplySnapshotChunkSync(
 // used later to determine if request should be dropped (if ctx.Err is
 // non-nil).
 //
+// It returns an error if the client is not running.
+//
 // The caller is responsible for checking cli.Error.
 func (cli *socketClient) queueRequest(ctx context.Context, req *types.Request, sync bool) (*ReqRes, error) {
+	if !cli.IsRunning() {
+		return nil, errClientNotRunning
+	}
+
 	reqres := NewReqRes(req)
 
 	if sync {
@@ -492,6 +636,11 @@ func (cli *socketClient) queueRequest(ctx context.Context, req *types.Request, s
 		}
 	}
 
+	// The client may have been stopped and its queue flushed in the meantime.
+	if !cli.IsRunning() {
+		cli.flushQueue()
+	}
+
 	// Maybe auto-flush, or unset auto-flush
 	switch req.Value.(type) {
 	case *types.Request_Flush:
@@ -526,7 +675,20 @@ func (cli *socketClient) queueRequestAndFlushSync(
 		return nil, queueErr(err)
 	}
 
-	if err := cli.FlushSync(ctx); err != nil {
+	// With the framed protocol, requests are written without waiting for a
+	// flush.
+	if cli.framed {
+		gotResp := make(chan struct{})
+		go func() {
+			reqres.Wait()
+			close(gotResp)
+		}()
+		select {
+		case <-gotResp:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else if err := cli.FlushSync(ctx); err != nil {
 		return nil, err
 	}
 
@@ -537,28 +699,49 @@ func queueErr(e error) error {
 	return fmt.Errorf("can't queue req: %w", e)
 }
 
+// flushQueue resolves all the in-flight and queued requests with an exception,
+// invoking their callbacks, so that no caller waits for a response that will
+// never arrive.
 func (cli *socketClient) flushQueue() {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
 
-	// mark all in-flight messages as resolved (they will get cli.Error())
+	// resolve all in-flight messages
 	for req := cli.reqSent.Front(); req != nil; req = req.Next() {
-		reqres := req.Value.(*ReqRes)
-		reqres.Done()
+		cli.resolveWithException(req.Value.(*ReqRes))
 	}
+	cli.reqSent.Init()
+	cli.sentByID = make(map[uint64]*list.Element)
 
-	// mark all queued messages as resolved
+	// resolve all queued messages
 LOOP:
 	for {
 		select {
 		case reqres := <-cli.reqQueue:
-			reqres.R.Done()
+			cli.resolveWithException(reqres.R)
 		default:
 			break LOOP
 		}
 	}
 }
 
+// resolveWithException sets an exception with the client error as the
+// response to reqres, releases waiters and invokes the callbacks. The caller
+// must hold cli.mtx.
+func (cli *socketClient) resolveWithException(reqres *ReqRes) {
+	err := cli.err
+	if err == nil {
+		err = errClientNotRunning
+	}
+	reqres.Response = types.ToResponseException(err.Error())
+	reqres.Done() // release waiters
+
+	if cli.resCb != nil {
+		cli.resCb(reqres.Request, reqres.Response)
+	}
+	reqres.InvokeCallback()
+}
+
 //----------------------------------------
 
 func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
@@ -591,6 +774,8 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_ListSnapshots)
 	case *types.Request_OfferSnapshot:
 		_, ok = res.Value.(*types.Response_OfferSnapshot)
+	case *types.Request_FinalizeBlock:
+		_, ok = res.Value.(*types.Response_FinalizeBlock)
 	}
 	return ok
 }
@@ -1,8 +1,11 @@
 package abcicli_test
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -98,6 +101,166 @@ func TestHangingSyncCalls(t *testing.T) {
 	}
 }
 
+func TestCallbacksOnConnectionLoss(t *testing.T) {
+	app := slowApp{}
+
+	s, c := setupClientServer(t, app)
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	globalRes := make(chan *types.Response, 1)
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if req.GetBeginBlock() != nil {
+			globalRes <- res
+		}
+	})
+	reqres, err := c.BeginBlockAsync(ctx, types.RequestBeginBlock{})
+	require.NoError(t, err)
+	reqRes := make(chan *types.Response, 1)
+	reqres.SetCallback(func(res *types.Response) {
+		reqRes <- res
+	})
+	_, err = c.FlushAsync(ctx)
+	require.NoError(t, err)
+
+	// wait for the request to travel the socket, then kill the server
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, s.Stop())
+
+	for _, ch := range []chan *types.Response{globalRes, reqRes} {
+		select {
+		case res := <-ch:
+			assert.NotNil(t, res.GetException())
+		case <-time.After(time.Second):
+			require.Fail(t, "callback wasn't called")
+		}
+	}
+
+	// the client is stopped, so new requests fail right away
+	require.Eventually(t, func() bool { return !c.IsRunning() }, time.Second, 10*time.Millisecond)
+	_, err = c.EchoSync(ctx, "hello")
+	assert.Error(t, err)
+}
+
+// concurrentApp checks txs concurrently, taking as many milliseconds as the
+// first byte of the tx.
+type concurrentApp struct {
+	types.BaseApplication
+}
+
+func (concurrentApp) ConcurrentCheckTx() bool { return true }
+
+func (concurrentApp) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	time.Sleep(time.Duration(req.Tx[0]) * time.Millisecond)
+	return types.ResponseCheckTx{Data: req.Tx}
+}
+
+func TestFramedProtocol(t *testing.T) {
+	addr := fmt.Sprintf("unix:///tmp/abci-framed-%s.sock", tmrand.Str(6))
+	s, err := server.NewServer(addr, "socket", concurrentApp{})
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	c, err := abcicli.NewClient(addr, "socket-framed", true)
+	require.NoError(t, err)
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var delivered [][]byte
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if res.GetCheckTx() != nil {
+			delivered = append(delivered, res.GetCheckTx().Data)
+		}
+	})
+
+	// the txs are checked concurrently, the slowest first
+	const n = 20
+	var sent [][]byte
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		tx := []byte{byte(200 - i*10), byte(i)}
+		sent = append(sent, tx)
+		_, err := c.CheckTxAsync(ctx, types.RequestCheckTx{Tx: tx})
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.FlushSync(ctx))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	// but delivered in order
+	assert.Equal(t, sent, delivered)
+
+	res, err := c.CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, res.Data)
+	echo, err := c.EchoSync(ctx, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", echo.Message)
+}
+
+// TestFramedProtocolFallback checks that a framed client keeps using
+// varint-delimited messages with a server which doesn't support frames.
+func TestFramedProtocolFallback(t *testing.T) {
+	addr := fmt.Sprintf("unix:///tmp/abci-legacy-%s.sock", tmrand.Str(6))
+	ln, err := net.Listen("unix", strings.TrimPrefix(addr, "unix://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+		for {
+			req := &types.Request{}
+			if err := types.ReadMessage(r, req); err != nil {
+				return
+			}
+			var res *types.Response
+			switch r := req.Value.(type) {
+			case *types.Request_Echo:
+				res = types.ToResponseEcho(r.Echo.Message)
+			case *types.Request_Flush:
+				res = types.ToResponseFlush()
+			case *types.Request_Info:
+				res = types.ToResponseInfo(types.ResponseInfo{Data: "legacy"})
+			default:
+				res = types.ToResponseException("unknown request")
+			}
+			if err := types.WriteMessage(res, w); err != nil {
+				return
+			}
+			if res.GetFlush() != nil {
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	c := abcicli.NewFramedSocketClient(addr, true)
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	res, err := c.InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", res.Data)
+}
+
 func setupClientServer(t *testing.T, app types.Application) (
 	service.Service, abcicli.Client) {
 	// some port between 20k and 30k
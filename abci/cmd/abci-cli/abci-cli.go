@@ -12,18 +12,20 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/tendermint/tendermint/libs/log"
-	tmos "github.com/tendermint/tendermint/libs/os"
-
-	abcicli "github.com/tendermint/tendermint/abci/client"
-	"github.com/tendermint/tendermint/abci/example/code"
-	"github.com/tendermint/tendermint/abci/example/counter"
-	"github.com/tendermint/tendermint/abci/example/kvstore"
-	"github.com/tendermint/tendermint/abci/server"
-	servertest "github.com/tendermint/tendermint/abci/tests/server"
-	"github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/abci/version"
-	"github.com/tendermint/tendermint/proto/tendermint/crypto"
+	"github.com/klyed/tendermint/libs/log"
+	tmos "github.com/klyed/tendermint/libs/os"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/example/code"
+	"github.com/klyed/tendermint/abci/example/counter"
+	"github.com/klyed/tendermint/abci/example/kvstore"
+	"github.com/klyed/tendermint/abci/server"
+	"github.com/klyed/tendermint/abci/tests/conformance"
+	servertest "github.com/klyed/tendermint/abci/tests/server"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/abci/version"
+	"github.com/klyed/tendermint/proto/tendermint/crypto"
+	"github.com/klyed/tendermint/proxy"
 )
 
 // client is a global variable so it can be reused by the console
@@ -52,6 +54,11 @@ var (
 
 	// kvstore
 	flagPersist string
+
+	// conformance
+	flagReplicaAddress    string
+	flagBlocks            int64
+	flagStrictQueryHeight bool
 )
 
 var RootCmd = &cobra.Command{
@@ -146,6 +153,20 @@ func addKVStoreFlags() {
 	kvstoreCmd.PersistentFlags().StringVarP(&flagPersist, "persist", "", "", "directory to use for a database")
 }
 
+func addConformanceFlags() {
+	conformanceCmd.PersistentFlags().StringVarP(&flagReplicaAddress,
+		"replica-address",
+		"",
+		"",
+		"address of a replica of the application, to restore its snapshots and check it's deterministic")
+	conformanceCmd.PersistentFlags().Int64VarP(&flagBlocks, "blocks", "", 5, "number of blocks to execute")
+	conformanceCmd.PersistentFlags().BoolVarP(&flagStrictQueryHeight,
+		"strict-query-height",
+		"",
+		false,
+		"require queries at past heights to be answered at that height, instead of the latest one, or to fail")
+}
+
 func addCommands() {
 	RootCmd.AddCommand(batchCmd)
 	RootCmd.AddCommand(consoleCmd)
@@ -156,6 +177,9 @@ func addCommands() {
 	RootCmd.AddCommand(commitCmd)
 	RootCmd.AddCommand(versionCmd)
 	RootCmd.AddCommand(testCmd)
+	RootCmd.AddCommand(replayCmd)
+	addConformanceFlags()
+	RootCmd.AddCommand(conformanceCmd)
 	addQueryFlags()
 	RootCmd.AddCommand(queryCmd)
 
@@ -285,6 +309,26 @@ var testCmd = &cobra.Command{
 	RunE:  cmdTest,
 }
 
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "replay a recording of ABCI calls against an application",
+	Long: "replay the ABCI calls recorded by a node (see abci-record-file) against an application, " +
+		"and report the first response which differs from the recorded one. The application must be " +
+		"in the same state as when the recording started.",
+	Args: cobra.ExactArgs(1),
+	RunE: cmdReplay,
+}
+
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "run the ABCI conformance scenarios against an application",
+	Long: "run scripted scenarios against a fresh instance of an application (InitChain, block execution, " +
+		"Query at each height, snapshots), and report the violations of the ABCI contract. If a replica " +
+		"is given, the snapshots are restored on it and it must compute the same results.",
+	Args: cobra.ExactArgs(0),
+	RunE: cmdConformance,
+}
+
 // Generates new Args array based off of previous call args to maintain flag persistence
 func persistentArgs(line []byte) []string {
 
@@ -335,6 +379,60 @@ func cmdTest(cmd *cobra.Command, args []string) error {
 		})
 }
 
+func cmdReplay(cmd *cobra.Command, args []string) error {
+	rd, err := proxy.OpenRecording(args[0])
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+
+	n, divergence, err := proxy.ReplayRecording(ctx, rd, proxy.NewRemoteClientCreator(flagAddress, flagAbci, true))
+	if err != nil {
+		return err
+	}
+	if divergence != nil {
+		fmt.Println(divergence)
+		return fmt.Errorf("response diverged after replaying %d calls", n)
+	}
+	fmt.Printf("replayed %d calls, no divergence\n", n)
+	return nil
+}
+
+func cmdConformance(cmd *cobra.Command, args []string) error {
+	var replica abcicli.Client
+	if flagReplicaAddress != "" {
+		var err error
+		replica, err = abcicli.NewClient(flagReplicaAddress, flagAbci, true)
+		if err != nil {
+			return err
+		}
+		replica.SetLogger(logger.With("module", "abci-replica"))
+		if err := replica.Start(); err != nil {
+			return err
+		}
+		defer func() {
+			if err := replica.Stop(); err != nil {
+				logger.Error("error stopping the replica client", "err", err)
+			}
+		}()
+	}
+
+	cfg := conformance.DefaultConfig()
+	cfg.Blocks = flagBlocks
+	cfg.StrictQueryHeight = flagStrictQueryHeight
+	report, err := conformance.Run(ctx, cfg, client, replica)
+	if report != nil {
+		fmt.Print(report)
+	}
+	if err != nil {
+		return err
+	}
+	if n := report.Violations(); n > 0 {
+		return fmt.Errorf("found %d violations of the ABCI contract", n)
+	}
+	return nil
+}
+
 func cmdBatch(cmd *cobra.Command, args []string) error {
 	bufReader := bufio.NewReader(os.Stdin)
 LOOP:
@@ -64,6 +64,10 @@ func (app *Application) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 	return types.ResponseCheckTx{Code: code.CodeTypeOK}
 }
 
+func (app *Application) FinalizeBlock(req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	return types.ExecFinalizeBlock(app, req)
+}
+
 func (app *Application) Commit() (resp types.ResponseCommit) {
 	app.hashCount++
 	if app.txCount == 0 {
@@ -323,6 +323,29 @@ func runClientTests(t *testing.T, client abcicli.Client) {
 	value = testValue
 	tx = []byte(key + "=" + value)
 	testClient(t, client, tx, key, value)
+
+	testFinalizeBlock(t, client)
+}
+
+func testFinalizeBlock(t *testing.T, app abcicli.Client) {
+	res, err := app.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{
+		Txs:      [][]byte{[]byte("foo=1"), []byte("bar=2")},
+		EndBlock: types.RequestEndBlock{Height: 1},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.BeginBlock)
+	require.NotNil(t, res.EndBlock)
+	require.Len(t, res.DeliverTxs, 2)
+	for _, dtx := range res.DeliverTxs {
+		require.False(t, dtx.IsErr(), dtx)
+	}
+	_, err = app.CommitSync(ctx)
+	require.NoError(t, err)
+
+	resQuery, err := app.QuerySync(ctx, types.RequestQuery{Path: "/store", Data: []byte("bar")})
+	require.NoError(t, err)
+	require.Equal(t, code.CodeTypeOK, resQuery.Code)
+	require.Equal(t, "2", string(resQuery.Value))
 }
 
 func testClient(t *testing.T, app abcicli.Client, tx []byte, key, value string) {
@@ -146,6 +146,10 @@ func (app *PersistentKVStoreApplication) EndBlock(req types.RequestEndBlock) typ
 	return types.ResponseEndBlock{ValidatorUpdates: app.ValUpdates}
 }
 
+func (app *PersistentKVStoreApplication) FinalizeBlock(req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	return types.ExecFinalizeBlock(app, req)
+}
+
 func (app *PersistentKVStoreApplication) ListSnapshots(
 	req types.RequestListSnapshots) types.ResponseListSnapshots {
 	return types.ResponseListSnapshots{}
@@ -19,7 +19,8 @@ func NewServer(protoAddr, transport string, app types.Application) (service.Serv
 	var s service.Service
 	var err error
 	switch transport {
-	case "socket":
+	case "socket", "socket-framed":
+		// the socket server supports both protocols
 		s = NewSocketServer(protoAddr, app)
 	case "grpc":
 		s = NewGRPCServer(protoAddr, types.NewGRPCApplication(app))
@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"sync"
 
 	"github.com/klyed/tendermint/abci/types"
 	tmlog "github.com/klyed/tendermint/libs/log"
@@ -17,6 +18,19 @@ import (
 
 // var maxNumberConnections = 2
 
+// maxConcurrentRequests is the max number of requests handled concurrently on
+// a connection using the framed protocol.
+const maxConcurrentRequests = 64
+
+// response is a response to write, with the ID of its request if the
+// connection uses the framed protocol.
+type response struct {
+	id  uint64
+	res *types.Response
+	// framed is set on the last response before the responses are framed.
+	framed bool
+}
+
 type SocketServer struct {
 	service.BaseService
 	isLoggerSet bool
@@ -31,6 +45,10 @@ type SocketServer struct {
 
 	appMtx tmsync.Mutex
 	app    types.Application
+
+	// concurrentCheckTx is set if the app handles CheckTx concurrently, in
+	// which case CheckTx requests aren't serialized by appMtx.
+	concurrentCheckTx bool
 }
 
 func NewSocketServer(protoAddr string, app types.Application) service.Service {
@@ -42,6 +60,9 @@ func NewSocketServer(protoAddr string, app types.Application) service.Service {
 		app:      app,
 		conns:    make(map[int]net.Conn),
 	}
+	if app, ok := app.(types.ConcurrentCheckTxApplication); ok {
+		s.concurrentCheckTx = app.ConcurrentCheckTx()
+	}
 	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
 	return s
 }
@@ -120,8 +141,8 @@ func (s *SocketServer) acceptConnectionsRoutine() {
 
 		connID := s.addConn(conn)
 
-		closeConn := make(chan error, 2)              // Push to signal connection closed
-		responses := make(chan *types.Response, 1000) // A channel to buffer responses
+		closeConn := make(chan error, 2)       // Push to signal connection closed
+		responses := make(chan response, 1000) // A channel to buffer responses
 
 		// Read requests from conn and deal with them
 		go s.handleRequests(closeConn, conn, responses)
@@ -152,30 +173,43 @@ func (s *SocketServer) waitForClose(closeConn chan error, connID int) {
 }
 
 // Read requests from conn and deal with them
-func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, responses chan<- *types.Response) {
+func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, responses chan<- response) {
 	var count int
 	var bufReader = bufio.NewReader(conn)
+	var locked bool
+
+	// the connection switches to the framed protocol after the Flush
+	// following the negotiation Echo
+	var negotiated, framed bool
+	var frameReader *types.FrameReader
+	// CheckTx requests handled concurrently on a framed connection, which are
+	// waited for before handling any other request.
+	var inFlight sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentRequests)
 
 	defer func() {
 		// make sure to recover from any app-related panics to allow proper socket cleanup
-		r := recover()
-		if r != nil {
-			const size = 64 << 10
-			buf := make([]byte, size)
-			buf = buf[:runtime.Stack(buf, false)]
-			err := fmt.Errorf("recovered from panic: %v\n%s", r, buf)
+		if err := recoverPanic(recover()); err != nil {
 			if !s.isLoggerSet {
 				fmt.Fprintln(os.Stderr, err)
 			}
 			closeConn <- err
-			s.appMtx.Unlock()
+			if locked {
+				s.appMtx.Unlock()
+			}
 		}
 	}()
 
 	for {
 
 		var req = &types.Request{}
-		err := types.ReadMessage(bufReader, req)
+		var id uint64
+		var err error
+		if framed {
+			id, err = frameReader.ReadFrame(req)
+		} else {
+			err = types.ReadMessage(bufReader, req)
+		}
 		if err != nil {
 			if err == io.EOF {
 				closeConn <- err
@@ -184,72 +218,146 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 			}
 			return
 		}
-		s.appMtx.Lock()
 		count++
-		s.handleRequest(req, responses)
+
+		switch r := req.Value.(type) {
+		case *types.Request_Echo:
+			if !framed && r.Echo.Message == types.EchoFramedProtocol {
+				negotiated = true
+				responses <- response{res: types.ToResponseEcho(types.EchoFramedProtocolAck)}
+				continue
+			}
+		case *types.Request_Flush:
+			if negotiated && !framed {
+				framed = true
+				frameReader = types.NewFrameReader(bufReader)
+				responses <- response{res: types.ToResponseFlush(), framed: true}
+				continue
+			}
+		case *types.Request_CheckTx:
+			if !s.concurrentCheckTx {
+				break
+			}
+			if framed {
+				// pipelined: the response is written when ready, possibly
+				// before the responses to earlier requests.
+				sem <- struct{}{}
+				inFlight.Add(1)
+				go func(id uint64, req *types.Request) {
+					defer func() {
+						if err := recoverPanic(recover()); err != nil {
+							select {
+							case closeConn <- err:
+							default: // the connection is already closing
+							}
+						}
+						inFlight.Done()
+						<-sem
+					}()
+					responses <- response{id: id, res: s.handleRequest(req)}
+				}(id, req)
+				continue
+			}
+			// requests on a connection are still handled in order, so
+			// concurrency comes from the node using several connections.
+			responses <- response{id: id, res: s.handleRequest(req)}
+			continue
+		}
+
+		inFlight.Wait()
+		s.appMtx.Lock()
+		locked = true
+		res := s.handleRequest(req)
 		s.appMtx.Unlock()
+		locked = false
+		responses <- response{id: id, res: res}
 	}
 }
 
-func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types.Response) {
+// recoverPanic returns an error with the stack trace if r, recovered from a
+// panic, isn't nil.
+func recoverPanic(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+	const size = 64 << 10
+	buf := make([]byte, size)
+	buf = buf[:runtime.Stack(buf, false)]
+	return fmt.Errorf("recovered from panic: %v\n%s", r, buf)
+}
+
+func (s *SocketServer) handleRequest(req *types.Request) *types.Response {
 	switch r := req.Value.(type) {
 	case *types.Request_Echo:
-		responses <- types.ToResponseEcho(r.Echo.Message)
+		return types.ToResponseEcho(r.Echo.Message)
 	case *types.Request_Flush:
-		responses <- types.ToResponseFlush()
+		return types.ToResponseFlush()
 	case *types.Request_Info:
 		res := s.app.Info(*r.Info)
-		responses <- types.ToResponseInfo(res)
+		return types.ToResponseInfo(res)
 	case *types.Request_DeliverTx:
 		res := s.app.DeliverTx(*r.DeliverTx)
-		responses <- types.ToResponseDeliverTx(res)
+		return types.ToResponseDeliverTx(res)
 	case *types.Request_CheckTx:
 		res := s.app.CheckTx(*r.CheckTx)
-		responses <- types.ToResponseCheckTx(res)
+		return types.ToResponseCheckTx(res)
 	case *types.Request_Commit:
 		res := s.app.Commit()
-		responses <- types.ToResponseCommit(res)
+		return types.ToResponseCommit(res)
 	case *types.Request_Query:
 		res := s.app.Query(*r.Query)
-		responses <- types.ToResponseQuery(res)
+		return types.ToResponseQuery(res)
 	case *types.Request_InitChain:
 		res := s.app.InitChain(*r.InitChain)
-		responses <- types.ToResponseInitChain(res)
+		return types.ToResponseInitChain(res)
 	case *types.Request_BeginBlock:
 		res := s.app.BeginBlock(*r.BeginBlock)
-		responses <- types.ToResponseBeginBlock(res)
+		return types.ToResponseBeginBlock(res)
 	case *types.Request_EndBlock:
 		res := s.app.EndBlock(*r.EndBlock)
-		responses <- types.ToResponseEndBlock(res)
+		return types.ToResponseEndBlock(res)
 	case *types.Request_ListSnapshots:
 		res := s.app.ListSnapshots(*r.ListSnapshots)
-		responses <- types.ToResponseListSnapshots(res)
+		return types.ToResponseListSnapshots(res)
 	case *types.Request_OfferSnapshot:
 		res := s.app.OfferSnapshot(*r.OfferSnapshot)
-		responses <- types.ToResponseOfferSnapshot(res)
+		return types.ToResponseOfferSnapshot(res)
 	case *types.Request_LoadSnapshotChunk:
 		res := s.app.LoadSnapshotChunk(*r.LoadSnapshotChunk)
-		responses <- types.ToResponseLoadSnapshotChunk(res)
+		return types.ToResponseLoadSnapshotChunk(res)
 	case *types.Request_ApplySnapshotChunk:
 		res := s.app.ApplySnapshotChunk(*r.ApplySnapshotChunk)
-		responses <- types.ToResponseApplySnapshotChunk(res)
+		return types.ToResponseApplySnapshotChunk(res)
+	case *types.Request_FinalizeBlock:
+		res := s.app.FinalizeBlock(*r.FinalizeBlock)
+		return types.ToResponseFinalizeBlock(res)
 	default:
-		responses <- types.ToResponseException("Unknown request")
+		return types.ToResponseException("Unknown request")
 	}
 }
 
 // Pull responses from 'responses' and write them to conn.
-func (s *SocketServer) handleResponses(closeConn chan error, conn io.Writer, responses <-chan *types.Response) {
+func (s *SocketServer) handleResponses(closeConn chan error, conn io.Writer, responses <-chan response) {
 	var count int
 	var bufWriter = bufio.NewWriter(conn)
+	var framed bool
 	for {
 		var res = <-responses
-		err := types.WriteMessage(res, bufWriter)
+		var err error
+		if framed {
+			err = types.WriteFrame(bufWriter, res.id, res.res)
+		} else {
+			err = types.WriteMessage(res.res, bufWriter)
+		}
 		if err != nil {
 			closeConn <- fmt.Errorf("error writing message: %w", err)
 			return
 		}
-		if _, ok := res.Value.(*types.Response_Flush); ok {
+		framed = framed || res.framed
+
+		// framed responses are flushed as soon as no other response is ready
+		_, isFlush := res.res.Value.(*types.Response_Flush)
+		if isFlush || (framed && len(responses) == 0) {
 			err = bufWriter.Flush()
 			if err != nil {
 				closeConn <- fmt.Errorf("error flushing write buffer: %w", err)
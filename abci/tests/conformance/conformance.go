@@ -0,0 +1,200 @@
+// Package conformance runs a scripted set of scenarios against an ABCI
+// application, over any client, and reports violations of the ABCI contract.
+//
+// The scenarios initialize the chain, execute blocks and check that the app
+// hashes returned by Commit are reported by Info, query the application at
+// each height, load its latest snapshot and, if a replica of the application
+// is given, restore the snapshot on the replica and check that both compute
+// the same results for the same blocks.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/crypto/ed25519"
+	cryptoenc "github.com/klyed/tendermint/crypto/encoding"
+	tmtypes "github.com/klyed/tendermint/types"
+)
+
+// ErrNotFresh is returned if an application already executed blocks.
+var ErrNotFresh = errors.New("the application is not fresh")
+
+// Config is the configuration of the conformance scenarios.
+type Config struct {
+	// ChainID and InitialHeight are passed to InitChain.
+	ChainID       string
+	InitialHeight int64
+	// AppStateBytes is the genesis app state passed to InitChain.
+	AppStateBytes []byte
+	// Validators is the number of genesis validators.
+	Validators int
+
+	// Blocks is the number of blocks executed, with TxsPerBlock txs each.
+	Blocks      int64
+	TxsPerBlock int
+	// Tx returns the i-th tx of the block at height.
+	Tx func(height int64, i int) []byte
+
+	// QueryPath and QueryData are used to query the application at each
+	// height.
+	QueryPath string
+	QueryData []byte
+	// If StrictQueryHeight is true, queries at a given height must be answered
+	// at that height, or fail. Otherwise they may also be answered at the
+	// latest height, like the kvstore example application does.
+	StrictQueryHeight bool
+}
+
+// DefaultConfig returns a default configuration, whose txs are "key=value"
+// pairs, as understood by the kvstore example application.
+func DefaultConfig() Config {
+	return Config{
+		ChainID:       "conformance",
+		InitialHeight: 1,
+		Validators:    4,
+		Blocks:        5,
+		TxsPerBlock:   3,
+		Tx: func(height int64, i int) []byte {
+			return []byte(fmt.Sprintf("conformance-%d-%d=%d", height, i, height))
+		},
+		QueryPath: "/store",
+		QueryData: []byte("conformance-1-0"),
+	}
+}
+
+// Scenario is the result of a conformance scenario.
+type Scenario struct {
+	Name string
+	// Skipped is the reason why the scenario was skipped, if it was.
+	Skipped string
+	// Violations are the breaches of the ABCI contract found by the scenario.
+	Violations []string
+}
+
+func (sc *Scenario) violation(format string, args ...interface{}) {
+	sc.Violations = append(sc.Violations, fmt.Sprintf(format, args...))
+}
+
+// Report is the result of a conformance run.
+type Report struct {
+	Scenarios []*Scenario
+}
+
+// Violations returns the number of violations found by all the scenarios.
+func (r *Report) Violations() int {
+	n := 0
+	for _, sc := range r.Scenarios {
+		n += len(sc.Violations)
+	}
+	return n
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, sc := range r.Scenarios {
+		switch {
+		case sc.Skipped != "":
+			fmt.Fprintf(&sb, "SKIP %s: %s\n", sc.Name, sc.Skipped)
+		case len(sc.Violations) > 0:
+			fmt.Fprintf(&sb, "FAIL %s\n", sc.Name)
+			for _, v := range sc.Violations {
+				fmt.Fprintf(&sb, "  - %s\n", v)
+			}
+		default:
+			fmt.Fprintf(&sb, "PASS %s\n", sc.Name)
+		}
+	}
+	return sb.String()
+}
+
+// Run runs the conformance scenarios against a fresh instance of an
+// application, connected to client. If replica isn't nil, it must be connected
+// to another fresh instance of the application, which is used to restore the
+// snapshots of the first one and to check that both compute the same results.
+//
+// The violations of the ABCI contract are listed in the report. An error is
+// returned, along with the report of the scenarios run so far, if a request
+// fails or if an application isn't fresh.
+func Run(ctx context.Context, cfg Config, client, replica abcicli.Client) (*Report, error) {
+	s := newSuite(cfg, client, replica)
+	scenarios := []struct {
+		name string
+		run  func(context.Context, *Scenario) error
+	}{
+		{"info", s.info},
+		{"init_chain", s.initChain},
+		{"execute_blocks", s.executeBlocks},
+		{"query", s.query},
+		{"snapshot_load", s.snapshotLoad},
+		{"snapshot_restore", s.snapshotRestore},
+		{"determinism", s.determinism},
+	}
+
+	report := &Report{}
+	for _, scenario := range scenarios {
+		sc := &Scenario{Name: scenario.name}
+		report.Scenarios = append(report.Scenarios, sc)
+		if err := scenario.run(ctx, sc); err != nil {
+			return report, fmt.Errorf("%s: %w", scenario.name, err)
+		}
+	}
+	return report, nil
+}
+
+// genesisTime is the genesis time passed to InitChain. Blocks are one second
+// apart.
+var genesisTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newSuite(cfg Config, client, replica abcicli.Client) *suite {
+	vals := make([]types.ValidatorUpdate, cfg.Validators)
+	votes := make([]types.VoteInfo, cfg.Validators)
+	for i := range vals {
+		pubKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("validator-%d", i))).PubKey()
+		vals[i] = types.Ed25519ValidatorUpdate(pubKey.Bytes(), 10)
+		votes[i] = types.VoteInfo{
+			Validator:       types.Validator{Address: pubKey.Address(), Power: 10},
+			SignedLastBlock: true,
+		}
+	}
+	params := tmtypes.DefaultConsensusParams().ToProto()
+
+	return &suite{
+		cfg:     cfg,
+		client:  client,
+		replica: replica,
+		initChainReq: types.RequestInitChain{
+			Time:            genesisTime,
+			ChainId:         cfg.ChainID,
+			ConsensusParams: &params,
+			Validators:      vals,
+			AppStateBytes:   cfg.AppStateBytes,
+			InitialHeight:   cfg.InitialHeight,
+		},
+		votes: votes,
+	}
+}
+
+// checkValidatorUpdates checks the validator updates returned by method.
+func checkValidatorUpdates(sc *Scenario, method string, updates []types.ValidatorUpdate) {
+	seen := make(map[string]bool, len(updates))
+	for _, update := range updates {
+		pubKey, err := cryptoenc.PubKeyFromProto(update.PubKey)
+		if err != nil {
+			sc.violation("%s returned a validator update with an invalid public key: %v", method, err)
+			continue
+		}
+		if update.Power < 0 {
+			sc.violation("%s returned a negative power %d for validator %X", method, update.Power, pubKey.Address())
+		}
+		if seen[string(pubKey.Address())] {
+			sc.violation("%s returned several updates for validator %X", method, pubKey.Address())
+		}
+		seen[string(pubKey.Address())] = true
+	}
+}
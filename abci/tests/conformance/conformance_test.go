@@ -0,0 +1,283 @@
+package conformance_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/example/code"
+	"github.com/klyed/tendermint/abci/example/kvstore"
+	abciserver "github.com/klyed/tendermint/abci/server"
+	"github.com/klyed/tendermint/abci/tests/conformance"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/libs/log"
+	tmrand "github.com/klyed/tendermint/libs/rand"
+)
+
+// chunkSize is the size of the snapshot chunks of snapshotApp.
+const chunkSize = 64
+
+// snapshotApp is a key/value store which takes a snapshot at every height.
+type snapshotApp struct {
+	types.BaseApplication
+
+	Height int64
+	Store  map[string]string
+	hash   []byte
+
+	snapshots map[uint64][]byte
+	restoring *types.Snapshot
+	chunks    [][]byte
+
+	// nondeterministic makes the app hash depend on the instance.
+	nondeterministic []byte
+	noSnapshots      bool
+}
+
+func newSnapshotApp() *snapshotApp {
+	return &snapshotApp{Store: make(map[string]string), snapshots: make(map[uint64][]byte)}
+}
+
+func (app *snapshotApp) Info(req types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{LastBlockHeight: app.Height, LastBlockAppHash: app.hash}
+}
+
+func (app *snapshotApp) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	parts := strings.SplitN(string(req.Tx), "=", 2)
+	if len(parts) != 2 {
+		return types.ResponseDeliverTx{Code: code.CodeTypeEncodingError}
+	}
+	app.Store[parts[0]] = parts[1]
+	return types.ResponseDeliverTx{Code: code.CodeTypeOK}
+}
+
+func (app *snapshotApp) Commit() types.ResponseCommit {
+	app.Height++
+	state, err := json.Marshal(app)
+	if err != nil {
+		panic(err)
+	}
+	app.snapshots[uint64(app.Height)] = state
+	app.hash = app.stateHash(state)
+	return types.ResponseCommit{Data: app.hash}
+}
+
+func (app *snapshotApp) stateHash(state []byte) []byte {
+	hash := sha256.Sum256(append(state, app.nondeterministic...))
+	return hash[:]
+}
+
+func (app *snapshotApp) Query(req types.RequestQuery) types.ResponseQuery {
+	if req.Height != 0 && req.Height != app.Height {
+		return types.ResponseQuery{Code: code.CodeTypeUnknownError}
+	}
+	return types.ResponseQuery{Key: req.Data, Value: []byte(app.Store[string(req.Data)]), Height: app.Height}
+}
+
+func (app *snapshotApp) ListSnapshots(req types.RequestListSnapshots) types.ResponseListSnapshots {
+	res := types.ResponseListSnapshots{}
+	if app.noSnapshots {
+		return res
+	}
+	for height, state := range app.snapshots {
+		hash := sha256.Sum256(state)
+		res.Snapshots = append(res.Snapshots, &types.Snapshot{
+			Height: height,
+			Format: 1,
+			Chunks: uint32((len(state) + chunkSize - 1) / chunkSize),
+			Hash:   hash[:],
+		})
+	}
+	return res
+}
+
+func (app *snapshotApp) LoadSnapshotChunk(req types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk {
+	state := app.snapshots[req.Height]
+	start := int(req.Chunk) * chunkSize
+	if start >= len(state) {
+		return types.ResponseLoadSnapshotChunk{}
+	}
+	end := start + chunkSize
+	if end > len(state) {
+		end = len(state)
+	}
+	return types.ResponseLoadSnapshotChunk{Chunk: state[start:end]}
+}
+
+func (app *snapshotApp) OfferSnapshot(req types.RequestOfferSnapshot) types.ResponseOfferSnapshot {
+	app.restoring, app.chunks = req.Snapshot, nil
+	return types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_ACCEPT}
+}
+
+func (app *snapshotApp) ApplySnapshotChunk(req types.RequestApplySnapshotChunk) types.ResponseApplySnapshotChunk {
+	app.chunks = append(app.chunks, req.Chunk)
+	if len(app.chunks) < int(app.restoring.Chunks) {
+		return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ACCEPT}
+	}
+	var state []byte
+	for _, chunk := range app.chunks {
+		state = append(state, chunk...)
+	}
+	if err := json.Unmarshal(state, app); err != nil {
+		return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+	app.hash = app.stateHash(state)
+	return types.ResponseApplySnapshotChunk{Result: types.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+func startClient(t *testing.T, transport string, app types.Application) abcicli.Client {
+	var addr string
+	switch transport {
+	case "socket":
+		addr = fmt.Sprintf("unix:///tmp/conformance-%s.sock", tmrand.Str(6))
+	case "grpc":
+		addr = fmt.Sprintf("127.0.0.1:%d", 30000+tmrand.Intn(10000))
+	}
+
+	server, err := abciserver.NewServer(addr, transport, app)
+	require.NoError(t, err)
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	client, err := abcicli.NewClient(addr, transport, true)
+	require.NoError(t, err)
+	client.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return client
+}
+
+// results returns the result of each scenario: pass, skip or its violations.
+func results(report *conformance.Report) map[string]interface{} {
+	results := make(map[string]interface{})
+	for _, sc := range report.Scenarios {
+		switch {
+		case sc.Skipped != "":
+			results[sc.Name] = "skip"
+		case len(sc.Violations) > 0:
+			results[sc.Name] = sc.Violations
+		default:
+			results[sc.Name] = "pass"
+		}
+	}
+	return results
+}
+
+func TestRun_KVStore(t *testing.T) {
+	for _, transport := range []string{"socket", "grpc"} {
+		transport := transport
+		t.Run(transport, func(t *testing.T) {
+			client := startClient(t, transport, kvstore.NewApplication())
+			replica := startClient(t, transport, kvstore.NewApplication())
+
+			report, err := conformance.Run(context.Background(), conformance.DefaultConfig(), client, replica)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{
+				"info":             "pass",
+				"init_chain":       "pass",
+				"execute_blocks":   "pass",
+				"query":            "pass",
+				"snapshot_load":    "skip",
+				"snapshot_restore": "skip",
+				"determinism":      "pass",
+			}, results(report), report.String())
+		})
+	}
+}
+
+func TestRun_StrictQueryHeight(t *testing.T) {
+	cfg := conformance.DefaultConfig()
+	cfg.StrictQueryHeight = true
+
+	// the kvstore answers queries at past heights at the latest height
+	report, err := conformance.Run(context.Background(), cfg, startClient(t, "socket", kvstore.NewApplication()), nil)
+	require.NoError(t, err)
+	violations, ok := results(report)["query"].([]string)
+	require.True(t, ok, report.String())
+	assert.Contains(t, violations[0], "Query at height 1 returned height 5")
+
+	// while the snapshot app fails them
+	report, err = conformance.Run(context.Background(), cfg, startClient(t, "socket", newSnapshotApp()), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pass", results(report)["query"], report.String())
+}
+
+func TestRun_Snapshots(t *testing.T) {
+	client := startClient(t, "socket", newSnapshotApp())
+	replica := startClient(t, "socket", newSnapshotApp())
+
+	report, err := conformance.Run(context.Background(), conformance.DefaultConfig(), client, replica)
+	require.NoError(t, err)
+	assert.Zero(t, report.Violations(), report.String())
+	for _, sc := range report.Scenarios {
+		assert.Empty(t, sc.Skipped, sc.Name)
+	}
+}
+
+func TestRun_NoReplica(t *testing.T) {
+	client := startClient(t, "socket", newSnapshotApp())
+
+	report, err := conformance.Run(context.Background(), conformance.DefaultConfig(), client, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pass", results(report)["snapshot_load"])
+	assert.Equal(t, "skip", results(report)["snapshot_restore"])
+	assert.Equal(t, "skip", results(report)["determinism"])
+}
+
+func TestRun_Violations(t *testing.T) {
+	testcases := map[string]struct {
+		snapshots bool
+		scenario  string
+		violation string
+		skipped   string
+	}{
+		"restore": {true, "snapshot_restore", "Info returned height 5 and app hash", "determinism"},
+		"replay":  {false, "determinism", "Commit at height 1 returned app hash", "snapshot_restore"},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			app := newSnapshotApp()
+			app.noSnapshots = !tc.snapshots
+			replica := newSnapshotApp()
+			replica.nondeterministic = []byte("replica")
+
+			report, err := conformance.Run(context.Background(), conformance.DefaultConfig(),
+				startClient(t, "socket", app), startClient(t, "socket", replica))
+			require.NoError(t, err)
+			require.Equal(t, 1, report.Violations(), report.String())
+
+			results := results(report)
+			require.Len(t, results[tc.scenario], 1)
+			assert.Contains(t, results[tc.scenario].([]string)[0], tc.violation)
+			assert.Equal(t, "skip", results[tc.skipped])
+		})
+	}
+}
+
+func TestRun_NotFresh(t *testing.T) {
+	app := newSnapshotApp()
+	app.Commit()
+
+	report, err := conformance.Run(context.Background(), conformance.DefaultConfig(),
+		startClient(t, "socket", app), nil)
+	require.True(t, errors.Is(err, conformance.ErrNotFresh), err)
+	require.Len(t, report.Scenarios, 1)
+}
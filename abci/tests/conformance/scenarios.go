@@ -0,0 +1,423 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/crypto/tmhash"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	tmtypes "github.com/klyed/tendermint/types"
+	"github.com/klyed/tendermint/version"
+)
+
+// snapshotSender is the peer the snapshot chunks are applied from.
+const snapshotSender = "conformance"
+
+var requestInfo = types.RequestInfo{
+	Version:      version.TMCoreSemVer,
+	BlockVersion: version.BlockProtocol,
+	P2PVersion:   version.P2PProtocol,
+	AbciVersion:  version.ABCIVersion,
+}
+
+type suite struct {
+	cfg     Config
+	client  abcicli.Client
+	replica abcicli.Client
+
+	initChainReq types.RequestInitChain
+	votes        []types.VoteInfo
+	initChainRes *types.ResponseInitChain
+	blocks       []*block
+
+	// snapshot is the snapshot loaded from the application, and chunks its
+	// chunks.
+	snapshot *types.Snapshot
+	chunks   [][]byte
+	// restoredHeight is the height of the snapshot restored on the replica,
+	// and restoreFailed whether restoring it failed.
+	restoredHeight int64
+	restoreFailed  bool
+}
+
+// block is a block executed by the application, along with its results.
+type block struct {
+	beginBlock types.RequestBeginBlock
+	txs        [][]byte
+
+	deliverTxs []*types.ResponseDeliverTx
+	endBlock   *types.ResponseEndBlock
+	appHash    []byte
+}
+
+func (b *block) height() int64 {
+	return b.beginBlock.Header.Height
+}
+
+// lastHeight returns the height of the last block executed.
+func (s *suite) lastHeight() int64 {
+	return s.cfg.InitialHeight + int64(len(s.blocks)) - 1
+}
+
+// appHash returns the app hash of the application after the block at height.
+func (s *suite) appHash(height int64) []byte {
+	if height < s.cfg.InitialHeight {
+		return s.initChainRes.AppHash
+	}
+	return s.blocks[height-s.cfg.InitialHeight].appHash
+}
+
+func (s *suite) info(ctx context.Context, sc *Scenario) error {
+	for _, client := range []abcicli.Client{s.client, s.replica} {
+		if client == nil {
+			continue
+		}
+		res, err := client.InfoSync(ctx, requestInfo)
+		if err != nil {
+			return err
+		}
+		if res.LastBlockHeight != 0 {
+			return fmt.Errorf("%w (last block height %d)", ErrNotFresh, res.LastBlockHeight)
+		}
+	}
+	return nil
+}
+
+func (s *suite) initChain(ctx context.Context, sc *Scenario) error {
+	res, err := s.client.InitChainSync(ctx, s.initChainReq)
+	if err != nil {
+		return err
+	}
+	s.initChainRes = res
+	checkValidatorUpdates(sc, "InitChain", res.Validators)
+	s.checkConsensusParams(sc, "InitChain", res.ConsensusParams)
+
+	info, err := s.client.InfoSync(ctx, requestInfo)
+	if err != nil {
+		return err
+	}
+	if info.LastBlockHeight != 0 {
+		sc.violation("Info returned last block height %d after InitChain, expected 0", info.LastBlockHeight)
+	}
+	return nil
+}
+
+func (s *suite) executeBlocks(ctx context.Context, sc *Scenario) error {
+	for i := int64(0); i < s.cfg.Blocks; i++ {
+		height := s.cfg.InitialHeight + i
+		b := &block{
+			beginBlock: types.RequestBeginBlock{
+				Hash: tmhash.Sum([]byte(fmt.Sprintf("block-%d", height))),
+				Header: tmproto.Header{
+					ChainID:         s.cfg.ChainID,
+					Height:          height,
+					Time:            genesisTime.Add(time.Duration(i+1) * time.Second),
+					AppHash:         s.appHash(height - 1),
+					ProposerAddress: s.votes[0].Validator.Address,
+				},
+			},
+		}
+		if i > 0 {
+			b.beginBlock.LastCommitInfo = types.LastCommitInfo{Votes: s.votes}
+		}
+		for j := 0; j < s.cfg.TxsPerBlock; j++ {
+			b.txs = append(b.txs, s.cfg.Tx(height, j))
+		}
+
+		deliverTxs, endBlock, commit, err := execBlock(ctx, s.client, b)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", height, err)
+		}
+		b.deliverTxs, b.endBlock, b.appHash = deliverTxs, endBlock, commit.Data
+		s.blocks = append(s.blocks, b)
+
+		method := fmt.Sprintf("EndBlock at height %d", height)
+		checkValidatorUpdates(sc, method, endBlock.ValidatorUpdates)
+		s.checkConsensusParams(sc, method, endBlock.ConsensusParamUpdates)
+		if commit.RetainHeight < 0 || commit.RetainHeight > height {
+			sc.violation("Commit at height %d returned retain height %d, expected at most %d",
+				height, commit.RetainHeight, height)
+		}
+
+		info, err := s.client.InfoSync(ctx, requestInfo)
+		if err != nil {
+			return err
+		}
+		if info.LastBlockHeight != height {
+			sc.violation("Info returned last block height %d after the Commit at height %d",
+				info.LastBlockHeight, height)
+		}
+		if !bytes.Equal(info.LastBlockAppHash, commit.Data) {
+			sc.violation("Info returned app hash %X after the Commit at height %d, which returned %X",
+				info.LastBlockAppHash, height, commit.Data)
+		}
+	}
+	return nil
+}
+
+func (s *suite) query(ctx context.Context, sc *Scenario) error {
+	before, err := s.client.InfoSync(ctx, requestInfo)
+	if err != nil {
+		return err
+	}
+
+	lastHeight := s.lastHeight()
+	for height := int64(0); height <= lastHeight+1; height++ {
+		if height > 0 && height < s.cfg.InitialHeight {
+			continue
+		}
+		res, err := s.client.QuerySync(ctx, types.RequestQuery{
+			Path:   s.cfg.QueryPath,
+			Data:   s.cfg.QueryData,
+			Height: height,
+		})
+		if err != nil {
+			return err
+		}
+		if res.IsErr() {
+			continue
+		}
+		switch {
+		case height == 0 && res.Height != lastHeight:
+			sc.violation("Query at the latest height returned height %d, expected %d", res.Height, lastHeight)
+		case !s.cfg.StrictQueryHeight && res.Height == lastHeight:
+			// answered at the latest height
+		case height > lastHeight:
+			sc.violation("Query at height %d succeeded, but the last block height is %d", height, lastHeight)
+		case height > 0 && res.Height != height:
+			// the application must fail queries at heights it doesn't serve
+			sc.violation("Query at height %d returned height %d", height, res.Height)
+		}
+	}
+
+	after, err := s.client.InfoSync(ctx, requestInfo)
+	if err != nil {
+		return err
+	}
+	if after.LastBlockHeight != before.LastBlockHeight || !bytes.Equal(after.LastBlockAppHash, before.LastBlockAppHash) {
+		sc.violation("Query changed the last block height or app hash reported by Info")
+	}
+	return nil
+}
+
+func (s *suite) snapshotLoad(ctx context.Context, sc *Scenario) error {
+	res, err := s.client.ListSnapshotsSync(ctx, types.RequestListSnapshots{})
+	if err != nil {
+		return err
+	}
+	if len(res.Snapshots) == 0 {
+		sc.Skipped = "the application has no snapshots"
+		return nil
+	}
+
+	seen := make(map[string]bool, len(res.Snapshots))
+	for _, snapshot := range res.Snapshots {
+		height := int64(snapshot.Height)
+		key := fmt.Sprintf("%d/%d", snapshot.Height, snapshot.Format)
+		switch {
+		case height < s.cfg.InitialHeight || height > s.lastHeight():
+			sc.violation("ListSnapshots returned a snapshot at height %d, but the last block height is %d",
+				height, s.lastHeight())
+		case snapshot.Chunks == 0:
+			sc.violation("ListSnapshots returned a snapshot at height %d with no chunks", height)
+		case seen[key]:
+			sc.violation("ListSnapshots returned several snapshots at height %d in format %d",
+				height, snapshot.Format)
+		case s.snapshot == nil || snapshot.Height > s.snapshot.Height:
+			s.snapshot = snapshot
+		}
+		seen[key] = true
+	}
+	if s.snapshot == nil {
+		return nil
+	}
+
+	for i := uint32(0); i < s.snapshot.Chunks; i++ {
+		res, err := s.client.LoadSnapshotChunkSync(ctx, types.RequestLoadSnapshotChunk{
+			Height: s.snapshot.Height,
+			Format: s.snapshot.Format,
+			Chunk:  i,
+		})
+		if err != nil {
+			return err
+		}
+		if len(res.Chunk) == 0 {
+			sc.violation("LoadSnapshotChunk returned an empty chunk %d for the snapshot at height %d",
+				i, s.snapshot.Height)
+			s.snapshot, s.chunks = nil, nil
+			return nil
+		}
+		s.chunks = append(s.chunks, res.Chunk)
+	}
+	return nil
+}
+
+func (s *suite) snapshotRestore(ctx context.Context, sc *Scenario) error {
+	switch {
+	case s.replica == nil:
+		sc.Skipped = "no replica"
+		return nil
+	case s.snapshot == nil:
+		sc.Skipped = "no snapshot was loaded"
+		return nil
+	}
+
+	// any violation leaves the replica in an unknown state
+	defer func() {
+		if len(sc.Violations) > 0 {
+			s.restoreFailed = true
+		}
+	}()
+
+	height := int64(s.snapshot.Height)
+	appHash := s.appHash(height)
+	offer, err := s.replica.OfferSnapshotSync(ctx, types.RequestOfferSnapshot{
+		Snapshot: s.snapshot,
+		AppHash:  appHash,
+	})
+	if err != nil {
+		return err
+	}
+	if offer.Result != types.ResponseOfferSnapshot_ACCEPT {
+		sc.violation("OfferSnapshot returned %v for the snapshot at height %d", offer.Result, height)
+		return nil
+	}
+
+	for i, chunk := range s.chunks {
+		res, err := s.replica.ApplySnapshotChunkSync(ctx, types.RequestApplySnapshotChunk{
+			Index:  uint32(i),
+			Chunk:  chunk,
+			Sender: snapshotSender,
+		})
+		if err != nil {
+			return err
+		}
+		if res.Result != types.ResponseApplySnapshotChunk_ACCEPT {
+			sc.violation("ApplySnapshotChunk returned %v for chunk %d of the snapshot at height %d",
+				res.Result, i, height)
+			return nil
+		}
+	}
+
+	info, err := s.replica.InfoSync(ctx, requestInfo)
+	if err != nil {
+		return err
+	}
+	if info.LastBlockHeight != height || !bytes.Equal(info.LastBlockAppHash, appHash) {
+		sc.violation("Info returned height %d and app hash %X after restoring the snapshot, expected %d and %X",
+			info.LastBlockHeight, info.LastBlockAppHash, height, appHash)
+		return nil
+	}
+	s.restoredHeight = height
+	return nil
+}
+
+func (s *suite) determinism(ctx context.Context, sc *Scenario) error {
+	switch {
+	case s.replica == nil:
+		sc.Skipped = "no replica"
+		return nil
+	case s.restoreFailed:
+		sc.Skipped = "the replica failed to restore the snapshot"
+		return nil
+	}
+
+	if s.restoredHeight == 0 {
+		res, err := s.replica.InitChainSync(ctx, s.initChainReq)
+		if err != nil {
+			return err
+		}
+		if !proto.Equal(res, s.initChainRes) {
+			sc.violation("InitChain returned %v on the replica, and %v on the application", res, s.initChainRes)
+			return nil
+		}
+	}
+
+	for _, b := range s.blocks {
+		if b.height() <= s.restoredHeight {
+			continue
+		}
+		deliverTxs, endBlock, commit, err := execBlock(ctx, s.replica, b)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", b.height(), err)
+		}
+
+		// the results are compared like the last results hash of the next block
+		results, expected := tmtypes.NewResults(deliverTxs), tmtypes.NewResults(b.deliverTxs)
+		for i := range results {
+			if !proto.Equal(results[i], expected[i]) {
+				sc.violation("DeliverTx of tx %d at height %d returned %v on the replica, and %v on the application",
+					i, b.height(), results[i], expected[i])
+			}
+		}
+		if !equalValidatorUpdates(endBlock.ValidatorUpdates, b.endBlock.ValidatorUpdates) ||
+			!proto.Equal(endBlock.ConsensusParamUpdates, b.endBlock.ConsensusParamUpdates) {
+			sc.violation("EndBlock at height %d returned different updates on the replica and the application",
+				b.height())
+		}
+		if !bytes.Equal(commit.Data, b.appHash) {
+			sc.violation("Commit at height %d returned app hash %X on the replica, and %X on the application",
+				b.height(), commit.Data, b.appHash)
+		}
+		// the next blocks would diverge as well
+		if len(sc.Violations) > 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+// execBlock executes a block with BeginBlock, DeliverTx, EndBlock and Commit.
+func execBlock(ctx context.Context, client abcicli.Client, b *block) (
+	[]*types.ResponseDeliverTx, *types.ResponseEndBlock, *types.ResponseCommit, error) {
+
+	if _, err := client.BeginBlockSync(ctx, b.beginBlock); err != nil {
+		return nil, nil, nil, err
+	}
+	deliverTxs := make([]*types.ResponseDeliverTx, len(b.txs))
+	for i, tx := range b.txs {
+		res, err := client.DeliverTxSync(ctx, types.RequestDeliverTx{Tx: tx})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		deliverTxs[i] = res
+	}
+	endBlock, err := client.EndBlockSync(ctx, types.RequestEndBlock{Height: b.height()})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	commit, err := client.CommitSync(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return deliverTxs, endBlock, commit, nil
+}
+
+// checkConsensusParams checks the consensus params updates returned by method.
+func (s *suite) checkConsensusParams(sc *Scenario, method string, updates *tmproto.ConsensusParams) {
+	if updates == nil {
+		return
+	}
+	params := tmtypes.ConsensusParamsFromProto(*s.initChainReq.ConsensusParams).UpdateConsensusParams(updates)
+	if err := params.ValidateConsensusParams(); err != nil {
+		sc.violation("%s returned invalid consensus params: %v", method, err)
+	}
+}
+
+func equalValidatorUpdates(a, b []types.ValidatorUpdate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(&a[i], &b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -23,6 +23,11 @@ type Application interface {
 	EndBlock(RequestEndBlock) ResponseEndBlock       // Signals the end of a block, returns changes to the validator set
 	Commit() ResponseCommit                          // Commit the state and return the application Merkle root hash
 
+	// FinalizeBlock delivers a whole block in one call, instead of BeginBlock,
+	// DeliverTx for each tx and EndBlock. It's only used if the node is
+	// configured to, see ExecFinalizeBlock to implement it.
+	FinalizeBlock(RequestFinalizeBlock) ResponseFinalizeBlock
+
 	// State Sync Connection
 	ListSnapshots(RequestListSnapshots) ResponseListSnapshots                // List available snapshots
 	OfferSnapshot(RequestOfferSnapshot) ResponseOfferSnapshot                // Offer a snapshot to the application
@@ -30,6 +35,20 @@ type Application interface {
 	ApplySnapshotChunk(RequestApplySnapshotChunk) ResponseApplySnapshotChunk // Apply a shapshot chunk
 }
 
+// ConcurrentCheckTxApplication is an optional interface for applications whose
+// CheckTx is safe to call concurrently with itself and with the methods of the
+// other connections. If ConcurrentCheckTx returns true, the socket server
+// doesn't serialize CheckTx calls with the rest of the application, so that
+// the node can check txs in parallel over several mempool connections (see
+// the mempool check-tx-connections config option). Over the framed socket
+// protocol, CheckTx requests on the same connection are also handled
+// concurrently, and their responses may be sent out of order.
+type ConcurrentCheckTxApplication interface {
+	Application
+
+	ConcurrentCheckTx() bool
+}
+
 //-------------------------------------------------------
 // BaseApplication is a base form of Application
 
@@ -74,6 +93,14 @@ func (BaseApplication) EndBlock(req RequestEndBlock) ResponseEndBlock {
 	return ResponseEndBlock{}
 }
 
+// FinalizeBlock panics: it can't call the BeginBlock, DeliverTx or EndBlock
+// methods of applications embedding BaseApplication, so these must implement
+// FinalizeBlock themselves (see ExecFinalizeBlock) for the node to use it.
+func (BaseApplication) FinalizeBlock(req RequestFinalizeBlock) ResponseFinalizeBlock {
+	panic("FinalizeBlock is not implemented by the application: implement it, e.g. with " +
+		"ExecFinalizeBlock, or disable abci-finalize-block")
+}
+
 func (BaseApplication) ListSnapshots(req RequestListSnapshots) ResponseListSnapshots {
 	return ResponseListSnapshots{}
 }
@@ -90,6 +117,24 @@ func (BaseApplication) ApplySnapshotChunk(req RequestApplySnapshotChunk) Respons
 	return ResponseApplySnapshotChunk{}
 }
 
+// ExecFinalizeBlock executes a FinalizeBlock request against app by calling
+// BeginBlock, DeliverTx for each tx and EndBlock, in order. Applications can
+// use it to implement FinalizeBlock on top of these methods.
+func ExecFinalizeBlock(app Application, req RequestFinalizeBlock) ResponseFinalizeBlock {
+	beginBlock := app.BeginBlock(req.BeginBlock)
+	deliverTxs := make([]*ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.DeliverTx(RequestDeliverTx{Tx: tx})
+		deliverTxs[i] = &res
+	}
+	endBlock := app.EndBlock(req.EndBlock)
+	return ResponseFinalizeBlock{
+		BeginBlock: &beginBlock,
+		DeliverTxs: deliverTxs,
+		EndBlock:   &endBlock,
+	}
+}
+
 //-------------------------------------------------------
 
 // GRPCApplication is a GRPC wrapper for Application
@@ -149,6 +194,12 @@ func (app *GRPCApplication) EndBlock(ctx context.Context, req *RequestEndBlock)
 	return &res, nil
 }
 
+func (app *GRPCApplication) FinalizeBlock(
+	ctx context.Context, req *RequestFinalizeBlock) (*ResponseFinalizeBlock, error) {
+	res := app.app.FinalizeBlock(*req)
+	return &res, nil
+}
+
 func (app *GRPCApplication) ListSnapshots(
 	ctx context.Context, req *RequestListSnapshots) (*ResponseListSnapshots, error) {
 	res := app.app.ListSnapshots(*req)
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseApplicationFinalizeBlockPanics(t *testing.T) {
+	// the block must not be reported as executed without calling the
+	// application's BeginBlock, DeliverTx and EndBlock
+	assert.Panics(t, func() {
+		BaseApplication{}.FinalizeBlock(RequestFinalizeBlock{Txs: [][]byte{[]byte("tx")}})
+	})
+}
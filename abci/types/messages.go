@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/binary"
+	"fmt"
 	"io"
 
 	"github.com/gogo/protobuf/proto"
@@ -9,8 +11,32 @@ import (
 
 const (
 	maxMsgSize = 104857600 // 100MB
+
+	// frameHeaderSize is the size of the header of a frame: the size of the
+	// message as a big-endian uint32, followed by the request ID as a
+	// big-endian uint64.
+	frameHeaderSize = 12
+)
+
+// The framed socket protocol is negotiated by a client sending an Echo request
+// with EchoFramedProtocol, followed by a Flush. A server which supports it
+// responds with EchoFramedProtocolAck, and both switch to frames after the
+// Flush response. Older servers echo the message back, in which case the
+// connection keeps using varint length-delimited messages.
+const (
+	EchoFramedProtocol    = "abci-protocol:framed/1"
+	EchoFramedProtocolAck = "abci-protocol:framed/1:ok"
 )
 
+// FrameMessage is a message which can be written in a frame, i.e. a Request or
+// a Response.
+type FrameMessage interface {
+	proto.Message
+	Size() int
+	MarshalToSizedBuffer([]byte) (int, error)
+	Unmarshal([]byte) error
+}
+
 // WriteMessage writes a varint length-delimited protobuf message.
 func WriteMessage(msg proto.Message, w io.Writer) error {
 	protoWriter := protoio.NewDelimitedWriter(w)
@@ -28,6 +54,61 @@ func ReadMessage(r io.Reader, msg proto.Message) error {
 	return err
 }
 
+// WriteFrame writes a protobuf message in a frame with the ID of the request it
+// belongs to, which lets responses be matched to requests out of order. The
+// message is marshaled right after the header, in a single buffer.
+func WriteFrame(w io.Writer, id uint64, msg FrameMessage) error {
+	size := msg.Size()
+	if size > maxMsgSize {
+		return fmt.Errorf("message size %d exceeds the maximum of %d bytes", size, maxMsgSize)
+	}
+	buf := make([]byte, frameHeaderSize+size)
+	binary.BigEndian.PutUint32(buf, uint32(size))
+	binary.BigEndian.PutUint64(buf[4:], id)
+	if _, err := msg.MarshalToSizedBuffer(buf[frameHeaderSize:]); err != nil {
+		return err
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// FrameReader reads the frames written by WriteFrame. The messages are
+// unmarshaled from a buffer which is reused across frames.
+type FrameReader struct {
+	r      io.Reader
+	header [frameHeaderSize]byte
+	buf    []byte
+}
+
+// NewFrameReader returns a FrameReader reading from r, which should be
+// buffered.
+func NewFrameReader(r io.Reader) *FrameReader {
+	return &FrameReader{r: r}
+}
+
+// ReadFrame reads the next frame into msg, and returns its request ID.
+func (fr *FrameReader) ReadFrame(msg FrameMessage) (uint64, error) {
+	if _, err := io.ReadFull(fr.r, fr.header[:]); err != nil {
+		return 0, err
+	}
+	size := binary.BigEndian.Uint32(fr.header[:])
+	id := binary.BigEndian.Uint64(fr.header[4:])
+	if size > maxMsgSize {
+		return 0, fmt.Errorf("message size %d exceeds the maximum of %d bytes", size, maxMsgSize)
+	}
+	if cap(fr.buf) < int(size) {
+		fr.buf = make([]byte, size)
+	}
+	buf := fr.buf[:size]
+	if _, err := io.ReadFull(fr.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	return id, msg.Unmarshal(buf)
+}
+
 //----------------------------------------
 
 func ToRequestEcho(message string) *Request {
@@ -114,6 +195,12 @@ func ToRequestApplySnapshotChunk(req RequestApplySnapshotChunk) *Request {
 	}
 }
 
+func ToRequestFinalizeBlock(req RequestFinalizeBlock) *Request {
+	return &Request{
+		Value: &Request_FinalizeBlock{&req},
+	}
+}
+
 //----------------------------------------
 
 func ToResponseException(errStr string) *Response {
@@ -204,3 +291,9 @@ func ToResponseApplySnapshotChunk(res ResponseApplySnapshotChunk) *Response {
 		Value: &Response_ApplySnapshotChunk{&res},
 	}
 }
+
+func ToResponseFinalizeBlock(res ResponseFinalizeBlock) *Response {
+	return &Response{
+		Value: &Response_FinalizeBlock{&res},
+	}
+}
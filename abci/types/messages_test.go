@@ -3,11 +3,13 @@ package types
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
 )
@@ -112,3 +114,38 @@ func TestWriteReadMessage2(t *testing.T) {
 		assert.True(t, proto.Equal(c, msg))
 	}
 }
+
+func TestWriteReadFrame(t *testing.T) {
+	cases := []*Request{
+		ToRequestCheckTx(RequestCheckTx{Tx: []byte("a=1")}),
+		ToRequestFlush(),
+		ToRequestCheckTx(RequestCheckTx{Tx: bytes.Repeat([]byte("b"), 1000)}),
+	}
+
+	buf := new(bytes.Buffer)
+	for i, c := range cases {
+		require.NoError(t, WriteFrame(buf, uint64(i+10), c))
+	}
+
+	fr := NewFrameReader(buf)
+	var msgs []*Request
+	for i, c := range cases {
+		msg := new(Request)
+		id, err := fr.ReadFrame(msg)
+		require.NoError(t, err)
+		assert.EqualValues(t, i+10, id)
+		assert.True(t, proto.Equal(c, msg))
+		msgs = append(msgs, msg)
+	}
+	// the messages don't share the reused buffer
+	assert.Equal(t, []byte("a=1"), msgs[0].GetCheckTx().Tx)
+
+	_, err := fr.ReadFrame(new(Request))
+	assert.Equal(t, io.EOF, err)
+
+	// truncated frame
+	require.NoError(t, WriteFrame(buf, 1, cases[0]))
+	buf.Truncate(buf.Len() - 1)
+	_, err = fr.ReadFrame(new(Request))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
@@ -120,7 +120,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{30, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +157,7 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{32, 0}
 }
 
 type Request struct {
@@ -176,6 +176,7 @@ type Request struct {
 	//	*Request_OfferSnapshot
 	//	*Request_LoadSnapshotChunk
 	//	*Request_ApplySnapshotChunk
+	//	*Request_FinalizeBlock
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -260,6 +261,9 @@ type Request_LoadSnapshotChunk struct {
 type Request_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *RequestApplySnapshotChunk `protobuf:"bytes,14,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
+type Request_FinalizeBlock struct {
+	FinalizeBlock *RequestFinalizeBlock `protobuf:"bytes,15,opt,name=finalize_block,json=finalizeBlock,proto3,oneof" json:"finalize_block,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -275,6 +279,7 @@ func (*Request_ListSnapshots) isRequest_Value()      {}
 func (*Request_OfferSnapshot) isRequest_Value()      {}
 func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
+func (*Request_FinalizeBlock) isRequest_Value()      {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -381,6 +386,13 @@ func (m *Request) GetApplySnapshotChunk() *RequestApplySnapshotChunk {
 	return nil
 }
 
+func (m *Request) GetFinalizeBlock() *RequestFinalizeBlock {
+	if x, ok := m.GetValue().(*Request_FinalizeBlock); ok {
+		return x.FinalizeBlock
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -398,6 +410,7 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_OfferSnapshot)(nil),
 		(*Request_LoadSnapshotChunk)(nil),
 		(*Request_ApplySnapshotChunk)(nil),
+		(*Request_FinalizeBlock)(nil),
 	}
 }
 
@@ -1157,6 +1170,67 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 	return ""
 }
 
+// delivers a whole block: BeginBlock, a DeliverTx for each tx, and EndBlock
+type RequestFinalizeBlock struct {
+	BeginBlock RequestBeginBlock `protobuf:"bytes,1,opt,name=begin_block,json=beginBlock,proto3" json:"begin_block"`
+	Txs        [][]byte          `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
+	EndBlock   RequestEndBlock   `protobuf:"bytes,3,opt,name=end_block,json=endBlock,proto3" json:"end_block"`
+}
+
+func (m *RequestFinalizeBlock) Reset()         { *m = RequestFinalizeBlock{} }
+func (m *RequestFinalizeBlock) String() string { return proto.CompactTextString(m) }
+func (*RequestFinalizeBlock) ProtoMessage()    {}
+func (*RequestFinalizeBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{15}
+}
+func (m *RequestFinalizeBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestFinalizeBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestFinalizeBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestFinalizeBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestFinalizeBlock.Merge(m, src)
+}
+func (m *RequestFinalizeBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestFinalizeBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestFinalizeBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestFinalizeBlock proto.InternalMessageInfo
+
+func (m *RequestFinalizeBlock) GetBeginBlock() RequestBeginBlock {
+	if m != nil {
+		return m.BeginBlock
+	}
+	return RequestBeginBlock{}
+}
+
+func (m *RequestFinalizeBlock) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *RequestFinalizeBlock) GetEndBlock() RequestEndBlock {
+	if m != nil {
+		return m.EndBlock
+	}
+	return RequestEndBlock{}
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -1174,6 +1248,7 @@ type Response struct {
 	//	*Response_OfferSnapshot
 	//	*Response_LoadSnapshotChunk
 	//	*Response_ApplySnapshotChunk
+	//	*Response_FinalizeBlock
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1181,7 +1256,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{15}
+	return fileDescriptor_252557cfdd89a31a, []int{16}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1261,6 +1336,9 @@ type Response_LoadSnapshotChunk struct {
 type Response_ApplySnapshotChunk struct {
 	ApplySnapshotChunk *ResponseApplySnapshotChunk `protobuf:"bytes,15,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,proto3,oneof" json:"apply_snapshot_chunk,omitempty"`
 }
+type Response_FinalizeBlock struct {
+	FinalizeBlock *ResponseFinalizeBlock `protobuf:"bytes,16,opt,name=finalize_block,json=finalizeBlock,proto3,oneof" json:"finalize_block,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1277,6 +1355,7 @@ func (*Response_ListSnapshots) isResponse_Value()      {}
 func (*Response_OfferSnapshot) isResponse_Value()      {}
 func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
+func (*Response_FinalizeBlock) isResponse_Value()      {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1390,6 +1469,13 @@ func (m *Response) GetApplySnapshotChunk() *ResponseApplySnapshotChunk {
 	return nil
 }
 
+func (m *Response) GetFinalizeBlock() *ResponseFinalizeBlock {
+	if x, ok := m.GetValue().(*Response_FinalizeBlock); ok {
+		return x.FinalizeBlock
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1408,6 +1494,7 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_OfferSnapshot)(nil),
 		(*Response_LoadSnapshotChunk)(nil),
 		(*Response_ApplySnapshotChunk)(nil),
+		(*Response_FinalizeBlock)(nil),
 	}
 }
 
@@ -1420,7 +1507,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{17}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1464,7 +1551,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{18}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1507,7 +1594,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1549,7 +1636,7 @@ func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1623,7 +1710,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1690,7 +1777,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1790,7 +1877,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1835,13 +1922,16 @@ type ResponseCheckTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// The sender of the tx, as defined by the application, used to select
+	// the txs rechecked after a block (see RecheckTxs).
+	Sender string `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1926,6 +2016,13 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -1941,7 +2038,7 @@ func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2036,7 +2133,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2090,13 +2187,16 @@ type ResponseCommit struct {
 	// reserve 1
 	Data         []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	RetainHeight int64  `protobuf:"varint,3,opt,name=retain_height,json=retainHeight,proto3" json:"retain_height,omitempty"`
+	// If set, only the mempool txs selected by recheck are rechecked after
+	// the block, instead of all of them.
+	Recheck *RecheckTxs `protobuf:"bytes,4,opt,name=recheck,proto3" json:"recheck,omitempty"`
 }
 
 func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2139,6 +2239,80 @@ func (m *ResponseCommit) GetRetainHeight() int64 {
 	return 0
 }
 
+func (m *ResponseCommit) GetRecheck() *RecheckTxs {
+	if m != nil {
+		return m.Recheck
+	}
+	return nil
+}
+
+// RecheckTxs selects the mempool txs rechecked after a block.
+type RecheckTxs struct {
+	// The txs with these hashes are removed from the mempool without being
+	// rechecked.
+	InvalidTxHashes [][]byte `protobuf:"bytes,1,rep,name=invalid_tx_hashes,json=invalidTxHashes,proto3" json:"invalid_tx_hashes,omitempty"`
+	// The txs from these senders (see ResponseCheckTx.sender) are removed
+	// from the mempool without being rechecked.
+	InvalidSenders []string `protobuf:"bytes,2,rep,name=invalid_senders,json=invalidSenders,proto3" json:"invalid_senders,omitempty"`
+	// Only the txs from these senders are rechecked, the other txs are kept
+	// without being rechecked.
+	RecheckSenders []string `protobuf:"bytes,3,rep,name=recheck_senders,json=recheckSenders,proto3" json:"recheck_senders,omitempty"`
+}
+
+func (m *RecheckTxs) Reset()         { *m = RecheckTxs{} }
+func (m *RecheckTxs) String() string { return proto.CompactTextString(m) }
+func (*RecheckTxs) ProtoMessage()    {}
+func (*RecheckTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{28}
+}
+func (m *RecheckTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecheckTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecheckTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecheckTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecheckTxs.Merge(m, src)
+}
+func (m *RecheckTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecheckTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecheckTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecheckTxs proto.InternalMessageInfo
+
+func (m *RecheckTxs) GetInvalidTxHashes() [][]byte {
+	if m != nil {
+		return m.InvalidTxHashes
+	}
+	return nil
+}
+
+func (m *RecheckTxs) GetInvalidSenders() []string {
+	if m != nil {
+		return m.InvalidSenders
+	}
+	return nil
+}
+
+func (m *RecheckTxs) GetRecheckSenders() []string {
+	if m != nil {
+		return m.RecheckSenders
+	}
+	return nil
+}
+
 type ResponseListSnapshots struct {
 	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
 }
@@ -2147,7 +2321,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2191,7 +2365,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2235,7 +2409,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2281,7 +2455,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2331,6 +2505,66 @@ func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 	return nil
 }
 
+type ResponseFinalizeBlock struct {
+	BeginBlock *ResponseBeginBlock  `protobuf:"bytes,1,opt,name=begin_block,json=beginBlock,proto3" json:"begin_block,omitempty"`
+	DeliverTxs []*ResponseDeliverTx `protobuf:"bytes,2,rep,name=deliver_txs,json=deliverTxs,proto3" json:"deliver_txs,omitempty"`
+	EndBlock   *ResponseEndBlock    `protobuf:"bytes,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+}
+
+func (m *ResponseFinalizeBlock) Reset()         { *m = ResponseFinalizeBlock{} }
+func (m *ResponseFinalizeBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseFinalizeBlock) ProtoMessage()    {}
+func (*ResponseFinalizeBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{33}
+}
+func (m *ResponseFinalizeBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseFinalizeBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseFinalizeBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseFinalizeBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseFinalizeBlock.Merge(m, src)
+}
+func (m *ResponseFinalizeBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseFinalizeBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseFinalizeBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseFinalizeBlock proto.InternalMessageInfo
+
+func (m *ResponseFinalizeBlock) GetBeginBlock() *ResponseBeginBlock {
+	if m != nil {
+		return m.BeginBlock
+	}
+	return nil
+}
+
+func (m *ResponseFinalizeBlock) GetDeliverTxs() []*ResponseDeliverTx {
+	if m != nil {
+		return m.DeliverTxs
+	}
+	return nil
+}
+
+func (m *ResponseFinalizeBlock) GetEndBlock() *ResponseEndBlock {
+	if m != nil {
+		return m.EndBlock
+	}
+	return nil
+}
+
 type LastCommitInfo struct {
 	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
@@ -2340,7 +2574,7 @@ func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2395,7 +2629,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{35}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2449,7 +2683,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{36}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2513,7 +2747,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2581,7 +2815,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2634,7 +2868,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2687,7 +2921,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{40}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2748,7 +2982,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2824,7 +3058,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2908,6 +3142,7 @@ func init() {
 	proto.RegisterType((*RequestOfferSnapshot)(nil), "tendermint.abci.RequestOfferSnapshot")
 	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
 	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestFinalizeBlock)(nil), "tendermint.abci.RequestFinalizeBlock")
 	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
 	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
@@ -2920,10 +3155,12 @@ func init() {
 	proto.RegisterType((*ResponseDeliverTx)(nil), "tendermint.abci.ResponseDeliverTx")
 	proto.RegisterType((*ResponseEndBlock)(nil), "tendermint.abci.ResponseEndBlock")
 	proto.RegisterType((*ResponseCommit)(nil), "tendermint.abci.ResponseCommit")
+	proto.RegisterType((*RecheckTxs)(nil), "tendermint.abci.RecheckTxs")
 	proto.RegisterType((*ResponseListSnapshots)(nil), "tendermint.abci.ResponseListSnapshots")
 	proto.RegisterType((*ResponseOfferSnapshot)(nil), "tendermint.abci.ResponseOfferSnapshot")
 	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponseFinalizeBlock)(nil), "tendermint.abci.ResponseFinalizeBlock")
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
 	proto.RegisterType((*Event)(nil), "tendermint.abci.Event")
 	proto.RegisterType((*EventAttribute)(nil), "tendermint.abci.EventAttribute")
@@ -2938,169 +3175,181 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2583 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0xc6, 0x1b, 0xd8, 0x26, 0x01, 0x82, 0x23, 0x5a, 0x86, 0x60, 0x89, 0x94, 0x57, 0x25, 0xc7,
-	0x92, 0x6d, 0x32, 0xa6, 0x4a, 0x8a, 0x5c, 0xce, 0xc3, 0x04, 0x04, 0x05, 0xb4, 0x18, 0x92, 0x19,
-	0x42, 0x72, 0x39, 0x89, 0xb5, 0x5e, 0x60, 0x87, 0xc0, 0x5a, 0xc0, 0xee, 0x1a, 0x3b, 0xa0, 0x48,
-	0x1f, 0xf3, 0xb8, 0xa8, 0x72, 0xd0, 0x31, 0x17, 0x57, 0xe5, 0x1f, 0xe4, 0x9a, 0x53, 0x4e, 0x39,
-	0xf8, 0x90, 0x54, 0xf9, 0x98, 0x93, 0x93, 0x92, 0x6e, 0xf9, 0x03, 0x39, 0xa5, 0x2a, 0x35, 0x8f,
-	0x7d, 0x01, 0x58, 0x00, 0x8c, 0x73, 0xcb, 0x6d, 0xa6, 0xb7, 0xbb, 0x31, 0xd3, 0x33, 0xdd, 0xfd,
-	0x75, 0x0f, 0xe0, 0x35, 0x4a, 0x2c, 0x83, 0x0c, 0x07, 0xa6, 0x45, 0xb7, 0xf4, 0x76, 0xc7, 0xdc,
-	0xa2, 0x67, 0x0e, 0x71, 0x37, 0x9d, 0xa1, 0x4d, 0x6d, 0xb4, 0x12, 0x7c, 0xdc, 0x64, 0x1f, 0xab,
-	0x57, 0x42, 0xdc, 0x9d, 0xe1, 0x99, 0x43, 0xed, 0x2d, 0x67, 0x68, 0xdb, 0xc7, 0x82, 0xbf, 0x7a,
-	0x39, 0xf4, 0x99, 0xeb, 0x09, 0x6b, 0x8b, 0x7c, 0x95, 0xc2, 0x4f, 0xc8, 0x99, 0xf7, 0xf5, 0xca,
-	0x84, 0xac, 0xa3, 0x0f, 0xf5, 0x81, 0xf7, 0x79, 0xa3, 0x6b, 0xdb, 0xdd, 0x3e, 0xd9, 0xe2, 0xb3,
-	0xf6, 0xe8, 0x78, 0x8b, 0x9a, 0x03, 0xe2, 0x52, 0x7d, 0xe0, 0x48, 0x86, 0xb5, 0xae, 0xdd, 0xb5,
-	0xf9, 0x70, 0x8b, 0x8d, 0x04, 0x55, 0xfd, 0x6b, 0x1e, 0xf2, 0x98, 0x7c, 0x3e, 0x22, 0x2e, 0x45,
-	0xdb, 0x90, 0x21, 0x9d, 0x9e, 0x5d, 0x49, 0x5e, 0x4d, 0xbe, 0xb9, 0xb4, 0x7d, 0x79, 0x73, 0x6c,
-	0x73, 0x9b, 0x92, 0xaf, 0xd1, 0xe9, 0xd9, 0xcd, 0x04, 0xe6, 0xbc, 0xe8, 0x36, 0x64, 0x8f, 0xfb,
-	0x23, 0xb7, 0x57, 0x49, 0x71, 0xa1, 0x2b, 0x71, 0x42, 0xf7, 0x19, 0x53, 0x33, 0x81, 0x05, 0x37,
-	0xfb, 0x29, 0xd3, 0x3a, 0xb6, 0x2b, 0xe9, 0xd9, 0x3f, 0xb5, 0x6b, 0x1d, 0xf3, 0x9f, 0x62, 0xbc,
-	0xa8, 0x06, 0x60, 0x5a, 0x26, 0xd5, 0x3a, 0x3d, 0xdd, 0xb4, 0x2a, 0x19, 0x2e, 0xf9, 0x7a, 0xbc,
-	0xa4, 0x49, 0xeb, 0x8c, 0xb1, 0x99, 0xc0, 0x8a, 0xe9, 0x4d, 0xd8, 0x72, 0x3f, 0x1f, 0x91, 0xe1,
-	0x59, 0x25, 0x3b, 0x7b, 0xb9, 0x3f, 0x65, 0x4c, 0x6c, 0xb9, 0x9c, 0x1b, 0x35, 0x60, 0xa9, 0x4d,
-	0xba, 0xa6, 0xa5, 0xb5, 0xfb, 0x76, 0xe7, 0x49, 0x25, 0xc7, 0x85, 0xd5, 0x38, 0xe1, 0x1a, 0x63,
-	0xad, 0x31, 0xce, 0x66, 0x02, 0x43, 0xdb, 0x9f, 0xa1, 0xef, 0x43, 0xa1, 0xd3, 0x23, 0x9d, 0x27,
-	0x1a, 0x3d, 0xad, 0xe4, 0xb9, 0x8e, 0x8d, 0x38, 0x1d, 0x75, 0xc6, 0xd7, 0x3a, 0x6d, 0x26, 0x70,
-	0xbe, 0x23, 0x86, 0x6c, 0xff, 0x06, 0xe9, 0x9b, 0x27, 0x64, 0xc8, 0xe4, 0x0b, 0xb3, 0xf7, 0x7f,
-	0x4f, 0x70, 0x72, 0x0d, 0x8a, 0xe1, 0x4d, 0xd0, 0x8f, 0x40, 0x21, 0x96, 0x21, 0xb7, 0xa1, 0x70,
-	0x15, 0x57, 0x63, 0xcf, 0xd9, 0x32, 0xbc, 0x4d, 0x14, 0x88, 0x1c, 0xa3, 0xbb, 0x90, 0xeb, 0xd8,
-	0x83, 0x81, 0x49, 0x2b, 0xc0, 0xa5, 0xd7, 0x63, 0x37, 0xc0, 0xb9, 0x9a, 0x09, 0x2c, 0xf9, 0xd1,
-	0x3e, 0x94, 0xfa, 0xa6, 0x4b, 0x35, 0xd7, 0xd2, 0x1d, 0xb7, 0x67, 0x53, 0xb7, 0xb2, 0xc4, 0x35,
-	0x5c, 0x8f, 0xd3, 0xb0, 0x67, 0xba, 0xf4, 0xc8, 0x63, 0x6e, 0x26, 0x70, 0xb1, 0x1f, 0x26, 0x30,
-	0x7d, 0xf6, 0xf1, 0x31, 0x19, 0xfa, 0x0a, 0x2b, 0xcb, 0xb3, 0xf5, 0x1d, 0x30, 0x6e, 0x4f, 0x9e,
-	0xe9, 0xb3, 0xc3, 0x04, 0xf4, 0x73, 0xb8, 0xd0, 0xb7, 0x75, 0xc3, 0x57, 0xa7, 0x75, 0x7a, 0x23,
-	0xeb, 0x49, 0xa5, 0xc8, 0x95, 0xde, 0x88, 0x5d, 0xa4, 0xad, 0x1b, 0x9e, 0x8a, 0x3a, 0x13, 0x68,
-	0x26, 0xf0, 0x6a, 0x7f, 0x9c, 0x88, 0x1e, 0xc3, 0x9a, 0xee, 0x38, 0xfd, 0xb3, 0x71, 0xed, 0x25,
-	0xae, 0xfd, 0x66, 0x9c, 0xf6, 0x1d, 0x26, 0x33, 0xae, 0x1e, 0xe9, 0x13, 0xd4, 0x5a, 0x1e, 0xb2,
-	0x27, 0x7a, 0x7f, 0x44, 0xd4, 0xef, 0xc0, 0x52, 0xc8, 0x4d, 0x51, 0x05, 0xf2, 0x03, 0xe2, 0xba,
-	0x7a, 0x97, 0x70, 0xaf, 0x56, 0xb0, 0x37, 0x55, 0x4b, 0xb0, 0x1c, 0x76, 0x4d, 0xf5, 0x79, 0xd2,
-	0x97, 0x64, 0x5e, 0xc7, 0x24, 0x4f, 0xc8, 0xd0, 0x35, 0x6d, 0xcb, 0x93, 0x94, 0x53, 0x74, 0x0d,
-	0x8a, 0xfc, 0xfe, 0x68, 0xde, 0x77, 0xe6, 0xfa, 0x19, 0xbc, 0xcc, 0x89, 0x8f, 0x24, 0xd3, 0x06,
-	0x2c, 0x39, 0xdb, 0x8e, 0xcf, 0x92, 0xe6, 0x2c, 0xe0, 0x6c, 0x3b, 0x1e, 0xc3, 0xeb, 0xb0, 0xcc,
-	0x76, 0xea, 0x73, 0x64, 0xf8, 0x8f, 0x2c, 0x31, 0x9a, 0x64, 0x51, 0xff, 0x92, 0x82, 0xf2, 0xb8,
-	0x3b, 0xa3, 0xbb, 0x90, 0x61, 0x91, 0x4d, 0x06, 0xa9, 0xea, 0xa6, 0x08, 0x7b, 0x9b, 0x5e, 0xd8,
-	0xdb, 0x6c, 0x79, 0x61, 0xaf, 0x56, 0xf8, 0xea, 0x9b, 0x8d, 0xc4, 0xf3, 0xbf, 0x6f, 0x24, 0x31,
-	0x97, 0x40, 0x97, 0x98, 0xf7, 0xe9, 0xa6, 0xa5, 0x99, 0x06, 0x5f, 0xb2, 0xc2, 0x5c, 0x4b, 0x37,
-	0xad, 0x5d, 0x03, 0xed, 0x41, 0xb9, 0x63, 0x5b, 0x2e, 0xb1, 0xdc, 0x91, 0xab, 0x89, 0xb0, 0x2a,
-	0x43, 0x53, 0xc4, 0xc1, 0x44, 0xb0, 0xae, 0x7b, 0x9c, 0x87, 0x9c, 0x11, 0xaf, 0x74, 0xa2, 0x04,
-	0x74, 0x1f, 0xe0, 0x44, 0xef, 0x9b, 0x86, 0x4e, 0xed, 0xa1, 0x5b, 0xc9, 0x5c, 0x4d, 0x4f, 0xf5,
-	0xb2, 0x47, 0x1e, 0xcb, 0x43, 0xc7, 0xd0, 0x29, 0xa9, 0x65, 0xd8, 0x72, 0x71, 0x48, 0x12, 0xbd,
-	0x01, 0x2b, 0xba, 0xe3, 0x68, 0x2e, 0xd5, 0x29, 0xd1, 0xda, 0x67, 0x94, 0xb8, 0x3c, 0x6c, 0x2d,
-	0xe3, 0xa2, 0xee, 0x38, 0x47, 0x8c, 0x5a, 0x63, 0x44, 0x74, 0x1d, 0x4a, 0x2c, 0xc2, 0x99, 0x7a,
-	0x5f, 0xeb, 0x11, 0xb3, 0xdb, 0xa3, 0x3c, 0x40, 0xa5, 0x71, 0x51, 0x52, 0x9b, 0x9c, 0xa8, 0x1a,
-	0xfe, 0x89, 0xf3, 0xe8, 0x86, 0x10, 0x64, 0x0c, 0x9d, 0xea, 0xdc, 0x92, 0xcb, 0x98, 0x8f, 0x19,
-	0xcd, 0xd1, 0x69, 0x4f, 0xda, 0x87, 0x8f, 0xd1, 0x45, 0xc8, 0x49, 0xb5, 0x69, 0xae, 0x56, 0xce,
-	0xd0, 0x1a, 0x64, 0x9d, 0xa1, 0x7d, 0x42, 0xf8, 0xd1, 0x15, 0xb0, 0x98, 0xa8, 0xbf, 0x4e, 0xc1,
-	0xea, 0x44, 0x1c, 0x64, 0x7a, 0x7b, 0xba, 0xdb, 0xf3, 0x7e, 0x8b, 0x8d, 0xd1, 0x1d, 0xa6, 0x57,
-	0x37, 0xc8, 0x50, 0xe6, 0x8e, 0xca, 0xa4, 0xa9, 0x9b, 0xfc, 0xbb, 0x34, 0x8d, 0xe4, 0x46, 0x07,
-	0x50, 0xee, 0xeb, 0x2e, 0xd5, 0x44, 0x5c, 0xd1, 0x42, 0x79, 0x64, 0x32, 0x9a, 0xee, 0xe9, 0x5e,
-	0x24, 0x62, 0x97, 0x5a, 0x2a, 0x2a, 0xf5, 0x23, 0x54, 0x84, 0x61, 0xad, 0x7d, 0xf6, 0x85, 0x6e,
-	0x51, 0xd3, 0x22, 0xda, 0xc4, 0xc9, 0x5d, 0x9a, 0x50, 0xda, 0x38, 0x31, 0x0d, 0x62, 0x75, 0xbc,
-	0x23, 0xbb, 0xe0, 0x0b, 0xfb, 0x47, 0xea, 0xaa, 0x18, 0x4a, 0xd1, 0x48, 0x8e, 0x4a, 0x90, 0xa2,
-	0xa7, 0xd2, 0x00, 0x29, 0x7a, 0x8a, 0xbe, 0x0b, 0x19, 0xb6, 0x49, 0xbe, 0xf9, 0xd2, 0x94, 0x14,
-	0x28, 0xe5, 0x5a, 0x67, 0x0e, 0xc1, 0x9c, 0x53, 0x55, 0x7d, 0x77, 0xf0, 0xa3, 0xfb, 0xb8, 0x56,
-	0xf5, 0x06, 0xac, 0x8c, 0x85, 0xef, 0xd0, 0xf9, 0x25, 0xc3, 0xe7, 0xa7, 0xae, 0x40, 0x31, 0x12,
-	0xab, 0xd5, 0x8b, 0xb0, 0x36, 0x2d, 0xf4, 0xaa, 0x3d, 0x9f, 0x1e, 0x09, 0xa1, 0xe8, 0x36, 0x14,
-	0xfc, 0xd8, 0x2b, 0xdc, 0x71, 0xd2, 0x56, 0x1e, 0x33, 0xf6, 0x59, 0x99, 0x1f, 0xb2, 0x6b, 0xcd,
-	0xef, 0x43, 0x8a, 0x2f, 0x3c, 0xaf, 0x3b, 0x4e, 0x53, 0x77, 0x7b, 0xea, 0xa7, 0x50, 0x89, 0x8b,
-	0xab, 0x63, 0xdb, 0xc8, 0xf8, 0xd7, 0xf0, 0x22, 0xe4, 0x8e, 0xed, 0xe1, 0x40, 0xa7, 0x5c, 0x59,
-	0x11, 0xcb, 0x19, 0xbb, 0x9e, 0x22, 0xc6, 0xa6, 0x39, 0x59, 0x4c, 0x54, 0x0d, 0x2e, 0xc5, 0xc6,
-	0x56, 0x26, 0x62, 0x5a, 0x06, 0x11, 0xf6, 0x2c, 0x62, 0x31, 0x09, 0x14, 0x89, 0xc5, 0x8a, 0x09,
-	0xfb, 0x59, 0x97, 0xef, 0x95, 0xeb, 0x57, 0xb0, 0x9c, 0xa9, 0xbf, 0x2f, 0x40, 0x01, 0x13, 0xd7,
-	0x61, 0x31, 0x01, 0xd5, 0x40, 0x21, 0xa7, 0x1d, 0xe2, 0x50, 0x2f, 0x8c, 0x4e, 0x47, 0x0d, 0x82,
-	0xbb, 0xe1, 0x71, 0xb2, 0x94, 0xed, 0x8b, 0xa1, 0x5b, 0x12, 0x95, 0xc5, 0x03, 0x2c, 0x29, 0x1e,
-	0x86, 0x65, 0x77, 0x3c, 0x58, 0x96, 0x8e, 0xcd, 0xd2, 0x42, 0x6a, 0x0c, 0x97, 0xdd, 0x92, 0xb8,
-	0x2c, 0x33, 0xe7, 0xc7, 0x22, 0xc0, 0xac, 0x1e, 0x01, 0x66, 0xd9, 0x39, 0xdb, 0x8c, 0x41, 0x66,
-	0x77, 0x3c, 0x64, 0x96, 0x9b, 0xb3, 0xe2, 0x31, 0x68, 0x76, 0x3f, 0x0a, 0xcd, 0x04, 0xac, 0xba,
-	0x16, 0x2b, 0x1d, 0x8b, 0xcd, 0x7e, 0x10, 0xc2, 0x66, 0x85, 0x58, 0x60, 0x24, 0x94, 0x4c, 0x01,
-	0x67, 0xf5, 0x08, 0x38, 0x53, 0xe6, 0xd8, 0x20, 0x06, 0x9d, 0x7d, 0x10, 0x46, 0x67, 0x10, 0x0b,
-	0xf0, 0xe4, 0x79, 0x4f, 0x83, 0x67, 0xef, 0xf9, 0xf0, 0x6c, 0x29, 0x16, 0x5f, 0xca, 0x3d, 0x8c,
-	0xe3, 0xb3, 0x83, 0x09, 0x7c, 0x26, 0xf0, 0xd4, 0x1b, 0xb1, 0x2a, 0xe6, 0x00, 0xb4, 0x83, 0x09,
-	0x80, 0x56, 0x9c, 0xa3, 0x70, 0x0e, 0x42, 0xfb, 0xc5, 0x74, 0x84, 0x16, 0x8f, 0xa1, 0xe4, 0x32,
-	0x17, 0x83, 0x68, 0x5a, 0x0c, 0x44, 0x5b, 0xe1, 0xea, 0xdf, 0x8a, 0x55, 0x7f, 0x7e, 0x8c, 0x76,
-	0x83, 0x65, 0xc8, 0x31, 0x9f, 0x67, 0x51, 0x86, 0x0c, 0x87, 0xf6, 0x50, 0xa2, 0x2d, 0x31, 0x51,
-	0xdf, 0x64, 0x39, 0x3b, 0xf0, 0xef, 0x19, 0x78, 0x8e, 0x47, 0xf3, 0x90, 0x4f, 0xab, 0x7f, 0x4c,
-	0x06, 0xb2, 0x3c, 0xcd, 0x85, 0xf3, 0xbd, 0x22, 0xf3, 0x7d, 0x08, 0xe5, 0xa5, 0xa2, 0x28, 0x6f,
-	0x03, 0x96, 0x58, 0x94, 0x1e, 0x03, 0x70, 0xba, 0xe3, 0x03, 0xb8, 0x9b, 0xb0, 0xca, 0xd3, 0xb0,
-	0xc0, 0x82, 0x32, 0x34, 0x67, 0x78, 0x86, 0x59, 0x61, 0x1f, 0xc4, 0xe5, 0x14, 0x31, 0xfa, 0x1d,
-	0xb8, 0x10, 0xe2, 0xf5, 0xa3, 0xbf, 0x40, 0x33, 0x65, 0x9f, 0x7b, 0x47, 0xa6, 0x81, 0x3f, 0x27,
-	0x03, 0x0b, 0x05, 0xc8, 0x6f, 0x1a, 0x48, 0x4b, 0xfe, 0x8f, 0x40, 0x5a, 0xea, 0xbf, 0x06, 0x69,
-	0xe1, 0x6c, 0x96, 0x8e, 0x66, 0xb3, 0x7f, 0x25, 0x83, 0x33, 0xf1, 0x21, 0x57, 0xc7, 0x36, 0x88,
-	0xcc, 0x2f, 0x7c, 0x8c, 0xca, 0x90, 0xee, 0xdb, 0x5d, 0x99, 0x45, 0xd8, 0x90, 0x71, 0xf9, 0x41,
-	0x58, 0x91, 0x31, 0xd6, 0x4f, 0x4d, 0x59, 0x6e, 0x61, 0x99, 0x9a, 0xca, 0x90, 0x7e, 0x42, 0x44,
-	0xc8, 0x5c, 0xc6, 0x6c, 0xc8, 0xf8, 0xf8, 0x25, 0xe3, 0x81, 0x70, 0x19, 0x8b, 0x09, 0xba, 0x0b,
-	0x0a, 0x6f, 0x43, 0x68, 0xb6, 0xe3, 0xca, 0xe8, 0xf6, 0x5a, 0x78, 0xaf, 0xa2, 0xdb, 0xb0, 0x79,
-	0xc8, 0x78, 0x0e, 0x1c, 0x17, 0x17, 0x1c, 0x39, 0x0a, 0x65, 0x5d, 0x25, 0x02, 0xfe, 0x2e, 0x83,
-	0xc2, 0x56, 0xef, 0x3a, 0x7a, 0x87, 0xf0, 0x50, 0xa5, 0xe0, 0x80, 0xa0, 0x3e, 0x06, 0x34, 0x19,
-	0x70, 0x51, 0x13, 0x72, 0xe4, 0x84, 0x58, 0x94, 0x1d, 0x1b, 0x33, 0xf7, 0xc5, 0x29, 0xc8, 0x8a,
-	0x58, 0xb4, 0x56, 0x61, 0x46, 0xfe, 0xe7, 0x37, 0x1b, 0x65, 0xc1, 0xfd, 0xb6, 0x3d, 0x30, 0x29,
-	0x19, 0x38, 0xf4, 0x0c, 0x4b, 0x79, 0xf5, 0x57, 0x29, 0x06, 0x73, 0x22, 0xc1, 0x78, 0xaa, 0x6d,
-	0xbd, 0x2b, 0x9f, 0x0a, 0x41, 0xdc, 0xc5, 0xec, 0xbd, 0x0e, 0xd0, 0xd5, 0x5d, 0xed, 0xa9, 0x6e,
-	0x51, 0x62, 0x48, 0xa3, 0x87, 0x28, 0xa8, 0x0a, 0x05, 0x36, 0x1b, 0xb9, 0xc4, 0x90, 0x68, 0xdb,
-	0x9f, 0x87, 0xf6, 0x99, 0xff, 0x76, 0xfb, 0x8c, 0x5a, 0xb9, 0x30, 0x6e, 0xe5, 0xdf, 0xa4, 0x02,
-	0x37, 0x09, 0x10, 0xe1, 0xff, 0x9f, 0x1d, 0x7e, 0xcb, 0xeb, 0xc4, 0x68, 0x56, 0x44, 0x47, 0xb0,
-	0xea, 0x7b, 0xa9, 0x36, 0xe2, 0xde, 0xeb, 0xdd, 0xbb, 0x45, 0xdd, 0xbc, 0x7c, 0x12, 0x25, 0xbb,
-	0xe8, 0x63, 0x78, 0x75, 0x2c, 0x04, 0xf9, 0xaa, 0x53, 0x8b, 0x46, 0xa2, 0x57, 0xa2, 0x91, 0xc8,
-	0x53, 0x1d, 0x18, 0x2b, 0xfd, 0x2d, 0x9d, 0x63, 0x97, 0x95, 0x1e, 0xe1, 0x24, 0x3f, 0xf5, 0xf8,
-	0xaf, 0x41, 0x71, 0x48, 0x28, 0x2b, 0x87, 0x23, 0xc5, 0xdd, 0xb2, 0x20, 0xca, 0x92, 0xf1, 0x10,
-	0x5e, 0x99, 0x9a, 0xec, 0xd1, 0xf7, 0x40, 0x09, 0x70, 0x42, 0x32, 0xa6, 0x4e, 0xf2, 0xb1, 0x7f,
-	0xc0, 0xab, 0xfe, 0x29, 0x19, 0xa8, 0x8c, 0x56, 0x13, 0x0d, 0xc8, 0x0d, 0x89, 0x3b, 0xea, 0x0b,
-	0x7c, 0x5f, 0xda, 0x7e, 0x67, 0x31, 0x98, 0xc0, 0xa8, 0xa3, 0x3e, 0xc5, 0x52, 0x58, 0x7d, 0x0c,
-	0x39, 0x41, 0x41, 0x4b, 0x90, 0x7f, 0xb8, 0xff, 0x60, 0xff, 0xe0, 0xa3, 0xfd, 0x72, 0x02, 0x01,
-	0xe4, 0x76, 0xea, 0xf5, 0xc6, 0x61, 0xab, 0x9c, 0x44, 0x0a, 0x64, 0x77, 0x6a, 0x07, 0xb8, 0x55,
-	0x4e, 0x31, 0x32, 0x6e, 0x7c, 0xd8, 0xa8, 0xb7, 0xca, 0x69, 0xb4, 0x0a, 0x45, 0x31, 0xd6, 0xee,
-	0x1f, 0xe0, 0x9f, 0xec, 0xb4, 0xca, 0x99, 0x10, 0xe9, 0xa8, 0xb1, 0x7f, 0xaf, 0x81, 0xcb, 0x59,
-	0xf5, 0x5d, 0x56, 0x40, 0xc4, 0x00, 0x8b, 0xa0, 0x54, 0x48, 0x86, 0x4a, 0x05, 0xf5, 0x77, 0x29,
-	0xa8, 0xc6, 0xa3, 0x05, 0xf4, 0xe1, 0xd8, 0xc6, 0xb7, 0xcf, 0x01, 0x35, 0xc6, 0x76, 0x8f, 0xae,
-	0x43, 0x69, 0x48, 0x8e, 0x09, 0xed, 0xf4, 0x04, 0x7a, 0x11, 0x99, 0xad, 0x88, 0x8b, 0x92, 0xca,
-	0x85, 0x5c, 0xc1, 0xf6, 0x19, 0xe9, 0x50, 0x4d, 0x54, 0x2d, 0xe2, 0xd2, 0x29, 0x8c, 0x8d, 0x51,
-	0x8f, 0x04, 0x51, 0xfd, 0xf4, 0x5c, 0xb6, 0x54, 0x20, 0x8b, 0x1b, 0x2d, 0xfc, 0x71, 0x39, 0x8d,
-	0x10, 0x94, 0xf8, 0x50, 0x3b, 0xda, 0xdf, 0x39, 0x3c, 0x6a, 0x1e, 0x30, 0x5b, 0x5e, 0x80, 0x15,
-	0xcf, 0x96, 0x1e, 0x31, 0xab, 0x7e, 0x02, 0xa5, 0x68, 0x89, 0xce, 0x4c, 0x38, 0xb4, 0x47, 0x96,
-	0xc1, 0x8d, 0x91, 0xc5, 0x62, 0x82, 0x6e, 0x43, 0xf6, 0xc4, 0x16, 0x6e, 0x36, 0xfd, 0xae, 0x3d,
-	0xb2, 0x29, 0x09, 0x95, 0xf8, 0x82, 0x5b, 0xfd, 0x02, 0xb2, 0xdc, 0x6b, 0x98, 0x07, 0xf0, 0x62,
-	0x5b, 0x62, 0x1f, 0x36, 0x46, 0x9f, 0x00, 0xe8, 0x94, 0x0e, 0xcd, 0xf6, 0x28, 0x50, 0xbc, 0x31,
-	0xdd, 0xeb, 0x76, 0x3c, 0xbe, 0xda, 0x65, 0xe9, 0x7e, 0x6b, 0x81, 0x68, 0xc8, 0x05, 0x43, 0x0a,
-	0xd5, 0x7d, 0x28, 0x45, 0x65, 0xbd, 0x6c, 0x9d, 0x9c, 0x92, 0xad, 0x53, 0xe1, 0x6c, 0xed, 0xe7,
-	0xfa, 0xb4, 0x68, 0xac, 0xf0, 0x89, 0xfa, 0x2c, 0x09, 0x85, 0xd6, 0xa9, 0x3c, 0x8f, 0x98, 0x9a,
-	0x3e, 0x10, 0x4d, 0x85, 0x2b, 0x58, 0xd1, 0x24, 0x48, 0xfb, 0xad, 0x87, 0x0f, 0xfc, 0x1b, 0x97,
-	0x59, 0xb4, 0x50, 0xf1, 0x7a, 0x30, 0xd2, 0xcb, 0xde, 0x07, 0xc5, 0x8f, 0x99, 0x0c, 0x44, 0xea,
-	0x86, 0x31, 0x24, 0xae, 0x2b, 0xf7, 0xe6, 0x4d, 0x79, 0x8b, 0xc8, 0x7e, 0x2a, 0x6b, 0xe4, 0x34,
-	0x16, 0x13, 0xd5, 0x80, 0x95, 0xb1, 0x80, 0x8b, 0xde, 0x87, 0xbc, 0x33, 0x6a, 0x6b, 0x9e, 0x79,
-	0xc6, 0x9e, 0x04, 0x3c, 0x78, 0x32, 0x6a, 0xf7, 0xcd, 0xce, 0x03, 0x72, 0xe6, 0x2d, 0xc6, 0x19,
-	0xb5, 0x1f, 0x08, 0x2b, 0x8a, 0x5f, 0x49, 0x85, 0x7f, 0xe5, 0x04, 0x0a, 0xde, 0xa5, 0x40, 0x3f,
-	0x04, 0xc5, 0x8f, 0xe5, 0x7e, 0xe7, 0x30, 0x36, 0x09, 0x48, 0xf5, 0x81, 0x08, 0xc3, 0xba, 0xae,
-	0xd9, 0xb5, 0x88, 0xa1, 0x05, 0x30, 0x96, 0xff, 0x5a, 0x01, 0xaf, 0x88, 0x0f, 0x7b, 0x1e, 0x86,
-	0x55, 0xff, 0x9d, 0x84, 0x82, 0xd7, 0x21, 0x42, 0xef, 0x86, 0xee, 0x5d, 0x69, 0x4a, 0x3d, 0xed,
-	0x31, 0x06, 0x5d, 0x9e, 0xe8, 0x5a, 0x53, 0xe7, 0x5f, 0x6b, 0x5c, 0xbb, 0xce, 0x6b, 0x9c, 0x66,
-	0xce, 0xdd, 0x38, 0x7d, 0x1b, 0x10, 0xb5, 0xa9, 0xde, 0xd7, 0x4e, 0x6c, 0x6a, 0x5a, 0x5d, 0x4d,
-	0x18, 0x5b, 0x60, 0x81, 0x32, 0xff, 0xf2, 0x88, 0x7f, 0x38, 0xe4, 0x76, 0xff, 0x65, 0x12, 0x0a,
-	0x7e, 0x50, 0x3f, 0x6f, 0xd3, 0xe6, 0x22, 0xe4, 0x64, 0xdc, 0x12, 0x5d, 0x1b, 0x39, 0xf3, 0xfb,
-	0x87, 0x99, 0x50, 0xff, 0xb0, 0x0a, 0x85, 0x01, 0xa1, 0x3a, 0xcf, 0x6c, 0xa2, 0x92, 0xf0, 0xe7,
-	0x37, 0xdf, 0x83, 0xa5, 0x50, 0xff, 0x8c, 0x79, 0xde, 0x7e, 0xe3, 0xa3, 0x72, 0xa2, 0x9a, 0x7f,
-	0xf6, 0xe5, 0xd5, 0xf4, 0x3e, 0x79, 0xca, 0xee, 0x2c, 0x6e, 0xd4, 0x9b, 0x8d, 0xfa, 0x83, 0x72,
-	0xb2, 0xba, 0xf4, 0xec, 0xcb, 0xab, 0x79, 0x4c, 0x78, 0x2d, 0x7f, 0xb3, 0x09, 0xcb, 0xe1, 0x53,
-	0x89, 0x86, 0x3e, 0x04, 0xa5, 0x7b, 0x0f, 0x0f, 0xf7, 0x76, 0xeb, 0x3b, 0xad, 0x86, 0xf6, 0xe8,
-	0xa0, 0xd5, 0x28, 0x27, 0xd1, 0xab, 0x70, 0x61, 0x6f, 0xf7, 0xc7, 0xcd, 0x96, 0x56, 0xdf, 0xdb,
-	0x6d, 0xec, 0xb7, 0xb4, 0x9d, 0x56, 0x6b, 0xa7, 0xfe, 0xa0, 0x9c, 0xda, 0xfe, 0x83, 0x02, 0x2b,
-	0x3b, 0xb5, 0xfa, 0x2e, 0x0b, 0xdb, 0x66, 0x47, 0xe7, 0x65, 0x5e, 0x1d, 0x32, 0xbc, 0x90, 0x9b,
-	0xf9, 0xba, 0x56, 0x9d, 0xdd, 0xe5, 0x41, 0xf7, 0x21, 0xcb, 0x6b, 0x3c, 0x34, 0xfb, 0xb9, 0xad,
-	0x3a, 0xa7, 0xed, 0xc3, 0x16, 0xc3, 0xdd, 0x63, 0xe6, 0xfb, 0x5b, 0x75, 0x76, 0x17, 0x08, 0x61,
-	0x50, 0x02, 0xf0, 0x39, 0xff, 0x3d, 0xaa, 0xba, 0x40, 0xb0, 0x41, 0x7b, 0x90, 0xf7, 0x60, 0xfd,
-	0xbc, 0x17, 0xb2, 0xea, 0xdc, 0x36, 0x0d, 0x33, 0x97, 0x28, 0xbf, 0x66, 0x3f, 0xf7, 0x55, 0xe7,
-	0xf4, 0x9c, 0xd0, 0x2e, 0xe4, 0x24, 0xa0, 0x9a, 0xf3, 0xea, 0x55, 0x9d, 0xd7, 0x76, 0x61, 0x46,
-	0x0b, 0x0a, 0xdb, 0xf9, 0x8f, 0x98, 0xd5, 0x05, 0xda, 0x69, 0xe8, 0x21, 0x40, 0xa8, 0xd8, 0x5a,
-	0xe0, 0x75, 0xb2, 0xba, 0x48, 0x9b, 0x0c, 0x1d, 0x40, 0xc1, 0x07, 0xd5, 0x73, 0xdf, 0x0a, 0xab,
-	0xf3, 0xfb, 0x55, 0xe8, 0x31, 0x14, 0xa3, 0x60, 0x72, 0xb1, 0x17, 0xc0, 0xea, 0x82, 0x8d, 0x28,
-	0xa6, 0x3f, 0x8a, 0x2c, 0x17, 0x7b, 0x11, 0xac, 0x2e, 0xd8, 0x97, 0x42, 0x9f, 0xc1, 0xea, 0x24,
-	0xf2, 0x5b, 0xfc, 0x81, 0xb0, 0x7a, 0x8e, 0x4e, 0x15, 0x1a, 0x00, 0x9a, 0x82, 0x18, 0xcf, 0xf1,
-	0x5e, 0x58, 0x3d, 0x4f, 0xe3, 0xaa, 0xd6, 0xf8, 0xea, 0xc5, 0x7a, 0xf2, 0xeb, 0x17, 0xeb, 0xc9,
-	0x7f, 0xbc, 0x58, 0x4f, 0x3e, 0x7f, 0xb9, 0x9e, 0xf8, 0xfa, 0xe5, 0x7a, 0xe2, 0x6f, 0x2f, 0xd7,
-	0x13, 0x3f, 0x7b, 0xab, 0x6b, 0xd2, 0xde, 0xa8, 0xbd, 0xd9, 0xb1, 0x07, 0x5b, 0xe1, 0x3f, 0x22,
-	0x4c, 0xfb, 0x73, 0x44, 0x3b, 0xc7, 0x93, 0xca, 0xad, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x63,
-	0x45, 0xfb, 0xc8, 0x3c, 0x21, 0x00, 0x00,
+	// 2780 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x73, 0x23, 0xc5,
+	0x15, 0xd7, 0xa7, 0x25, 0x3d, 0x7d, 0xba, 0xd7, 0x2c, 0xda, 0x61, 0xb1, 0x97, 0xa1, 0xf8, 0xda,
+	0x80, 0x1d, 0x4c, 0x41, 0xa0, 0xc8, 0x07, 0x96, 0x56, 0x1b, 0x99, 0x75, 0x6c, 0xa7, 0xad, 0x5d,
+	0x8a, 0x24, 0xec, 0x30, 0xd2, 0xb4, 0xad, 0x61, 0xa5, 0x99, 0x41, 0xd3, 0x32, 0x36, 0xa7, 0x54,
+	0x2a, 0xb9, 0x50, 0xa9, 0x0a, 0xc7, 0x54, 0xa5, 0xf8, 0x3f, 0x72, 0xca, 0x29, 0x07, 0x0e, 0x39,
+	0x70, 0xcc, 0x89, 0xa4, 0x96, 0x5b, 0x6e, 0x39, 0xe5, 0x94, 0xaa, 0x54, 0x7f, 0x8d, 0x66, 0x24,
+	0x8d, 0x24, 0x87, 0xdc, 0x72, 0xeb, 0x7e, 0x7a, 0xef, 0x4d, 0xf7, 0xeb, 0xee, 0xdf, 0xfb, 0xf5,
+	0x6b, 0xc1, 0x53, 0x94, 0x38, 0x16, 0x19, 0x0d, 0x6d, 0x87, 0xee, 0x98, 0xdd, 0x9e, 0xbd, 0x43,
+	0x2f, 0x3d, 0xe2, 0x6f, 0x7b, 0x23, 0x97, 0xba, 0xa8, 0x3a, 0xf9, 0x71, 0x9b, 0xfd, 0xa8, 0x3d,
+	0x1d, 0xd2, 0xee, 0x8d, 0x2e, 0x3d, 0xea, 0xee, 0x78, 0x23, 0xd7, 0x3d, 0x15, 0xfa, 0xda, 0xcd,
+	0xd0, 0xcf, 0xdc, 0x4f, 0xd8, 0x9b, 0x76, 0x73, 0xd6, 0xf8, 0x11, 0xb9, 0x54, 0xbf, 0x3e, 0x3d,
+	0x63, 0xeb, 0x99, 0x23, 0x73, 0xa8, 0x7e, 0xde, 0x3a, 0x73, 0xdd, 0xb3, 0x01, 0xd9, 0xe1, 0xbd,
+	0xee, 0xf8, 0x74, 0x87, 0xda, 0x43, 0xe2, 0x53, 0x73, 0xe8, 0x49, 0x85, 0x8d, 0x33, 0xf7, 0xcc,
+	0xe5, 0xcd, 0x1d, 0xd6, 0x12, 0x52, 0xfd, 0x8b, 0x3c, 0xe4, 0x30, 0xf9, 0x78, 0x4c, 0x7c, 0x8a,
+	0x76, 0x21, 0x43, 0x7a, 0x7d, 0xb7, 0x9e, 0xbc, 0x95, 0x7c, 0xb1, 0xb8, 0x7b, 0x73, 0x7b, 0x6a,
+	0x72, 0xdb, 0x52, 0xaf, 0xd5, 0xeb, 0xbb, 0xed, 0x04, 0xe6, 0xba, 0xe8, 0x75, 0xc8, 0x9e, 0x0e,
+	0xc6, 0x7e, 0xbf, 0x9e, 0xe2, 0x46, 0x4f, 0xc7, 0x19, 0xdd, 0x65, 0x4a, 0xed, 0x04, 0x16, 0xda,
+	0xec, 0x53, 0xb6, 0x73, 0xea, 0xd6, 0xd3, 0x8b, 0x3f, 0xb5, 0xef, 0x9c, 0xf2, 0x4f, 0x31, 0x5d,
+	0xd4, 0x00, 0xb0, 0x1d, 0x9b, 0x1a, 0xbd, 0xbe, 0x69, 0x3b, 0xf5, 0x0c, 0xb7, 0x7c, 0x26, 0xde,
+	0xd2, 0xa6, 0x4d, 0xa6, 0xd8, 0x4e, 0xe0, 0x82, 0xad, 0x3a, 0x6c, 0xb8, 0x1f, 0x8f, 0xc9, 0xe8,
+	0xb2, 0x9e, 0x5d, 0x3c, 0xdc, 0x9f, 0x32, 0x25, 0x36, 0x5c, 0xae, 0x8d, 0x5a, 0x50, 0xec, 0x92,
+	0x33, 0xdb, 0x31, 0xba, 0x03, 0xb7, 0xf7, 0xa8, 0xbe, 0xc6, 0x8d, 0xf5, 0x38, 0xe3, 0x06, 0x53,
+	0x6d, 0x30, 0xcd, 0x76, 0x02, 0x43, 0x37, 0xe8, 0xa1, 0xef, 0x43, 0xbe, 0xd7, 0x27, 0xbd, 0x47,
+	0x06, 0xbd, 0xa8, 0xe7, 0xb8, 0x8f, 0xad, 0x38, 0x1f, 0x4d, 0xa6, 0xd7, 0xb9, 0x68, 0x27, 0x70,
+	0xae, 0x27, 0x9a, 0x6c, 0xfe, 0x16, 0x19, 0xd8, 0xe7, 0x64, 0xc4, 0xec, 0xf3, 0x8b, 0xe7, 0x7f,
+	0x47, 0x68, 0x72, 0x0f, 0x05, 0x4b, 0x75, 0xd0, 0x8f, 0xa0, 0x40, 0x1c, 0x4b, 0x4e, 0xa3, 0xc0,
+	0x5d, 0xdc, 0x8a, 0x5d, 0x67, 0xc7, 0x52, 0x93, 0xc8, 0x13, 0xd9, 0x46, 0x6f, 0xc2, 0x5a, 0xcf,
+	0x1d, 0x0e, 0x6d, 0x5a, 0x07, 0x6e, 0xbd, 0x19, 0x3b, 0x01, 0xae, 0xd5, 0x4e, 0x60, 0xa9, 0x8f,
+	0x0e, 0xa1, 0x32, 0xb0, 0x7d, 0x6a, 0xf8, 0x8e, 0xe9, 0xf9, 0x7d, 0x97, 0xfa, 0xf5, 0x22, 0xf7,
+	0xf0, 0x5c, 0x9c, 0x87, 0x03, 0xdb, 0xa7, 0x27, 0x4a, 0xb9, 0x9d, 0xc0, 0xe5, 0x41, 0x58, 0xc0,
+	0xfc, 0xb9, 0xa7, 0xa7, 0x64, 0x14, 0x38, 0xac, 0x97, 0x16, 0xfb, 0x3b, 0x62, 0xda, 0xca, 0x9e,
+	0xf9, 0x73, 0xc3, 0x02, 0xf4, 0x73, 0xb8, 0x36, 0x70, 0x4d, 0x2b, 0x70, 0x67, 0xf4, 0xfa, 0x63,
+	0xe7, 0x51, 0xbd, 0xcc, 0x9d, 0xbe, 0x14, 0x3b, 0x48, 0xd7, 0xb4, 0x94, 0x8b, 0x26, 0x33, 0x68,
+	0x27, 0xf0, 0xfa, 0x60, 0x5a, 0x88, 0x1e, 0xc2, 0x86, 0xe9, 0x79, 0x83, 0xcb, 0x69, 0xef, 0x15,
+	0xee, 0xfd, 0x76, 0x9c, 0xf7, 0x3d, 0x66, 0x33, 0xed, 0x1e, 0x99, 0x33, 0x52, 0x16, 0x8c, 0x53,
+	0xdb, 0x31, 0x07, 0xf6, 0xa7, 0x44, 0x2e, 0x6e, 0x75, 0x71, 0x30, 0xee, 0x4a, 0x6d, 0xb5, 0xc2,
+	0xe5, 0xd3, 0xb0, 0xa0, 0x91, 0x83, 0xec, 0xb9, 0x39, 0x18, 0x13, 0xfd, 0x05, 0x28, 0x86, 0x8e,
+	0x3d, 0xaa, 0x43, 0x6e, 0x48, 0x7c, 0xdf, 0x3c, 0x23, 0x1c, 0x25, 0x0a, 0x58, 0x75, 0xf5, 0x0a,
+	0x94, 0xc2, 0x47, 0x5d, 0xff, 0x3c, 0x09, 0xc5, 0xd0, 0x29, 0x66, 0x96, 0xe7, 0x64, 0xe4, 0xdb,
+	0xae, 0xa3, 0x2c, 0x65, 0x17, 0x3d, 0x0b, 0x65, 0x3e, 0x64, 0x43, 0xfd, 0xce, 0xa0, 0x24, 0x83,
+	0x4b, 0x5c, 0xf8, 0x40, 0x2a, 0x6d, 0x41, 0xd1, 0xdb, 0xf5, 0x02, 0x95, 0x34, 0x57, 0x01, 0x6f,
+	0xd7, 0x53, 0x0a, 0xcf, 0x40, 0x89, 0xcd, 0x2f, 0xd0, 0xc8, 0xf0, 0x8f, 0x14, 0x99, 0x4c, 0xaa,
+	0xe8, 0x7f, 0x49, 0x41, 0x6d, 0x1a, 0x1e, 0xd0, 0x9b, 0x90, 0x61, 0x48, 0x29, 0x41, 0x4f, 0xdb,
+	0x16, 0x30, 0xba, 0xad, 0x60, 0x74, 0xbb, 0xa3, 0x60, 0xb4, 0x91, 0xff, 0xf2, 0xeb, 0xad, 0xc4,
+	0xe7, 0x7f, 0xdb, 0x4a, 0x62, 0x6e, 0x81, 0x6e, 0xb0, 0xd3, 0x6c, 0xda, 0x8e, 0x61, 0x5b, 0x7c,
+	0xc8, 0x05, 0x76, 0x54, 0x4d, 0xdb, 0xd9, 0xb7, 0xd0, 0x01, 0xd4, 0x7a, 0xae, 0xe3, 0x13, 0xc7,
+	0x1f, 0xfb, 0x86, 0x80, 0xe9, 0x7a, 0x7a, 0xf6, 0xc0, 0x0a, 0xf0, 0x6f, 0x2a, 0xcd, 0x63, 0xae,
+	0x88, 0xab, 0xbd, 0xa8, 0x00, 0xdd, 0x05, 0x38, 0x37, 0x07, 0xb6, 0x65, 0x52, 0x77, 0xe4, 0xd7,
+	0x33, 0xb7, 0xd2, 0x73, 0x4f, 0xed, 0x03, 0xa5, 0x72, 0xdf, 0xb3, 0x4c, 0x4a, 0x1a, 0x19, 0x36,
+	0x5c, 0x1c, 0xb2, 0x44, 0xcf, 0x43, 0xd5, 0xf4, 0x3c, 0xc3, 0xa7, 0x26, 0x25, 0x46, 0xf7, 0x92,
+	0x12, 0x9f, 0xc3, 0x60, 0x09, 0x97, 0x4d, 0xcf, 0x3b, 0x61, 0xd2, 0x06, 0x13, 0xa2, 0xe7, 0xa0,
+	0xc2, 0x10, 0xd3, 0x36, 0x07, 0x46, 0x9f, 0xd8, 0x67, 0x7d, 0xca, 0x01, 0x2f, 0x8d, 0xcb, 0x52,
+	0xda, 0xe6, 0x42, 0xdd, 0x82, 0x52, 0x18, 0x2d, 0x11, 0x82, 0x8c, 0x65, 0x52, 0x93, 0x47, 0xb2,
+	0x84, 0x79, 0x9b, 0xc9, 0x3c, 0x93, 0xf6, 0x65, 0x7c, 0x78, 0x1b, 0x5d, 0x87, 0x35, 0xe9, 0x36,
+	0xcd, 0xdd, 0xca, 0x1e, 0xda, 0x80, 0xac, 0x37, 0x72, 0xcf, 0x09, 0x5f, 0xba, 0x3c, 0x16, 0x1d,
+	0xfd, 0xd7, 0x29, 0x58, 0x9f, 0xc1, 0x55, 0xe6, 0xb7, 0x6f, 0xfa, 0x7d, 0xf5, 0x2d, 0xd6, 0x46,
+	0x6f, 0x30, 0xbf, 0xa6, 0x45, 0x46, 0x32, 0x17, 0xd5, 0x67, 0x43, 0xdd, 0xe6, 0xbf, 0xcb, 0xd0,
+	0x48, 0x6d, 0x74, 0x04, 0xb5, 0x81, 0xe9, 0x53, 0x43, 0xe0, 0x94, 0x11, 0xca, 0x4b, 0xb3, 0xe8,
+	0x7c, 0x60, 0x2a, 0x64, 0x63, 0x9b, 0x5a, 0x3a, 0xaa, 0x0c, 0x22, 0x52, 0x84, 0x61, 0xa3, 0x7b,
+	0xf9, 0xa9, 0xe9, 0x50, 0xdb, 0x21, 0xc6, 0xcc, 0xca, 0xdd, 0x98, 0x71, 0xda, 0x3a, 0xb7, 0x2d,
+	0xe2, 0xf4, 0xd4, 0x92, 0x5d, 0x0b, 0x8c, 0x83, 0x25, 0xf5, 0x75, 0x0c, 0x95, 0x68, 0x66, 0x40,
+	0x15, 0x48, 0xd1, 0x0b, 0x19, 0x80, 0x14, 0xbd, 0x40, 0xdf, 0x85, 0x0c, 0x9b, 0x24, 0x9f, 0x7c,
+	0x65, 0x4e, 0x4a, 0x95, 0x76, 0x9d, 0x4b, 0x8f, 0x60, 0xae, 0xa9, 0xeb, 0x50, 0x9b, 0xce, 0x16,
+	0xd3, 0x5e, 0xf5, 0x97, 0xa0, 0x3a, 0x95, 0x0e, 0x42, 0xeb, 0x97, 0x0c, 0xaf, 0x9f, 0x5e, 0x85,
+	0x72, 0x04, 0xfb, 0xf5, 0xeb, 0xb0, 0x31, 0x0f, 0xca, 0xf5, 0x3e, 0x6c, 0xcc, 0x83, 0x64, 0xf4,
+	0x3a, 0xe4, 0x03, 0x2c, 0x17, 0xc7, 0x71, 0x36, 0x56, 0x4a, 0x19, 0x07, 0xaa, 0xec, 0x1c, 0xb2,
+	0x6d, 0xcd, 0xf7, 0x43, 0x8a, 0x0f, 0x3c, 0x67, 0x7a, 0x5e, 0xdb, 0xf4, 0xfb, 0xfa, 0x87, 0x50,
+	0x8f, 0xc3, 0xe9, 0xa9, 0x69, 0x64, 0x82, 0x6d, 0x78, 0x1d, 0xd6, 0x4e, 0xdd, 0xd1, 0xd0, 0xa4,
+	0xdc, 0x59, 0x19, 0xcb, 0x1e, 0xdb, 0x9e, 0x02, 0xb3, 0xd3, 0x5c, 0x2c, 0x3a, 0xba, 0x01, 0x37,
+	0x62, 0xb1, 0x9a, 0x99, 0xd8, 0x8e, 0x45, 0x44, 0x3c, 0xcb, 0x58, 0x74, 0x26, 0x8e, 0xc4, 0x60,
+	0x45, 0x87, 0x7d, 0xd6, 0xe7, 0x73, 0xe5, 0xfe, 0x0b, 0x58, 0xf6, 0xf4, 0x3f, 0x26, 0x61, 0x63,
+	0x1e, 0x66, 0xa3, 0xfd, 0x28, 0x27, 0x49, 0xae, 0xca, 0x49, 0x14, 0x30, 0x84, 0x78, 0x49, 0x0d,
+	0xd2, 0xf4, 0xc2, 0xaf, 0xa7, 0x6e, 0xa5, 0x5f, 0x2c, 0x61, 0xd6, 0x44, 0xcd, 0x30, 0x4f, 0x48,
+	0xaf, 0xc6, 0x13, 0xa4, 0xe3, 0x80, 0x2b, 0xe8, 0xff, 0xcc, 0x43, 0x1e, 0x13, 0xdf, 0x63, 0x70,
+	0x86, 0x1a, 0x50, 0x20, 0x17, 0x3d, 0xe2, 0x51, 0x95, 0x01, 0xe6, 0x0f, 0x56, 0x68, 0xb7, 0x94,
+	0x26, 0x63, 0x2f, 0x81, 0x19, 0x7a, 0x4d, 0x12, 0xd4, 0x78, 0xae, 0x29, 0xcd, 0xc3, 0x0c, 0xf5,
+	0x0d, 0xc5, 0x50, 0xd3, 0xb1, 0x84, 0x45, 0x58, 0x4d, 0x51, 0xd4, 0xd7, 0x24, 0x45, 0xcd, 0x2c,
+	0xf9, 0x58, 0x84, 0xa3, 0x36, 0x23, 0x1c, 0x35, 0xbb, 0x64, 0x9a, 0x31, 0x24, 0xf5, 0x0d, 0x45,
+	0x52, 0xd7, 0x96, 0x8c, 0x78, 0x8a, 0xa5, 0xde, 0x8d, 0xee, 0x08, 0xc1, 0x30, 0x9f, 0x8d, 0xb5,
+	0x8e, 0xa5, 0xa9, 0x3f, 0x08, 0xd1, 0xd4, 0x7c, 0xec, 0xda, 0x0b, 0x27, 0x73, 0x78, 0x6a, 0x33,
+	0xc2, 0x53, 0x0b, 0x4b, 0x62, 0x10, 0x43, 0x54, 0xdf, 0x09, 0x6f, 0x40, 0x88, 0xe5, 0xba, 0x72,
+	0xbd, 0xe7, 0x31, 0xd5, 0xb7, 0x02, 0xa6, 0x5a, 0x8c, 0xa5, 0xda, 0x72, 0x0e, 0xd3, 0x54, 0xf5,
+	0x68, 0x86, 0xaa, 0x0a, 0x6a, 0xf9, 0x7c, 0xac, 0x8b, 0x25, 0x5c, 0xf5, 0x68, 0x86, 0xab, 0x96,
+	0x97, 0x38, 0x5c, 0x42, 0x56, 0x7f, 0x31, 0x9f, 0xac, 0xc6, 0xd3, 0x49, 0x39, 0xcc, 0xd5, 0xd8,
+	0xaa, 0x11, 0xc3, 0x56, 0x05, 0xa7, 0xfc, 0x4e, 0xac, 0xfb, 0x95, 0xe9, 0xea, 0xd1, 0x0c, 0x5d,
+	0xad, 0x2d, 0x89, 0xc7, 0xaa, 0x7c, 0xf5, 0x25, 0x58, 0x57, 0x26, 0x01, 0x88, 0x30, 0xc4, 0x25,
+	0xa3, 0x91, 0x3b, 0x92, 0xcc, 0x53, 0x74, 0xf4, 0x17, 0xa1, 0x14, 0xa8, 0x2e, 0xe6, 0xb6, 0x3c,
+	0xb3, 0x85, 0x40, 0x82, 0x81, 0x72, 0x29, 0x7c, 0xfe, 0x23, 0xdc, 0xa7, 0x20, 0xb9, 0x4f, 0x88,
+	0xf1, 0xa6, 0xa2, 0x8c, 0x77, 0x0b, 0x8a, 0x2c, 0x63, 0x4d, 0x91, 0x59, 0xd3, 0x0b, 0xc8, 0xec,
+	0x6d, 0x58, 0xe7, 0x94, 0x44, 0xf0, 0x62, 0x99, 0xa6, 0x32, 0x3c, 0xdb, 0x56, 0xd9, 0x0f, 0x22,
+	0x0a, 0x5c, 0x8c, 0x5e, 0x81, 0x6b, 0x21, 0xdd, 0x20, 0x13, 0x0a, 0x66, 0x57, 0x0b, 0xb4, 0xf7,
+	0x64, 0x4a, 0xfc, 0x73, 0x12, 0xd6, 0x67, 0xf0, 0x67, 0x2e, 0x61, 0x4d, 0xfe, 0x8f, 0x08, 0x6b,
+	0xea, 0xbf, 0x26, 0xac, 0xe1, 0xcc, 0x9e, 0x8e, 0x66, 0xf6, 0x7f, 0x25, 0xa1, 0x1c, 0x81, 0x41,
+	0xb6, 0x04, 0x3d, 0xd7, 0x22, 0x32, 0xd7, 0xf2, 0x36, 0x4b, 0x6c, 0x03, 0xf7, 0x4c, 0x66, 0x54,
+	0xd6, 0x64, 0x5a, 0x01, 0xaa, 0x17, 0x24, 0x68, 0x07, 0x69, 0x3a, 0xcb, 0x23, 0x2c, 0x3a, 0xcc,
+	0xf6, 0x11, 0x11, 0x18, 0x5c, 0xc2, 0xac, 0x89, 0x36, 0xe4, 0x26, 0xe3, 0xc8, 0x5a, 0xc2, 0xa2,
+	0x83, 0xde, 0x84, 0x02, 0x2f, 0xf1, 0x18, 0xae, 0xe7, 0x4b, 0xb8, 0x7c, 0x2a, 0x3c, 0x57, 0x51,
+	0xc9, 0xd9, 0x3e, 0x66, 0x3a, 0x47, 0x9e, 0x8f, 0xf3, 0x9e, 0x6c, 0x85, 0x18, 0x48, 0x21, 0x42,
+	0x84, 0x6f, 0x42, 0x81, 0x8d, 0xde, 0xf7, 0xcc, 0x1e, 0xe1, 0xd8, 0x57, 0xc0, 0x13, 0x81, 0xfe,
+	0x10, 0xd0, 0x2c, 0x82, 0xa3, 0x36, 0xac, 0x91, 0x73, 0xe2, 0x50, 0xb6, 0x6c, 0x2c, 0xdc, 0xd7,
+	0xe7, 0xb0, 0x4c, 0xe2, 0xd0, 0x46, 0x9d, 0x05, 0xf9, 0x1f, 0x5f, 0x6f, 0xd5, 0x84, 0xf6, 0xcb,
+	0xee, 0xd0, 0xa6, 0x64, 0xe8, 0xd1, 0x4b, 0x2c, 0xed, 0xf5, 0x3f, 0xa4, 0xa0, 0xaa, 0x3e, 0xa0,
+	0xb8, 0xe6, 0xbc, 0xd8, 0xaa, 0x2d, 0x9f, 0x0a, 0xd1, 0xfd, 0xd5, 0xe2, 0xbd, 0x09, 0x70, 0x66,
+	0xfa, 0xc6, 0x27, 0xa6, 0x43, 0x89, 0x25, 0x83, 0x1e, 0x92, 0x20, 0x0d, 0xf2, 0xac, 0x37, 0xf6,
+	0x89, 0x25, 0x6f, 0x1e, 0x41, 0x3f, 0x34, 0xcf, 0xdc, 0xb7, 0x9b, 0x67, 0x34, 0xca, 0xf9, 0xa9,
+	0x28, 0x87, 0xe8, 0x58, 0x21, 0x42, 0xc7, 0x7e, 0x93, 0x82, 0xf5, 0x99, 0xd4, 0xf5, 0xff, 0x17,
+	0x1f, 0xfd, 0xb7, 0xfc, 0x2e, 0x1d, 0x4d, 0xbf, 0xe8, 0x04, 0xd6, 0x83, 0xd3, 0x6b, 0x8c, 0xf9,
+	0xa9, 0x56, 0xfb, 0x71, 0xd5, 0xe3, 0x5f, 0x3b, 0x8f, 0x8a, 0x7d, 0xf4, 0x3e, 0x3c, 0x39, 0x05,
+	0x4d, 0x81, 0xeb, 0xd4, 0xaa, 0x08, 0xf5, 0x44, 0x14, 0xa1, 0x94, 0xeb, 0x49, 0xb0, 0xd2, 0xdf,
+	0xf2, 0xd0, 0xfc, 0x32, 0x09, 0x15, 0x15, 0x0e, 0x41, 0x27, 0xe6, 0xae, 0xff, 0xb3, 0x50, 0x1e,
+	0x11, 0xca, 0x6a, 0x06, 0x91, 0x1b, 0x70, 0x49, 0x08, 0x25, 0xa0, 0xbf, 0x0e, 0xb9, 0x11, 0xe1,
+	0x64, 0xaa, 0x9e, 0x99, 0x85, 0x13, 0x99, 0x15, 0x25, 0xd9, 0xf2, 0xb1, 0xd2, 0xd5, 0x3f, 0x4b,
+	0x02, 0x4c, 0xe4, 0x2c, 0x85, 0xd8, 0x0e, 0x0f, 0xa6, 0x41, 0x2f, 0x38, 0x84, 0xca, 0xb5, 0x28,
+	0xe1, 0xaa, 0xfc, 0xa1, 0x73, 0xd1, 0xe6, 0x62, 0xf4, 0x02, 0x28, 0x91, 0x21, 0xb6, 0xb9, 0x00,
+	0xed, 0x02, 0xae, 0x48, 0xf1, 0x89, 0x90, 0x32, 0x45, 0xf9, 0xb9, 0x40, 0x31, 0x2d, 0x14, 0xa5,
+	0x58, 0x2a, 0xea, 0xc7, 0xf0, 0xc4, 0x5c, 0x6a, 0x84, 0xbe, 0x07, 0x85, 0x09, 0xab, 0x4a, 0xc6,
+	0x5c, 0x88, 0x95, 0x3a, 0x9e, 0xe8, 0xea, 0x7f, 0x4a, 0xc2, 0x13, 0x73, 0xc9, 0x11, 0x6a, 0xc1,
+	0xda, 0x88, 0xf8, 0xe3, 0x81, 0xb8, 0xc8, 0x55, 0x76, 0x5f, 0x59, 0x8d, 0x54, 0x31, 0xe9, 0x78,
+	0x40, 0xb1, 0x34, 0xd6, 0x1f, 0xc2, 0x9a, 0x90, 0xa0, 0x22, 0xe4, 0xee, 0x1f, 0xde, 0x3b, 0x3c,
+	0x7a, 0xef, 0xb0, 0x96, 0x40, 0x00, 0x6b, 0x7b, 0xcd, 0x66, 0xeb, 0xb8, 0x53, 0x4b, 0xa2, 0x02,
+	0x64, 0xf7, 0x1a, 0x47, 0xb8, 0x53, 0x4b, 0x31, 0x31, 0x6e, 0xbd, 0xdb, 0x6a, 0x76, 0x6a, 0x69,
+	0xb4, 0x0e, 0x65, 0xd1, 0x36, 0xee, 0x1e, 0xe1, 0x9f, 0xec, 0x75, 0x6a, 0x99, 0x90, 0xe8, 0xa4,
+	0x75, 0x78, 0xa7, 0x85, 0x6b, 0x59, 0xfd, 0x55, 0xb8, 0xa1, 0xc6, 0x31, 0x7b, 0x19, 0x0d, 0xee,
+	0x84, 0xc9, 0xd0, 0x9d, 0x50, 0xff, 0x7d, 0x0a, 0xb4, 0x78, 0x6e, 0x85, 0xde, 0x9d, 0x9a, 0xf8,
+	0xee, 0x15, 0x88, 0xd9, 0xd4, 0xec, 0x59, 0xcd, 0x67, 0x44, 0x4e, 0x09, 0xed, 0xf5, 0x05, 0xd7,
+	0x13, 0x3b, 0xa0, 0x8c, 0xcb, 0x52, 0xca, 0x8d, 0x7c, 0xa1, 0xf6, 0x11, 0xe9, 0xd1, 0xa9, 0xf5,
+	0x2f, 0x0b, 0xa9, 0x5a, 0xfe, 0x0f, 0xaf, 0x14, 0xcb, 0x02, 0x64, 0x71, 0xab, 0x83, 0xdf, 0xaf,
+	0xa5, 0x11, 0x82, 0x0a, 0x6f, 0x1a, 0x27, 0x87, 0x7b, 0xc7, 0x27, 0xed, 0x23, 0x16, 0xcb, 0x6b,
+	0x50, 0x55, 0xb1, 0x54, 0xc2, 0xac, 0xfe, 0x38, 0xb4, 0x1d, 0xa2, 0xf7, 0xe2, 0x3b, 0xf3, 0xee,
+	0xc5, 0xab, 0xdc, 0x82, 0x22, 0x77, 0xa0, 0x26, 0x14, 0x27, 0x97, 0x18, 0xc5, 0x61, 0x56, 0xb8,
+	0xc5, 0x60, 0x08, 0xee, 0x30, 0x3e, 0xfa, 0xe1, 0xec, 0x2d, 0x7a, 0xf9, 0x25, 0x26, 0x74, 0x81,
+	0xfe, 0x00, 0x2a, 0xd1, 0x82, 0x13, 0xdb, 0x27, 0x23, 0x77, 0xec, 0x58, 0x7c, 0x5a, 0x59, 0x2c,
+	0x3a, 0xec, 0x55, 0xe3, 0xdc, 0xa5, 0x44, 0x0d, 0x73, 0xf6, 0x40, 0x3d, 0x70, 0x29, 0x09, 0x15,
+	0xac, 0x84, 0xb6, 0xfe, 0x29, 0x64, 0x39, 0xbe, 0x31, 0xa8, 0xe2, 0xa5, 0x23, 0xc9, 0x5e, 0x59,
+	0x1b, 0x7d, 0x00, 0x60, 0x52, 0x3a, 0xb2, 0xbb, 0xe3, 0x89, 0xe3, 0xad, 0xf9, 0xf8, 0xb8, 0xa7,
+	0xf4, 0x1a, 0x37, 0x25, 0x50, 0x6e, 0x4c, 0x4c, 0x43, 0x60, 0x19, 0x72, 0xa8, 0x1f, 0x42, 0x25,
+	0x6a, 0xab, 0xf8, 0x56, 0x72, 0x0e, 0xdf, 0x4a, 0x85, 0xf9, 0x56, 0xc0, 0xd6, 0xd2, 0xa2, 0x4c,
+	0xc8, 0x3b, 0x0c, 0xfd, 0xf2, 0x9d, 0x0b, 0xb9, 0xe9, 0x62, 0x2a, 0x54, 0x13, 0xd3, 0x54, 0xb8,
+	0x1e, 0x23, 0x4a, 0x5e, 0xe9, 0xa0, 0x90, 0xf6, 0x4e, 0x70, 0xac, 0x32, 0xab, 0xde, 0x5d, 0x55,
+	0x45, 0x51, 0x42, 0xc9, 0xdb, 0x50, 0x08, 0xb2, 0x1b, 0xbb, 0x06, 0x98, 0x96, 0x35, 0x22, 0xbe,
+	0x2f, 0xe7, 0xa6, 0xba, 0x6c, 0x38, 0x9e, 0xfb, 0x89, 0xac, 0xf8, 0xa4, 0xb1, 0xe8, 0xe8, 0x16,
+	0x54, 0xa7, 0x52, 0x23, 0x7a, 0x1b, 0x72, 0xde, 0xb8, 0x6b, 0xa8, 0xf0, 0x4c, 0x3d, 0x98, 0x29,
+	0x82, 0x39, 0xee, 0x0e, 0xec, 0xde, 0x3d, 0x72, 0xa9, 0x06, 0xe3, 0x8d, 0xbb, 0xf7, 0x44, 0x14,
+	0xc5, 0x57, 0x52, 0xe1, 0xaf, 0x9c, 0x43, 0x5e, 0x6d, 0x0a, 0xb6, 0x4d, 0x83, 0xac, 0x1b, 0xd4,
+	0xc1, 0x63, 0xd3, 0xb5, 0x74, 0x3f, 0x31, 0x61, 0xa9, 0xc6, 0xb7, 0xcf, 0x1c, 0x62, 0x19, 0x93,
+	0x8b, 0x08, 0xff, 0x5a, 0x1e, 0x57, 0xc5, 0x0f, 0x07, 0xea, 0x16, 0xa2, 0xff, 0x3b, 0x09, 0x79,
+	0x55, 0xef, 0x44, 0xaf, 0x86, 0xf6, 0x5d, 0x65, 0x4e, 0x89, 0x45, 0x29, 0x4e, 0x6a, 0x96, 0xd1,
+	0xb1, 0xa6, 0xae, 0x3e, 0xd6, 0xb8, 0xe2, 0xb3, 0x7a, 0x06, 0xc8, 0x5c, 0xf9, 0x19, 0xe0, 0x65,
+	0x40, 0xd4, 0xa5, 0xe6, 0xc0, 0x38, 0x77, 0xa9, 0xed, 0x9c, 0x19, 0x22, 0xd8, 0x82, 0xb5, 0xd5,
+	0xf8, 0x2f, 0x0f, 0xf8, 0x0f, 0xc7, 0x3c, 0xee, 0xbf, 0x4a, 0x42, 0x3e, 0xc8, 0x5c, 0x57, 0x2d,
+	0x41, 0x5e, 0x87, 0x35, 0x09, 0xce, 0xa2, 0x06, 0x29, 0x7b, 0x41, 0x35, 0x3c, 0x13, 0xaa, 0x86,
+	0x6b, 0x90, 0x1f, 0x12, 0x6a, 0x72, 0x0a, 0x22, 0xee, 0x82, 0x41, 0xff, 0xf6, 0x5b, 0x50, 0x0c,
+	0x55, 0x83, 0xd9, 0xc9, 0x3b, 0x6c, 0xbd, 0x57, 0x4b, 0x68, 0xb9, 0xcf, 0xbe, 0xb8, 0x95, 0x3e,
+	0x24, 0x9f, 0xb0, 0x3d, 0x8b, 0x5b, 0xcd, 0x76, 0xab, 0x79, 0xaf, 0x96, 0xd4, 0x8a, 0x9f, 0x7d,
+	0x71, 0x2b, 0x27, 0x99, 0xc5, 0xed, 0x36, 0x94, 0xc2, 0xab, 0x12, 0xc5, 0x77, 0x04, 0x95, 0x3b,
+	0xf7, 0x8f, 0x0f, 0xf6, 0x9b, 0x7b, 0x9d, 0x96, 0xf1, 0xe0, 0xa8, 0xd3, 0xaa, 0x25, 0xd1, 0x93,
+	0x70, 0xed, 0x60, 0xff, 0xc7, 0xed, 0x8e, 0xd1, 0x3c, 0xd8, 0x6f, 0x1d, 0x76, 0x8c, 0xbd, 0x4e,
+	0x67, 0xaf, 0x79, 0xaf, 0x96, 0xda, 0xfd, 0x1d, 0x40, 0x75, 0xaf, 0xd1, 0xdc, 0x67, 0xb9, 0xc9,
+	0xee, 0x99, 0xfc, 0xa2, 0xde, 0x84, 0x0c, 0xbf, 0x8a, 0x2f, 0x7c, 0x7b, 0xd6, 0x16, 0x17, 0xfe,
+	0xd0, 0x5d, 0xc8, 0xf2, 0x5b, 0x3a, 0x5a, 0xfc, 0x18, 0xad, 0x2d, 0xa9, 0x04, 0xb2, 0xc1, 0xf0,
+	0xe3, 0xb1, 0xf0, 0x75, 0x5a, 0x5b, 0x5c, 0x18, 0x44, 0x18, 0x0a, 0x93, 0x6b, 0xc2, 0xf2, 0xd7,
+	0x5a, 0x6d, 0x05, 0xb0, 0x41, 0x07, 0x90, 0x53, 0x17, 0xb3, 0x65, 0xef, 0xc7, 0xda, 0xd2, 0xca,
+	0x1d, 0x0b, 0x97, 0xb8, 0x40, 0x2f, 0x7e, 0x0c, 0xd7, 0x96, 0x94, 0x21, 0xd1, 0x3e, 0xac, 0x49,
+	0xe6, 0xbb, 0xe4, 0x4d, 0x58, 0x5b, 0x56, 0x89, 0x63, 0x41, 0x9b, 0x94, 0x26, 0x96, 0x3f, 0xf1,
+	0x6b, 0x2b, 0x54, 0x58, 0xd1, 0x7d, 0x80, 0xd0, 0x75, 0x79, 0x85, 0x3a, 0xb9, 0xb6, 0x0a, 0x67,
+	0x40, 0x47, 0x90, 0x0f, 0xae, 0x3f, 0x4b, 0x2b, 0xe4, 0xda, 0xf2, 0xec, 0x8f, 0x1e, 0x42, 0x39,
+	0xca, 0x98, 0x57, 0x7b, 0x1f, 0xd7, 0x56, 0xac, 0x4d, 0x32, 0xff, 0x51, 0xfa, 0xbc, 0xda, 0x7b,
+	0xb9, 0xb6, 0x62, 0xa9, 0x12, 0x7d, 0x04, 0xeb, 0xb3, 0xf4, 0x76, 0xf5, 0xe7, 0x73, 0xed, 0x0a,
+	0xc5, 0x4b, 0x34, 0x04, 0x34, 0x87, 0x16, 0x5f, 0xe1, 0x35, 0x5d, 0xbb, 0x4a, 0x2d, 0x93, 0x85,
+	0x2e, 0x4a, 0x35, 0x57, 0x7b, 0x5d, 0xd7, 0x56, 0xac, 0x6a, 0x36, 0xde, 0xf9, 0xf2, 0xf1, 0x66,
+	0xf2, 0xab, 0xc7, 0x9b, 0xc9, 0xbf, 0x3f, 0xde, 0x4c, 0x7e, 0xfe, 0xcd, 0x66, 0xe2, 0xab, 0x6f,
+	0x36, 0x13, 0x7f, 0xfd, 0x66, 0x33, 0xf1, 0xb3, 0xe7, 0xcf, 0x6c, 0xda, 0x1f, 0x77, 0xb7, 0x7b,
+	0xee, 0x70, 0xe7, 0xd1, 0xe0, 0x92, 0x58, 0x3b, 0x73, 0xff, 0x95, 0xd4, 0x5d, 0xe3, 0xf9, 0xea,
+	0xb5, 0xff, 0x0c, 0x00, 0x65, 0x08, 0x3a, 0xb2, 0xb5, 0x24, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3129,6 +3378,7 @@ type ABCIApplicationClient interface {
 	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
+	FinalizeBlock(ctx context.Context, in *RequestFinalizeBlock, opts ...grpc.CallOption) (*ResponseFinalizeBlock, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3265,6 +3515,15 @@ func (c *aBCIApplicationClient) ApplySnapshotChunk(ctx context.Context, in *Requ
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) FinalizeBlock(ctx context.Context, in *RequestFinalizeBlock, opts ...grpc.CallOption) (*ResponseFinalizeBlock, error) {
+	out := new(ResponseFinalizeBlock)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/FinalizeBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3281,6 +3540,7 @@ type ABCIApplicationServer interface {
 	OfferSnapshot(context.Context, *RequestOfferSnapshot) (*ResponseOfferSnapshot, error)
 	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
+	FinalizeBlock(context.Context, *RequestFinalizeBlock) (*ResponseFinalizeBlock, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3329,6 +3589,9 @@ func (*UnimplementedABCIApplicationServer) LoadSnapshotChunk(ctx context.Context
 func (*UnimplementedABCIApplicationServer) ApplySnapshotChunk(ctx context.Context, req *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplySnapshotChunk not implemented")
 }
+func (*UnimplementedABCIApplicationServer) FinalizeBlock(ctx context.Context, req *RequestFinalizeBlock) (*ResponseFinalizeBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeBlock not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -3586,6 +3849,24 @@ func _ABCIApplication_ApplySnapshotChunk_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_FinalizeBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestFinalizeBlock)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).FinalizeBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/FinalizeBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).FinalizeBlock(ctx, req.(*RequestFinalizeBlock))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -3646,6 +3927,10 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplySnapshotChunk",
 			Handler:    _ABCIApplication_ApplySnapshotChunk_Handler,
 		},
+		{
+			MethodName: "FinalizeBlock",
+			Handler:    _ABCIApplication_FinalizeBlock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -3977,6 +4262,27 @@ func (m *Request_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_FinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_FinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizeBlock != nil {
+		{
+			size, err := m.FinalizeBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4142,12 +4448,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n16, err16 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err16 != nil {
-		return 0, err16
+	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err17 != nil {
+		return 0, err17
 	}
-	i -= n16
-	i = encodeVarintTypes(dAtA, i, uint64(n16))
+	i -= n17
+	i = encodeVarintTypes(dAtA, i, uint64(n17))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -4530,7 +4836,7 @@ func (m *RequestApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *RequestFinalizeBlock) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4540,36 +4846,88 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestFinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RequestFinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Value != nil {
-		{
-			size := m.Value.Size()
-			i -= size
-			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+	{
+		size, err := m.EndBlock.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.BeginBlock.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *Response_Exception) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Exception != nil {
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_Exception) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Exception != nil {
 		{
 			size, err := m.Exception.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
@@ -4877,6 +5235,29 @@ func (m *Response_ApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, er
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_FinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_FinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.FinalizeBlock != nil {
+		{
+			size, err := m.FinalizeBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5212,6 +5593,13 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -5435,6 +5823,18 @@ func (m *ResponseCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Recheck != nil {
+		{
+			size, err := m.Recheck.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.RetainHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.RetainHeight))
 		i--
@@ -5450,6 +5850,56 @@ func (m *ResponseCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RecheckTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecheckTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecheckTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RecheckSenders) > 0 {
+		for iNdEx := len(m.RecheckSenders) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RecheckSenders[iNdEx])
+			copy(dAtA[i:], m.RecheckSenders[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.RecheckSenders[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.InvalidSenders) > 0 {
+		for iNdEx := len(m.InvalidSenders) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.InvalidSenders[iNdEx])
+			copy(dAtA[i:], m.InvalidSenders[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.InvalidSenders[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.InvalidTxHashes) > 0 {
+		for iNdEx := len(m.InvalidTxHashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.InvalidTxHashes[iNdEx])
+			copy(dAtA[i:], m.InvalidTxHashes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.InvalidTxHashes[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ResponseListSnapshots) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5575,20 +6025,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA39 := make([]byte, len(m.RefetchChunks)*10)
-		var j38 int
+		dAtA44 := make([]byte, len(m.RefetchChunks)*10)
+		var j43 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA44[j43] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j43++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA44[j43] = uint8(num)
+			j43++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintTypes(dAtA, i, uint64(j38))
+		i -= j43
+		copy(dAtA[i:], dAtA44[:j43])
+		i = encodeVarintTypes(dAtA, i, uint64(j43))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -5600,6 +6050,67 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
+func (m *ResponseFinalizeBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseFinalizeBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseFinalizeBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EndBlock != nil {
+		{
+			size, err := m.EndBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.DeliverTxs) > 0 {
+		for iNdEx := len(m.DeliverTxs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DeliverTxs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.BeginBlock != nil {
+		{
+			size, err := m.BeginBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5924,12 +6435,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n43, err43 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err43 != nil {
-		return 0, err43
+	n50, err50 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err50 != nil {
+		return 0, err50
 	}
-	i -= n43
-	i = encodeVarintTypes(dAtA, i, uint64(n43))
+	i -= n50
+	i = encodeVarintTypes(dAtA, i, uint64(n50))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6198,6 +6709,18 @@ func (m *Request_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_FinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizeBlock != nil {
+		l = m.FinalizeBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6435,6 +6958,25 @@ func (m *RequestApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *RequestFinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.BeginBlock.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = m.EndBlock.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
 func (m *Response) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6627,6 +7169,18 @@ func (m *Response_ApplySnapshotChunk) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_FinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FinalizeBlock != nil {
+		l = m.FinalizeBlock.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6806,6 +7360,10 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -6887,6 +7445,37 @@ func (m *ResponseCommit) Size() (n int) {
 	if m.RetainHeight != 0 {
 		n += 1 + sovTypes(uint64(m.RetainHeight))
 	}
+	if m.Recheck != nil {
+		l = m.Recheck.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *RecheckTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.InvalidTxHashes) > 0 {
+		for _, b := range m.InvalidTxHashes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.InvalidSenders) > 0 {
+		for _, s := range m.InvalidSenders {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.RecheckSenders) > 0 {
+		for _, s := range m.RecheckSenders {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -6955,6 +7544,29 @@ func (m *ResponseApplySnapshotChunk) Size() (n int) {
 	return n
 }
 
+func (m *ResponseFinalizeBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BeginBlock != nil {
+		l = m.BeginBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.DeliverTxs) > 0 {
+		for _, e := range m.DeliverTxs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.EndBlock != nil {
+		l = m.EndBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *LastCommitInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7650,27 +8262,62 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_ApplySnapshotChunk{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestFinalizeBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_FinalizeBlock{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *RequestEcho) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -9250,6 +9897,154 @@ func (m *RequestApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RequestFinalizeBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestFinalizeBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestFinalizeBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BeginBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.EndBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -9804,6 +10599,41 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Response_ApplySnapshotChunk{v}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseFinalizeBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_FinalizeBlock{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11020,6 +11850,38 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11548,9 +12410,191 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recheck", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Recheck == nil {
+				m.Recheck = &RecheckTxs{}
+			}
+			if err := m.Recheck.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecheckTxs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecheckTxs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecheckTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidTxHashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InvalidTxHashes = append(m.InvalidTxHashes, make([]byte, postIndex-iNdEx))
+			copy(m.InvalidTxHashes[len(m.InvalidTxHashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidSenders", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InvalidSenders = append(m.InvalidSenders, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecheckSenders", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecheckSenders = append(m.RecheckSenders, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
@@ -11983,6 +13027,162 @@ func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseFinalizeBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseFinalizeBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseFinalizeBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BeginBlock == nil {
+				m.BeginBlock = &ResponseBeginBlock{}
+			}
+			if err := m.BeginBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTxs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverTxs = append(m.DeliverTxs, &ResponseDeliverTx{})
+			if err := m.DeliverTxs[len(m.DeliverTxs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndBlock == nil {
+				m.EndBlock = &ResponseEndBlock{}
+			}
+			if err := m.EndBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
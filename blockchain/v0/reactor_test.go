@@ -15,6 +15,7 @@ import (
 	"github.com/klyed/tendermint/mempool/mock"
 	"github.com/klyed/tendermint/p2p"
 	bcproto "github.com/klyed/tendermint/proto/tendermint/blockchain"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
 	"github.com/klyed/tendermint/proxy"
 	sm "github.com/klyed/tendermint/state"
 	"github.com/klyed/tendermint/store"
@@ -411,3 +412,31 @@ func TestReactor_BadBlockStopsPeer(t *testing.T) {
 		len(newSuite.reactor.pool.peers),
 	)
 }
+
+// BenchmarkVerifyBlockCommit measures the verification of a block with the
+// commit of the next one, as done for every block during fast sync, with 150
+// validators.
+func BenchmarkVerifyBlockCommit(b *testing.B) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+
+	genDoc, privVals := randGenesisDoc(config, 150, false, 30)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(b, err)
+
+	first := makeBlock(1, state, types.NewCommit(0, 0, types.BlockID{}, nil))
+	firstParts := first.MakePartSet(types.BlockPartSizeBytes)
+	firstID := types.BlockID{Hash: first.Hash(), PartSetHeader: firstParts.Header()}
+
+	voteSet := types.NewVoteSet(state.ChainID, first.Height, 0, tmproto.PrecommitType, state.Validators)
+	commit, err := types.MakeCommit(firstID, first.Height, 0, voteSet, privVals, time.Now())
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		err := state.Validators.VerifyCommitLight(state.ChainID, firstID, first.Height, commit)
+		require.NoError(b, err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/klyed/tendermint/crypto/keyfile"
 	"github.com/klyed/tendermint/libs/log"
 	tmnet "github.com/klyed/tendermint/libs/net"
 	tmos "github.com/klyed/tendermint/libs/os"
@@ -40,6 +41,9 @@ func main() {
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		passphraseFile   = flag.String("passphrase-file", "", "file containing the passphrase of an encrypted priv-key")
+		shareKeyPath     = flag.String("share-key", "", "threshold key share file path, served instead of priv-key")
+		shareStatePath   = flag.String("share-state", "", "threshold key share state file path")
 		insecure         = flag.Bool("insecure", false, "allow server to run insecurely (no TLS)")
 		certFile         = flag.String("certfile", "", "absolute path to server certificate")
 		keyFile          = flag.String("keyfile", "", "absolute path to server key")
@@ -58,18 +62,14 @@ func main() {
 		"chainID", *chainID,
 		"privKeyPath", *privValKeyPath,
 		"privStatePath", *privValStatePath,
+		"shareKeyPath", *shareKeyPath,
+		"shareStatePath", *shareStatePath,
 		"insecure", *insecure,
 		"certFile", *certFile,
 		"keyFile", *keyFile,
 		"rootCA", *rootCA,
 	)
 
-	pv, err := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
-	if err != nil {
-		fmt.Fprint(os.Stderr, err)
-		os.Exit(1)
-	}
-
 	opts := []grpc.ServerOption{}
 	if !*insecure {
 		certificate, err := tls.LoadX509KeyPair(*certFile, *keyFile)
@@ -107,8 +107,6 @@ func main() {
 	// add prometheus metrics for unary RPC calls
 	opts = append(opts, grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor))
 
-	ss := grpcprivval.NewSignerServer(*chainID, pv, logger)
-
 	protocol, address := tmnet.ProtocolAndAddress(*addr)
 
 	lis, err := net.Listen(protocol, address)
@@ -119,7 +117,26 @@ func main() {
 
 	s := grpc.NewServer(opts...)
 
-	privvalproto.RegisterPrivValidatorAPIServer(s, ss)
+	if *shareKeyPath != "" {
+		signer, err := privval.LoadLocalShareSigner(*shareKeyPath, *shareStatePath)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		privvalproto.RegisterThresholdShareSignerAPIServer(s, grpcprivval.NewShareSignerServer(signer, logger))
+	} else {
+		passphrase, err := keyPassphrase(*privValKeyPath, *passphraseFile)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		pv, err := privval.LoadFilePVWithPassphrase(*privValKeyPath, *privValStatePath, passphrase)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		privvalproto.RegisterPrivValidatorAPIServer(s, grpcprivval.NewSignerServer(*chainID, pv, logger))
+	}
 
 	var httpSrv *http.Server
 	if *prometheusAddr != "" {
@@ -150,6 +167,16 @@ func main() {
 	select {}
 }
 
+// keyPassphrase returns the passphrase of the key file if it's encrypted, read
+// like for the node, or an empty string otherwise.
+func keyPassphrase(keyFile, passphraseFile string) (string, error) {
+	encrypted, err := keyfile.IsEncryptedFile(keyFile)
+	if err != nil || !encrypted {
+		return "", err
+	}
+	return keyfile.ReadPassphrase(keyfile.PassphraseEnv, passphraseFile, "Enter the passphrase of the key file: ")
+}
+
 func registerPrometheus(addr string, s *grpc.Server) *http.Server {
 	// Initialize all metrics.
 	grpcMetrics.InitializeMetrics(s)
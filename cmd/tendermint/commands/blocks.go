@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	nm "github.com/klyed/tendermint/node"
+	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/store"
+	"github.com/klyed/tendermint/types"
+)
+
+var (
+	blocksFrom   int64
+	blocksTo     int64
+	blocksOutput string
+	blocksInput  string
+)
+
+// BlocksCmd groups commands operating on the block store.
+var BlocksCmd = &cobra.Command{
+	Use:   "blocks",
+	Short: "Manage the blocks in the block store",
+}
+
+// BlocksExportCmd exports blocks from the block store to a portable archive.
+var BlocksExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export blocks from the block store to an archive file",
+	Long: `Export blocks from the block store to an archive file.
+
+Blocks are written along with their commits and the ABCI responses from
+executing them, as length-prefixed protobuf messages, such that the archive is
+independent of the database backend. The node must not be running.
+
+Example:
+$ tendermint blocks export --from 1 --to 1000 --output blocks.archive`,
+	RunE: exportBlocks,
+}
+
+// BlocksImportCmd imports blocks from a portable archive into the block store.
+var BlocksImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import blocks from an archive file into the block store",
+	Long: `Import blocks from an archive file into the block store.
+
+Blocks must follow on from the latest block in the block store, unless it is
+empty, and are validated to form a hash chain of the genesis file's chain,
+with commits signed by their validators, before being written. Into an empty
+block store, the first block's validators must be known to the state store,
+or be the genesis validators at the initial height. Their ABCI responses,
+validator sets and consensus params are written to the state store, but the
+node's state is otherwise left as is. The node must not be running.
+
+Example:
+$ tendermint blocks import --input blocks.archive`,
+	RunE: importBlocks,
+}
+
+func init() {
+	BlocksExportCmd.Flags().Int64Var(&blocksFrom, "from", 0,
+		"height of the first block to export (0 exports from the block store's base)")
+	BlocksExportCmd.Flags().Int64Var(&blocksTo, "to", 0,
+		"height of the last block to export (0 exports up to the block store's height)")
+	BlocksExportCmd.Flags().StringVarP(&blocksOutput, "output", "o", "",
+		"archive file to export blocks to")
+	BlocksImportCmd.Flags().StringVarP(&blocksInput, "input", "i", "",
+		"archive file to import blocks from")
+
+	BlocksCmd.AddCommand(BlocksExportCmd)
+	BlocksCmd.AddCommand(BlocksImportCmd)
+}
+
+func exportBlocks(cmd *cobra.Command, args []string) error {
+	if blocksOutput == "" {
+		return errors.New("an output file is required")
+	}
+
+	blockStore, stateStore, closeStores, err := openStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	file, err := os.OpenFile(blocksOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	exported, err := store.ExportBlocks(w, blockStore, stateStore, blocksFrom, blocksTo)
+	if err != nil {
+		if err := os.Remove(blocksOutput); err != nil {
+			logger.Error("failed to remove incomplete archive", "file", blocksOutput, "err", err)
+		}
+		return fmt.Errorf("failed to export blocks: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	logger.Info("exported blocks", "blocks", exported, "file", blocksOutput)
+	return nil
+}
+
+func importBlocks(cmd *cobra.Command, args []string) error {
+	if blocksInput == "" {
+		return errors.New("an input file is required")
+	}
+
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+
+	blockStore, stateStore, closeStores, err := openStores()
+	if err != nil {
+		return err
+	}
+	defer closeStores()
+
+	file, err := os.Open(blocksInput)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	imported, err := store.ImportBlocks(bufio.NewReader(file), blockStore, stateStore, genDoc)
+	if err != nil {
+		return fmt.Errorf("failed to import blocks after %v blocks: %w", imported, err)
+	}
+
+	logger.Info("imported blocks", "blocks", imported, "base", blockStore.Base(), "height", blockStore.Height())
+	return nil
+}
+
+// openStores opens the node's block and state stores, returning a function
+// which closes them.
+func openStores() (*store.BlockStore, sm.Store, func(), error) {
+	blockStoreDB, err := nm.DefaultDBProvider(&nm.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	stateDB, err := nm.DefaultDBProvider(&nm.DBContext{ID: "state", Config: config})
+	if err != nil {
+		blockStoreDB.Close()
+		return nil, nil, nil, err
+	}
+
+	closeStores := func() {
+		if err := blockStoreDB.Close(); err != nil {
+			logger.Error("failed to close block store", "err", err)
+		}
+		if err := stateDB.Close(); err != nil {
+			logger.Error("failed to close state store", "err", err)
+		}
+	}
+	return store.NewBlockStore(blockStoreDB), sm.NewStore(stateDB), closeStores, nil
+}
@@ -44,7 +44,11 @@ func initFilesWithConfig(config *cfg.Config) error {
 		err error
 	)
 	if tmos.FileExists(privValKeyFile) {
-		pv, err = privval.LoadFilePV(privValKeyFile, privValStateFile)
+		passphrase, err := keyFilePassphrase(privValKeyFile)
+		if err != nil {
+			return err
+		}
+		pv, err = privval.LoadFilePVWithPassphrase(privValKeyFile, privValStateFile, passphrase)
 		if err != nil {
 			return err
 		}
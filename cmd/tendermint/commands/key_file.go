@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/klyed/tendermint/crypto/keyfile"
+	tmos "github.com/klyed/tendermint/libs/os"
+)
+
+var keyFileNewPassphraseFile string
+
+// KeyFileCmd groups commands managing the encryption of the key files.
+var KeyFileCmd = &cobra.Command{
+	Use:   "key-file",
+	Short: "Encrypt, decrypt or rotate the passphrase of the key files",
+	Long: `Encrypt, decrypt or rotate the passphrase of the key files.
+
+The commands operate on the files given as arguments, or else on the
+priv-validator-key-file and node-key-file of the config. The passphrase is read
+from the TM_KEY_PASSPHRASE environment variable, or else from the
+key-passphrase-file of the config, or else from stdin.`,
+}
+
+// KeyFileEncryptCmd encrypts plaintext key files.
+var KeyFileEncryptCmd = &cobra.Command{
+	Use:   "encrypt [file...]",
+	Short: "Encrypt the key files with a passphrase",
+	RunE:  encryptKeyFiles,
+}
+
+// KeyFileDecryptCmd decrypts encrypted key files.
+var KeyFileDecryptCmd = &cobra.Command{
+	Use:   "decrypt [file...]",
+	Short: "Decrypt the key files, storing them in plaintext",
+	RunE:  decryptKeyFiles,
+}
+
+// KeyFileRotateCmd changes the passphrase of encrypted key files.
+var KeyFileRotateCmd = &cobra.Command{
+	Use:   "rotate [file...]",
+	Short: "Change the passphrase of the key files",
+	Long: `Change the passphrase of the key files.
+
+The current passphrase is read like for the other commands. The new passphrase
+is read from the TM_NEW_KEY_PASSPHRASE environment variable, or else from the
+--new-passphrase-file, or else from stdin.
+
+Example:
+$ printf 'old passphrase\nnew passphrase\n' | tendermint key-file rotate`,
+	RunE: rotateKeyFiles,
+}
+
+func init() {
+	KeyFileRotateCmd.Flags().StringVar(&keyFileNewPassphraseFile, "new-passphrase-file", "",
+		"file containing the new passphrase")
+
+	KeyFileCmd.AddCommand(KeyFileEncryptCmd)
+	KeyFileCmd.AddCommand(KeyFileDecryptCmd)
+	KeyFileCmd.AddCommand(KeyFileRotateCmd)
+}
+
+// keyFiles returns the key files given as arguments, or else the key files of
+// the config which exist.
+func keyFiles(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	var files []string
+	for _, file := range []string{config.PrivValidatorKeyFile(), config.NodeKeyFile()} {
+		if tmos.FileExists(file) {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no key files found")
+	}
+	return files, nil
+}
+
+// readKeyPassphrase reads the passphrase of the key files.
+func readKeyPassphrase(prompt string) (string, error) {
+	return keyfile.ReadPassphrase(keyfile.PassphraseEnv, config.KeyPassphraseFile(), prompt)
+}
+
+// keyFilePassphrase returns the passphrase of filePath if it's encrypted, or
+// an empty string otherwise.
+func keyFilePassphrase(filePath string) (string, error) {
+	encrypted, err := keyfile.IsEncryptedFile(filePath)
+	if err != nil || !encrypted {
+		return "", err
+	}
+	return readKeyPassphrase("Enter the passphrase of the key files: ")
+}
+
+// rewriteKeyFiles decrypts all the files with oldPassphrase, then writes them
+// encrypted with newPassphrase, so that no file is written unless all of them
+// could be read.
+func rewriteKeyFiles(files []string, oldPassphrase, newPassphrase string) error {
+	plaintexts := make([][]byte, len(files))
+	for i, file := range files {
+		var err error
+		if plaintexts[i], err = keyfile.ReadFile(file, oldPassphrase); err != nil {
+			return err
+		}
+	}
+	for i, file := range files {
+		if err := keyfile.WriteFile(file, plaintexts[i], newPassphrase); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func encryptKeyFiles(cmd *cobra.Command, args []string) error {
+	files, err := keyFiles(args)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		encrypted, err := keyfile.IsEncryptedFile(file)
+		if err != nil {
+			return err
+		}
+		if encrypted {
+			return fmt.Errorf("%s is already encrypted, use rotate to change its passphrase", file)
+		}
+	}
+
+	passphrase, err := readKeyPassphrase("Enter the passphrase to encrypt the key files with: ")
+	if err != nil {
+		return err
+	}
+	if err := rewriteKeyFiles(files, "", passphrase); err != nil {
+		return err
+	}
+	logger.Info("Encrypted key files", "files", files)
+	return nil
+}
+
+func decryptKeyFiles(cmd *cobra.Command, args []string) error {
+	files, err := keyFiles(args)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := readKeyPassphrase("Enter the passphrase of the key files: ")
+	if err != nil {
+		return err
+	}
+	if err := rewriteKeyFiles(files, passphrase, ""); err != nil {
+		return err
+	}
+	logger.Info("Decrypted key files", "files", files)
+	return nil
+}
+
+func rotateKeyFiles(cmd *cobra.Command, args []string) error {
+	files, err := keyFiles(args)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		encrypted, err := keyfile.IsEncryptedFile(file)
+		if err != nil {
+			return err
+		}
+		if !encrypted {
+			return fmt.Errorf("%s is not encrypted, use encrypt to encrypt it", file)
+		}
+	}
+
+	oldPassphrase, err := readKeyPassphrase("Enter the current passphrase of the key files: ")
+	if err != nil {
+		return err
+	}
+	newPassphrase, err := keyfile.ReadPassphrase(
+		keyfile.NewPassphraseEnv, keyFileNewPassphraseFile, "Enter the new passphrase of the key files: ")
+	if err != nil {
+		return err
+	}
+	if err := rewriteKeyFiles(files, oldPassphrase, newPassphrase); err != nil {
+		return err
+	}
+	logger.Info("Rotated the passphrase of the key files", "files", files)
+	return nil
+}
@@ -48,12 +48,22 @@ func resetAll(cmd *cobra.Command, args []string) error {
 // XXX: this is totally unsafe.
 // it's only suitable for testnets.
 func resetPrivValidator(cmd *cobra.Command, args []string) error {
-	return resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
+	pv, err := loadFilePVEmptyState(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	if err != nil {
+		return err
+	}
+	return resetFilePV(pv, config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
 }
 
 // ResetAll removes address book files plus all data, and resets the privValdiator data.
 // Exported so other CLI tools can use it.
 func ResetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) error {
+	// load the key first, so that nothing is removed if it can't be decrypted
+	pv, err := loadFilePVEmptyState(privValKeyFile, privValStateFile)
+	if err != nil {
+		return err
+	}
+
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
 	} else {
@@ -68,15 +78,27 @@ func ResetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	if err := tmos.EnsureDir(dbDir, 0700); err != nil {
 		logger.Error("unable to recreate dbDir", "err", err)
 	}
-	return resetFilePV(privValKeyFile, privValStateFile, logger)
+	return resetFilePV(pv, privValKeyFile, privValStateFile, logger)
 }
 
-func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) error {
-	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv, err := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
-		if err != nil {
-			return err
-		}
+// loadFilePVEmptyState loads the private validator key with an empty state,
+// reading the passphrase of the key file if it's encrypted. It returns nil if
+// there's no key file.
+func loadFilePVEmptyState(privValKeyFile, privValStateFile string) (*privval.FilePV, error) {
+	if _, err := os.Stat(privValKeyFile); err != nil {
+		return nil, nil
+	}
+	passphrase, err := keyFilePassphrase(privValKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return privval.LoadFilePVEmptyStateWithPassphrase(privValKeyFile, privValStateFile, passphrase)
+}
+
+// resetFilePV resets pv to genesis state, or generates a new private validator
+// if pv is nil.
+func resetFilePV(pv *privval.FilePV, privValKeyFile, privValStateFile string, logger log.Logger) error {
+	if pv != nil {
 		pv.Reset()
 		logger.Info("Reset private validator file to genesis state", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
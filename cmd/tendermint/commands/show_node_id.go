@@ -18,7 +18,11 @@ var ShowNodeIDCmd = &cobra.Command{
 }
 
 func showNodeID(cmd *cobra.Command, args []string) error {
-	nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	passphrase, err := keyFilePassphrase(config.NodeKeyFile())
+	if err != nil {
+		return err
+	}
+	nodeKey, err := p2p.LoadNodeKeyWithPassphrase(config.NodeKeyFile(), passphrase)
 	if err != nil {
 		return err
 	}
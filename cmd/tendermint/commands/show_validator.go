@@ -30,10 +30,15 @@ func showValidator(cmd *cobra.Command, args []string) error {
 	)
 
 	//TODO: remove once gRPC is the only supported protocol
-	protocol, _ := tmnet.ProtocolAndAddress(config.PrivValidatorListenAddr)
+	// all the signers listed in priv-validator-laddr hold the same key
+	var addr string
+	if addrs := config.PrivValidatorListenAddrs(); len(addrs) > 0 {
+		addr = addrs[0]
+	}
+	protocol, _ := tmnet.ProtocolAndAddress(addr)
 	switch protocol {
 	case "grpc":
-		pvsc, err := tmgrpc.DialRemoteSigner(config, config.ChainID(), logger)
+		pvsc, err := tmgrpc.DialRemoteSigner(config, addr, config.ChainID(), logger)
 		if err != nil {
 			return fmt.Errorf("can't connect to remote validator %w", err)
 		}
@@ -52,7 +57,11 @@ func showValidator(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 		}
 
-		pv, err := privval.LoadFilePV(keyFilePath, config.PrivValidatorStateFile())
+		passphrase, err := keyFilePassphrase(keyFilePath)
+		if err != nil {
+			return err
+		}
+		pv, err := privval.LoadFilePVWithPassphrase(keyFilePath, config.PrivValidatorStateFile(), passphrase)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/klyed/tendermint/proxy"
+	"github.com/klyed/tendermint/statesync"
+)
+
+var (
+	snapshotOutputDir string
+	snapshotHeight    uint64
+)
+
+// SnapshotCmd groups commands operating on application state sync snapshots.
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage application state sync snapshots",
+}
+
+// SnapshotExportCmd dumps the application's snapshots into a directory which
+// can be used as a state sync snapshot source.
+var SnapshotExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the application's state sync snapshots to a directory",
+	Long: `Export the application's state sync snapshots to a directory.
+
+Snapshots are listed via ListSnapshots and their chunks fetched via
+LoadSnapshotChunk from the ABCI application configured in proxy-app. The
+resulting directory can be served as a state sync snapshot source, either
+directly (snapshot-source = "file:///path") or after uploading it to an
+S3-compatible object store (snapshot-source = "s3://bucket/prefix").
+
+Example:
+$ tendermint snapshot export --output /path/to/snapshots --height 1000`,
+	RunE: exportSnapshots,
+}
+
+func init() {
+	SnapshotExportCmd.Flags().StringVarP(&snapshotOutputDir, "output", "o", "",
+		"directory to export snapshots to")
+	SnapshotExportCmd.Flags().Uint64Var(&snapshotHeight, "height", 0,
+		"only export snapshots at this height (0 exports all snapshots)")
+
+	SnapshotCmd.AddCommand(SnapshotExportCmd)
+}
+
+func exportSnapshots(cmd *cobra.Command, args []string) error {
+	if snapshotOutputDir == "" {
+		return errors.New("an output directory is required")
+	}
+
+	proxyApp := proxy.NewAppConns(proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()))
+	proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := proxyApp.Start(); err != nil {
+		return fmt.Errorf("error starting proxy app connections: %w", err)
+	}
+	defer func() {
+		if err := proxyApp.Stop(); err != nil {
+			logger.Error("failed to stop proxy app connections", "err", err)
+		}
+	}()
+
+	snapshots, err := statesync.ExportSnapshots(context.Background(), proxyApp.Snapshot(), snapshotOutputDir,
+		snapshotHeight)
+	if err != nil {
+		return fmt.Errorf("failed to export snapshots: %w", err)
+	}
+
+	for _, snapshot := range snapshots {
+		logger.Info("exported snapshot", "height", snapshot.Height, "format", snapshot.Format,
+			"chunks", snapshot.Chunks, "hash", fmt.Sprintf("%X", snapshot.Hash))
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	tmos "github.com/klyed/tendermint/libs/os"
+	"github.com/klyed/tendermint/privval"
+)
+
+var (
+	splitKeyThreshold int
+	splitKeyShares    int
+	splitKeyOutputDir string
+)
+
+// SplitKeyCmd splits the validator key into shares for threshold signing.
+var SplitKeyCmd = &cobra.Command{
+	Use:   "split-key",
+	Short: "Split the validator key into shares for threshold signing",
+	Long: `Split the ed25519 key of priv-validator-key-file into shares, any threshold of
+which can sign for the validator.
+
+The output directory gets the threshold group file, to set as the node's
+priv-validator-threshold-group-file, and for each share i a key file
+threshold_key_share_<i>.json and an empty state file threshold_state_<i>.json,
+to move to the signer serving that share. The validator key file should then be
+removed from the node.
+
+Example:
+$ tendermint split-key --threshold 2 --shares 3 --output-dir ./shares`,
+	RunE: splitKey,
+}
+
+func init() {
+	SplitKeyCmd.Flags().IntVar(&splitKeyThreshold, "threshold", 2,
+		"number of shares needed to sign, must be more than half of them")
+	SplitKeyCmd.Flags().IntVar(&splitKeyShares, "shares", 3, "number of shares")
+	SplitKeyCmd.Flags().StringVar(&splitKeyOutputDir, "output-dir", "threshold",
+		"directory to write the group, share and state files to")
+}
+
+func splitKey(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !tmos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	passphrase, err := keyFilePassphrase(keyFilePath)
+	if err != nil {
+		return err
+	}
+	pv, err := privval.LoadFilePVWithPassphrase(keyFilePath, config.PrivValidatorStateFile(), passphrase)
+	if err != nil {
+		return err
+	}
+
+	group, shares, err := privval.SplitThresholdKey(pv.Key.PrivKey, splitKeyThreshold, splitKeyShares)
+	if err != nil {
+		return err
+	}
+
+	if err := tmos.EnsureDir(splitKeyOutputDir, 0700); err != nil {
+		return err
+	}
+	groupFile := filepath.Join(splitKeyOutputDir, "threshold_group.json")
+	if tmos.FileExists(groupFile) {
+		return fmt.Errorf("%s already exists", groupFile)
+	}
+	for _, share := range shares {
+		stateFile := filepath.Join(splitKeyOutputDir, fmt.Sprintf("threshold_state_%d.json", share.Index))
+		signer, err := privval.NewLocalShareSigner(share, stateFile)
+		if err != nil {
+			return err
+		}
+		signer.Key.SaveAs(filepath.Join(splitKeyOutputDir, fmt.Sprintf("threshold_key_share_%d.json", share.Index)))
+		signer.LastSignState.Save()
+	}
+	group.SaveAs(groupFile)
+
+	logger.Info("Split the validator key, move the shares to their signers and remove the key file",
+		"threshold", splitKeyThreshold, "shares", splitKeyShares, "group", groupFile, "key", keyFilePath)
+	return nil
+}
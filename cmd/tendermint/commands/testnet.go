@@ -147,7 +147,11 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 
 		pvKeyFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorKey)
 		pvStateFile := filepath.Join(nodeDir, config.BaseConfig.PrivValidatorState)
-		pv, err := privval.LoadFilePV(pvKeyFile, pvStateFile)
+		passphrase, err := keyFilePassphrase(pvKeyFile)
+		if err != nil {
+			return err
+		}
+		pv, err := privval.LoadFilePVWithPassphrase(pvKeyFile, pvStateFile, passphrase)
 		if err != nil {
 			return err
 		}
@@ -275,7 +279,11 @@ func persistentPeersArray(config *cfg.Config) ([]string, error) {
 	for i := 0; i < nValidators+nNonValidators; i++ {
 		nodeDir := filepath.Join(outputDir, fmt.Sprintf("%s%d", nodeDirPrefix, i))
 		config.SetRoot(nodeDir)
-		nodeKey, err := p2p.LoadNodeKey(config.NodeKeyFile())
+		passphrase, err := keyFilePassphrase(config.NodeKeyFile())
+		if err != nil {
+			return []string{}, err
+		}
+		nodeKey, err := p2p.LoadNodeKeyWithPassphrase(config.NodeKeyFile(), passphrase)
 		if err != nil {
 			return []string{}, err
 		}
@@ -25,7 +25,11 @@ func main() {
 		cmd.ShowValidatorCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
+		cmd.SnapshotCmd,
+		cmd.BlocksCmd,
 		cmd.GenNodeKeyCmd,
+		cmd.KeyFileCmd,
+		cmd.SplitKeyCmd,
 		cmd.VersionCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
@@ -4,9 +4,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,15 @@ const (
 	// LogFormatJSON is a format for json output
 	LogFormatJSON = "json"
 
+	// SubscriptionOverflowCancel cancels subscriptions whose buffer is full
+	SubscriptionOverflowCancel = "cancel"
+	// SubscriptionOverflowDropOldest drops the oldest buffered event
+	SubscriptionOverflowDropOldest = "drop-oldest"
+	// SubscriptionOverflowDropNewest drops the new event
+	SubscriptionOverflowDropNewest = "drop-newest"
+	// SubscriptionOverflowBlock waits for the subscriber up to a timeout
+	SubscriptionOverflowBlock = "block"
+
 	// DefaultLogLevel defines a default log level as INFO.
 	DefaultLogLevel = "info"
 
@@ -75,6 +86,7 @@ type Config struct {
 	FastSync        *FastSyncConfig        `mapstructure:"fastsync"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx-index"`
+	Pruning         *PruningConfig         `mapstructure:"pruning"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
 }
 
@@ -89,6 +101,7 @@ func DefaultConfig() *Config {
 		FastSync:        DefaultFastSyncConfig(),
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
+		Pruning:         DefaultPruningConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
 	}
 }
@@ -104,6 +117,7 @@ func TestConfig() *Config {
 		FastSync:        TestFastSyncConfig(),
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
+		Pruning:         TestPruningConfig(),
 		Instrumentation: TestInstrumentationConfig(),
 	}
 }
@@ -142,6 +156,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.Pruning.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [pruning] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
@@ -224,9 +241,21 @@ type BaseConfig struct { //nolint: maligned
 	PrivValidatorState string `mapstructure:"priv-validator-state-file"`
 
 	// TCP or UNIX socket address for Tendermint to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process.
+	// A comma separated list of addresses fails over between several
+	// signers holding the same key, using one signer per height.
 	PrivValidatorListenAddr string `mapstructure:"priv-validator-laddr"`
 
+	// How often to check the health of each external PrivValidator process
+	// when several addresses are listed in priv-validator-laddr, by
+	// requesting its public key. 0 disables the checks.
+	PrivValidatorHealthCheckInterval time.Duration `mapstructure:"priv-validator-health-check-interval"`
+
+	// Path to the JSON file describing a validator key split with split-key.
+	// If set, the gRPC addresses in priv-validator-laddr are the signers
+	// holding its shares, and a threshold of them must agree to sign.
+	PrivValidatorThresholdGroup string `mapstructure:"priv-validator-threshold-group-file"`
+
 	// Client certificate generated while creating needed files for secure connection.
 	// If a remote validator address is provided but no certificate, the connection will be insecure
 	PrivValidatorClientCertificate string `mapstructure:"priv-validator-client-certificate-file"`
@@ -240,9 +269,43 @@ type BaseConfig struct { //nolint: maligned
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node-key-file"`
 
-	// Mechanism to connect to the ABCI application: socket | grpc
+	// A file containing the passphrase of the encrypted key files. If empty,
+	// the passphrase is read from the TM_KEY_PASSPHRASE environment variable,
+	// or else from stdin when a key file is encrypted.
+	KeyPassphrase string `mapstructure:"key-passphrase-file"`
+
+	// Mechanism to connect to the ABCI application: socket | socket-framed | grpc
+	// socket-framed negotiates length-prefixed frames and pipelined requests
+	// with the application, falling back to socket if it doesn't support them.
 	ABCI string `mapstructure:"abci"`
 
+	// If true, deliver each block to the ABCI application in a single
+	// FinalizeBlock call, instead of BeginBlock, DeliverTx for each tx and
+	// EndBlock. The application must implement FinalizeBlock.
+	ABCIFinalizeBlock bool `mapstructure:"abci-finalize-block"`
+
+	// If set, record all the ABCI requests and responses to this file, in files of
+	// 10MB up to 1GB, e.g. to replay them with `abci-cli replay` to debug the
+	// application. Disabled if empty.
+	ABCIRecord string `mapstructure:"abci-record-file"`
+
+	// How often to check the health of each connection to the ABCI
+	// application, by sending it an echo request. The latency and state of
+	// the connections are reported by the /health RPC endpoint. 0 disables
+	// the checks.
+	ABCIHealthCheckInterval time.Duration `mapstructure:"abci-health-check-interval"`
+
+	// If true, reconnect to the ABCI application when the consensus
+	// connection is lost between blocks, halting consensus until the
+	// handshake brings the application back in sync. The node still stops if
+	// the connection is lost while a block is executing. If false, the node
+	// stops instead.
+	//
+	// The application must discard the state of any uncommitted block
+	// whenever a connection closes, since the block is executed again on the
+	// new connection.
+	ABCIConsensusReconnect bool `mapstructure:"abci-consensus-reconnect"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter-peers"` // false
@@ -251,20 +314,22 @@ type BaseConfig struct { //nolint: maligned
 // DefaultBaseConfig returns a default base configuration for a Tendermint node
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Mode:               defaultMode,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultLogLevel,
-		LogFormat:          LogFormatPlain,
-		FastSyncMode:       true,
-		FilterPeers:        false,
-		DBBackend:          "goleveldb",
-		DBPath:             "data",
+		Genesis:                          defaultGenesisJSONPath,
+		PrivValidatorKey:                 defaultPrivValKeyPath,
+		PrivValidatorState:               defaultPrivValStatePath,
+		PrivValidatorHealthCheckInterval: 5 * time.Second,
+		NodeKey:                          defaultNodeKeyPath,
+		Mode:                             defaultMode,
+		Moniker:                          defaultMoniker,
+		ProxyApp:                         "tcp://127.0.0.1:26658",
+		ABCI:                             "socket",
+		ABCIHealthCheckInterval:          10 * time.Second,
+		LogLevel:                         DefaultLogLevel,
+		LogFormat:                        LogFormatPlain,
+		FastSyncMode:                     true,
+		FilterPeers:                      false,
+		DBBackend:                        "goleveldb",
+		DBPath:                           "data",
 	}
 }
 
@@ -313,11 +378,51 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorListenAddrs returns the addresses listed in priv-validator-laddr.
+func (cfg BaseConfig) PrivValidatorListenAddrs() []string {
+	var addrs []string
+	for _, addr := range strings.Split(cfg.PrivValidatorListenAddr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// PrivValidatorThresholdGroupFile returns the full path to the threshold group
+// file, or an empty string if none is configured.
+func (cfg BaseConfig) PrivValidatorThresholdGroupFile() string {
+	if cfg.PrivValidatorThresholdGroup == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorThresholdGroup, cfg.RootDir)
+}
+
+// PrivValidatorFailoverStateFile returns the full path to the
+// priv_validator_failover_state.json file, next to the priv_validator_state.json file
+func (cfg BaseConfig) PrivValidatorFailoverStateFile() string {
+	return filepath.Join(filepath.Dir(cfg.PrivValidatorStateFile()), "priv_validator_failover_state.json")
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// KeyPassphraseFile returns the full path to the file containing the
+// passphrase of the key files, or an empty string if there is none.
+func (cfg BaseConfig) KeyPassphraseFile() string {
+	if cfg.KeyPassphrase == "" {
+		return ""
+	}
+	return rootify(cfg.KeyPassphrase, cfg.RootDir)
+}
+
+// ABCIRecordFile returns the full path to the ABCI recording file
+func (cfg BaseConfig) ABCIRecordFile() string {
+	return rootify(cfg.ABCIRecord, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -349,6 +454,24 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return fmt.Errorf("unknown mode: %v", cfg.Mode)
 	}
+	if cfg.ABCIHealthCheckInterval < 0 {
+		return errors.New("abci-health-check-interval can't be negative")
+	}
+	if cfg.PrivValidatorHealthCheckInterval < 0 {
+		return errors.New("priv-validator-health-check-interval can't be negative")
+	}
+	if cfg.PrivValidatorThresholdGroup != "" {
+		addrs := cfg.PrivValidatorListenAddrs()
+		if len(addrs) == 0 {
+			return errors.New("priv-validator-threshold-group-file requires the addresses of the share signers " +
+				"in priv-validator-laddr")
+		}
+		for _, addr := range addrs {
+			if !strings.HasPrefix(addr, "grpc://") {
+				return fmt.Errorf("share signer address %s must be prefixed with grpc://", addr)
+			}
+		}
+	}
 	return nil
 }
 
@@ -375,7 +498,8 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors-allowed-headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server supports /broadcast_tx_commit, the read-only query
+	// methods and streaming event subscriptions.
 	GRPCListenAddress string `mapstructure:"grpc-laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -403,16 +527,62 @@ type RPCConfig struct {
 	MaxSubscriptionClients int `mapstructure:"max-subscription-clients"`
 
 	// Maximum number of unique queries a given client can /subscribe to
-	// If you're using GRPC (or Local RPC client) and /broadcast_tx_commit, set
+	// If you're using the Local RPC client and /broadcast_tx_commit, set
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max-subscriptions-per-client"`
 
-	// How long to wait for a tx to be committed during /broadcast_tx_commit
+	// How long to wait for a tx to be committed during /broadcast_tx_commit,
+	// and the maximum and default wait for /broadcast_tx_wait
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
 	// See https://github.com/klyed/tendermint/issues/3435
 	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout-broadcast-tx-commit"`
 
+	// Number of recent heights for which to retain events in the event log,
+	// which clients can read from a cursor with /events, e.g. to resume
+	// after a disconnect without missing events.
+	// 0 disables the event log.
+	EventLogWindowSize int64 `mapstructure:"event-log-window-size"`
+
+	// What to do by default when a subscriber doesn't consume events fast
+	// enough and its buffer is full:
+	//   1) "cancel" - cancel the subscription (default)
+	//   2) "drop-oldest" - drop the oldest buffered event
+	//   3) "drop-newest" - drop the new event
+	//   4) "block" - wait up to subscription-overflow-timeout for the
+	//   subscriber, then cancel the subscription
+	// Subscribers can choose another policy when subscribing, but can only
+	// choose "block" if it's the default.
+	// WARNING: "block" delays the delivery of events to all other subscribers,
+	// including the node's own, while waiting.
+	SubscriptionOverflowPolicy string `mapstructure:"subscription-overflow-policy"`
+
+	// How long to wait for a slow subscriber with the "block" overflow policy.
+	// Subscribers can choose a shorter timeout when subscribing.
+	SubscriptionOverflowTimeout time.Duration `mapstructure:"subscription-overflow-timeout"`
+
+	// The queries whose dropped events are counted separately in the metrics,
+	// as written by the subscribers. The dropped events of the other queries
+	// are counted together, with the query "other".
+	SubscriptionMetricsQueries []string `mapstructure:"subscription-metrics-queries"`
+
+	// Maximum number of events per second sent to each WebSocket client, with
+	// bursts of up to subscription-event-burst events. Events exceeding the
+	// quota are dropped.
+	// 0 - unlimited.
+	SubscriptionEventRate int `mapstructure:"subscription-event-rate"`
+
+	// Maximum burst of events sent to each WebSocket client when
+	// subscription-event-rate is set.
+	SubscriptionEventBurst int `mapstructure:"subscription-event-burst"`
+
+	// Maximum number of results of /block, /block_results, /commit and
+	// /validators for past heights, which are immutable, to cache in memory.
+	// Such results are also served over URI requests with HTTP caching headers
+	// (Cache-Control and ETag), allowing clients and CDNs to cache them.
+	// 0 - disables the cache.
+	ResultCacheSize int `mapstructure:"result-cache-size"`
+
 	// Maximum size of request body, in bytes
 	MaxBodyBytes int64 `mapstructure:"max-body-bytes"`
 
@@ -437,6 +607,46 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls-key-file"`
 
+	// The path to a file containing the certificates of the authorities used to
+	// verify client certificates, which clients may present to authenticate.
+	// Clients are identified by the certificate's subject common name, and
+	// their roles are the subject's organizational units.
+	// Might be either absolute path or path related to Tendermint's config directory.
+	// NOTE: requires tls-cert-file and tls-key-file.
+	TLSClientCAFile string `mapstructure:"tls-client-ca-file"`
+
+	// The path to a file containing bearer tokens which clients may present in
+	// the Authorization header to authenticate, one per line in the format
+	// "<token> <name> <role>[,<role>...]".
+	// Might be either absolute path or path related to Tendermint's config directory.
+	AuthTokenFile string `mapstructure:"auth-token-file"`
+
+	// The path to a file containing an HMAC key (at least 32 bytes) used to
+	// verify HS256 JSON Web Tokens, which clients may present in the
+	// Authorization header to authenticate. Clients are identified by the
+	// "sub" claim, and their roles are listed in the "roles" claim.
+	// Might be either absolute path or path related to Tendermint's config directory.
+	AuthJWTKeyFile string `mapstructure:"auth-jwt-key-file"`
+
+	// Roles allowed to call each method, as a list of rules in the format
+	// "<method>=<role>[,<role>...]", applied to HTTP, URI and WebSocket calls.
+	// The method "*" applies to all methods without a rule of their own, and
+	// methods without any rule can be called by anyone. The role "*" allows
+	// any caller, and "authenticated" any authenticated caller.
+	// e.g. ["*=authenticated", "broadcast_tx_sync=*", "dial_peers=operator"]
+	MethodACL []string `mapstructure:"method-acl"`
+
+	// Rate limits for calls from each client IP, as a list of rules in the
+	// format "<method>=<rate>[:<burst>]", where rate is the number of calls per
+	// second and burst defaults to the rate. The method "*" limits all calls
+	// combined. Calls exceeding a limit are rejected.
+	// e.g. ["*=50:100", "tx_search=2:5", "blockchain=5"]
+	RateLimits []string `mapstructure:"rate-limits"`
+
+	// IP addresses or CIDR ranges of trusted reverse proxies, whose
+	// X-Forwarded-For header is used to find the client IP for rate limits.
+	TrustedProxies []string `mapstructure:"trusted-proxies"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof-laddr"`
 }
@@ -457,6 +667,17 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxSubscriptionClients:    100,
 		MaxSubscriptionsPerClient: 5,
 		TimeoutBroadcastTxCommit:  10 * time.Second,
+		EventLogWindowSize:        0,
+
+		SubscriptionOverflowPolicy:  SubscriptionOverflowCancel,
+		SubscriptionOverflowTimeout: 100 * time.Millisecond,
+		SubscriptionEventRate:       0,
+		SubscriptionEventBurst:      100,
+		SubscriptionMetricsQueries: []string{
+			"tm.event='NewBlock'", "tm.event='NewBlockHeader'", "tm.event='Tx'",
+		},
+
+		ResultCacheSize: 100,
 
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
@@ -493,12 +714,54 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout-broadcast-tx-commit can't be negative")
 	}
+	if cfg.EventLogWindowSize < 0 {
+		return errors.New("event-log-window-size can't be negative")
+	}
+	switch cfg.SubscriptionOverflowPolicy {
+	case SubscriptionOverflowCancel, SubscriptionOverflowDropOldest, SubscriptionOverflowDropNewest:
+	case SubscriptionOverflowBlock:
+		if cfg.SubscriptionOverflowTimeout <= 0 {
+			return errors.New("subscription-overflow-timeout must be positive with the block overflow policy")
+		}
+	default:
+		return fmt.Errorf("unknown subscription-overflow-policy %q", cfg.SubscriptionOverflowPolicy)
+	}
+	if cfg.SubscriptionOverflowTimeout < 0 {
+		return errors.New("subscription-overflow-timeout can't be negative")
+	}
+	if cfg.SubscriptionEventRate < 0 {
+		return errors.New("subscription-event-rate can't be negative")
+	}
+	if cfg.SubscriptionEventBurst < 0 {
+		return errors.New("subscription-event-burst can't be negative")
+	}
+	if cfg.ResultCacheSize < 0 {
+		return errors.New("result-cache-size can't be negative")
+	}
 	if cfg.MaxBodyBytes < 0 {
 		return errors.New("max-body-bytes can't be negative")
 	}
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max-header-bytes can't be negative")
 	}
+	if cfg.TLSClientCAFile != "" && !cfg.IsTLSEnabled() {
+		return errors.New("tls-client-ca-file requires tls-cert-file and tls-key-file")
+	}
+	for _, rule := range cfg.MethodACL {
+		if !strings.Contains(rule, "=") {
+			return fmt.Errorf("invalid method-acl rule %q, expected \"<method>=<roles>\"", rule)
+		}
+	}
+	for _, rule := range cfg.RateLimits {
+		if !strings.Contains(rule, "=") {
+			return fmt.Errorf("invalid rate-limits rule %q, expected \"<method>=<rate>[:<burst>]\"", rule)
+		}
+	}
+	for _, addr := range cfg.TrustedProxies {
+		if _, _, err := net.ParseCIDR(addr); err != nil && net.ParseIP(addr) == nil {
+			return fmt.Errorf("invalid trusted-proxies address %q", addr)
+		}
+	}
 	return nil
 }
 
@@ -527,6 +790,38 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+// ClientCAFile returns the full path to the client CA file, or an empty
+// string if unset.
+func (cfg RPCConfig) ClientCAFile() string {
+	return cfg.configFile(cfg.TLSClientCAFile)
+}
+
+// TokenFile returns the full path to the bearer token file, or an empty
+// string if unset.
+func (cfg RPCConfig) TokenFile() string {
+	return cfg.configFile(cfg.AuthTokenFile)
+}
+
+// JWTKeyFile returns the full path to the JWT key file, or an empty string if
+// unset.
+func (cfg RPCConfig) JWTKeyFile() string {
+	return cfg.configFile(cfg.AuthJWTKeyFile)
+}
+
+// IsAuthEnabled returns true if any RPC authentication or authorization is
+// configured.
+func (cfg RPCConfig) IsAuthEnabled() bool {
+	return cfg.TLSClientCAFile != "" || cfg.AuthTokenFile != "" || cfg.AuthJWTKeyFile != "" ||
+		len(cfg.MethodACL) > 0
+}
+
+func (cfg RPCConfig) configFile(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -697,6 +992,11 @@ type MempoolConfig struct {
 	// Including space needed by encoding (one varint per transaction).
 	// XXX: Unused due to https://github.com/klyed/tendermint/issues/5796
 	MaxBatchBytes int `mapstructure:"max-batch-bytes"`
+	// Number of connections to the application used to check txs (default 1).
+	// With more than one, CheckTx is called concurrently over all of them, so
+	// the application must opt into it (see ConcurrentCheckTxApplication).
+	// There is no gain for builtin applications, which are called under a lock.
+	CheckTxConnections int `mapstructure:"check-tx-connections"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
@@ -711,6 +1011,8 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:   10000,
 		MaxTxBytes:  1024 * 1024, // 1MB
+
+		CheckTxConnections: 1,
 	}
 }
 
@@ -746,6 +1048,9 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max-tx-bytes can't be negative")
 	}
+	if cfg.CheckTxConnections < 1 {
+		return errors.New("check-tx-connections must be positive")
+	}
 	return nil
 }
 
@@ -761,6 +1066,21 @@ type StateSyncConfig struct {
 	TrustHeight   int64         `mapstructure:"trust-height"`
 	TrustHash     string        `mapstructure:"trust-hash"`
 	DiscoveryTime time.Duration `mapstructure:"discovery-time"`
+
+	// SnapshotSource, if set, restores snapshots from an exported snapshot
+	// directory (file:///path) or an S3-compatible object store
+	// (s3://bucket/prefix) instead of discovering them from peers.
+	SnapshotSource    string `mapstructure:"snapshot-source"`
+	S3Endpoint        string `mapstructure:"s3-endpoint"`
+	S3Region          string `mapstructure:"s3-region"`
+	S3AccessKeyID     string `mapstructure:"s3-access-key-id"`
+	S3SecretAccessKey string `mapstructure:"s3-secret-access-key"`
+
+	// BackfillBlocks is the number of blocks preceding the state synced height
+	// whose headers, commits and validator sets are fetched from peers after
+	// state sync. If 0, the evidence max-age window of the consensus parameters
+	// is backfilled.
+	BackfillBlocks int64 `mapstructure:"backfill-blocks"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -813,6 +1133,12 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 			return fmt.Errorf("invalid trusted-hash: %w", err)
 		}
 	}
+	if (cfg.S3AccessKeyID == "") != (cfg.S3SecretAccessKey == "") {
+		return errors.New("s3-access-key-id and s3-secret-access-key must be set together")
+	}
+	if cfg.BackfillBlocks < 0 {
+		return errors.New("backfill-blocks can't be negative")
+	}
 	return nil
 }
 
@@ -1044,6 +1370,65 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+//-----------------------------------------------------------------------------
+// PruningConfig
+
+// PruningConfig defines the configuration for pruning blocks and states, which
+// runs in the background below the retain height requested by the application.
+type PruningConfig struct {
+	// MinRetainBlocks is the minimum number of blocks to retain, regardless of
+	// the retain height requested by the application. 0 retains all blocks the
+	// application asks to keep.
+	MinRetainBlocks int64 `mapstructure:"min-retain-blocks"`
+
+	// Interval is the time to wait between pruning batches.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// BatchSize is the maximum number of heights pruned per batch, limiting
+	// the rate of pruning together with Interval.
+	BatchSize int64 `mapstructure:"batch-size"`
+
+	// CompactionInterval is the number of pruned heights after which the
+	// pruned key ranges are compacted, reclaiming disk space. 0 disables
+	// compaction.
+	CompactionInterval int64 `mapstructure:"compaction-interval"`
+}
+
+// DefaultPruningConfig returns a default configuration for pruning.
+func DefaultPruningConfig() *PruningConfig {
+	return &PruningConfig{
+		MinRetainBlocks:    0,
+		Interval:           time.Second,
+		BatchSize:          100,
+		CompactionInterval: 10000,
+	}
+}
+
+// TestPruningConfig returns a configuration for pruning used for testing.
+func TestPruningConfig() *PruningConfig {
+	cfg := DefaultPruningConfig()
+	cfg.Interval = 10 * time.Millisecond
+	return cfg
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *PruningConfig) ValidateBasic() error {
+	if cfg.MinRetainBlocks < 0 {
+		return errors.New("min-retain-blocks can't be negative")
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.New("batch-size must be positive")
+	}
+	if cfg.CompactionInterval < 0 {
+		return errors.New("compaction-interval can't be negative")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // InstrumentationConfig
 
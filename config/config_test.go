@@ -28,6 +28,9 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal("/opt/data", cfg.DBDir())
 	assert.Equal("/foo/wal/mem", cfg.Mempool.WalDir())
 
+	assert.Equal("", cfg.KeyPassphraseFile())
+	cfg.KeyPassphrase = "config/passphrase"
+	assert.Equal("/foo/config/passphrase", cfg.KeyPassphraseFile())
 }
 
 func TestConfigValidateBasic(t *testing.T) {
@@ -62,6 +65,22 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.ABCIHealthCheckInterval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorHealthCheckInterval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.PrivValidatorThresholdGroup = "config/threshold_group.json"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorListenAddr = "grpc://127.0.0.1:26659,tcp://127.0.0.1:26660"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorListenAddr = "grpc://127.0.0.1:26659,grpc://127.0.0.1:26660"
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -74,6 +93,11 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
 		"TimeoutBroadcastTxCommit",
+		"EventLogWindowSize",
+		"SubscriptionOverflowTimeout",
+		"SubscriptionEventRate",
+		"SubscriptionEventBurst",
+		"ResultCacheSize",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
 	}
@@ -83,6 +107,35 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg = TestRPCConfig()
+	cfg.SubscriptionOverflowPolicy = "invalid"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowPolicy = SubscriptionOverflowBlock
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowTimeout = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.MethodACL = []string{"*=authenticated", "broadcast_tx_sync=*"}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.MethodACL = []string{"dial_peers"}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.RateLimits = []string{"*=50:100", "tx_search=2"}
+	cfg.TrustedProxies = []string{"10.0.0.1", "192.168.0.0/16", "::1"}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TrustedProxies = []string{"10.0.0"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RateLimits = []string{"tx_search"}
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestRPCConfig()
+	cfg.TLSClientCAFile = "ca.pem"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TLSCertFile, cfg.TLSKeyFile = "cert.pem", "key.pem"
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -121,6 +174,9 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.CheckTxConnections = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
@@ -190,3 +246,21 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
 }
+
+func TestPruningConfigValidateBasic(t *testing.T) {
+	cfg := TestPruningConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	fieldsToTest := []string{
+		"MinRetainBlocks",
+		"Interval",
+		"BatchSize",
+		"CompactionInterval",
+	}
+
+	for _, fieldName := range fieldsToTest {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(-1)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
+	}
+}
@@ -150,8 +150,19 @@ priv-validator-state-file = "{{ js .BaseConfig.PrivValidatorState }}"
 # TCP or UNIX socket address for Tendermint to listen on for
 # connections from an external PrivValidator process
 # when the listenAddr is prefixed with grpc instead of tcp it will use the gRPC Client
+# A comma separated list of addresses fails over between several signers
+# holding the same key, using one signer per height to avoid double signing.
 priv-validator-laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# How often to check the health of each external PrivValidator process when
+# several addresses are listed in priv-validator-laddr. 0 disables the checks.
+priv-validator-health-check-interval = "{{ .BaseConfig.PrivValidatorHealthCheckInterval }}"
+
+# Path to the JSON file describing a validator key split with split-key.
+# If set, priv-validator-laddr lists the grpc:// addresses of the signers
+# holding its shares, and a threshold of them must agree to sign.
+priv-validator-threshold-group-file = "{{ js .BaseConfig.PrivValidatorThresholdGroup }}"
+
 # Client certificate generated while creating needed files for secure connection.
 # If a remote validator address is provided but no certificate, the connection will be insecure
 priv-validator-client-certificate-file = "{{ js .BaseConfig.PrivValidatorClientCertificate }}"
@@ -165,9 +176,40 @@ priv-validator-certificate-authority = "{{ js .BaseConfig.PrivValidatorRootCA }}
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
-# Mechanism to connect to the ABCI application: socket | grpc
+# Path to a file containing the passphrase of the encrypted key files
+# (priv-validator-key-file and node-key-file). If empty, the passphrase is read
+# from the TM_KEY_PASSPHRASE environment variable, or else from stdin when one
+# of the key files is encrypted.
+key-passphrase-file = "{{ js .BaseConfig.KeyPassphrase }}"
+
+# Mechanism to connect to the ABCI application: socket | socket-framed | grpc
+# socket-framed negotiates length-prefixed frames and pipelined requests
+# with the application, falling back to socket if it doesn't support them.
 abci = "{{ .BaseConfig.ABCI }}"
 
+# If true, deliver each block to the ABCI application in a single FinalizeBlock
+# call, instead of BeginBlock, DeliverTx for each tx and EndBlock.
+# The application must implement FinalizeBlock.
+abci-finalize-block = {{ .BaseConfig.ABCIFinalizeBlock }}
+
+# If set, record all the ABCI requests and responses to this file, in files of
+# 10MB up to 1GB, e.g. to replay them with abci-cli replay to debug the
+# application. Disabled if empty.
+abci-record-file = "{{ js .BaseConfig.ABCIRecord }}"
+
+# How often to check the health of each connection to the ABCI application, by
+# sending it an echo request. The latency and state of the connections are
+# reported by the /health RPC endpoint. 0 disables the checks.
+abci-health-check-interval = "{{ .BaseConfig.ABCIHealthCheckInterval }}"
+
+# If true, reconnect to the ABCI application when the consensus connection is
+# lost between blocks, halting consensus until the handshake brings the
+# application back in sync. The node still stops if the connection is lost
+# while a block is executing. If false, the node stops instead.
+# The application must discard the state of any uncommitted block whenever a
+# connection closes, since the block is executed again on the new connection.
+abci-consensus-reconnect = {{ .BaseConfig.ABCIConsensusReconnect }}
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter-peers = {{ .BaseConfig.FilterPeers }}
@@ -197,7 +239,9 @@ cors-allowed-methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 cors-allowed-headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
 # TCP or UNIX socket address for the gRPC server to listen on
-# NOTE: This server only supports /broadcast_tx_commit
+# NOTE: This server supports /broadcast_tx_commit and the read-only query
+# methods (status, block, commit, validators, tx, tx_search, abci_query, ...),
+# as well as streaming event subscriptions.
 grpc-laddr = "{{ .RPC.GRPCListenAddress }}"
 
 # Maximum number of simultaneous connections.
@@ -227,16 +271,62 @@ max-open-connections = {{ .RPC.MaxOpenConnections }}
 max-subscription-clients = {{ .RPC.MaxSubscriptionClients }}
 
 # Maximum number of unique queries a given client can /subscribe to
-# If you're using GRPC (or Local RPC client) and /broadcast_tx_commit, set to
+# If you're using the Local RPC client and /broadcast_tx_commit, set to
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max-subscriptions-per-client = {{ .RPC.MaxSubscriptionsPerClient }}
 
-# How long to wait for a tx to be committed during /broadcast_tx_commit.
+# How long to wait for a tx to be committed during /broadcast_tx_commit,
+# and the maximum and default wait for /broadcast_tx_wait.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
 # See https://github.com/klyed/tendermint/issues/3435
 timeout-broadcast-tx-commit = "{{ .RPC.TimeoutBroadcastTxCommit }}"
 
+# Number of recent heights for which to retain events in the event log,
+# which clients can read from a cursor with /events, e.g. to resume after a
+# disconnect without missing events.
+# 0 disables the event log.
+event-log-window-size = {{ .RPC.EventLogWindowSize }}
+
+# What to do by default when a subscriber doesn't consume events fast enough
+# and its buffer is full:
+#   1) "cancel" - cancel the subscription (default)
+#   2) "drop-oldest" - drop the oldest buffered event
+#   3) "drop-newest" - drop the new event
+#   4) "block" - wait up to subscription-overflow-timeout for the subscriber,
+#   then cancel the subscription
+# Subscribers can choose another policy when subscribing, but can only choose
+# "block" if it's the default.
+# WARNING: "block" delays the delivery of events to all other subscribers,
+# including the node's own, while waiting.
+subscription-overflow-policy = "{{ .RPC.SubscriptionOverflowPolicy }}"
+
+# How long to wait for a slow subscriber with the "block" overflow policy.
+# Subscribers can choose a shorter timeout when subscribing.
+subscription-overflow-timeout = "{{ .RPC.SubscriptionOverflowTimeout }}"
+
+# The queries whose dropped events are counted separately in the metrics, as
+# written by the subscribers. The dropped events of the other queries are
+# counted together, with the query "other".
+subscription-metrics-queries = [{{ range .RPC.SubscriptionMetricsQueries }}{{ printf "%q, " . }}{{end}}]
+
+# Maximum number of events per second sent to each WebSocket client, with
+# bursts of up to subscription-event-burst events. Events exceeding the quota
+# are dropped.
+# 0 - unlimited.
+subscription-event-rate = {{ .RPC.SubscriptionEventRate }}
+
+# Maximum burst of events sent to each WebSocket client when
+# subscription-event-rate is set.
+subscription-event-burst = {{ .RPC.SubscriptionEventBurst }}
+
+# Maximum number of results of /block, /block_results, /commit and /validators
+# for past heights, which are immutable, to cache in memory. Such results are
+# also served over URI requests with HTTP caching headers (Cache-Control and
+# ETag), allowing clients and CDNs to cache them.
+# 0 - disables the cache.
+result-cache-size = {{ .RPC.ResultCacheSize }}
+
 # Maximum size of request body, in bytes
 max-body-bytes = {{ .RPC.MaxBodyBytes }}
 
@@ -258,6 +348,46 @@ tls-cert-file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls-key-file = "{{ .RPC.TLSKeyFile }}"
 
+# The path to a file containing the certificates of the authorities used to
+# verify client certificates, which clients may present to authenticate.
+# Clients are identified by the certificate's subject common name, and their
+# roles are the subject's organizational units.
+# Might be either absolute path or path related to Tendermint's config directory.
+# NOTE: requires tls-cert-file and tls-key-file.
+tls-client-ca-file = "{{ .RPC.TLSClientCAFile }}"
+
+# The path to a file containing bearer tokens which clients may present in the
+# Authorization header to authenticate, one per line in the format
+# "<token> <name> <role>[,<role>...]".
+# Might be either absolute path or path related to Tendermint's config directory.
+auth-token-file = "{{ .RPC.AuthTokenFile }}"
+
+# The path to a file containing an HMAC key (at least 32 bytes) used to verify
+# HS256 JSON Web Tokens, which clients may present in the Authorization header
+# to authenticate. Clients are identified by the "sub" claim, and their roles
+# are listed in the "roles" claim.
+# Might be either absolute path or path related to Tendermint's config directory.
+auth-jwt-key-file = "{{ .RPC.AuthJWTKeyFile }}"
+
+# Roles allowed to call each method, as a list of rules in the format
+# "<method>=<role>[,<role>...]", applied to HTTP, URI and WebSocket calls.
+# The method "*" applies to all methods without a rule of their own, and
+# methods without any rule can be called by anyone. The role "*" allows any
+# caller, and "authenticated" any authenticated caller.
+# e.g. ["*=authenticated", "broadcast_tx_sync=*", "dial_peers=operator"]
+method-acl = [{{ range .RPC.MethodACL }}{{ printf "%q, " . }}{{end}}]
+
+# Rate limits for calls from each client IP, as a list of rules in the format
+# "<method>=<rate>[:<burst>]", where rate is the number of calls per second and
+# burst defaults to the rate. The method "*" limits all calls combined. Calls
+# exceeding a limit are rejected.
+# e.g. ["*=50:100", "tx_search=2:5", "blockchain=5"]
+rate-limits = [{{ range .RPC.RateLimits }}{{ printf "%q, " . }}{{end}}]
+
+# IP addresses or CIDR ranges of trusted reverse proxies, whose X-Forwarded-For
+# header is used to find the client IP for rate limits.
+trusted-proxies = [{{ range .RPC.TrustedProxies }}{{ printf "%q, " . }}{{end}}]
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 
@@ -362,6 +492,13 @@ max-tx-bytes = {{ .Mempool.MaxTxBytes }}
 # XXX: Unused due to https://github.com/klyed/tendermint/issues/5796
 max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Number of connections to the application used to check txs. With more than
+# one, CheckTx is called concurrently over all of them, so the application must
+# handle it (for socket applications, by implementing ConcurrentCheckTx).
+# Txs are still added to the mempool in the order they were received.
+# There is no gain for builtin applications, which are called under a lock.
+check-tx-connections = {{ .Mempool.CheckTxConnections }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -391,6 +528,25 @@ discovery-time = "{{ .StateSync.DiscoveryTime }}"
 # Will create a new, randomly named directory within, and remove it when done.
 temp-dir = "{{ .StateSync.TempDir }}"
 
+# Restore snapshots from a snapshot source instead of discovering them from peers. Either an
+# exported snapshot directory (e.g. "file:///path/to/snapshots", as written by
+# "tendermint snapshot export") or an S3-compatible object store (e.g. "s3://bucket/prefix").
+# Snapshots are still verified against the app hash obtained via the rpc-servers above.
+snapshot-source = "{{ .StateSync.SnapshotSource }}"
+
+# S3-compatible object store settings, used for "s3://" snapshot sources. Objects are addressed
+# path-style below the endpoint. Requests are unsigned unless both access keys are set.
+s3-endpoint = "{{ .StateSync.S3Endpoint }}"
+s3-region = "{{ .StateSync.S3Region }}"
+s3-access-key-id = "{{ .StateSync.S3AccessKeyID }}"
+s3-secret-access-key = "{{ .StateSync.S3SecretAccessKey }}"
+
+# The number of blocks preceding the state synced height whose headers, commits and validator sets
+# are fetched from peers after state sync, such that the node can verify evidence and serve light
+# clients. If 0, the evidence max-age window (both height and duration) of the consensus parameters
+# is backfilled.
+backfill-blocks = {{ .StateSync.BackfillBlocks }}
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -458,6 +614,29 @@ peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = "{{ .TxIndex.Indexer }}"
 
+#######################################################
+###           Pruning Configuration Options         ###
+#######################################################
+[pruning]
+
+# Blocks and states below the retain height requested by the application (via ResponseCommit)
+# are pruned in the background, in batches. The pruning progress is persisted, such that it is
+# resumed after a restart.
+
+# The minimum number of blocks to retain, overriding a higher retain height requested by the
+# application. 0 retains exactly what the application asks for.
+min-retain-blocks = {{ .Pruning.MinRetainBlocks }}
+
+# The time to wait between pruning batches.
+interval = "{{ .Pruning.Interval }}"
+
+# The maximum number of heights pruned per batch.
+batch-size = {{ .Pruning.BatchSize }}
+
+# The number of pruned heights after which the pruned key ranges are compacted to reclaim disk
+# space (only supported by the goleveldb backend). 0 disables compaction.
+compaction-interval = {{ .Pruning.CompactionInterval }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -206,6 +206,9 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// deliver blocks to the app in a single FinalizeBlock call
+	finalizeBlock bool
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -233,6 +236,12 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetFinalizeBlock sets whether replayed blocks are delivered to the app in a
+// single FinalizeBlock call, see sm.BlockExecutorWithFinalizeBlock.
+func (h *Handshaker) SetFinalizeBlock(finalizeBlock bool) {
+	h.finalizeBlock = finalizeBlock
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -469,7 +478,9 @@ func (h *Handshaker) replayBlocks(
 			assertAppHashEqualsOneFromBlock(appHash, block)
 		}
 
-		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight)
+		appHash, err = sm.ExecCommitBlock(
+			proxyApp.Consensus(), block, h.logger, h.stateStore, h.genDoc.InitialHeight, h.finalizeBlock,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -497,7 +508,8 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 
 	// Use stubs for both mempool and evidence pool since no transactions nor
 	// evidence are needed here - block already exists.
-	blockExec := sm.NewBlockExecutor(h.stateStore, h.logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{})
+	blockExec := sm.NewBlockExecutor(h.stateStore, h.logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithFinalizeBlock(h.finalizeBlock))
 	blockExec.SetEventBus(h.eventBus)
 
 	var err error
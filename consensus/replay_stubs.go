@@ -23,10 +23,18 @@ func (emptyMempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo)
 }
 func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (emptyMempool) TxEntries(n int) []mempl.TxEntry         { return nil }
+func (emptyMempool) TxByKey(_ [mempl.TxKeySize]byte) (mempl.TxEntry, bool) {
+	return mempl.TxEntry{}, false
+}
+func (emptyMempool) RemoveTxByKey(_ [mempl.TxKeySize]byte, _ bool) error {
+	return mempl.ErrTxNotFound
+}
 func (emptyMempool) Update(
 	_ int64,
 	_ types.Txs,
 	_ []*abci.ResponseDeliverTx,
+	_ *abci.RecheckTxs,
 	_ mempl.PreCheckFunc,
 	_ mempl.PostCheckFunc,
 ) error {
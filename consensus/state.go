@@ -25,6 +25,7 @@ import (
 	tmsync "github.com/klyed/tendermint/libs/sync"
 	"github.com/klyed/tendermint/p2p"
 	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/proxy"
 	sm "github.com/klyed/tendermint/state"
 	"github.com/klyed/tendermint/types"
 	tmtime "github.com/klyed/tendermint/types/time"
@@ -42,6 +43,11 @@ var (
 
 var msgQueueSize = 1000
 
+// applyBlockRetryInterval is the time to wait before retrying to apply a
+// committed block while the consensus connection to the application is down,
+// see proxy.ErrBlockNotStarted.
+var applyBlockRetryInterval = time.Second
+
 // msgs from the reactor which may update the state
 type msgInfo struct {
 	Msg    Message    `json:"msg"`
@@ -140,6 +146,9 @@ type State struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// prunes blocks and states in the background, if set
+	pruner *sm.Pruner
 }
 
 // StateOption sets an optional parameter on the State.
@@ -211,6 +220,13 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StatePruner sets a background pruner, which prunes blocks and states below
+// the retain height requested by the app instead of pruning them synchronously
+// when committing a block.
+func StatePruner(pruner *sm.Pruner) StateOption {
+	return func(cs *State) { cs.pruner = pruner }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -917,6 +933,10 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 		cs.enterPrecommit(ti.Height, ti.Round)
 		cs.enterNewRound(ti.Height, ti.Round+1)
 
+	case cstypes.RoundStepCommit:
+		// retry to apply the block, see finalizeCommit
+		cs.tryFinalizeCommit(ti.Height)
+
 	default:
 		panic(fmt.Sprintf("invalid timeout step: %v", ti.Step))
 	}
@@ -1653,14 +1673,28 @@ func (cs *State) finalizeCommit(height int64) {
 		block,
 	)
 	if err != nil {
-		logger.Error("failed to apply block", "err", err)
+		if !errors.Is(err, proxy.ErrBlockNotStarted) {
+			logger.Error("failed to apply block", "err", err)
+			return
+		}
+		// The consensus connection to the application was down before the
+		// block was sent: the block stays committed, so retry once the
+		// connection is reestablished and the application resumed. If it was
+		// lost while the block was executing, the node is stopped instead.
+		logger.Error("failed to apply block; retrying", "err", err, "retry_in", applyBlockRetryInterval)
+		cs.scheduleTimeout(applyBlockRetryInterval, height, cs.Round, cstypes.RoundStepCommit)
 		return
 	}
 
 	fail.Fail() // XXX
 
 	// Prune old heights, if requested by ABCI app.
-	if retainHeight > 0 {
+	switch {
+	case retainHeight > 0 && cs.pruner != nil:
+		if err := cs.pruner.SetRetainHeight(retainHeight); err != nil {
+			logger.Error("failed to set retain height", "retain_height", retainHeight, "err", err)
+		}
+	case retainHeight > 0:
 		pruned, err := cs.pruneBlocks(retainHeight)
 		if err != nil {
 			logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
@@ -3,6 +3,7 @@ package consensus
 import (
 	"time"
 
+	cstypes "github.com/klyed/tendermint/consensus/types"
 	"github.com/klyed/tendermint/libs/log"
 	"github.com/klyed/tendermint/libs/service"
 )
@@ -93,7 +94,10 @@ func (t *timeoutTicker) stopTimer() {
 // timeouts of 0 on the tickChan will be immediately relayed to the tockChan
 func (t *timeoutTicker) timeoutRoutine() {
 	t.Logger.Debug("Starting timeout routine")
-	var ti timeoutInfo
+	var (
+		ti    timeoutInfo
+		fired bool // whether ti timed out already
+	)
 	for {
 		select {
 		case newti := <-t.tickChan:
@@ -106,7 +110,9 @@ func (t *timeoutTicker) timeoutRoutine() {
 				if newti.Round < ti.Round {
 					continue
 				} else if newti.Round == ti.Round {
-					if ti.Step > 0 && newti.Step <= ti.Step {
+					// a commit which failed is retried after its timeout
+					retry := fired && ti.Step == cstypes.RoundStepCommit && newti.Step == cstypes.RoundStepCommit
+					if ti.Step > 0 && newti.Step <= ti.Step && !retry {
 						continue
 					}
 				}
@@ -118,10 +124,12 @@ func (t *timeoutTicker) timeoutRoutine() {
 			// update timeoutInfo and reset timer
 			// NOTE time.Timer allows duration to be non-positive
 			ti = newti
+			fired = false
 			t.timer.Reset(ti.Duration)
 			t.Logger.Debug("Scheduled timeout", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
 		case <-t.timer.C:
 			t.Logger.Info("Timed out", "dur", ti.Duration, "height", ti.Height, "round", ti.Round, "step", ti.Step)
+			fired = true
 			// go routine here guarantees timeoutRoutine doesn't block.
 			// Determinism comes from playback in the receiveRoutine.
 			// We can eliminate it by merging the timeoutRoutine into receiveRoutine
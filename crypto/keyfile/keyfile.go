@@ -0,0 +1,291 @@
+// Package keyfile encrypts key files, such as priv_validator_key.json and
+// node_key.json, with a passphrase.
+//
+// The key is derived from the passphrase with scrypt, and the file is sealed
+// with xchacha20poly1305. An encrypted file is a JSON document holding the KDF
+// parameters, the nonce and the ciphertext, so that it can be told apart from a
+// plaintext key file.
+package keyfile
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/klyed/tendermint/crypto"
+	"github.com/klyed/tendermint/crypto/xchacha20poly1305"
+	"github.com/klyed/tendermint/libs/tempfile"
+)
+
+const (
+	// KDFScrypt is the only supported key derivation function.
+	KDFScrypt = "scrypt"
+	// CipherXChaCha20Poly1305 is the only supported cipher.
+	CipherXChaCha20Poly1305 = "xchacha20poly1305"
+
+	// Default scrypt parameters, as recommended for interactive logins in 2017.
+	DefaultScryptN = 1 << 15
+	DefaultScryptR = 8
+	DefaultScryptP = 1
+
+	// Maximum scrypt parameters accepted, so that a key file can't make the
+	// key derivation take more than 1 GiB of memory or run for hours.
+	MaxScryptN      = 1 << 20
+	MaxScryptR      = 32
+	MaxScryptP      = 16
+	maxScryptMemory = 1 << 30 // 128 * N * r bytes
+
+	saltSize = 32
+)
+
+var (
+	// ErrWrongPassphrase is returned when a file can't be decrypted with the
+	// given passphrase.
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted key file")
+
+	// ErrNoPassphrase is returned when reading an encrypted file without a
+	// passphrase.
+	ErrNoPassphrase = errors.New("key file is encrypted, but no passphrase was given")
+)
+
+// ScryptParams are the parameters of the scrypt KDF.
+type ScryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt []byte `json:"salt"`
+}
+
+// EncryptedFile is the JSON document of an encrypted key file.
+type EncryptedFile struct {
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdf_params"`
+	Cipher     string       `json:"cipher"`
+	Nonce      []byte       `json:"nonce"`
+	Ciphertext []byte       `json:"ciphertext"`
+}
+
+// Encrypt encrypts plaintext with a key derived from passphrase, using the
+// default scrypt parameters.
+func Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	return EncryptWithParams(plaintext, passphrase, DefaultScryptN, DefaultScryptR, DefaultScryptP)
+}
+
+// EncryptWithParams encrypts plaintext with a key derived from passphrase,
+// using the given scrypt parameters.
+func EncryptWithParams(plaintext []byte, passphrase string, n, r, p int) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty passphrase")
+	}
+	params := ScryptParams{N: n, R: r, P: p, Salt: crypto.CRandBytes(saltSize)}
+	aead, err := params.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	nonce := crypto.CRandBytes(xchacha20poly1305.NonceSize)
+	return json.MarshalIndent(EncryptedFile{
+		KDF:        KDFScrypt,
+		KDFParams:  params,
+		Cipher:     CipherXChaCha20Poly1305,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+}
+
+// Decrypt decrypts data produced by Encrypt. It returns ErrWrongPassphrase if
+// the passphrase doesn't match.
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	var ef EncryptedFile
+	if err := json.Unmarshal(data, &ef); err != nil {
+		return nil, fmt.Errorf("invalid encrypted key file: %w", err)
+	}
+	if ef.KDF != KDFScrypt {
+		return nil, fmt.Errorf("unsupported key derivation function %q", ef.KDF)
+	}
+	if ef.Cipher != CipherXChaCha20Poly1305 {
+		return nil, fmt.Errorf("unsupported cipher %q", ef.Cipher)
+	}
+	if len(ef.Nonce) != xchacha20poly1305.NonceSize {
+		return nil, fmt.Errorf("invalid nonce size %d", len(ef.Nonce))
+	}
+	aead, err := ef.KDFParams.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, ef.Nonce, ef.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// cipher derives the key from passphrase and returns the AEAD sealing the
+// file.
+func (params ScryptParams) cipher(passphrase string) (cipher.AEAD, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key([]byte(passphrase), params.Salt, params.N, params.R, params.P, xchacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+	}
+	return xchacha20poly1305.New(key)
+}
+
+// validate checks the parameters are within the maximums, before deriving a
+// key with them.
+func (params ScryptParams) validate() error {
+	switch {
+	case params.N > MaxScryptN:
+		return fmt.Errorf("scrypt parameter N %d is above the maximum %d", params.N, MaxScryptN)
+	case params.R > MaxScryptR:
+		return fmt.Errorf("scrypt parameter r %d is above the maximum %d", params.R, MaxScryptR)
+	case params.P > MaxScryptP:
+		return fmt.Errorf("scrypt parameter p %d is above the maximum %d", params.P, MaxScryptP)
+	case int64(params.N)*int64(params.R)*128 > maxScryptMemory:
+		return fmt.Errorf("scrypt parameters N %d and r %d need more memory than the maximum %d bytes",
+			params.N, params.R, maxScryptMemory)
+	}
+	return nil
+}
+
+// IsEncrypted returns true if data is an encrypted key file.
+func IsEncrypted(data []byte) bool {
+	var ef struct {
+		KDF        string `json:"kdf"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(data, &ef); err != nil {
+		return false
+	}
+	return ef.KDF != "" && ef.Ciphertext != nil
+}
+
+// IsEncryptedFile returns true if the file at filePath is an encrypted key
+// file.
+func IsEncryptedFile(filePath string) (bool, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	return IsEncrypted(data), nil
+}
+
+// ReadFile reads a key file, decrypting it if it's encrypted. It returns
+// ErrNoPassphrase if the file is encrypted and passphrase is empty. A
+// plaintext file is returned as is, whatever the passphrase.
+func ReadFile(filePath, passphrase string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("%v: %w", filePath, ErrNoPassphrase)
+	}
+	plaintext, err := Decrypt(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", filePath, err)
+	}
+	return plaintext, nil
+}
+
+// WriteFile atomically writes a key file, encrypting it if passphrase isn't
+// empty.
+func WriteFile(filePath string, plaintext []byte, passphrase string) error {
+	data := plaintext
+	if passphrase != "" {
+		var err error
+		data, err = Encrypt(plaintext, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	return tempfile.WriteFileAtomic(filePath, data, 0600)
+}
+
+//-----------------------------------------------------------------------------
+// Passphrases
+
+const (
+	// PassphraseEnv is the environment variable the passphrase of the key
+	// files is read from.
+	PassphraseEnv = "TM_KEY_PASSPHRASE"
+	// NewPassphraseEnv is the environment variable the new passphrase is read
+	// from when rotating it.
+	NewPassphraseEnv = "TM_NEW_KEY_PASSPHRASE"
+)
+
+var (
+	// stdin is shared by all the prompts, so that several passphrases can be
+	// piped in, one per line.
+	stdinMtx sync.Mutex
+	stdin    *bufio.Reader
+)
+
+// ReadPassphrase reads a passphrase from the environment variable env if it's
+// set, else from passphraseFile if it isn't empty, else from the standard
+// input, after writing prompt to the standard error. Only the first line of
+// the file or input is used. If the standard input is a terminal, the
+// passphrase isn't echoed.
+func ReadPassphrase(env, passphraseFile, prompt string) (string, error) {
+	if passphrase, ok := os.LookupEnv(env); ok {
+		return checkPassphrase(passphrase, "environment variable "+env)
+	}
+
+	if passphraseFile != "" {
+		data, err := ioutil.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading passphrase file: %w", err)
+		}
+		return checkPassphrase(firstLine(string(data)), "passphrase file "+passphraseFile)
+	}
+
+	stdinMtx.Lock()
+	defer stdinMtx.Unlock()
+	fmt.Fprint(os.Stderr, prompt)
+	if fd := int(os.Stdin.Fd()); terminal.IsTerminal(fd) {
+		passphrase, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("error reading passphrase from terminal: %w", err)
+		}
+		return checkPassphrase(string(passphrase), "terminal")
+	}
+
+	// piped input
+	if stdin == nil {
+		stdin = bufio.NewReader(os.Stdin)
+	}
+	line, err := stdin.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", fmt.Errorf("error reading passphrase from stdin: %w", err)
+	}
+	return checkPassphrase(firstLine(line), "stdin")
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSuffix(s, "\r")
+}
+
+func checkPassphrase(passphrase, source string) (string, error) {
+	if passphrase == "" {
+		return "", fmt.Errorf("empty passphrase from %s", source)
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,121 @@
+package keyfile
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	plaintext := []byte(`{"priv_key":"secret"}`)
+
+	data, err := EncryptWithParams(plaintext, "passphrase", 1<<10, 8, 1)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(data))
+	assert.False(t, IsEncrypted(plaintext))
+	assert.NotContains(t, string(data), "secret")
+
+	decrypted, err := Decrypt(data, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = Decrypt(data, "wrong")
+	assert.Equal(t, ErrWrongPassphrase, err)
+
+	_, err = EncryptWithParams(plaintext, "", 1<<10, 8, 1)
+	assert.Error(t, err)
+
+	// invalid scrypt parameters (N must be a power of 2)
+	_, err = EncryptWithParams(plaintext, "passphrase", 1000, 8, 1)
+	assert.Error(t, err)
+}
+
+func TestDecryptScryptParamsLimits(t *testing.T) {
+	plaintext := []byte(`{"priv_key":"secret"}`)
+	data, err := EncryptWithParams(plaintext, "passphrase", 1<<10, 8, 1)
+	require.NoError(t, err)
+
+	testcases := map[string]ScryptParams{
+		"N too large":     {N: 1 << 30, R: 8, P: 1},
+		"r too large":     {N: 1 << 10, R: 1 << 20, P: 1},
+		"p too large":     {N: 1 << 10, R: 8, P: 1 << 20},
+		"too much memory": {N: MaxScryptN, R: MaxScryptR, P: 1},
+	}
+	for name, params := range testcases {
+		params := params
+		t.Run(name, func(t *testing.T) {
+			var ef EncryptedFile
+			require.NoError(t, json.Unmarshal(data, &ef))
+			params.Salt = ef.KDFParams.Salt
+			ef.KDFParams = params
+			tampered, err := json.Marshal(ef)
+			require.NoError(t, err)
+
+			_, err = Decrypt(tampered, "passphrase")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "maximum")
+		})
+	}
+}
+
+func TestReadWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyfile_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "key.json")
+	plaintext := []byte(`{"priv_key":"secret"}`)
+
+	// plaintext file: read as is, whatever the passphrase
+	require.NoError(t, WriteFile(filePath, plaintext, ""))
+	encrypted, err := IsEncryptedFile(filePath)
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+	data, err := ReadFile(filePath, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+
+	// encrypted file
+	require.NoError(t, WriteFile(filePath, plaintext, "passphrase"))
+	encrypted, err = IsEncryptedFile(filePath)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+	data, err = ReadFile(filePath, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, data)
+
+	_, err = ReadFile(filePath, "")
+	assert.ErrorIs(t, err, ErrNoPassphrase)
+	_, err = ReadFile(filePath, "wrong")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestReadPassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyfile_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("from file\n"), 0600))
+
+	const env = "TM_TEST_KEY_PASSPHRASE"
+	os.Unsetenv(env)
+
+	passphrase, err := ReadPassphrase(env, passphraseFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, "from file", passphrase)
+
+	// the environment variable has precedence
+	os.Setenv(env, "from env")
+	defer os.Unsetenv(env)
+	passphrase, err = ReadPassphrase(env, passphraseFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, "from env", passphrase)
+
+	os.Setenv(env, "")
+	_, err = ReadPassphrase(env, passphraseFile, "")
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ module github.com/klyed/tendermint
 go 1.15
 
 require (
+	filippo.io/edwards25519 v1.0.0-beta.2
 	github.com/BurntSushi/toml v0.3.1
 	github.com/ChainSafe/go-schnorrkel v0.0.0-20210222182958-bd440c890782
 	github.com/Workiva/go-datastructures v1.0.52
@@ -32,8 +33,10 @@ require (
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.7.0
+	github.com/syndtr/goleveldb v1.0.1-0.20200815110645-5c35d600f0ca
 	github.com/klyed/tm-db v0.6.4
 	golang.org/x/crypto v0.0.0-20201117144127-c1f2f97bffc9
 	golang.org/x/net v0.0.0-20201021035429-f5854403a974
 	google.golang.org/grpc v1.36.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,50 @@
+package pubsub
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pubsub"
+
+	// OtherQueries is the query label of the metrics of the queries which
+	// aren't set with MetricsQueries.
+	OtherQueries = "other"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of messages which were not delivered to subscribers because
+	// their buffers were full, by query and overflow policy.
+	DroppedMessages metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		DroppedMessages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dropped_messages",
+			Help:      "Number of messages not delivered to subscribers because their buffers were full.",
+		}, append(labels, "query", "policy")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		DroppedMessages: discard.NewCounter(),
+	}
+}
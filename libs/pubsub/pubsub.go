@@ -38,6 +38,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/klyed/tendermint/libs/service"
 	tmsync "github.com/klyed/tendermint/libs/sync"
@@ -93,6 +95,10 @@ type Server struct {
 
 	cmds    chan cmd
 	cmdsCap int
+	metrics *Metrics
+	// queries whose dropped messages are counted separately, the others are
+	// counted together as OtherQueries
+	metricsQueries map[string]bool
 
 	// check if we have subscription before
 	// subscribing or unsubscribing
@@ -109,6 +115,7 @@ type Option func(*Server)
 func NewServer(options ...Option) *Server {
 	s := &Server{
 		subscriptions: make(map[string]map[string]struct{}),
+		metrics:       NopMetrics(),
 	}
 	s.BaseService = *service.NewBaseService(nil, "PubSub", s)
 
@@ -134,6 +141,26 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *Server) {
+		s.metrics = metrics
+	}
+}
+
+// MetricsQueries sets the queries whose dropped messages are counted
+// separately in the metrics, to bound the number of series created by
+// arbitrary client queries. The dropped messages of the other queries are
+// counted together as OtherQueries.
+func MetricsQueries(queries ...Query) Option {
+	return func(s *Server) {
+		s.metricsQueries = make(map[string]bool, len(queries))
+		for _, q := range queries {
+			s.metricsQueries[q.String()] = true
+		}
+	}
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -160,17 +187,41 @@ func (s *Server) Subscribe(
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, outCap, OverflowPolicy{})
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, 0, OverflowPolicy{})
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+// SubscribeWithPolicy does the same as Subscribe, except that the given policy
+// determines what happens when the subscription's buffer is full, rather than
+// the subscription being canceled. Panics if outCapacity is less than or
+// equal to zero.
+func (s *Server) SubscribeWithPolicy(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	policy OverflowPolicy) (*Subscription, error) {
+	if outCapacity <= 0 {
+		panic("Negative or zero capacity. Use SubscribeUnbuffered if you want an unbuffered channel")
+	}
+	if err := policy.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return s.subscribe(ctx, clientID, query, outCapacity, policy)
+}
+
+func (s *Server) subscribe(
+	ctx context.Context,
+	clientID string,
+	query Query,
+	outCapacity int,
+	policy OverflowPolicy) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -181,7 +232,7 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 		return nil, ErrAlreadySubscribed
 	}
 
-	subscription := NewSubscription(outCapacity)
+	subscription := NewSubscriptionWithPolicy(outCapacity, policy)
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -296,6 +347,9 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+
+	metrics        *Metrics
+	metricsQueries map[string]bool
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -308,8 +362,10 @@ type queryPlusRefCount struct {
 // OnStart implements Service.OnStart by starting the server.
 func (s *Server) OnStart() error {
 	go s.loop(state{
-		subscriptions: make(map[string]map[string]*Subscription),
-		queries:       make(map[string]*queryPlusRefCount),
+		subscriptions:  make(map[string]map[string]*Subscription),
+		queries:        make(map[string]*queryPlusRefCount),
+		metrics:        s.metrics,
+		metricsQueries: s.metricsQueries,
 	})
 	return nil
 }
@@ -419,12 +475,7 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 					// block on unbuffered channel
 					subscription.out <- NewMessage(msg, events)
 				} else {
-					// don't block on buffered channels
-					select {
-					case subscription.out <- NewMessage(msg, events):
-					default:
-						state.remove(clientID, qStr, ErrOutOfCapacity)
-					}
+					state.sendBuffered(clientID, qStr, subscription, NewMessage(msg, events))
 				}
 			}
 		}
@@ -432,3 +483,59 @@ func (state *state) send(msg interface{}, events map[string][]string) error {
 
 	return nil
 }
+
+// sendBuffered sends the message to the subscription's buffered channel,
+// applying the subscription's overflow policy if the buffer is full.
+func (state *state) sendBuffered(clientID string, qStr string, subscription *Subscription, msg Message) {
+	select {
+	case subscription.out <- msg:
+		return
+	default:
+	}
+
+	policy := subscription.policy
+	switch policy.Action {
+	case OverflowDropOldest:
+		// the client may concurrently receive messages, so don't block on
+		// either operation.
+		select {
+		case <-subscription.out:
+			state.dropped(qStr, subscription)
+		default:
+		}
+		select {
+		case subscription.out <- msg:
+			return
+		default:
+		}
+
+	case OverflowBlock:
+		timer := time.NewTimer(policy.Timeout)
+		defer timer.Stop()
+		select {
+		case subscription.out <- msg:
+			return
+		case <-timer.C:
+			state.dropped(qStr, subscription)
+			state.remove(clientID, qStr, ErrOutOfCapacity)
+			return
+		}
+
+	case OverflowCancel:
+		state.dropped(qStr, subscription)
+		state.remove(clientID, qStr, ErrOutOfCapacity)
+		return
+	}
+
+	// OverflowDropNewest, or the buffer was refilled while dropping the oldest
+	// message.
+	state.dropped(qStr, subscription)
+}
+
+func (state *state) dropped(qStr string, subscription *Subscription) {
+	atomic.AddUint64(&subscription.dropped, 1)
+	if !state.metricsQueries[qStr] {
+		qStr = OtherQueries
+	}
+	state.metrics.DroppedMessages.With("query", qStr, "policy", subscription.policy.Action.String()).Add(1)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -149,6 +152,164 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCanceled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestOverflowPolicies(t *testing.T) {
+	q := query.MustParse("tm.events.type='NewBlock'")
+	events := map[string][]string{"tm.events.type": {"NewBlock"}}
+
+	testcases := map[string]struct {
+		policy   pubsub.OverflowPolicy
+		received []string
+		dropped  uint64
+		canceled bool
+	}{
+		"cancel":      {pubsub.OverflowPolicy{}, []string{"1", "2"}, 1, true},
+		"drop oldest": {pubsub.OverflowPolicy{Action: pubsub.OverflowDropOldest}, []string{"3", "4"}, 2, false},
+		"drop newest": {pubsub.OverflowPolicy{Action: pubsub.OverflowDropNewest}, []string{"1", "2"}, 2, false},
+		"block": {pubsub.OverflowPolicy{Action: pubsub.OverflowBlock, Timeout: 10 * time.Millisecond},
+			[]string{"1", "2"}, 1, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := pubsub.NewServer()
+			s.SetLogger(log.TestingLogger())
+			require.NoError(t, s.Start())
+			t.Cleanup(func() {
+				if err := s.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+
+			ctx := context.Background()
+			subscription, err := s.SubscribeWithPolicy(ctx, clientID, q, 2, tc.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.policy, subscription.Policy())
+
+			for _, msg := range []string{"1", "2", "3", "4"} {
+				require.NoError(t, s.PublishWithEvents(ctx, msg, events))
+			}
+			// the server processes commands in order, so once this has been
+			// published the messages above have been processed.
+			require.NoError(t, s.Publish(ctx, "sync"))
+			require.NoError(t, s.Publish(ctx, "sync"))
+
+			for _, msg := range tc.received {
+				assertReceive(t, msg, subscription.Out())
+			}
+			assert.Equal(t, tc.dropped, subscription.Dropped())
+			if tc.canceled {
+				assertCanceled(t, subscription, pubsub.ErrOutOfCapacity)
+			} else {
+				assert.NoError(t, subscription.Err())
+			}
+		})
+	}
+}
+
+// labelCounter counts the values added to it by label values.
+type labelCounter struct {
+	lvs    []string
+	mtx    *sync.Mutex
+	counts map[string]float64
+}
+
+func (c labelCounter) With(labelValues ...string) metrics.Counter {
+	c.lvs = append(append([]string{}, c.lvs...), labelValues...)
+	return c
+}
+
+func (c labelCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.counts[strings.Join(c.lvs, " ")] += delta
+}
+
+func TestDroppedMessagesMetrics(t *testing.T) {
+	tracked := query.MustParse("tm.events.type='NewBlock'")
+	untracked := query.MustParse("tm.events.type='NewBlock' AND abci.account.name='John'")
+	dropped := labelCounter{mtx: &sync.Mutex{}, counts: make(map[string]float64)}
+
+	s := pubsub.NewServer(
+		pubsub.WithMetrics(&pubsub.Metrics{DroppedMessages: dropped}),
+		pubsub.MetricsQueries(tracked),
+	)
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	policy := pubsub.OverflowPolicy{Action: pubsub.OverflowDropNewest}
+	_, err := s.SubscribeWithPolicy(ctx, clientID, tracked, 1, policy)
+	require.NoError(t, err)
+	_, err = s.SubscribeWithPolicy(ctx, clientID, untracked, 1, policy)
+	require.NoError(t, err)
+
+	events := map[string][]string{"tm.events.type": {"NewBlock"}, "abci.account.name": {"John"}}
+	for _, msg := range []string{"1", "2", "3"} {
+		require.NoError(t, s.PublishWithEvents(ctx, msg, events))
+	}
+	// the server processes commands in order, so once this has been published
+	// the messages above have been processed.
+	require.NoError(t, s.Publish(ctx, "sync"))
+
+	dropped.mtx.Lock()
+	defer dropped.mtx.Unlock()
+	assert.Equal(t, map[string]float64{
+		"query " + tracked.String() + " policy drop-newest": 2,
+		"query other policy drop-newest":                    2,
+	}, dropped.counts)
+}
+
+func TestOverflowBlockWaitsForClient(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	subscription, err := s.SubscribeWithPolicy(ctx, clientID, query.Empty{}, 1,
+		pubsub.OverflowPolicy{Action: pubsub.OverflowBlock, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for _, msg := range []string{"1", "2", "3"} {
+			assert.NoError(t, s.Publish(ctx, msg))
+		}
+	}()
+	for _, msg := range []string{"1", "2", "3"} {
+		assertReceive(t, msg, subscription.Out())
+	}
+	<-published
+	assert.Zero(t, subscription.Dropped())
+	assert.NoError(t, subscription.Err())
+
+	_, err = s.SubscribeWithPolicy(ctx, "other", query.Empty{}, 1,
+		pubsub.OverflowPolicy{Action: pubsub.OverflowBlock})
+	require.Error(t, err)
+}
+
+func TestParseOverflowAction(t *testing.T) {
+	for _, action := range []pubsub.OverflowAction{
+		pubsub.OverflowCancel, pubsub.OverflowDropOldest, pubsub.OverflowDropNewest, pubsub.OverflowBlock,
+	} {
+		parsed, err := pubsub.ParseOverflowAction(action.String())
+		require.NoError(t, err)
+		assert.Equal(t, action, parsed)
+	}
+	_, err := pubsub.ParseOverflowAction("invalid")
+	require.Error(t, err)
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -2,6 +2,9 @@ package pubsub
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
 
 	tmsync "github.com/klyed/tendermint/libs/sync"
 )
@@ -15,23 +18,107 @@ var (
 	ErrOutOfCapacity = errors.New("client is not pulling messages fast enough")
 )
 
+// OverflowAction is the action taken when a message is published to a
+// subscription whose buffer is full.
+type OverflowAction int
+
+const (
+	// OverflowCancel cancels the subscription with ErrOutOfCapacity.
+	OverflowCancel OverflowAction = iota
+	// OverflowDropOldest drops the oldest buffered message to make room for
+	// the new message.
+	OverflowDropOldest
+	// OverflowDropNewest drops the new message.
+	OverflowDropNewest
+	// OverflowBlock blocks the server until there is room for the message, or
+	// until the policy's timeout expires, in which case the subscription is
+	// canceled with ErrOutOfCapacity. Note that this delays delivery to all
+	// other subscribers, and the publishers, by up to the timeout.
+	OverflowBlock
+)
+
+// ParseOverflowAction parses an overflow action name, as returned by
+// OverflowAction.String.
+func ParseOverflowAction(name string) (OverflowAction, error) {
+	switch name {
+	case "cancel":
+		return OverflowCancel, nil
+	case "drop-oldest":
+		return OverflowDropOldest, nil
+	case "drop-newest":
+		return OverflowDropNewest, nil
+	case "block":
+		return OverflowBlock, nil
+	default:
+		return 0, fmt.Errorf("unknown overflow action %q", name)
+	}
+}
+
+func (a OverflowAction) String() string {
+	switch a {
+	case OverflowCancel:
+		return "cancel"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowAction(%d)", int(a))
+	}
+}
+
+// OverflowPolicy determines what happens when a message is published to a
+// subscription whose buffer is full. The zero value cancels the subscription.
+type OverflowPolicy struct {
+	Action OverflowAction
+	// Timeout is how long OverflowBlock blocks before canceling the
+	// subscription. It must be positive for OverflowBlock.
+	Timeout time.Duration
+}
+
+// ValidateBasic performs basic validation of the policy.
+func (p OverflowPolicy) ValidateBasic() error {
+	switch p.Action {
+	case OverflowCancel, OverflowDropOldest, OverflowDropNewest:
+	case OverflowBlock:
+		if p.Timeout <= 0 {
+			return errors.New("block overflow policy requires a positive timeout")
+		}
+	default:
+		return fmt.Errorf("unknown overflow action %v", p.Action)
+	}
+	return nil
+}
+
 // A Subscription represents a client subscription for a particular query and
 // consists of three things:
 // 1) channel onto which messages and events are published
 // 2) channel which is closed if a client is too slow or choose to unsubscribe
 // 3) err indicating the reason for (2)
 type Subscription struct {
-	out chan Message
+	out     chan Message
+	policy  OverflowPolicy
+	dropped uint64 // atomic
 
 	canceled chan struct{}
 	mtx      tmsync.RWMutex
 	err      error
 }
 
-// NewSubscription returns a new subscription with the given outCapacity.
+// NewSubscription returns a new subscription with the given outCapacity,
+// which is canceled if its buffer overflows.
 func NewSubscription(outCapacity int) *Subscription {
+	return NewSubscriptionWithPolicy(outCapacity, OverflowPolicy{})
+}
+
+// NewSubscriptionWithPolicy returns a new subscription with the given
+// outCapacity and overflow policy.
+func NewSubscriptionWithPolicy(outCapacity int, policy OverflowPolicy) *Subscription {
 	return &Subscription{
 		out:      make(chan Message, outCapacity),
+		policy:   policy,
 		canceled: make(chan struct{}),
 	}
 }
@@ -62,6 +149,17 @@ func (s *Subscription) Err() error {
 	return s.err
 }
 
+// Policy returns the subscription's overflow policy.
+func (s *Subscription) Policy() OverflowPolicy {
+	return s.policy
+}
+
+// Dropped returns the number of messages which were dropped because the
+// subscription's buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
 func (s *Subscription) cancel(err error) {
 	s.mtx.Lock()
 	s.err = err
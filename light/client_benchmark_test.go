@@ -12,6 +12,7 @@ import (
 	"github.com/klyed/tendermint/light/provider"
 	mockp "github.com/klyed/tendermint/light/provider/mock"
 	dbs "github.com/klyed/tendermint/light/store/db"
+	"github.com/klyed/tendermint/types"
 )
 
 // NOTE: block is produced every minute. Make sure the verification time
@@ -108,3 +109,44 @@ func BenchmarkBackwards(b *testing.B) {
 		}
 	}
 }
+
+// genBenchmarkHeaders returns a trusted header and an untrusted header at
+// the given height, both signed by all of 150 validators.
+func genBenchmarkHeaders(height int64) (trusted, untrusted *types.SignedHeader, vals *types.ValidatorSet) {
+	keys := genPrivKeys(150)
+	vals = keys.ToValidators(10, 0)
+	trusted = keys.GenSignedHeader(chainID, 1, bTime, nil, vals, vals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+	untrusted = keys.GenSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute), nil, vals, vals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys))
+	return trusted, untrusted, vals
+}
+
+func BenchmarkVerifyAdjacent(b *testing.B) {
+	trusted, untrusted, vals := genBenchmarkHeaders(2)
+	now := bTime.Add(time.Hour)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		err := light.VerifyAdjacent(trusted, untrusted, vals, 24*time.Hour, now, 10*time.Second)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVerifyNonAdjacent(b *testing.B) {
+	trusted, untrusted, vals := genBenchmarkHeaders(100)
+	now := bTime.Add(24 * time.Hour)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		err := light.VerifyNonAdjacent(trusted, vals, untrusted, vals, 48*time.Hour, now, 10*time.Second,
+			light.DefaultTrustLevel)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
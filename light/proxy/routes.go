@@ -1,6 +1,8 @@
 package proxy
 
 import (
+	"time"
+
 	"github.com/klyed/tendermint/libs/bytes"
 	lrpc "github.com/klyed/tendermint/light/rpc"
 	rpcclient "github.com/klyed/tendermint/rpc/client"
@@ -13,41 +15,57 @@ import (
 func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 	return map[string]*rpcserver.RPCFunc{
 		// Subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpcserver.NewWSRPCFunc(c.SubscribeWS, "query"),
-		"unsubscribe":     rpcserver.NewWSRPCFunc(c.UnsubscribeWS, "query"),
-		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
+		"subscribe":       newRPCFunc("subscribe", c.SubscribeWS),
+		"unsubscribe":     newRPCFunc("unsubscribe", c.UnsubscribeWS),
+		"unsubscribe_all": newRPCFunc("unsubscribe_all", c.UnsubscribeAllWS),
+
+		// event log API
+		"events": newRPCFunc("events", makeEventsFunc(c)),
 
 		// info API
-		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
-		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
-		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
-		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight"),
-		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), ""),
-		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height"),
-		"block_by_hash":        rpcserver.NewRPCFunc(makeBlockByHashFunc(c), "hash"),
-		"block_results":        rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height"),
-		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height"),
-		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove"),
-		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by"),
-		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page"),
-		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
-		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
-		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height"),
-		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
-		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
+		"health":               newRPCFunc("health", makeHealthFunc(c)),
+		"status":               newRPCFunc("status", makeStatusFunc(c)),
+		"net_info":             newRPCFunc("net_info", makeNetInfoFunc(c)),
+		"blockchain":           newRPCFunc("blockchain", makeBlockchainInfoFunc(c)),
+		"genesis":              newRPCFunc("genesis", makeGenesisFunc(c)),
+		"block":                newRPCFunc("block", makeBlockFunc(c)),
+		"block_by_hash":        newRPCFunc("block_by_hash", makeBlockByHashFunc(c)),
+		"block_results":        newRPCFunc("block_results", makeBlockResultsFunc(c)),
+		"commit":               newRPCFunc("commit", makeCommitFunc(c)),
+		"tx":                   newRPCFunc("tx", makeTxFunc(c)),
+		"tx_search":            newRPCFunc("tx_search", makeTxSearchFunc(c)),
+		"validators":           newRPCFunc("validators", makeValidatorsFunc(c)),
+		"dump_consensus_state": newRPCFunc("dump_consensus_state", makeDumpConsensusStateFunc(c)),
+		"consensus_state":      newRPCFunc("consensus_state", makeConsensusStateFunc(c)),
+		"consensus_params":     newRPCFunc("consensus_params", makeConsensusParamsFunc(c)),
+		"unconfirmed_txs":      newRPCFunc("unconfirmed_txs", makeUnconfirmedTxsFunc(c)),
+		"num_unconfirmed_txs":  newRPCFunc("num_unconfirmed_txs", makeNumUnconfirmedTxsFunc(c)),
+		"unconfirmed_tx":       newRPCFunc("unconfirmed_tx", makeUnconfirmedTxFunc(c)),
+		"unconfirmed_txs_info": newRPCFunc("unconfirmed_txs_info", makeUnconfirmedTxsInfoFunc(c)),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
-		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx"),
-		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx"),
+		"broadcast_tx_commit": newRPCFunc("broadcast_tx_commit", makeBroadcastTxCommitFunc(c)),
+		"broadcast_tx_sync":   newRPCFunc("broadcast_tx_sync", makeBroadcastTxSyncFunc(c)),
+		"broadcast_tx_async":  newRPCFunc("broadcast_tx_async", makeBroadcastTxAsyncFunc(c)),
+		"broadcast_tx_wait":   newRPCFunc("broadcast_tx_wait", makeBroadcastTxWaitFunc(c)),
 
 		// abci API
-		"abci_query": rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove"),
-		"abci_info":  rpcserver.NewRPCFunc(makeABCIInfoFunc(c), ""),
+		"abci_query": newRPCFunc("abci_query", makeABCIQueryFunc(c)),
+		"abci_info":  newRPCFunc("abci_info", makeABCIInfoFunc(c)),
 
 		// evidence API
-		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
+		"broadcast_evidence": newRPCFunc("broadcast_evidence", makeBroadcastEvidenceFunc(c)),
+	}
+}
+
+// newRPCFunc wraps f to serve the given method, with the parameters documented
+// in the OpenAPI spec. It panics if f doesn't match the spec.
+func newRPCFunc(method string, f interface{}) *rpcserver.RPCFunc {
+	spec := ctypes.MustRouteSpec(method, f)
+	if spec.WebSocket {
+		return rpcserver.NewWSRPCFunc(f, spec.ParamNames())
 	}
+	return rpcserver.NewRPCFunc(f, spec.ParamNames())
 }
 
 type rpcHealthFunc func(ctx *rpctypes.Context) (*ctypes.ResultHealth, error)
@@ -67,10 +85,10 @@ func makeStatusFunc(c *lrpc.Client) rpcStatusFunc {
 	}
 }
 
-type rpcNetInfoFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultNetInfo, error)
+type rpcNetInfoFunc func(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error)
 
 func makeNetInfoFunc(c *lrpc.Client) rpcNetInfoFunc {
-	return func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultNetInfo, error) {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 		return c.NetInfo(ctx.Context())
 	}
 }
@@ -141,6 +159,16 @@ func makeTxSearchFunc(c *lrpc.Client) rpcTxSearchFunc {
 	}
 }
 
+type rpcEventsFunc func(ctx *rpctypes.Context, filter string, maxItems *int, after, before string,
+	waitTime time.Duration) (*ctypes.ResultEvents, error)
+
+func makeEventsFunc(c *lrpc.Client) rpcEventsFunc {
+	return func(ctx *rpctypes.Context, filter string, maxItems *int, after, before string,
+		waitTime time.Duration) (*ctypes.ResultEvents, error) {
+		return c.Events(ctx.Context(), filter, maxItems, after, before, waitTime)
+	}
+}
+
 type rpcValidatorsFunc func(ctx *rpctypes.Context, height *int64,
 	page, perPage *int) (*ctypes.ResultValidators, error)
 
@@ -190,6 +218,22 @@ func makeNumUnconfirmedTxsFunc(c *lrpc.Client) rpcNumUnconfirmedTxsFunc {
 	}
 }
 
+type rpcUnconfirmedTxFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error)
+
+func makeUnconfirmedTxFunc(c *lrpc.Client) rpcUnconfirmedTxFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+		return c.UnconfirmedTx(ctx.Context(), hash)
+	}
+}
+
+type rpcUnconfirmedTxsInfoFunc func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxsInfo, error)
+
+func makeUnconfirmedTxsInfoFunc(c *lrpc.Client) rpcUnconfirmedTxsInfoFunc {
+	return func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxsInfo, error) {
+		return c.UnconfirmedTxsInfo(ctx.Context(), limit)
+	}
+}
+
 type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
 
 func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
@@ -198,6 +242,15 @@ func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
 	}
 }
 
+type rpcBroadcastTxWaitFunc func(ctx *rpctypes.Context, tx types.Tx, timeout time.Duration) (
+	*ctypes.ResultBroadcastTxCommit, error)
+
+func makeBroadcastTxWaitFunc(c *lrpc.Client) rpcBroadcastTxWaitFunc {
+	return func(ctx *rpctypes.Context, tx types.Tx, timeout time.Duration) (*ctypes.ResultBroadcastTxCommit, error) {
+		return c.BroadcastTxWait(ctx.Context(), tx, timeout)
+	}
+}
+
 type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 
 func makeBroadcastTxSyncFunc(c *lrpc.Client) rpcBroadcastTxSyncFunc {
@@ -189,6 +189,14 @@ func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Re
 	return c.next.BroadcastTxCommit(ctx, tx)
 }
 
+func (c *Client) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	return c.next.BroadcastTxWait(ctx, tx, timeout)
+}
+
 func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxAsync(ctx, tx)
 }
@@ -205,6 +213,18 @@ func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirm
 	return c.next.NumUnconfirmedTxs(ctx)
 }
 
+func (c *Client) UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	return c.next.UnconfirmedTx(ctx, hash)
+}
+
+func (c *Client) UnconfirmedTxsInfo(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxsInfo, error) {
+	return c.next.UnconfirmedTxsInfo(ctx, limit)
+}
+
+func (c *Client) RemoveTx(ctx context.Context, hash []byte) (*ctypes.ResultUnsafeRemoveTx, error) {
+	return c.next.RemoveTx(ctx, hash)
+}
+
 func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return c.next.CheckTx(ctx, tx)
 }
@@ -518,6 +538,12 @@ func (c *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return c.next.UnsubscribeAll(ctx, subscriber)
 }
 
+// Events calls rpcclient#Events. Events are not verified.
+func (c *Client) Events(ctx context.Context, filter string, maxItems *int, after, before string,
+	waitTime time.Duration) (*ctypes.ResultEvents, error) {
+	return c.next.Events(ctx, filter, maxItems, after, before, waitTime)
+}
+
 func (c *Client) updateLightClientIfNeededTo(ctx context.Context, height *int64) (*types.LightBlock, error) {
 	var (
 		l   *types.LightBlock
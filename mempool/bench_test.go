@@ -2,9 +2,16 @@ package mempool
 
 import (
 	"encoding/binary"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/klyed/tendermint/abci/example/kvstore"
+	abciserver "github.com/klyed/tendermint/abci/server"
+	abci "github.com/klyed/tendermint/abci/types"
+	cfg "github.com/klyed/tendermint/config"
+	"github.com/klyed/tendermint/libs/log"
+	tmrand "github.com/klyed/tendermint/libs/rand"
 	"github.com/klyed/tendermint/proxy"
 )
 
@@ -43,6 +50,47 @@ func BenchmarkCheckTx(b *testing.B) {
 	}
 }
 
+// BenchmarkCheckTxConnections checks txs with an app which takes up to 200µs
+// per CheckTx, over a varying number of mempool connections.
+func BenchmarkCheckTxConnections(b *testing.B) {
+	for _, n := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("conns=%d", n), func(b *testing.B) {
+			sockPath := fmt.Sprintf("unix:///tmp/bench_%v.sock", tmrand.Str(6))
+			app := &concurrentCheckTxApp{Application: kvstore.NewApplication(), maxDelay: 200 * time.Microsecond}
+			server := abciserver.NewSocketServer(sockPath, app)
+			server.SetLogger(log.TestingLogger())
+			if err := server.Start(); err != nil {
+				b.Fatal(err)
+			}
+			defer server.Stop() //nolint:errcheck // ignore for tests
+
+			config := cfg.ResetTestRoot("mempool_test")
+			config.Mempool.Size = b.N
+			mempool, cleanup := newMempoolWithAppConns(proxy.NewRemoteClientCreator(sockPath, "socket", true), config, n)
+			defer cleanup()
+
+			// limit the number of txs in flight, so as not to overflow the
+			// clients' request queues.
+			inFlight := make(chan struct{}, 128)
+			release := func(*abci.Response) { <-inFlight }
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tx := make([]byte, 8)
+				binary.BigEndian.PutUint64(tx, uint64(i))
+				inFlight <- struct{}{}
+				if err := mempool.CheckTx(tx, release, TxInfo{}); err != nil {
+					b.Error(err)
+					<-inFlight
+				}
+			}
+			if err := mempool.FlushAppConn(); err != nil {
+				b.Error(err)
+			}
+		})
+	}
+}
+
 func BenchmarkCacheInsertTime(b *testing.B) {
 	cache := newMapTxCache(b.N)
 	txs := make([][]byte, b.N)
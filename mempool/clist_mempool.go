@@ -56,8 +56,10 @@ type CListMempool struct {
 	proxyAppConn proxy.AppConnMempool
 
 	// Track whether we're rechecking txs.
-	// These are not protected by a mutex and are expected to be mutated in
-	// serial (ie. by abci responses which are called in serial).
+	// These are mutated in serial by abci responses, which are called in
+	// serial, but also by recheckTxs if a request fails, so they are protected
+	// by recheckMtx.
+	recheckMtx    tmsync.Mutex
 	recheckCursor *clist.CElement // next expected response
 	recheckEnd    *clist.CElement // re-checking stops here
 
@@ -315,6 +317,9 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 // When rechecking, we don't need the peerID, so the recheck callback happens
 // here.
 func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
+	mem.recheckMtx.Lock()
+	defer mem.recheckMtx.Unlock()
+
 	if mem.recheckCursor == nil {
 		return
 	}
@@ -342,7 +347,10 @@ func (mem *CListMempool) reqResCb(
 	externalCb func(*abci.Response),
 ) func(res *abci.Response) {
 	return func(res *abci.Response) {
-		if mem.recheckCursor != nil {
+		mem.recheckMtx.Lock()
+		rechecking := mem.recheckCursor != nil
+		mem.recheckMtx.Unlock()
+		if rechecking {
 			// this should never happen
 			panic("recheck cursor is not nil in reqResCb")
 		}
@@ -370,6 +378,7 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 
 // Called from:
 //  - Update (lock held) if tx was committed
+//  - invalidateTxs (lock held) if tx was invalidated by the app
 // 	- resCbRecheck (lock not held) if tx was invalidated
 func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
 	mem.txs.Remove(elem)
@@ -383,13 +392,24 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
-func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool) {
+func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool) error {
 	if e, ok := mem.txsMap.Load(txKey); ok {
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
 			mem.removeTx(memTx.tx, e.(*clist.CElement), removeFromCache)
+			return nil
 		}
 	}
+	return ErrTxNotFound
+}
+
+// TxByKey returns a transaction and its metadata by its TxKey index.
+func (mem *CListMempool) TxByKey(txKey [TxKeySize]byte) (TxEntry, bool) {
+	e, ok := mem.txsMap.Load(txKey)
+	if !ok {
+		return TxEntry{}, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).entry(), true
 }
 
 func (mem *CListMempool) isFull(txSize int) error {
@@ -438,6 +458,8 @@ func (mem *CListMempool) resCbFirstTime(
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
 				tx:        tx,
+				sender:    peerP2PID,
+				appSender: r.CheckTx.Sender,
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
@@ -458,6 +480,11 @@ func (mem *CListMempool) resCbFirstTime(
 				mem.cache.Remove(tx)
 			}
 		}
+	case *abci.Response_Exception:
+		// The request failed, e.g. because the connection to the app was lost.
+		mem.logger.Error("failed to check tx", "tx", txID(tx), "peerID", peerP2PID, "err", r.Exception.Error)
+		// remove from cache, so that the tx can be resubmitted
+		mem.cache.Remove(tx)
 	default:
 		// ignore other messages
 	}
@@ -490,22 +517,66 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			// NOTE: we remove tx from the cache because it might be good later
 			mem.removeTx(tx, mem.recheckCursor, !mem.config.KeepInvalidTxsInCache)
 		}
+		mem.advanceRecheckCursor()
+	case *abci.Response_Exception:
+		if req.GetCheckTx() == nil {
+			// e.g. a flush request
+			return
+		}
+		// The request failed, e.g. because the connection to the app was lost.
+		// Keep the tx, it will be rechecked after the next block.
+		mem.logger.Error("failed to recheck tx", "tx", txID(req.GetCheckTx().Tx), "err", r.Exception.Error)
+		mem.advanceRecheckCursor()
+	default:
+		// ignore other messages
+	}
+}
+
+// advanceRecheckCursor moves the recheck cursor to the next tx to be
+// rechecked, skipping the txs which aren't. The caller must hold recheckMtx.
+func (mem *CListMempool) advanceRecheckCursor() {
+	for {
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
-		} else {
-			mem.recheckCursor = mem.recheckCursor.Next()
+			break
 		}
-		if mem.recheckCursor == nil {
-			// Done!
-			mem.logger.Debug("done rechecking txs")
-
-			// incase the recheck removed all txs
-			if mem.Size() > 0 {
-				mem.notifyTxsAvailable()
-			}
+		mem.recheckCursor = mem.recheckCursor.Next()
+		if mem.recheckCursor == nil || mem.recheckCursor.Value.(*mempoolTx).recheck {
+			break
 		}
-	default:
-		// ignore other messages
+	}
+	if mem.recheckCursor == nil {
+		mem.recheckDone()
+	}
+}
+
+// stopRecheckBefore ends the recheck before elem, whose recheck request
+// failed: the txs from elem on won't get a response. They will be rechecked
+// after the next block.
+func (mem *CListMempool) stopRecheckBefore(elem *clist.CElement) {
+	mem.recheckMtx.Lock()
+	defer mem.recheckMtx.Unlock()
+
+	if mem.recheckCursor == nil {
+		return
+	}
+	if mem.recheckCursor == elem {
+		// all the txs before elem were rechecked already
+		mem.recheckCursor = nil
+		mem.recheckDone()
+		return
+	}
+	mem.recheckEnd = elem.Prev()
+}
+
+// recheckDone is called once all txs were rechecked. The caller must hold
+// recheckMtx.
+func (mem *CListMempool) recheckDone() {
+	mem.logger.Debug("done rechecking txs")
+
+	// incase the recheck removed all txs
+	if mem.Size() > 0 {
+		mem.notifyTxsAvailable()
 	}
 }
 
@@ -579,11 +650,28 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	return txs
 }
 
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) TxEntries(max int) []TxEntry {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	if max < 0 {
+		max = mem.txs.Len()
+	}
+
+	entries := make([]TxEntry, 0, tmmath.MinInt(mem.txs.Len(), max))
+	for e := mem.txs.Front(); e != nil && len(entries) < max; e = e.Next() {
+		entries = append(entries, e.Value.(*mempoolTx).entry())
+	}
+	return entries
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) Update(
 	height int64,
 	txs types.Txs,
 	deliverTxResponses []*abci.ResponseDeliverTx,
+	recheck *abci.RecheckTxs,
 	preCheck PreCheckFunc,
 	postCheck PostCheckFunc,
 ) error {
@@ -622,12 +710,15 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	if recheck != nil {
+		mem.invalidateTxs(recheck)
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
 		if mem.config.Recheck {
-			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
-			mem.recheckTxs()
+			mem.recheckTxs(recheck)
 			// At this point, mem.txs are being rechecked.
 			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
 			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
@@ -642,20 +733,91 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
-func (mem *CListMempool) recheckTxs() {
+// invalidateTxs removes the txs invalidated by the application from the
+// mempool, without rechecking them.
+func (mem *CListMempool) invalidateTxs(recheck *abci.RecheckTxs) {
+	removed := 0
+	for _, hash := range recheck.InvalidTxHashes {
+		if len(hash) != TxKeySize {
+			continue
+		}
+		var txKey [TxKeySize]byte
+		copy(txKey[:], hash)
+		if e, ok := mem.txsMap.Load(txKey); ok {
+			elem := e.(*clist.CElement)
+			mem.removeTx(elem.Value.(*mempoolTx).tx, elem, !mem.config.KeepInvalidTxsInCache)
+			removed++
+		}
+	}
+
+	if len(recheck.InvalidSenders) > 0 {
+		senders := make(map[string]bool, len(recheck.InvalidSenders))
+		for _, sender := range recheck.InvalidSenders {
+			senders[sender] = true
+		}
+		for e := mem.txs.Front(); e != nil; e = e.Next() {
+			memTx := e.Value.(*mempoolTx)
+			if memTx.appSender != "" && senders[memTx.appSender] {
+				mem.removeTx(memTx.tx, e, !mem.config.KeepInvalidTxsInCache)
+				removed++
+			}
+		}
+	}
+
+	if removed > 0 {
+		mem.logger.Debug("removed txs invalidated by the app", "numtxs", removed, "height", mem.height)
+	}
+}
+
+// recheckTxs rechecks the txs selected by recheck, or all the txs if it's nil.
+func (mem *CListMempool) recheckTxs(recheck *abci.RecheckTxs) {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
 	}
 
-	mem.recheckCursor = mem.txs.Front()
-	mem.recheckEnd = mem.txs.Back()
+	var senders map[string]bool
+	if recheck != nil {
+		senders = make(map[string]bool, len(recheck.RecheckSenders))
+		for _, sender := range recheck.RecheckSenders {
+			senders[sender] = true
+		}
+	}
+
+	// mark the txs to recheck
+	var first, last *clist.CElement
+	numTxs := 0
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		memTx.recheck = senders == nil || (memTx.appSender != "" && senders[memTx.appSender])
+		if memTx.recheck {
+			if first == nil {
+				first = e
+			}
+			last = e
+			numTxs++
+		}
+	}
+	if first == nil {
+		mem.logger.Debug("no txs to recheck", "height", mem.height)
+		mem.notifyTxsAvailable()
+		return
+	}
+	mem.logger.Debug("recheck txs", "numtxs", numTxs, "height", mem.height)
+
+	mem.recheckMtx.Lock()
+	mem.recheckCursor = first
+	mem.recheckEnd = last
+	mem.recheckMtx.Unlock()
 
 	ctx := context.Background()
 
 	// Push txs to proxyAppConn
 	// NOTE: globalCb may be called concurrently.
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
+	for e := first; e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
+		if !memTx.recheck {
+			continue
+		}
 		_, err := mem.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
 			Tx:   memTx.tx,
 			Type: abci.CheckTxType_Recheck,
@@ -663,6 +825,8 @@ func (mem *CListMempool) recheckTxs() {
 		if err != nil {
 			// No need in retrying since memTx will be rechecked after next block.
 			mem.logger.Error("Can't check tx", "err", err)
+			mem.stopRecheckBefore(e)
+			break
 		}
 	}
 
@@ -683,6 +847,15 @@ type mempoolTx struct {
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders sync.Map
+
+	// the peer the tx was first received from, empty if submitted locally
+	sender p2p.NodeID
+
+	// the sender of the tx returned by the app in CheckTx, if any
+	appSender string
+
+	// whether the tx is rechecked after the last block
+	recheck bool
 }
 
 // Height returns the height for this transaction
@@ -690,6 +863,15 @@ func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
 
+func (memTx *mempoolTx) entry() TxEntry {
+	return TxEntry{
+		Tx:        memTx.tx,
+		Height:    memTx.Height(),
+		GasWanted: memTx.gasWanted,
+		Sender:    memTx.sender,
+	}
+}
+
 //--------------------------------------------------------------------------------
 
 type txCache interface {
@@ -5,19 +5,23 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	mrand "math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/klyed/tendermint/abci/client"
 	"github.com/klyed/tendermint/abci/example/counter"
 	"github.com/klyed/tendermint/abci/example/kvstore"
 	abciserver "github.com/klyed/tendermint/abci/server"
@@ -26,7 +30,9 @@ import (
 	"github.com/klyed/tendermint/libs/log"
 	tmrand "github.com/klyed/tendermint/libs/rand"
 	"github.com/klyed/tendermint/libs/service"
+	"github.com/klyed/tendermint/p2p"
 	"github.com/klyed/tendermint/proxy"
+	proxymocks "github.com/klyed/tendermint/proxy/mocks"
 	"github.com/klyed/tendermint/types"
 )
 
@@ -50,6 +56,46 @@ func newMempoolWithAppAndConfig(cc proxy.ClientCreator, config *cfg.Config) (*CL
 	return mempool, func() { os.RemoveAll(config.RootDir) }
 }
 
+// newMempoolWithAppConns creates a mempool which checks txs over n
+// connections to the app.
+func newMempoolWithAppConns(
+	cc proxy.ClientCreator,
+	config *cfg.Config,
+	n int,
+) (*CListMempool, cleanupFunc) {
+	appConns := proxy.NewAppConns(cc, proxy.WithMempoolConnections(n))
+	appConns.SetLogger(log.TestingLogger().With("module", "proxy"))
+	if err := appConns.Start(); err != nil {
+		panic(err)
+	}
+	mempool := NewCListMempool(config.Mempool, appConns.Mempool(), 0)
+	mempool.SetLogger(log.TestingLogger())
+	return mempool, func() {
+		if err := appConns.Stop(); err != nil {
+			panic(err)
+		}
+		os.RemoveAll(config.RootDir)
+	}
+}
+
+// concurrentCheckTxApp is a kvstore app which opts into concurrent CheckTx,
+// and takes up to maxDelay to check a tx.
+type concurrentCheckTxApp struct {
+	*kvstore.Application
+	maxDelay time.Duration
+}
+
+var _ abci.ConcurrentCheckTxApplication = (*concurrentCheckTxApp)(nil)
+
+func (app *concurrentCheckTxApp) ConcurrentCheckTx() bool {
+	return true
+}
+
+func (app *concurrentCheckTxApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	time.Sleep(time.Duration(mrand.Int63n(int64(app.maxDelay) + 1)))
+	return app.Application.CheckTx(req)
+}
+
 func ensureNoFire(t *testing.T, ch <-chan struct{}, timeoutMS int) {
 	timer := time.NewTimer(time.Duration(timeoutMS) * time.Millisecond)
 	select {
@@ -171,7 +217,7 @@ func TestMempoolFilters(t *testing.T) {
 		{10, PreCheckMaxBytes(22), PostCheckMaxGas(0), 0},
 	}
 	for tcIndex, tt := range tests {
-		err := mempool.Update(1, emptyTxArr, abciResponses(len(emptyTxArr), abci.CodeTypeOK), tt.preFilter, tt.postFilter)
+		err := mempool.Update(1, emptyTxArr, abciResponses(len(emptyTxArr), abci.CodeTypeOK), nil, tt.preFilter, tt.postFilter)
 		require.NoError(t, err)
 		checkTxs(t, mempool, tt.numTxsToCreate, UnknownPeerID)
 		require.Equal(t, tt.expectedNumTxs, mempool.Size(), "mempool had the incorrect size, on test case %d", tcIndex)
@@ -187,7 +233,7 @@ func TestMempoolUpdate(t *testing.T) {
 
 	// 1. Adds valid txs to the cache
 	{
-		err := mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+		err := mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil, nil)
 		require.NoError(t, err)
 		err = mempool.CheckTx([]byte{0x01}, nil, TxInfo{})
 		require.NoError(t, err)
@@ -197,7 +243,7 @@ func TestMempoolUpdate(t *testing.T) {
 	{
 		err := mempool.CheckTx([]byte{0x02}, nil, TxInfo{})
 		require.NoError(t, err)
-		err = mempool.Update(1, []types.Tx{[]byte{0x02}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+		err = mempool.Update(1, []types.Tx{[]byte{0x02}}, abciResponses(1, abci.CodeTypeOK), nil, nil, nil)
 		require.NoError(t, err)
 		assert.Zero(t, mempool.Size())
 	}
@@ -206,7 +252,7 @@ func TestMempoolUpdate(t *testing.T) {
 	{
 		err := mempool.CheckTx([]byte{0x03}, nil, TxInfo{})
 		require.NoError(t, err)
-		err = mempool.Update(1, []types.Tx{[]byte{0x03}}, abciResponses(1, 1), nil, nil)
+		err = mempool.Update(1, []types.Tx{[]byte{0x03}}, abciResponses(1, 1), nil, nil, nil)
 		require.NoError(t, err)
 		assert.Zero(t, mempool.Size())
 
@@ -238,7 +284,7 @@ func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 		_ = app.DeliverTx(abci.RequestDeliverTx{Tx: a})
 		_ = app.DeliverTx(abci.RequestDeliverTx{Tx: b})
 		err = mempool.Update(1, []types.Tx{a, b},
-			[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: 2}}, nil, nil)
+			[]*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: 2}}, nil, nil, nil)
 		require.NoError(t, err)
 
 		// a must be added to the cache
@@ -284,7 +330,7 @@ func TestTxsAvailable(t *testing.T) {
 	// it should fire once now for the new height
 	// since there are still txs left
 	committedTxs, txs := txs[:50], txs[50:]
-	if err := mempool.Update(1, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil); err != nil {
+	if err := mempool.Update(1, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil, nil); err != nil {
 		t.Error(err)
 	}
 	ensureFire(t, mempool.TxsAvailable(), timeoutMS)
@@ -296,7 +342,7 @@ func TestTxsAvailable(t *testing.T) {
 
 	// now call update with all the txs. it should not fire as there are no txs left
 	committedTxs = append(txs, moreTxs...) //nolint: gocritic
-	if err := mempool.Update(2, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil); err != nil {
+	if err := mempool.Update(2, committedTxs, abciResponses(len(committedTxs), abci.CodeTypeOK), nil, nil, nil); err != nil {
 		t.Error(err)
 	}
 	ensureNoFire(t, mempool.TxsAvailable(), timeoutMS)
@@ -354,7 +400,7 @@ func TestSerialReap(t *testing.T) {
 			binary.BigEndian.PutUint64(txBytes, uint64(i))
 			txs = append(txs, txBytes)
 		}
-		if err := mempool.Update(0, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil); err != nil {
+		if err := mempool.Update(0, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil, nil); err != nil {
 			t.Error(err)
 		}
 	}
@@ -526,7 +572,7 @@ func TestMempoolTxsBytes(t *testing.T) {
 	assert.EqualValues(t, 1, mempool.TxsBytes())
 
 	// 3. zero again after tx is removed by Update
-	err = mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	err = mempool.Update(1, []types.Tx{[]byte{0x01}}, abciResponses(1, abci.CodeTypeOK), nil, nil, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
@@ -577,7 +623,7 @@ func TestMempoolTxsBytes(t *testing.T) {
 	require.NotEmpty(t, res2.Data)
 
 	// Pretend like we committed nothing so txBytes gets rechecked and removed.
-	err = mempool.Update(1, []types.Tx{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	err = mempool.Update(1, []types.Tx{}, abciResponses(0, abci.CodeTypeOK), nil, nil, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
@@ -585,13 +631,128 @@ func TestMempoolTxsBytes(t *testing.T) {
 	err = mempool.CheckTx([]byte{0x06}, nil, TxInfo{})
 	require.NoError(t, err)
 	assert.EqualValues(t, 1, mempool.TxsBytes())
-	mempool.RemoveTxByKey(TxKey([]byte{0x07}), true)
+	assert.ErrorIs(t, mempool.RemoveTxByKey(TxKey([]byte{0x07}), true), ErrTxNotFound)
 	assert.EqualValues(t, 1, mempool.TxsBytes())
-	mempool.RemoveTxByKey(TxKey([]byte{0x06}), true)
+	assert.NoError(t, mempool.RemoveTxByKey(TxKey([]byte{0x06}), true))
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
 }
 
+func TestMempoolTxEntries(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	peerID := p2p.NodeID("0123456789abcdef0123456789abcdef01234567")
+	txs := types.Txs{[]byte("a=1"), []byte("b=2"), []byte("c=3")}
+	require.NoError(t, mempool.CheckTx(txs[0], nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(txs[1], nil, TxInfo{SenderID: 1, SenderP2PID: peerID}))
+	require.NoError(t, mempool.CheckTx(txs[2], nil, TxInfo{}))
+
+	entries := mempool.TxEntries(-1)
+	require.Len(t, entries, 3)
+	for i, entry := range entries {
+		assert.Equal(t, txs[i], entry.Tx)
+		assert.EqualValues(t, 1, entry.GasWanted)
+	}
+	assert.Empty(t, entries[0].Sender)
+	assert.Equal(t, peerID, entries[1].Sender)
+	assert.Len(t, mempool.TxEntries(2), 2)
+
+	entry, ok := mempool.TxByKey(TxKey(txs[1]))
+	require.True(t, ok)
+	assert.Equal(t, entries[1], entry)
+
+	require.NoError(t, mempool.RemoveTxByKey(TxKey(txs[1]), true))
+	_, ok = mempool.TxByKey(TxKey(txs[1]))
+	assert.False(t, ok)
+	assert.Equal(t, types.Txs{txs[0], txs[2]}, mempool.ReapMaxTxs(-1))
+
+	// the tx was removed from the cache, so it can be resubmitted
+	require.NoError(t, mempool.CheckTx(txs[1], nil, TxInfo{}))
+	assert.Equal(t, 3, mempool.Size())
+}
+
+// senderApp is a kvstore app whose tx senders are the tx keys. It records the
+// rechecked txs, and rejects the rechecked txs in invalid.
+type senderApp struct {
+	*kvstore.Application
+	rechecked types.Txs
+	invalid   map[string]bool
+}
+
+func (app *senderApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := app.Application.CheckTx(req)
+	res.Sender = strings.SplitN(string(req.Tx), "=", 2)[0]
+	if req.Type == abci.CheckTxType_Recheck {
+		app.rechecked = append(app.rechecked, req.Tx)
+		if app.invalid[string(req.Tx)] {
+			res.Code = 1
+		}
+	}
+	return res
+}
+
+func TestMempoolSelectiveRecheck(t *testing.T) {
+	app := &senderApp{Application: kvstore.NewApplication(), invalid: make(map[string]bool)}
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := types.Txs{
+		[]byte("alice=1"), []byte("bob=1"), []byte("carol=1"), []byte("alice=2"), []byte("dave=1"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	// carol's tx is invalidated by hash and dave's by sender, and only
+	// alice's txs are rechecked
+	app.invalid["alice=2"] = true
+	mempool.Lock()
+	err := mempool.Update(1, nil, nil, &abci.RecheckTxs{
+		InvalidTxHashes: [][]byte{txs[2].Hash()},
+		InvalidSenders:  []string{"dave"},
+		RecheckSenders:  []string{"alice"},
+	}, nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{txs[0], txs[3]}, app.rechecked)
+	assert.Equal(t, types.Txs{txs[0], txs[1]}, mempool.ReapMaxTxs(-1))
+
+	// the invalidated txs can be resubmitted
+	require.NoError(t, mempool.CheckTx(txs[2], nil, TxInfo{}))
+
+	// no txs are rechecked
+	app.rechecked = nil
+	mempool.Lock()
+	err = mempool.Update(2, nil, nil, &abci.RecheckTxs{}, nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Empty(t, app.rechecked)
+	assert.Equal(t, 3, mempool.Size())
+
+	// only invalidating txs doesn't recheck the others
+	mempool.Lock()
+	err = mempool.Update(3, nil, nil, &abci.RecheckTxs{
+		InvalidTxHashes: [][]byte{txs[2].Hash()},
+	}, nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Empty(t, app.rechecked)
+	assert.Equal(t, types.Txs{txs[0], txs[1]}, mempool.ReapMaxTxs(-1))
+	require.NoError(t, mempool.CheckTx(txs[2], nil, TxInfo{}))
+
+	// all the txs are rechecked
+	mempool.Lock()
+	err = mempool.Update(4, nil, nil, nil, nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{txs[0], txs[1], txs[2]}, app.rechecked)
+	assert.Equal(t, 3, mempool.Size())
+}
+
 // This will non-deterministically catch some concurrency failures like
 // https://github.com/klyed/tendermint/issues/3509
 // TODO: all of the tests should probably also run using the remote proxy app
@@ -632,6 +793,119 @@ func TestMempoolRemoteAppConcurrency(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMempoolCheckTxConnectionsOrder(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", tmrand.Str(6))
+	app := &concurrentCheckTxApp{Application: kvstore.NewApplication(), maxDelay: time.Millisecond}
+	cc, server := newRemoteApp(t, sockPath, app)
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mempool, cleanup := newMempoolWithAppConns(cc, cfg.ResetTestRoot("mempool_test"), 4)
+	defer cleanup()
+
+	// txs are checked concurrently, but added in the order they were received
+	txs := make(types.Txs, 100)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(20)
+		require.NoError(t, mempool.CheckTx(txs[i], nil, TxInfo{}))
+	}
+	require.NoError(t, mempool.FlushAppConn())
+	require.Equal(t, txs, mempool.ReapMaxTxs(-1))
+}
+
+func TestMempoolAppConnFailure(t *testing.T) {
+	var (
+		globalCb abcicli.Callback
+		reqRess  []*abcicli.ReqRes
+		allowed  = -1 // number of requests to send before the connection fails, if non-negative
+	)
+	appConn := &proxymocks.AppConnMempool{}
+	appConn.On("SetResponseCallback", mock.Anything).Run(func(args mock.Arguments) {
+		globalCb = args.Get(0).(abcicli.Callback)
+	}).Return()
+	appConn.On("Error").Return(nil)
+	appConn.On("FlushAsync", mock.Anything).Return(nil, nil)
+	appConn.On("CheckTxAsync", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req abci.RequestCheckTx) *abcicli.ReqRes {
+			if allowed == 0 {
+				return nil
+			}
+			allowed--
+			reqRes := abcicli.NewReqRes(abci.ToRequestCheckTx(req))
+			reqRess = append(reqRess, reqRes)
+			return reqRes
+		},
+		func(context.Context, abci.RequestCheckTx) error {
+			if allowed == 0 {
+				return errors.New("connection lost")
+			}
+			return nil
+		})
+
+	config := cfg.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(config.RootDir)
+	mempool := NewCListMempool(config.Mempool, appConn, 0)
+	mempool.SetLogger(log.TestingLogger())
+
+	respond := func(i int, res *abci.Response) {
+		reqRess[i].Response = res
+		globalCb(reqRess[i].Request, res)
+		reqRess[i].InvokeCallback()
+	}
+	ok := abci.ToResponseCheckTx(abci.ResponseCheckTx{Code: abci.CodeTypeOK})
+	exception := abci.ToResponseException("connection lost")
+	update := func(height int64) {
+		mempool.Lock()
+		defer mempool.Unlock()
+		require.NoError(t, mempool.Update(height, nil, nil, nil, nil, nil))
+	}
+
+	// a tx which failed to be checked can be resubmitted
+	var res *abci.Response
+	require.NoError(t, mempool.CheckTx(types.Tx("a"), func(r *abci.Response) { res = r }, TxInfo{}))
+	respond(0, exception)
+	assert.NotNil(t, res.GetException())
+	assert.Zero(t, mempool.Size())
+
+	for _, tx := range []string{"a", "b", "c"} {
+		require.NoError(t, mempool.CheckTx(types.Tx(tx), nil, TxInfo{}))
+	}
+	require.Len(t, reqRess, 4)
+	respond(1, ok)
+	respond(2, ok)
+	respond(3, ok)
+	require.Equal(t, 3, mempool.Size())
+
+	// txs whose recheck failed are kept
+	update(1)
+	require.Len(t, reqRess, 7)
+	respond(4, exception)
+	respond(5, ok)
+	respond(6, exception)
+	assert.Equal(t, 3, mempool.Size())
+
+	// the connection fails after the recheck of "a" was sent: the recheck ends
+	// with "a", and new txs can be checked
+	allowed = 1
+	update(2)
+	require.Len(t, reqRess, 8)
+	respond(7, ok)
+	allowed = -1
+	require.NoError(t, mempool.CheckTx(types.Tx("d"), nil, TxInfo{}))
+	respond(8, ok)
+	assert.Equal(t, 4, mempool.Size())
+
+	// the connection fails right away
+	allowed = 0
+	update(3)
+	allowed = -1
+	require.NoError(t, mempool.CheckTx(types.Tx("e"), nil, TxInfo{}))
+	respond(9, ok)
+	assert.Equal(t, 5, mempool.Size())
+}
+
 // caller must close server
 func newRemoteApp(
 	t *testing.T,
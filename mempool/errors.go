@@ -8,6 +8,9 @@ import (
 var (
 	// ErrTxInCache is returned to the client if we saw tx earlier
 	ErrTxInCache = errors.New("tx already exists in cache")
+
+	// ErrTxNotFound is returned when a tx is not in the mempool
+	ErrTxNotFound = errors.New("tx not found in mempool")
 )
 
 // ErrTxTooLarge means the tx is too big to be sent in a message to other peers
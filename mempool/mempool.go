@@ -30,6 +30,22 @@ type Mempool interface {
 	// transactions (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// TxEntries returns up to max transactions from the mempool, in the same
+	// order as ReapMaxTxs, along with their metadata.
+	// If max is negative, all transactions are returned.
+	TxEntries(max int) []TxEntry
+
+	// TxByKey returns the transaction with the given key (see TxKey) along
+	// with its metadata, and false if it's not in the mempool.
+	TxByKey(txKey [TxKeySize]byte) (TxEntry, bool)
+
+	// RemoveTxByKey removes the transaction with the given key (see TxKey)
+	// from the mempool, and from the cache if removeFromCache is true, such
+	// that it may be resubmitted. It returns ErrTxNotFound if the transaction
+	// is not in the mempool.
+	// NOTE: Lock/Unlock must be managed by caller
+	RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool) error
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely update.
 	Lock()
 
@@ -37,12 +53,15 @@ type Mempool interface {
 	Unlock()
 
 	// Update informs the mempool that the given txs were committed and can be discarded.
+	// If recheck isn't nil, the txs it invalidates are discarded as well, and
+	// only the txs of its recheck senders are rechecked.
 	// NOTE: this should be called *after* block is committed by consensus.
 	// NOTE: Lock/Unlock must be managed by caller
 	Update(
 		blockHeight int64,
 		blockTxs types.Txs,
 		deliverTxResponses []*abci.ResponseDeliverTx,
+		recheck *abci.RecheckTxs,
 		newPreFn PreCheckFunc,
 		newPostFn PostCheckFunc,
 	) error
@@ -103,6 +122,18 @@ type TxInfo struct {
 	Context context.Context
 }
 
+// TxEntry is a transaction in the mempool along with its metadata.
+type TxEntry struct {
+	Tx types.Tx
+	// Height is the height at which the transaction was validated.
+	Height int64
+	// GasWanted is the amount of gas the transaction states it will require.
+	GasWanted int64
+	// Sender is the peer the transaction was first received from, and empty
+	// if it was submitted locally, e.g. over RPC.
+	Sender p2p.NodeID
+}
+
 //--------------------------------------------------------------------------------
 
 // PreCheckMaxBytes checks that the size of the transaction is smaller or equal to the expected maxBytes.
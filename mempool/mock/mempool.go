@@ -20,10 +20,18 @@ func (Mempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo) error
 }
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (Mempool) TxEntries(n int) []mempl.TxEntry         { return nil }
+func (Mempool) TxByKey(_ [mempl.TxKeySize]byte) (mempl.TxEntry, bool) {
+	return mempl.TxEntry{}, false
+}
+func (Mempool) RemoveTxByKey(_ [mempl.TxKeySize]byte, _ bool) error {
+	return mempl.ErrTxNotFound
+}
 func (Mempool) Update(
 	_ int64,
 	_ types.Txs,
 	_ []*abci.ResponseDeliverTx,
+	_ *abci.RecheckTxs,
 	_ mempl.PreCheckFunc,
 	_ mempl.PostCheckFunc,
 ) error {
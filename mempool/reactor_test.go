@@ -215,7 +215,7 @@ func TestReactorConcurrency(t *testing.T) {
 				deliverTxResponses[i] = &abci.ResponseDeliverTx{Code: 0}
 			}
 
-			require.NoError(t, mempool.Update(1, txs, deliverTxResponses, nil, nil))
+			require.NoError(t, mempool.Update(1, txs, deliverTxResponses, nil, nil, nil))
 		}()
 
 		// 1. submit a bunch of txs
@@ -229,7 +229,7 @@ func TestReactorConcurrency(t *testing.T) {
 			mempool.Lock()
 			defer mempool.Unlock()
 
-			err := mempool.Update(1, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil)
+			err := mempool.Update(1, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil, nil)
 			require.NoError(t, err)
 		}()
 
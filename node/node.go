@@ -3,8 +3,10 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
@@ -24,11 +26,13 @@ import (
 	cfg "github.com/klyed/tendermint/config"
 	cs "github.com/klyed/tendermint/consensus"
 	"github.com/klyed/tendermint/crypto"
+	"github.com/klyed/tendermint/crypto/keyfile"
 	"github.com/klyed/tendermint/evidence"
 	tmjson "github.com/klyed/tendermint/libs/json"
 	"github.com/klyed/tendermint/libs/log"
 	tmnet "github.com/klyed/tendermint/libs/net"
 	tmpubsub "github.com/klyed/tendermint/libs/pubsub"
+	tmquery "github.com/klyed/tendermint/libs/pubsub/query"
 	"github.com/klyed/tendermint/libs/service"
 	"github.com/klyed/tendermint/light"
 	mempl "github.com/klyed/tendermint/mempool"
@@ -42,6 +46,7 @@ import (
 	grpccore "github.com/klyed/tendermint/rpc/grpc"
 	rpcserver "github.com/klyed/tendermint/rpc/jsonrpc/server"
 	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/state/eventlog"
 	"github.com/klyed/tendermint/state/txindex"
 	"github.com/klyed/tendermint/state/txindex/kv"
 	"github.com/klyed/tendermint/state/txindex/null"
@@ -96,7 +101,12 @@ type Provider func(*cfg.Config, log.Logger) (*Node, error)
 // PrivValidator, ClientCreator, GenesisDoc, and DBProvider.
 // It implements NodeProvider.
 func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
-	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	passphrase, err := keyPassphrase(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key passphrase: %w", err)
+	}
+
+	nodeKey, err := p2p.LoadOrGenNodeKeyWithPassphrase(config.NodeKeyFile(), passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
@@ -110,7 +120,8 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 
 	var pval *privval.FilePV
 	if config.Mode == cfg.ModeValidator {
-		pval, err = privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+		pval, err = privval.LoadOrGenFilePVWithPassphrase(
+			config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), passphrase)
 		if err != nil {
 			return nil, err
 		}
@@ -128,20 +139,49 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// keyPassphrase returns the passphrase of the key files. It is read from the
+// TM_KEY_PASSPHRASE environment variable or the key passphrase file, if either
+// is set, in which case newly generated keys are encrypted too. Otherwise, it
+// is only read from stdin if one of the key files is encrypted.
+func keyPassphrase(config *cfg.Config) (string, error) {
+	if _, ok := os.LookupEnv(keyfile.PassphraseEnv); ok || config.KeyPassphraseFile() != "" {
+		return keyfile.ReadPassphrase(keyfile.PassphraseEnv, config.KeyPassphraseFile(), "")
+	}
+
+	keyFiles := []string{config.NodeKeyFile()}
+	if config.Mode == cfg.ModeValidator {
+		keyFiles = append(keyFiles, config.PrivValidatorKeyFile())
+	}
+	for _, keyFile := range keyFiles {
+		// a missing or unreadable file is reported when loading the key
+		if encrypted, err := keyfile.IsEncryptedFile(keyFile); err == nil && encrypted {
+			return keyfile.ReadPassphrase(keyfile.PassphraseEnv, "", "Enter the passphrase of the key files: ")
+		}
+	}
+	return "", nil
+}
+
+// MetricsProvider returns a consensus, p2p, mempool, state, statesync, pubsub
+// and RPC Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *statesync.Metrics,
+	*tmpubsub.Metrics, *rpcserver.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *statesync.Metrics,
+		*tmpubsub.Metrics, *rpcserver.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				statesync.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				tmpubsub.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				rpcserver.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), statesync.NopMetrics(),
+			tmpubsub.NopMetrics(), rpcserver.NopMetrics()
 	}
 }
 
@@ -214,6 +254,7 @@ type Node struct {
 	eventBus          *types.EventBus // pub/sub for services
 	stateStore        sm.Store
 	blockStore        *store.BlockStore // store the blockchain to disk
+	pruner            *sm.Pruner        // prunes blocks and states in the background
 	bcReactor         service.Service   // for fast-syncing
 	mempoolReactor    *mempl.Reactor    // for gossipping transactions
 	mempool           mempl.Mempool
@@ -226,11 +267,15 @@ type Node struct {
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
 	pexReactorV2      *pex.ReactorV2          // for exchanging peer addresses
 	evidenceReactor   *evidence.Reactor
-	evidencePool      *evidence.Pool // tracking evidence
-	proxyApp          proxy.AppConns // connection to the application
-	rpcListeners      []net.Listener // rpc servers
+	evidencePool      *evidence.Pool  // tracking evidence
+	proxyApp          proxy.AppConns  // connection to the application
+	abciRecorder      *proxy.Recorder // nil if disabled
+	rpcListeners      []net.Listener  // rpc servers
 	txIndexer         txindex.TxIndexer
 	indexerService    *txindex.IndexerService
+	eventLog          *eventlog.EventLog // nil if disabled
+	txWaiter          *rpccore.TxWaiter
+	rpcMetrics        *rpcserver.Metrics
 	prometheusSrv     *http.Server
 }
 
@@ -250,8 +295,36 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	return
 }
 
-func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
-	proxyApp := proxy.NewAppConns(clientCreator)
+func createAndStartABCIRecorder(config *cfg.Config, logger log.Logger) (*proxy.Recorder, error) {
+	if config.ABCIRecord == "" {
+		return nil, nil
+	}
+
+	recorder, err := proxy.NewRecorder(config.ABCIRecordFile())
+	if err != nil {
+		return nil, err
+	}
+	recorder.SetLogger(logger.With("module", "abci-recorder"))
+	if err := recorder.Start(); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func createAndStartProxyAppConns(
+	clientCreator proxy.ClientCreator,
+	config *cfg.Config,
+	logger log.Logger,
+	resume proxy.ConsensusResumeFunc,
+) (proxy.AppConns, error) {
+	opts := []proxy.MultiAppConnOption{
+		proxy.WithMempoolConnections(config.Mempool.CheckTxConnections),
+		proxy.WithHealthCheckInterval(config.ABCIHealthCheckInterval),
+	}
+	if config.ABCIConsensusReconnect {
+		opts = append(opts, proxy.WithConsensusResume(resume))
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, opts...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
@@ -259,8 +332,23 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.L
 	return proxyApp, nil
 }
 
-func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
-	eventBus := types.NewEventBus()
+func createAndStartEventBus(
+	config *cfg.Config,
+	logger log.Logger,
+	metrics *tmpubsub.Metrics,
+) (*types.EventBus, error) {
+	metricsQueries := make([]tmpubsub.Query, 0, len(config.RPC.SubscriptionMetricsQueries))
+	for _, s := range config.RPC.SubscriptionMetricsQueries {
+		q, err := tmquery.New(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subscription-metrics-queries query %q: %w", s, err)
+		}
+		metricsQueries = append(metricsQueries, q)
+	}
+	eventBus := types.NewEventBusWithOptions(
+		tmpubsub.WithMetrics(metrics),
+		tmpubsub.MetricsQueries(metricsQueries...),
+	)
 	eventBus.SetLogger(logger.With("module", "events"))
 	if err := eventBus.Start(); err != nil {
 		return nil, err
@@ -291,6 +379,36 @@ func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider,
 	return indexerService, txIndexer, nil
 }
 
+func createAndStartEventLog(config *cfg.Config, dbProvider DBProvider,
+	eventBus *types.EventBus, logger log.Logger) (*eventlog.EventLog, error) {
+	if config.RPC.EventLogWindowSize == 0 {
+		return nil, nil
+	}
+
+	db, err := dbProvider(&DBContext{"eventlog", config})
+	if err != nil {
+		return nil, err
+	}
+	eventLog, err := eventlog.NewEventLog(db, eventBus, config.RPC.EventLogWindowSize)
+	if err != nil {
+		return nil, err
+	}
+	eventLog.SetLogger(logger.With("module", "eventlog"))
+	if err := eventLog.Start(); err != nil {
+		return nil, err
+	}
+	return eventLog, nil
+}
+
+func createAndStartTxWaiter(eventBus *types.EventBus, logger log.Logger) (*rpccore.TxWaiter, error) {
+	txWaiter := rpccore.NewTxWaiter(eventBus)
+	txWaiter.SetLogger(logger.With("module", "txwaiter"))
+	if err := txWaiter.Start(); err != nil {
+		return nil, err
+	}
+	return txWaiter, nil
+}
+
 func doHandshake(
 	stateStore sm.Store,
 	state sm.State,
@@ -298,17 +416,59 @@ func doHandshake(
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
+	finalizeBlock bool,
 	consensusLogger log.Logger) error {
 
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetFinalizeBlock(finalizeBlock)
 	if err := handshaker.Handshake(proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
 	return nil
 }
 
+// cappedBlockStore is a block store which hides the blocks above height.
+type cappedBlockStore struct {
+	sm.BlockStore
+	height int64
+}
+
+func (bs cappedBlockStore) Height() int64 {
+	if height := bs.BlockStore.Height(); height < bs.height {
+		return height
+	}
+	return bs.height
+}
+
+// consensusResumeFunc returns the function resuming consensus once the
+// consensus connection to the ABCI app is re-established. It does the
+// handshake again, up to the last block applied by consensus, to bring the app
+// back in sync: the block consensus failed to send is applied once consensus
+// retries it. The connection isn't reestablished if it was lost while a block
+// was executing, see proxy.WithConsensusResume. If the app is ahead of consensus (e.g. it committed the block but
+// the connection was lost before it responded), the handshake fails and the
+// node must be restarted.
+func consensusResumeFunc(
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	genDoc *types.GenesisDoc,
+	eventBus types.BlockEventPublisher,
+	finalizeBlock bool,
+	consensusLogger log.Logger,
+) proxy.ConsensusResumeFunc {
+	return func(proxyApp proxy.AppConns) error {
+		state, err := stateStore.Load()
+		if err != nil {
+			return fmt.Errorf("cannot load state: %w", err)
+		}
+		blockStore := cappedBlockStore{BlockStore: blockStore, height: state.LastBlockHeight}
+		return doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp,
+			finalizeBlock, consensusLogger)
+	}
+}
+
 func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusLogger log.Logger, mode string) {
 	// Log the version info.
 	logger.Info("Version info",
@@ -504,6 +664,7 @@ func createConsensusReactor(
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
+	pruner *sm.Pruner,
 	mempool *mempl.CListMempool,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
@@ -523,6 +684,7 @@ func createConsensusReactor(
 		mempool,
 		evidencePool,
 		cs.StateMetrics(csMetrics),
+		cs.StatePruner(pruner),
 	)
 	consensusState.SetLogger(logger)
 	if privValidator != nil && config.Mode == cfg.ModeValidator {
@@ -797,8 +959,31 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 		}
 	}
 
+	var source statesync.SnapshotSource
+	if config.SnapshotSource != "" {
+		var err error
+		source, err = statesync.NewSnapshotSource(config.SnapshotSource, statesync.S3Options{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up snapshot source: %w", err)
+		}
+	}
+
 	go func() {
-		state, commit, err := ssR.Sync(stateProvider, config.DiscoveryTime)
+		var (
+			state  sm.State
+			commit *types.Commit
+			err    error
+		)
+		if source != nil {
+			state, commit, err = ssR.SyncFromSource(stateProvider, source)
+		} else {
+			state, commit, err = ssR.Sync(stateProvider, config.DiscoveryTime)
+		}
 		if err != nil {
 			ssR.Logger.Error("State sync failed", "err", err)
 			return
@@ -814,6 +999,16 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 			return
 		}
 
+		// Backfilling is best-effort: the node can still participate in
+		// consensus, but may not be able to verify all evidence. It runs in
+		// the background, so that it doesn't hold up fast sync or consensus.
+		go func() {
+			if err := ssR.Backfill(state, config.BackfillBlocks); err != nil {
+				ssR.Logger.Error("Backfill failed; node has insufficient history to verify all evidence",
+					"err", err)
+			}
+		}()
+
 		if fastSync {
 			// FIXME Very ugly to have these metrics bleed through here.
 			conR.Metrics.StateSyncing.Set(0)
@@ -947,17 +1142,31 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger)
+	// Record the calls to the ABCI app, if enabled.
+	abciRecorder, err := createAndStartABCIRecorder(config, logger)
 	if err != nil {
 		return nil, err
 	}
+	if abciRecorder != nil {
+		clientCreator = proxy.NewRecordingClientCreator(clientCreator, abciRecorder)
+	}
 
 	// EventBus and IndexerService must be started before the handshake because
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
 	// but before it indexed the txs, or, endblocker panicked)
-	eventBus, err := createAndStartEventBus(logger)
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, ssMetrics, pubsubMetrics, rpcMetrics :=
+		metricsProvider(genDoc.ChainID)
+
+	eventBus, err := createAndStartEventBus(config, logger, pubsubMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
+	consensusLogger := logger.With("module", "consensus")
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, config, logger,
+		consensusResumeFunc(stateStore, blockStore, genDoc, eventBus, config.ABCIFinalizeBlock, consensusLogger))
 	if err != nil {
 		return nil, err
 	}
@@ -968,9 +1177,34 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// Event log, for clients reading events from a cursor
+	eventLog, err := createAndStartEventLog(config, dbProvider, eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tx waiter, for clients waiting for txs to be committed
+	txWaiter, err := createAndStartTxWaiter(eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// If an address is provided, listen on the socket for a connection from an
 	// external signing process.
-	if config.PrivValidatorListenAddr != "" {
+	// With several addresses, fail over between them, or sign with a threshold
+	// of them if they hold shares of the key.
+	if groupFile := config.PrivValidatorThresholdGroupFile(); groupFile != "" {
+		privValidator, err = createAndStartPrivValidatorThresholdSigner(
+			config, groupFile, config.PrivValidatorListenAddrs(), logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator threshold signer: %w", err)
+		}
+	} else if addrs := config.PrivValidatorListenAddrs(); len(addrs) > 1 {
+		privValidator, err = createAndStartPrivValidatorFailoverClient(config, addrs, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator failover client: %w", err)
+		}
+	} else if config.PrivValidatorListenAddr != "" {
 		protocol, _ := tmnet.ProtocolAndAddress(config.PrivValidatorListenAddr)
 		// FIXME: we should start services inside OnStart
 		switch protocol {
@@ -1006,9 +1240,9 @@ func NewNode(config *cfg.Config,
 
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync tendermint with the app.
-	consensusLogger := logger.With("module", "consensus")
 	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp,
+			config.ABCIFinalizeBlock, consensusLogger); err != nil {
 			return nil, err
 		}
 
@@ -1047,7 +1281,6 @@ func NewNode(config *cfg.Config,
 		return nil, fmt.Errorf("failed to create router: %w", err)
 	}
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
 	mpReactorShim, mpReactor, mempool := createMempoolReactor(
 		config, proxyApp, state, memplMetrics, peerManager, router, logger,
 	)
@@ -1067,10 +1300,22 @@ func NewNode(config *cfg.Config,
 		mempool,
 		evPool,
 		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithFinalizeBlock(config.ABCIFinalizeBlock),
+	)
+
+	pruner := sm.NewPruner(
+		logger.With("module", "pruner"),
+		stateStore,
+		blockStore,
+		sm.PrunerMinRetainBlocks(config.Pruning.MinRetainBlocks),
+		sm.PrunerInterval(config.Pruning.Interval),
+		sm.PrunerBatchSize(config.Pruning.BatchSize),
+		sm.PrunerCompactionInterval(config.Pruning.CompactionInterval),
+		sm.PrunerWithMetrics(smMetrics),
 	)
 
 	csReactorShim, csReactor, csState := createConsensusReactor(
-		config, state, blockExec, blockStore, mempool, evPool,
+		config, state, blockExec, blockStore, pruner, mempool, evPool,
 		privValidator, csMetrics, stateSync || fastSync, eventBus,
 		peerManager, router, consensusLogger,
 	)
@@ -1129,8 +1374,12 @@ func NewNode(config *cfg.Config,
 		proxyApp.Query(),
 		channels[statesync.SnapshotChannel],
 		channels[statesync.ChunkChannel],
+		channels[statesync.LightBlockChannel],
 		peerUpdates,
+		stateStore,
+		blockStore,
 		config.StateSync.TempDir,
+		ssMetrics,
 	)
 
 	// Setup Transport and Switch.
@@ -1200,6 +1449,7 @@ func NewNode(config *cfg.Config,
 
 		stateStore:       stateStore,
 		blockStore:       blockStore,
+		pruner:           pruner,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mpReactor,
 		mempool:          mempool,
@@ -1213,8 +1463,12 @@ func NewNode(config *cfg.Config,
 		evidenceReactor:  evReactor,
 		evidencePool:     evPool,
 		proxyApp:         proxyApp,
+		abciRecorder:     abciRecorder,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
+		eventLog:         eventLog,
+		txWaiter:         txWaiter,
+		rpcMetrics:       rpcMetrics,
 		eventBus:         eventBus,
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
@@ -1291,6 +1545,11 @@ func (n *Node) OnStart() error {
 			}
 		}
 
+		// Start pruning before consensus, which requests it.
+		if err := n.pruner.Start(); err != nil {
+			return err
+		}
+
 		// Start the real consensus reactor separately since the switch uses the shim.
 		if err := n.consensusReactor.Start(); err != nil {
 			return err
@@ -1353,6 +1612,14 @@ func (n *Node) OnStop() {
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if n.eventLog != nil {
+		if err := n.eventLog.Stop(); err != nil {
+			n.Logger.Error("Error closing eventLog", "err", err)
+		}
+	}
+	if err := n.txWaiter.Stop(); err != nil {
+		n.Logger.Error("Error closing txWaiter", "err", err)
+	}
 
 	if n.config.Mode != cfg.ModeSeed {
 
@@ -1369,6 +1636,10 @@ func (n *Node) OnStop() {
 			n.Logger.Error("failed to stop the consensus reactor", "err", err)
 		}
 
+		if err := n.pruner.Stop(); err != nil {
+			n.Logger.Error("failed to stop the pruner", "err", err)
+		}
+
 		// Stop the real state sync reactor separately since the switch uses the shim.
 		if err := n.stateSyncReactor.Stop(); err != nil {
 			n.Logger.Error("failed to stop the state sync reactor", "err", err)
@@ -1432,6 +1703,12 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	if n.abciRecorder != nil {
+		if err := n.abciRecorder.Stop(); err != nil {
+			n.Logger.Error("Error closing ABCI recorder", "err", err)
+		}
+	}
 }
 
 // ConfigureRPC makes sure RPC has all the objects it needs to operate.
@@ -1439,6 +1716,7 @@ func (n *Node) ConfigureRPC() error {
 	rpcCoreEnv := rpccore.Environment{
 		ProxyAppQuery:   n.proxyApp.Query(),
 		ProxyAppMempool: n.proxyApp.Mempool(),
+		ProxyAppStatus:  n.proxyApp,
 
 		StateStore:     n.stateStore,
 		BlockStore:     n.blockStore,
@@ -1450,7 +1728,10 @@ func (n *Node) ConfigureRPC() error {
 		GenDoc:           n.genesisDoc,
 		TxIndexer:        n.txIndexer,
 		ConsensusReactor: n.consensusReactor,
+		StateSyncReactor: n.stateSyncReactor,
 		EventBus:         n.eventBus,
+		EventLog:         n.eventLog,
+		TxWaiter:         n.txWaiter,
 		Mempool:          n.mempool,
 
 		Logger: n.Logger.With("module", "rpc"),
@@ -1491,6 +1772,31 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	var (
+		acl            *rpcserver.ACL
+		authenticators []rpcserver.Authenticator
+	)
+	if n.config.RPC.IsAuthEnabled() {
+		var err error
+		acl, authenticators, config.TLSClientCAs, err = loadRPCAuth(n.config.RPC)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rateLimiter *rpcserver.RateLimiter
+	if len(n.config.RPC.RateLimits) > 0 {
+		limits, err := rpcserver.ParseRateLimits(n.config.RPC.RateLimits)
+		if err != nil {
+			return nil, err
+		}
+		trustedProxies, err := rpcserver.ParseTrustedProxies(n.config.RPC.TrustedProxies)
+		if err != nil {
+			return nil, err
+		}
+		rateLimiter = rpcserver.NewRateLimiter(limits, trustedProxies, n.rpcMetrics)
+	}
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -1505,6 +1811,9 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 				}
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
+			rpcserver.EventRateLimit(n.config.RPC.SubscriptionEventRate, n.config.RPC.SubscriptionEventBurst),
+			rpcserver.WithMetrics(n.rpcMetrics),
+			rpcserver.EventMetricsQueries(n.config.RPC.SubscriptionMetricsQueries...),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
@@ -1518,13 +1827,19 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		}
 
 		var rootHandler http.Handler = mux
+		if rateLimiter != nil {
+			rootHandler = rpcserver.RateLimitHandler(rootHandler, rateLimiter)
+		}
+		if acl != nil {
+			rootHandler = rpcserver.AuthHandler(rootHandler, acl, authenticators...)
+		}
 		if n.config.RPC.IsCorsEnabled() {
 			corsMiddleware := cors.New(cors.Options{
 				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
 				AllowedMethods: n.config.RPC.CORSAllowedMethods,
 				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
 		}
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
@@ -1586,6 +1901,46 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 
 }
 
+// loadRPCAuth loads the RPC method ACL, the authenticators and the client CAs
+// for TLS client certificates, if configured.
+func loadRPCAuth(config *cfg.RPCConfig) (*rpcserver.ACL, []rpcserver.Authenticator, *x509.CertPool, error) {
+	acl, err := rpcserver.ParseACL(config.MethodACL)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var (
+		authenticators []rpcserver.Authenticator
+		clientCAs      *x509.CertPool
+	)
+	if path := config.ClientCAFile(); path != "" {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, nil, nil, fmt.Errorf("no certificates found in client CA file %s", path)
+		}
+		authenticators = append(authenticators, rpcserver.ClientCertAuthenticator{})
+	}
+	if path := config.TokenFile(); path != "" {
+		tokens, err := rpcserver.LoadTokenAuthenticator(path)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load RPC auth tokens: %w", err)
+		}
+		authenticators = append(authenticators, tokens)
+	}
+	if path := config.JWTKeyFile(); path != "" {
+		jwt, err := rpcserver.LoadJWTAuthenticator(path)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load RPC JWT key: %w", err)
+		}
+		authenticators = append(authenticators, jwt)
+	}
+	return acl, authenticators, clientCAs, nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer(addr string) *http.Server {
@@ -1733,6 +2088,7 @@ func makeNodeInfo(
 			byte(evidence.EvidenceChannel),
 			byte(statesync.SnapshotChannel),
 			byte(statesync.ChunkChannel),
+			byte(statesync.LightBlockChannel),
 		},
 		Moniker: config.Moniker,
 		Other: p2p.NodeInfoOther{
@@ -1901,7 +2257,7 @@ func createAndStartPrivValidatorGRPCClient(
 	chainID string,
 	logger log.Logger,
 ) (types.PrivValidator, error) {
-	pvsc, err := tmgrpc.DialRemoteSigner(config, chainID, logger)
+	pvsc, err := tmgrpc.DialRemoteSigner(config, config.PrivValidatorListenAddr, chainID, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
@@ -1915,6 +2271,77 @@ func createAndStartPrivValidatorGRPCClient(
 	return pvsc, nil
 }
 
+func createAndStartPrivValidatorFailoverClient(
+	config *cfg.Config,
+	addrs []string,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	endpoints := make([]privval.FailoverEndpoint, 0, len(addrs))
+	for _, addr := range addrs {
+		var signer types.PrivValidator
+		protocol, _ := tmnet.ProtocolAndAddress(addr)
+		switch protocol {
+		case "grpc":
+			pvsc, err := tmgrpc.DialRemoteSigner(config, addr, chainID, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start private validator %s: %w", addr, err)
+			}
+			signer = pvsc
+		default:
+			pve, err := privval.NewSignerListener(addr, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start private validator %s: %w", addr, err)
+			}
+			// no retries: the failover client switches to another signer instead
+			pvsc, err := privval.NewSignerClient(pve, chainID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start private validator %s: %w", addr, err)
+			}
+			signer = pvsc
+		}
+		endpoints = append(endpoints, privval.FailoverEndpoint{Address: addr, Signer: signer})
+	}
+
+	pvfc, err := privval.NewFailoverSignerClient(
+		endpoints,
+		config.PrivValidatorFailoverStateFile(),
+		config.PrivValidatorHealthCheckInterval,
+		logger.With("module", "privval"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := pvfc.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	return pvfc, nil
+}
+
+func createAndStartPrivValidatorThresholdSigner(
+	config *cfg.Config,
+	groupFile string,
+	addrs []string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	group, err := privval.LoadThresholdGroup(groupFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load threshold group: %w", err)
+	}
+
+	signers := make([]privval.ThresholdShareSigner, 0, len(addrs))
+	for _, addr := range addrs {
+		sc, err := tmgrpc.DialRemoteShareSigner(config, addr, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start share signer %s: %w", addr, err)
+		}
+		signers = append(signers, sc)
+	}
+
+	return privval.NewThresholdSigner(group, signers)
+}
+
 // FIXME: Temporary helper function, shims should be removed.
 func makeChannelsFromShims(
 	router *p2p.Router,
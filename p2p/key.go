@@ -5,13 +5,13 @@ import (
 
 	"github.com/klyed/tendermint/crypto"
 	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/keyfile"
 	tmjson "github.com/klyed/tendermint/libs/json"
 	tmos "github.com/klyed/tendermint/libs/os"
 )
 
 //------------------------------------------------------------------------------
 // Persistent peer ID
-// TODO: encrypt on disk
 
 // NodeKey is the persistent peer key.
 // It contains the nodes private key for authentication.
@@ -40,11 +40,27 @@ func (nodeKey NodeKey) SaveAs(filePath string) error {
 	return nil
 }
 
+// SaveAsWithPassphrase persists the NodeKey to filePath, encrypted with
+// passphrase. An empty passphrase saves it in plaintext.
+func (nodeKey NodeKey) SaveAsWithPassphrase(filePath, passphrase string) error {
+	jsonBytes, err := tmjson.Marshal(nodeKey)
+	if err != nil {
+		return err
+	}
+	return keyfile.WriteFile(filePath, jsonBytes, passphrase)
+}
+
 // LoadOrGenNodeKey attempts to load the NodeKey from the given filePath. If
 // the file does not exist, it generates and saves a new NodeKey.
 func LoadOrGenNodeKey(filePath string) (NodeKey, error) {
+	return LoadOrGenNodeKeyWithPassphrase(filePath, "")
+}
+
+// LoadOrGenNodeKeyWithPassphrase is like LoadOrGenNodeKey, but decrypts the
+// file with passphrase, or encrypts the generated key with it.
+func LoadOrGenNodeKeyWithPassphrase(filePath, passphrase string) (NodeKey, error) {
 	if tmos.FileExists(filePath) {
-		nodeKey, err := LoadNodeKey(filePath)
+		nodeKey, err := LoadNodeKeyWithPassphrase(filePath, passphrase)
 		if err != nil {
 			return NodeKey{}, err
 		}
@@ -53,7 +69,7 @@ func LoadOrGenNodeKey(filePath string) (NodeKey, error) {
 
 	nodeKey := GenNodeKey()
 
-	if err := nodeKey.SaveAs(filePath); err != nil {
+	if err := nodeKey.SaveAsWithPassphrase(filePath, passphrase); err != nil {
 		return NodeKey{}, err
 	}
 
@@ -71,7 +87,13 @@ func GenNodeKey() NodeKey {
 
 // LoadNodeKey loads NodeKey located in filePath.
 func LoadNodeKey(filePath string) (NodeKey, error) {
-	jsonBytes, err := ioutil.ReadFile(filePath)
+	return LoadNodeKeyWithPassphrase(filePath, "")
+}
+
+// LoadNodeKeyWithPassphrase loads NodeKey located in filePath, decrypting it
+// with passphrase if it's encrypted.
+func LoadNodeKeyWithPassphrase(filePath, passphrase string) (NodeKey, error) {
+	jsonBytes, err := keyfile.ReadFile(filePath, passphrase)
 	if err != nil {
 		return NodeKey{}, err
 	}
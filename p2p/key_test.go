@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/klyed/tendermint/crypto/keyfile"
 	tmrand "github.com/klyed/tendermint/libs/rand"
 	"github.com/klyed/tendermint/p2p"
 )
@@ -44,3 +45,22 @@ func TestNodeKeySaveAs(t *testing.T) {
 	require.NoError(t, nodeKey.SaveAs(filePath))
 	require.FileExists(t, filePath)
 }
+
+func TestEncryptedNodeKey(t *testing.T) {
+	filePath := filepath.Join(os.TempDir(), tmrand.Str(12)+"_peer_id.json")
+
+	nodeKey, err := p2p.LoadOrGenNodeKeyWithPassphrase(filePath, "passphrase")
+	require.NoError(t, err)
+	encrypted, err := keyfile.IsEncryptedFile(filePath)
+	require.NoError(t, err)
+	require.True(t, encrypted)
+
+	_, err = p2p.LoadNodeKey(filePath)
+	require.ErrorIs(t, err, keyfile.ErrNoPassphrase)
+	_, err = p2p.LoadNodeKeyWithPassphrase(filePath, "wrong")
+	require.ErrorIs(t, err, keyfile.ErrWrongPassphrase)
+
+	nodeKey2, err := p2p.LoadOrGenNodeKeyWithPassphrase(filePath, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, nodeKey, nodeKey2)
+}
@@ -25,5 +25,13 @@ SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
 
+ThresholdSigner
+
+ThresholdSigner splits an ed25519 key across several signers, so that it only gets
+a signature when a threshold of them agree on the vote or proposal. The signers
+implement ThresholdShareSigner, and LocalShareSigner holds a key share in process.
+The grpc package serves a LocalShareSigner remotely, and the node uses a
+ThresholdSigner over such signers when priv-validator-threshold-group-file is set.
+
 */
 package privval
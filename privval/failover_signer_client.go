@@ -0,0 +1,306 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/klyed/tendermint/crypto"
+	tmjson "github.com/klyed/tendermint/libs/json"
+	"github.com/klyed/tendermint/libs/log"
+	"github.com/klyed/tendermint/libs/service"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+	"github.com/klyed/tendermint/libs/tempfile"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/types"
+)
+
+// FailoverEndpoint is a remote signer used by FailoverSignerClient.
+type FailoverEndpoint struct {
+	// Address identifies the signer in logs and in the failover state.
+	Address string
+	Signer  types.PrivValidator
+}
+
+// FailoverState stores the signer a FailoverSignerClient is locked to, and the
+// height it is locked for.
+type FailoverState struct {
+	Height  int64  `json:"height"`
+	Address string `json:"address"`
+
+	filePath string
+}
+
+// Save persists the FailoverState to its filePath.
+func (fs FailoverState) Save() {
+	if fs.filePath == "" {
+		panic("cannot save FailoverState: filePath not set")
+	}
+	jsonBytes, err := tmjson.MarshalIndent(fs, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	err = tempfile.WriteFileAtomic(fs.filePath, jsonBytes, 0600)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// FailoverSignerClient implements PrivValidator over several remote signers
+// holding the same key. It sends requests to one active signer, checks the
+// health of all of them at an interval and fails over to a healthy signer when
+// the active one is unhealthy or returns an error.
+//
+// Since the signers don't share their last sign state, failing over within a
+// height could make the validator double sign. Therefore, once a request for a
+// height has been sent to a signer, all the requests up to that height go to
+// the same signer, even if it fails: the client only fails over for the next
+// height. The height and signer are persisted to stateFilePath, so that this
+// also holds across restarts.
+type FailoverSignerClient struct {
+	service.BaseService
+
+	endpoints           []FailoverEndpoint
+	healthCheckInterval time.Duration
+
+	mtx     tmsync.Mutex
+	pubKey  crypto.PubKey
+	healthy []bool
+	active  int
+	state   FailoverState
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// NewFailoverSignerClient returns a FailoverSignerClient for the given
+// endpoints. It gets the public key from the endpoints, and fails unless at
+// least one of them answers.
+func NewFailoverSignerClient(
+	endpoints []FailoverEndpoint,
+	stateFilePath string,
+	healthCheckInterval time.Duration,
+	logger log.Logger,
+) (*FailoverSignerClient, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no signer endpoints")
+	}
+
+	state, err := loadFailoverState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := &FailoverSignerClient{
+		endpoints:           endpoints,
+		healthCheckInterval: healthCheckInterval,
+		healthy:             make([]bool, len(endpoints)),
+		state:               state,
+	}
+	fc.BaseService = *service.NewBaseService(logger, "FailoverSignerClient", fc)
+
+	// Start with the signer locked in the state, if any, so that a restart
+	// at the same height doesn't switch signers.
+	for i, e := range endpoints {
+		if e.Address == state.Address {
+			fc.active = i
+		}
+	}
+
+	fc.checkHealth()
+	if fc.pubKey == nil {
+		return nil, errors.New("none of the signer endpoints is available")
+	}
+	return fc, nil
+}
+
+func loadFailoverState(filePath string) (FailoverState, error) {
+	state := FailoverState{filePath: filePath}
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	} else if err != nil {
+		return state, err
+	}
+	if err := tmjson.Unmarshal(jsonBytes, &state); err != nil {
+		return state, fmt.Errorf("error reading failover state from %v: %w", filePath, err)
+	}
+	return state, nil
+}
+
+// OnStart implements service.Service.
+func (fc *FailoverSignerClient) OnStart() error {
+	if fc.healthCheckInterval > 0 {
+		go fc.healthCheckRoutine()
+	}
+	return nil
+}
+
+// OnStop implements service.Service by closing the endpoints.
+func (fc *FailoverSignerClient) OnStop() {
+	for _, e := range fc.endpoints {
+		if closer, ok := e.Signer.(interface{ Close() error }); ok {
+			if err := closer.Close(); err != nil {
+				fc.Logger.Error("Error closing signer endpoint", "addr", e.Address, "err", err)
+			}
+		}
+	}
+}
+
+// Active returns the address of the active signer.
+func (fc *FailoverSignerClient) Active() string {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	return fc.endpoints[fc.active].Address
+}
+
+func (fc *FailoverSignerClient) healthCheckRoutine() {
+	ticker := time.NewTicker(fc.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			fc.checkHealth()
+		case <-fc.Quit():
+			return
+		}
+	}
+}
+
+// checkHealth gets the public key from every endpoint. An endpoint is healthy
+// if it answers within the health check interval with the same key as the
+// others.
+func (fc *FailoverSignerClient) checkHealth() {
+	pubKeys := make([]crypto.PubKey, len(fc.endpoints))
+	errs := make([]error, len(fc.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range fc.endpoints {
+		wg.Add(1)
+		go func(i int, signer types.PrivValidator) {
+			defer wg.Done()
+			ctx := context.Background()
+			if fc.healthCheckInterval > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, fc.healthCheckInterval)
+				defer cancel()
+			}
+			pubKeys[i], errs[i] = signer.GetPubKey(ctx)
+		}(i, e.Signer)
+	}
+	wg.Wait()
+
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	initial := fc.pubKey == nil
+	for i, e := range fc.endpoints {
+		healthy := false
+		switch {
+		case errs[i] != nil:
+			if fc.healthy[i] || initial {
+				fc.Logger.Error("Signer endpoint is unhealthy", "addr", e.Address, "err", errs[i])
+			}
+		case pubKeys[i] == nil:
+			fc.Logger.Error("Signer endpoint returned no public key", "addr", e.Address)
+		case fc.pubKey == nil:
+			fc.pubKey = pubKeys[i]
+			healthy = true
+		case !fc.pubKey.Equals(pubKeys[i]):
+			fc.Logger.Error("Signer endpoint has a different public key",
+				"addr", e.Address, "pubKey", pubKeys[i], "expected", fc.pubKey)
+		default:
+			healthy = true
+		}
+		if healthy && !fc.healthy[i] {
+			fc.Logger.Info("Signer endpoint is healthy", "addr", e.Address)
+		}
+		fc.healthy[i] = healthy
+	}
+}
+
+// signerFor returns the signer to use for a request at the given height,
+// failing over to a healthy signer if the height isn't locked yet.
+func (fc *FailoverSignerClient) signerFor(height int64) (int, types.PrivValidator, error) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	if height <= fc.state.Height {
+		if fc.state.Address != fc.endpoints[fc.active].Address {
+			return 0, nil, fmt.Errorf("height %d is locked to signer %s, which is not configured",
+				fc.state.Height, fc.state.Address)
+		}
+		return fc.active, fc.endpoints[fc.active].Signer, nil
+	}
+
+	if !fc.healthy[fc.active] {
+		for i := 1; i < len(fc.endpoints); i++ {
+			next := (fc.active + i) % len(fc.endpoints)
+			if fc.healthy[next] {
+				fc.Logger.Info("Failing over to another signer",
+					"height", height, "from", fc.endpoints[fc.active].Address, "to", fc.endpoints[next].Address)
+				fc.active = next
+				break
+			}
+		}
+	}
+
+	// lock the height before sending the request, which might be signed even
+	// if we don't get a response
+	fc.state.Height = height
+	fc.state.Address = fc.endpoints[fc.active].Address
+	fc.state.Save()
+
+	return fc.active, fc.endpoints[fc.active].Signer, nil
+}
+
+// failed marks the signer as unhealthy after a failed request, unless it
+// refused to sign. The next health check marks it healthy again if it is.
+func (fc *FailoverSignerClient) failed(i int, err error) {
+	if _, ok := err.(*RemoteSignerError); ok {
+		return
+	}
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	if fc.healthy[i] {
+		fc.Logger.Error("Signer endpoint failed", "addr", fc.endpoints[i].Address, "err", err)
+		fc.healthy[i] = false
+	}
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey returns the public key of the signers.
+func (fc *FailoverSignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	return fc.pubKey, nil
+}
+
+// SignVote requests the signer locked for the vote's height to sign it.
+func (fc *FailoverSignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	i, signer, err := fc.signerFor(vote.Height)
+	if err != nil {
+		return err
+	}
+	if err = signer.SignVote(ctx, chainID, vote); err != nil {
+		fc.failed(i, err)
+		return fmt.Errorf("signer %s failed to sign vote: %w", fc.endpoints[i].Address, err)
+	}
+	return nil
+}
+
+// SignProposal requests the signer locked for the proposal's height to sign it.
+func (fc *FailoverSignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	i, signer, err := fc.signerFor(proposal.Height)
+	if err != nil {
+		return err
+	}
+	if err = signer.SignProposal(ctx, chainID, proposal); err != nil {
+		fc.failed(i, err)
+		return fmt.Errorf("signer %s failed to sign proposal: %w", fc.endpoints[i].Address, err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/crypto"
+	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/libs/log"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/types"
+)
+
+var errSignerDown = errors.New("signer is down")
+
+// testFailoverSigner is a signer which can be taken down, and records the
+// heights it signed.
+type testFailoverSigner struct {
+	types.MockPV
+
+	mtx     sync.Mutex
+	down    bool
+	heights []int64
+}
+
+func newTestFailoverSigner(privKey crypto.PrivKey) *testFailoverSigner {
+	return &testFailoverSigner{MockPV: types.NewMockPVWithParams(privKey, false, false)}
+}
+
+func (s *testFailoverSigner) setDown(down bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.down = down
+}
+
+func (s *testFailoverSigner) signedHeights() []int64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.heights
+}
+
+func (s *testFailoverSigner) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.down {
+		return nil, errSignerDown
+	}
+	return s.MockPV.GetPubKey(ctx)
+}
+
+func (s *testFailoverSigner) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.down {
+		return errSignerDown
+	}
+	s.heights = append(s.heights, vote.Height)
+	return s.MockPV.SignVote(ctx, chainID, vote)
+}
+
+func (s *testFailoverSigner) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.down {
+		return errSignerDown
+	}
+	s.heights = append(s.heights, proposal.Height)
+	return s.MockPV.SignProposal(ctx, chainID, proposal)
+}
+
+func newTestFailoverClient(t *testing.T, stateFile string, signers ...*testFailoverSigner) *FailoverSignerClient {
+	endpoints := make([]FailoverEndpoint, len(signers))
+	for i, s := range signers {
+		endpoints[i] = FailoverEndpoint{Address: string(rune('a' + i)), Signer: s}
+	}
+	// no health check routine: the tests check the health explicitly
+	fc, err := NewFailoverSignerClient(endpoints, stateFile, 0, log.TestingLogger())
+	require.NoError(t, err)
+	return fc
+}
+
+func failoverStateFile(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "failover_signer_")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir + "/priv_validator_failover_state.json"
+}
+
+func signTestVote(fc *FailoverSignerClient, height int64, round int32) error {
+	vote := newVote(nil, 0, height, round, tmproto.PrevoteType, randBlockID()).ToProto()
+	return fc.SignVote(context.Background(), "mychainid", vote)
+}
+
+func TestFailoverSignerClientFailsOverAtNextHeight(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	a, b := newTestFailoverSigner(privKey), newTestFailoverSigner(privKey)
+	fc := newTestFailoverClient(t, failoverStateFile(t), a, b)
+
+	pubKey, err := fc.GetPubKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	require.NoError(t, signTestVote(fc, 1, 0))
+	assert.Equal(t, "a", fc.Active())
+
+	// the active signer goes down: no failover within the height
+	a.setDown(true)
+	assert.ErrorIs(t, signTestVote(fc, 1, 1), errSignerDown)
+	assert.ErrorIs(t, signTestVote(fc, 1, 2), errSignerDown)
+	assert.Empty(t, b.signedHeights())
+
+	// the next height is signed by the other signer
+	require.NoError(t, signTestVote(fc, 2, 0))
+	assert.Equal(t, "b", fc.Active())
+	assert.Equal(t, []int64{1}, a.signedHeights())
+	assert.Equal(t, []int64{2}, b.signedHeights())
+
+	// the first signer is back, but the client sticks to the active one
+	a.setDown(false)
+	fc.checkHealth()
+	require.NoError(t, signTestVote(fc, 3, 0))
+	assert.Equal(t, []int64{2, 3}, b.signedHeights())
+}
+
+func TestFailoverSignerClientUnhealthySigner(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	a, b := newTestFailoverSigner(privKey), newTestFailoverSigner(privKey)
+	other := newTestFailoverSigner(ed25519.GenPrivKey())
+	fc := newTestFailoverClient(t, failoverStateFile(t), a, other, b)
+
+	// the health check finds the first signer down before the next height, so
+	// it's never used. The signer with another key is skipped.
+	a.setDown(true)
+	fc.checkHealth()
+	require.NoError(t, signTestVote(fc, 1, 0))
+	assert.Equal(t, "c", fc.Active())
+	assert.Empty(t, other.signedHeights())
+
+	// all down
+	b.setDown(true)
+	fc.checkHealth()
+	assert.Error(t, signTestVote(fc, 2, 0))
+
+	_, err := NewFailoverSignerClient(
+		[]FailoverEndpoint{{Address: "a", Signer: a}, {Address: "b", Signer: b}},
+		failoverStateFile(t), 0, log.TestingLogger())
+	assert.Error(t, err, "expected an error when no signer is available")
+}
+
+func TestFailoverSignerClientLockedHeightAfterRestart(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	a, b := newTestFailoverSigner(privKey), newTestFailoverSigner(privKey)
+	stateFile := failoverStateFile(t)
+
+	fc := newTestFailoverClient(t, stateFile, a, b)
+	a.setDown(true)
+	fc.checkHealth()
+	require.NoError(t, signTestVote(fc, 5, 0))
+	assert.Equal(t, "b", fc.Active())
+
+	// after a restart, the height stays locked to the same signer even if
+	// the other one is healthy again
+	a.setDown(false)
+	fc = newTestFailoverClient(t, stateFile, a, b)
+	assert.Equal(t, "b", fc.Active())
+	require.NoError(t, signTestVote(fc, 5, 1))
+	assert.Empty(t, a.signedHeights())
+
+	// a height locked to a signer which is no longer configured isn't signed
+	fc = newTestFailoverClient(t, stateFile, a)
+	assert.Error(t, signTestVote(fc, 5, 2))
+	require.NoError(t, signTestVote(fc, 6, 0))
+	assert.Equal(t, []int64{6}, a.signedHeights())
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/klyed/tendermint/crypto"
 	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/keyfile"
 	"github.com/klyed/tendermint/crypto/secp256k1"
 	tmbytes "github.com/klyed/tendermint/libs/bytes"
 	tmjson "github.com/klyed/tendermint/libs/json"
@@ -51,10 +52,18 @@ type FilePVKey struct {
 	PubKey  crypto.PubKey  `json:"pub_key"`
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
-	filePath string
+	filePath   string
+	passphrase string
+}
+
+// SetPassphrase sets the passphrase the key file is encrypted with when
+// saved. An empty passphrase saves the key in plaintext.
+func (pvKey *FilePVKey) SetPassphrase(passphrase string) {
+	pvKey.passphrase = passphrase
 }
 
-// Save persists the FilePVKey to its filePath.
+// Save persists the FilePVKey to its filePath, encrypted if it has a
+// passphrase.
 func (pvKey FilePVKey) Save() {
 	outFile := pvKey.filePath
 	if outFile == "" {
@@ -65,7 +74,7 @@ func (pvKey FilePVKey) Save() {
 	if err != nil {
 		panic(err)
 	}
-	err = tempfile.WriteFileAtomic(outFile, jsonBytes, 0600)
+	err = keyfile.WriteFile(outFile, jsonBytes, pvKey.passphrase)
 	if err != nil {
 		panic(err)
 	}
@@ -189,18 +198,31 @@ func GenFilePV(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
 func LoadFilePV(keyFilePath, stateFilePath string) (*FilePV, error) {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, "", true)
+}
+
+// LoadFilePVWithPassphrase loads a FilePV like LoadFilePV, decrypting the key
+// file with passphrase if it's encrypted. The key is saved encrypted with the
+// same passphrase.
+func LoadFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase string) (*FilePV, error) {
+	return loadFilePV(keyFilePath, stateFilePath, passphrase, true)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) (*FilePV, error) {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, "", false)
+}
+
+// LoadFilePVEmptyStateWithPassphrase loads a FilePV like LoadFilePVEmptyState,
+// decrypting the key file with passphrase if it's encrypted.
+func LoadFilePVEmptyStateWithPassphrase(keyFilePath, stateFilePath, passphrase string) (*FilePV, error) {
+	return loadFilePV(keyFilePath, stateFilePath, passphrase, false)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, error) {
-	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
+func loadFilePV(keyFilePath, stateFilePath, passphrase string, loadState bool) (*FilePV, error) {
+	keyJSONBytes, err := keyfile.ReadFile(keyFilePath, passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -214,6 +236,7 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
+	pvKey.passphrase = passphrase
 
 	pvState := FilePVLastSignState{}
 
@@ -239,14 +262,22 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) (*FilePV, error) {
+	return LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath, "")
+}
+
+// LoadOrGenFilePVWithPassphrase is like LoadOrGenFilePV, but decrypts the key
+// file with passphrase, or encrypts the generated one with it. An empty
+// passphrase only loads plaintext key files.
+func LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase string) (*FilePV, error) {
 	var (
 		pv  *FilePV
 		err error
 	)
 	if tmos.FileExists(keyFilePath) {
-		pv, err = LoadFilePV(keyFilePath, stateFilePath)
+		pv, err = LoadFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase)
 	} else {
 		pv, err = GenFilePV(keyFilePath, stateFilePath, "")
+		pv.Key.SetPassphrase(passphrase)
 		pv.Save()
 	}
 	return pv, err
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/keyfile"
 	"github.com/klyed/tendermint/crypto/tmhash"
 	tmjson "github.com/klyed/tendermint/libs/json"
 	tmrand "github.com/klyed/tendermint/libs/rand"
@@ -98,6 +99,45 @@ func TestLoadOrGenValidator(t *testing.T) {
 	assert.Equal(addr, privVal.GetAddress(), "expected privval addr to be the same")
 }
 
+func TestEncryptedValidatorKey(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+	require.NoError(t, os.Remove(tempKeyFile.Name()))
+
+	privVal, err := LoadOrGenFilePVWithPassphrase(tempKeyFile.Name(), tempStateFile.Name(), "passphrase")
+	require.NoError(t, err)
+	addr := privVal.GetAddress()
+
+	// the key isn't stored in plaintext
+	keyJSONBytes, err := ioutil.ReadFile(tempKeyFile.Name())
+	require.NoError(t, err)
+	assert.True(t, keyfile.IsEncrypted(keyJSONBytes))
+	assert.NotContains(t, string(keyJSONBytes), privVal.Key.Address.String())
+
+	_, err = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	assert.ErrorIs(t, err, keyfile.ErrNoPassphrase)
+	_, err = LoadFilePVWithPassphrase(tempKeyFile.Name(), tempStateFile.Name(), "wrong")
+	assert.ErrorIs(t, err, keyfile.ErrWrongPassphrase)
+
+	// the key stays encrypted when the validator is saved again
+	privVal, err = LoadFilePVWithPassphrase(tempKeyFile.Name(), tempStateFile.Name(), "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, addr, privVal.GetAddress())
+	privVal.Reset()
+	privVal, err = LoadFilePVWithPassphrase(tempKeyFile.Name(), tempStateFile.Name(), "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, addr, privVal.GetAddress())
+
+	// a plaintext key is loaded whatever the passphrase
+	privVal.Key.SetPassphrase("")
+	privVal.Save()
+	privVal, err = LoadFilePVWithPassphrase(tempKeyFile.Name(), tempStateFile.Name(), "other")
+	require.NoError(t, err)
+	assert.Equal(t, addr, privVal.GetAddress())
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/klyed/tendermint/libs/log"
+	"github.com/klyed/tendermint/privval"
+	privvalproto "github.com/klyed/tendermint/proto/tendermint/privval"
+)
+
+// ShareSignerClient implements privval.ThresholdShareSigner.
+// Handles connections to a remote signer holding a share of a threshold key.
+type ShareSignerClient struct {
+	logger log.Logger
+
+	client privvalproto.ThresholdShareSignerAPIClient
+	conn   *grpc.ClientConn
+}
+
+var _ privval.ThresholdShareSigner = (*ShareSignerClient)(nil)
+
+// NewShareSignerClient returns an instance of ShareSignerClient.
+func NewShareSignerClient(conn *grpc.ClientConn, log log.Logger) *ShareSignerClient {
+	return &ShareSignerClient{
+		logger: log,
+		client: privvalproto.NewThresholdShareSignerAPIClient(conn),
+		conn:   conn,
+	}
+}
+
+// Close closes the underlying connection
+func (sc *ShareSignerClient) Close() error {
+	sc.logger.Info("Stopping service")
+	if sc.conn != nil {
+		return sc.conn.Close()
+	}
+	return nil
+}
+
+//--------------------------------------------------------
+// Implement ThresholdShareSigner
+
+// Commit requests the remote signer to commit to nonces for the request
+func (sc *ShareSignerClient) Commit(
+	ctx context.Context,
+	req privval.ThresholdSignRequest,
+) (privval.ThresholdCommitment, error) {
+	resp, err := sc.client.Commit(ctx, thresholdSignRequestToProto(req))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("ShareSignerClient::Commit", "err", errStatus.Message())
+		return privval.ThresholdCommitment{}, errStatus.Err()
+	}
+
+	return thresholdCommitmentFromProto(*resp), nil
+}
+
+// SignShare requests the remote signer to sign its share of the request
+func (sc *ShareSignerClient) SignShare(
+	ctx context.Context,
+	req privval.ThresholdSignRequest,
+	commitments []privval.ThresholdCommitment,
+) (privval.ThresholdSignatureShare, error) {
+	pcs := make([]privvalproto.ThresholdCommitment, len(commitments))
+	for i, c := range commitments {
+		pcs[i] = thresholdCommitmentToProto(c)
+	}
+
+	resp, err := sc.client.SignShare(ctx, &privvalproto.ThresholdSignShareRequest{
+		Request:     *thresholdSignRequestToProto(req),
+		Commitments: pcs,
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("ShareSignerClient::SignShare", "err", errStatus.Message())
+		return privval.ThresholdSignatureShare{}, errStatus.Err()
+	}
+
+	return privval.ThresholdSignatureShare{Index: resp.Index, Share: resp.Share}, nil
+}
+
+//--------------------------------------------------------
+
+func thresholdSignRequestToProto(req privval.ThresholdSignRequest) *privvalproto.ThresholdSignRequest {
+	return &privvalproto.ThresholdSignRequest{
+		SessionId: req.SessionID,
+		ChainId:   req.ChainID,
+		Vote:      req.Vote,
+		Proposal:  req.Proposal,
+	}
+}
+
+func thresholdSignRequestFromProto(pr *privvalproto.ThresholdSignRequest) privval.ThresholdSignRequest {
+	return privval.ThresholdSignRequest{
+		SessionID: pr.SessionId,
+		ChainID:   pr.ChainId,
+		Vote:      pr.Vote,
+		Proposal:  pr.Proposal,
+	}
+}
+
+func thresholdCommitmentToProto(c privval.ThresholdCommitment) privvalproto.ThresholdCommitment {
+	return privvalproto.ThresholdCommitment{
+		Index:         c.Index,
+		SignBytesHash: c.SignBytesHash,
+		Hiding:        c.Hiding,
+		Binding:       c.Binding,
+	}
+}
+
+func thresholdCommitmentFromProto(pc privvalproto.ThresholdCommitment) privval.ThresholdCommitment {
+	return privval.ThresholdCommitment{
+		Index:         pc.Index,
+		SignBytesHash: pc.SignBytesHash,
+		Hiding:        pc.Hiding,
+		Binding:       pc.Binding,
+	}
+}
@@ -0,0 +1,97 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/tmhash"
+	"github.com/klyed/tendermint/libs/log"
+	tmrand "github.com/klyed/tendermint/libs/rand"
+	"github.com/klyed/tendermint/privval"
+	tmgrpc "github.com/klyed/tendermint/privval/grpc"
+	privvalproto "github.com/klyed/tendermint/proto/tendermint/privval"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/types"
+)
+
+func shareDialer(signer privval.ThresholdShareSigner, logger log.Logger) (*grpc.Server, func(context.Context, string) (net.Conn, error)) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer()
+
+	s := tmgrpc.NewShareSignerServer(signer, logger)
+
+	privvalproto.RegisterThresholdShareSignerAPIServer(server, s)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			panic(err)
+		}
+	}()
+
+	return server, func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+}
+
+func TestShareSignerClient_ThresholdSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "share_signer_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	group, shares, err := privval.SplitThresholdKey(ed25519.GenPrivKey(), 2, 3)
+	require.NoError(t, err)
+
+	logger := log.TestingLogger()
+	clients := make([]privval.ThresholdShareSigner, len(shares))
+	for i, share := range shares {
+		signer, err := privval.NewLocalShareSigner(share, filepath.Join(dir, fmt.Sprintf("share_%d_state.json", share.Index)))
+		require.NoError(t, err)
+		srv, dialer := shareDialer(signer, logger)
+		defer srv.Stop()
+
+		conn, err := grpc.DialContext(context.Background(), "", grpc.WithInsecure(), grpc.WithContextDialer(dialer))
+		require.NoError(t, err)
+		client := tmgrpc.NewShareSignerClient(conn, logger)
+		defer client.Close()
+		clients[i] = client
+	}
+
+	ts, err := privval.NewThresholdSigner(group, clients)
+	require.NoError(t, err)
+
+	hash := tmrand.Bytes(tmhash.Size)
+	vote := &types.Vote{
+		Type:      tmproto.PrecommitType,
+		Height:    1,
+		Round:     2,
+		BlockID:   types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp: time.Now(),
+	}
+	pbVote := vote.ToProto()
+	require.NoError(t, ts.SignVote(context.Background(), chainID, pbVote))
+	assert.True(t, group.PubKey.VerifySignature(types.VoteSignBytes(chainID, pbVote), pbVote.Signature))
+
+	// a new ThresholdSigner, as after a restart, can't get a conflicting vote
+	// signed by the remote signers
+	ts, err = privval.NewThresholdSigner(group, clients)
+	require.NoError(t, err)
+	otherHash := tmrand.Bytes(tmhash.Size)
+	vote.BlockID = types.BlockID{Hash: otherHash, PartSetHeader: types.PartSetHeader{Hash: otherHash, Total: 2}}
+	err = ts.SignVote(context.Background(), chainID, vote.ToProto())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicting data")
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	context "context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/klyed/tendermint/libs/log"
+	"github.com/klyed/tendermint/privval"
+	privvalproto "github.com/klyed/tendermint/proto/tendermint/privval"
+)
+
+// ShareSignerServer implements ThresholdShareSignerAPIServer (generated via
+// protobuf services). It serves the signing rounds of a ThresholdSigner with a
+// share of the threshold key.
+type ShareSignerServer struct {
+	logger log.Logger
+	signer privval.ThresholdShareSigner
+}
+
+func NewShareSignerServer(signer privval.ThresholdShareSigner, log log.Logger) *ShareSignerServer {
+	return &ShareSignerServer{
+		logger: log,
+		signer: signer,
+	}
+}
+
+var _ privvalproto.ThresholdShareSignerAPIServer = (*ShareSignerServer)(nil)
+
+// Commit receives a request to commit to nonces for a signing session
+// returns the commitment on success and error on failure
+func (ss *ShareSignerServer) Commit(ctx context.Context, req *privvalproto.ThresholdSignRequest) (
+	*privvalproto.ThresholdCommitment, error) {
+
+	commitment, err := ss.signer.Commit(ctx, thresholdSignRequestFromProto(req))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error committing: %v", err)
+	}
+
+	ss.logger.Info("ShareSignerServer: Commit Success", "index", commitment.Index)
+
+	pc := thresholdCommitmentToProto(commitment)
+	return &pc, nil
+}
+
+// SignShare receives a request to sign in the session committed to
+// returns the signature share on success and error on failure
+func (ss *ShareSignerServer) SignShare(ctx context.Context, req *privvalproto.ThresholdSignShareRequest) (
+	*privvalproto.ThresholdSignatureShare, error) {
+
+	commitments := make([]privval.ThresholdCommitment, len(req.Commitments))
+	for i, pc := range req.Commitments {
+		commitments[i] = thresholdCommitmentFromProto(pc)
+	}
+
+	share, err := ss.signer.SignShare(ctx, thresholdSignRequestFromProto(&req.Request), commitments)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing share: %v", err)
+	}
+
+	ss.logger.Info("ShareSignerServer: SignShare Success", "index", share.Index)
+
+	return &privvalproto.ThresholdSignatureShare{Index: share.Index, Share: share.Share}, nil
+}
@@ -86,12 +86,38 @@ func GenerateTLS(certPath, keyPath, ca string, log log.Logger) grpc.DialOption {
 	return grpc.WithTransportCredentials(transportCreds)
 }
 
-// DialRemoteSigner is  a generalized function to dial the gRPC server.
+// DialRemoteSigner is  a generalized function to dial the gRPC server at the
+// given address, prefixed with grpc://.
 func DialRemoteSigner(
 	config *cfg.Config,
+	addr string,
 	chainID string,
 	logger log.Logger,
 ) (*SignerClient, error) {
+	conn, err := dialRemote(config, addr, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignerClient(conn, chainID, logger)
+}
+
+// DialRemoteShareSigner dials the gRPC server of a share signer at the given
+// address, prefixed with grpc://.
+func DialRemoteShareSigner(
+	config *cfg.Config,
+	addr string,
+	logger log.Logger,
+) (*ShareSignerClient, error) {
+	conn, err := dialRemote(config, addr, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewShareSignerClient(conn, logger), nil
+}
+
+func dialRemote(config *cfg.Config, addr string, logger log.Logger) (*grpc.ClientConn, error) {
 	var transportSecurity grpc.DialOption
 	if config.BaseConfig.ArePrivValidatorClientSecurityOptionsPresent() {
 		transportSecurity = GenerateTLS(config.PrivValidatorClientCertificateFile(),
@@ -110,11 +136,12 @@ func DialRemoteSigner(
 	dialOptions = append(dialOptions, transportSecurity)
 
 	ctx := context.Background()
-	_, address := tmnet.ProtocolAndAddress(config.PrivValidatorListenAddr)
+	_, address := tmnet.ProtocolAndAddress(addr)
 	conn, err := grpc.DialContext(ctx, address, dialOptions...)
 	if err != nil {
 		logger.Error("unable to connect to server", "target", address, "err", err)
+		return nil, err
 	}
 
-	return NewSignerClient(conn, chainID, logger)
+	return conn, nil
 }
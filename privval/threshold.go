@@ -0,0 +1,354 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"filippo.io/edwards25519"
+
+	"github.com/klyed/tendermint/crypto"
+	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/tmhash"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/types"
+)
+
+// ThresholdSignRequest asks a ThresholdShareSigner to take part in signing a
+// vote or a proposal. Each signer computes the sign bytes itself, so that it
+// only signs what it checked against its own last sign state.
+type ThresholdSignRequest struct {
+	// SessionID identifies the signing session across Commit and SignShare.
+	SessionID []byte
+	ChainID   string
+	// Only one of Vote and Proposal is set.
+	Vote     *tmproto.Vote
+	Proposal *tmproto.Proposal
+}
+
+func (req ThresholdSignRequest) hrs() (height int64, round int32, step int8, err error) {
+	switch {
+	case req.Vote != nil && req.Proposal == nil:
+		if req.Vote.Type != tmproto.PrevoteType && req.Vote.Type != tmproto.PrecommitType {
+			return 0, 0, 0, fmt.Errorf("unknown vote type: %v", req.Vote.Type)
+		}
+		return req.Vote.Height, req.Vote.Round, voteToStep(req.Vote), nil
+	case req.Proposal != nil && req.Vote == nil:
+		return req.Proposal.Height, req.Proposal.Round, stepPropose, nil
+	default:
+		return 0, 0, 0, errors.New("request must have either a vote or a proposal")
+	}
+}
+
+func (req ThresholdSignRequest) signBytes() []byte {
+	if req.Vote != nil {
+		return types.VoteSignBytes(req.ChainID, req.Vote)
+	}
+	return types.ProposalSignBytes(req.ChainID, req.Proposal)
+}
+
+// ThresholdCommitment is a signer's commitment to the nonces it will use to
+// sign in a session.
+type ThresholdCommitment struct {
+	Index         uint32
+	SignBytesHash []byte
+	Hiding        []byte
+	Binding       []byte
+}
+
+// ThresholdSignatureShare is a signer's share of the signature.
+type ThresholdSignatureShare struct {
+	Index uint32
+	Share []byte
+}
+
+// ThresholdShareSigner holds a share of a threshold key. Signing takes two
+// rounds: every signer commits to nonces for the request, then the signers
+// whose commitments were selected sign with them.
+type ThresholdShareSigner interface {
+	// Commit checks the request against what the signer already signed and
+	// returns commitments to fresh nonces.
+	Commit(ctx context.Context, req ThresholdSignRequest) (ThresholdCommitment, error)
+	// SignShare signs the request in the same session as the previous Commit,
+	// given the commitments of all the participants sorted by index.
+	SignShare(ctx context.Context, req ThresholdSignRequest, commitments []ThresholdCommitment) (ThresholdSignatureShare, error)
+}
+
+//-------------------------------------------------------------------------------
+
+// ThresholdSigner implements PrivValidator with an ed25519 key split across
+// several ThresholdShareSigners. It only gets a signature when a threshold of
+// them agree on the height, round, step and sign bytes of a vote or proposal,
+// and checks every signature share so that a faulty signer is identified.
+//
+// The signature is a plain ed25519 signature for the group's public key, so
+// the rest of the network can't tell a threshold validator apart.
+type ThresholdSigner struct {
+	pubKey    ed25519.PubKey
+	threshold int
+	shareKeys []*edwards25519.Point
+	signers   []ThresholdShareSigner
+
+	mtx sync.Mutex
+	// lastSignState is kept in memory to reuse the last signature: double
+	// signing is prevented by the signers.
+	lastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*ThresholdSigner)(nil)
+
+// NewThresholdSigner returns a ThresholdSigner for the given group, using the
+// given signers, which must be at least as many as the group's threshold.
+func NewThresholdSigner(group ThresholdGroup, signers []ThresholdShareSigner) (*ThresholdSigner, error) {
+	pubKey, ok := group.PubKey.(ed25519.PubKey)
+	if !ok {
+		return nil, fmt.Errorf("key type: %s is not supported", group.PubKey.Type())
+	}
+	if err := checkThreshold(int(group.Threshold), len(group.ShareKeys)); err != nil {
+		return nil, err
+	}
+	if len(signers) < int(group.Threshold) {
+		return nil, fmt.Errorf("got %d signers, need at least %d", len(signers), group.Threshold)
+	}
+	shareKeys := make([]*edwards25519.Point, len(group.ShareKeys))
+	for i, bz := range group.ShareKeys {
+		p, err := new(edwards25519.Point).SetBytes(bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of share %d: %w", i+1, err)
+		}
+		shareKeys[i] = p
+	}
+	return &ThresholdSigner{
+		pubKey:        pubKey,
+		threshold:     int(group.Threshold),
+		shareKeys:     shareKeys,
+		signers:       signers,
+		lastSignState: FilePVLastSignState{Step: stepNone},
+	}, nil
+}
+
+// GetPubKey returns the public key of the group.
+// Implements PrivValidator.
+func (ts *ThresholdSigner) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	return ts.pubKey, nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (ts *ThresholdSigner) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	signBytes := types.VoteSignBytes(chainID, vote)
+
+	lss := ts.lastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
+	}
+	// Same as FilePV: reuse the last signature if we crashed before the vote hit the WAL.
+	if sameHRS {
+		if bytes.Equal(signBytes, lss.SignBytes) {
+			vote.Signature = lss.Signature
+			return nil
+		} else if timestamp, ok := checkVotesOnlyDifferByTimestamp(lss.SignBytes, signBytes); ok {
+			vote.Timestamp = timestamp
+			vote.Signature = lss.Signature
+			return nil
+		}
+		return errors.New("error signing vote: conflicting data")
+	}
+
+	sig, err := ts.sign(ctx, ThresholdSignRequest{ChainID: chainID, Vote: vote}, signBytes)
+	if err != nil {
+		return fmt.Errorf("error signing vote: %w", err)
+	}
+	ts.saveSigned(height, round, step, signBytes, sig)
+	vote.Signature = sig
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (ts *ThresholdSigner) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	height, round, step := proposal.Height, proposal.Round, stepPropose
+	signBytes := types.ProposalSignBytes(chainID, proposal)
+
+	lss := ts.lastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+	if sameHRS {
+		if bytes.Equal(signBytes, lss.SignBytes) {
+			proposal.Signature = lss.Signature
+			return nil
+		} else if timestamp, ok := checkProposalsOnlyDifferByTimestamp(lss.SignBytes, signBytes); ok {
+			proposal.Timestamp = timestamp
+			proposal.Signature = lss.Signature
+			return nil
+		}
+		return errors.New("error signing proposal: conflicting data")
+	}
+
+	sig, err := ts.sign(ctx, ThresholdSignRequest{ChainID: chainID, Proposal: proposal}, signBytes)
+	if err != nil {
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+	ts.saveSigned(height, round, step, signBytes, sig)
+	proposal.Signature = sig
+	return nil
+}
+
+func (ts *ThresholdSigner) saveSigned(height int64, round int32, step int8, signBytes []byte, sig []byte) {
+	ts.lastSignState.Height = height
+	ts.lastSignState.Round = round
+	ts.lastSignState.Step = step
+	ts.lastSignState.Signature = sig
+	ts.lastSignState.SignBytes = signBytes
+}
+
+// sign runs both signing rounds: it collects commitments from the first
+// threshold signers agreeing on the sign bytes, has them sign and aggregates
+// their shares into an ed25519 signature.
+func (ts *ThresholdSigner) sign(ctx context.Context, req ThresholdSignRequest, signBytes []byte) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req.SessionID = crypto.CRandBytes(16)
+	participants, commitments, err := ts.commit(ctx, req, tmhash.Sum(signBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseCommitments(commitments, uint32(ts.threshold), tmhash.Sum(signBytes))
+	if err != nil {
+		return nil, err
+	}
+	rhos := bindingFactors(signBytes, commitments)
+	r := groupCommitment(parsed, rhos)
+	c := challenge(r, ts.pubKey, signBytes)
+
+	type result struct {
+		share ThresholdSignatureShare
+		err   error
+	}
+	results := make([]result, len(participants))
+	var wg sync.WaitGroup
+	for i, signer := range participants {
+		wg.Add(1)
+		go func(i int, signer ThresholdShareSigner) {
+			defer wg.Done()
+			share, err := signer.SignShare(ctx, req, commitments)
+			results[i] = result{share, err}
+		}(i, signer)
+	}
+	wg.Wait()
+
+	z := edwards25519.NewScalar()
+	for i, res := range results {
+		index := parsed[i].index
+		if res.err != nil {
+			return nil, fmt.Errorf("signer %d failed to sign: %w", index, res.err)
+		}
+		if res.share.Index != index {
+			return nil, fmt.Errorf("signer %d returned the share of signer %d", index, res.share.Index)
+		}
+		zi, err := new(edwards25519.Scalar).SetCanonicalBytes(res.share.Share)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature share of signer %d: %w", index, err)
+		}
+		// z_i*B must be equal to D_i + rho_i*E_i + c*lambda_i*Y_i.
+		cl := edwards25519.NewScalar().Multiply(c, lagrangeCoefficient(index, parsed))
+		expected := edwards25519.NewIdentityPoint().ScalarMult(rhos[index], parsed[i].binding)
+		expected.Add(expected, parsed[i].hiding)
+		expected.Add(expected, edwards25519.NewIdentityPoint().ScalarMult(cl, ts.shareKeys[index-1]))
+		if new(edwards25519.Point).ScalarBaseMult(zi).Equal(expected) != 1 {
+			return nil, fmt.Errorf("invalid signature share of signer %d", index)
+		}
+		z.Add(z, zi)
+	}
+
+	sig := append(r.Bytes(), z.Bytes()...)
+	if !ts.pubKey.VerifySignature(signBytes, sig) {
+		return nil, errors.New("aggregated signature is invalid")
+	}
+	return sig, nil
+}
+
+// commit asks every signer to commit and returns the first threshold signers
+// whose commitments are for the given sign bytes, sorted by index, along with
+// their commitments.
+func (ts *ThresholdSigner) commit(
+	ctx context.Context,
+	req ThresholdSignRequest,
+	signBytesHash []byte,
+) ([]ThresholdShareSigner, []ThresholdCommitment, error) {
+	type result struct {
+		signer     ThresholdShareSigner
+		commitment ThresholdCommitment
+		err        error
+	}
+	resultCh := make(chan result, len(ts.signers))
+	for _, signer := range ts.signers {
+		go func(signer ThresholdShareSigner) {
+			commitment, err := signer.Commit(ctx, req)
+			resultCh <- result{signer, commitment, err}
+		}(signer)
+	}
+
+	var (
+		selected = make(map[uint32]result, ts.threshold)
+		errs     []string
+	)
+	for received := 0; received < len(ts.signers) && len(selected) < ts.threshold; received++ {
+		var res result
+		select {
+		case res = <-resultCh:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+
+		index := res.commitment.Index
+		switch {
+		case res.err != nil:
+			errs = append(errs, res.err.Error())
+		case index == 0 || int(index) > len(ts.shareKeys):
+			errs = append(errs, fmt.Sprintf("unknown signer %d", index))
+		case !bytes.Equal(res.commitment.SignBytesHash, signBytesHash):
+			errs = append(errs, fmt.Sprintf("signer %d committed to different sign bytes", index))
+		default:
+			if _, ok := selected[index]; ok {
+				errs = append(errs, fmt.Sprintf("several signers with index %d", index))
+				continue
+			}
+			selected[index] = res
+		}
+	}
+	if len(selected) < ts.threshold {
+		return nil, nil, fmt.Errorf("only %d of %d signers agreed to sign, need %d: %s",
+			len(selected), len(ts.signers), ts.threshold, strings.Join(errs, "; "))
+	}
+
+	indexes := make([]uint32, 0, len(selected))
+	for index := range selected {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	signers := make([]ThresholdShareSigner, len(indexes))
+	commitments := make([]ThresholdCommitment, len(indexes))
+	for i, index := range indexes {
+		signers[i] = selected[index].signer
+		commitments[i] = selected[index].commitment
+	}
+	return signers, commitments, nil
+}
@@ -0,0 +1,493 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"filippo.io/edwards25519"
+
+	"github.com/klyed/tendermint/crypto"
+	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/tmhash"
+	tmjson "github.com/klyed/tendermint/libs/json"
+	"github.com/klyed/tendermint/libs/tempfile"
+)
+
+// ThresholdKeyShare stores one signer's share of an ed25519 key split with
+// SplitThresholdKey. Any Threshold shares can sign together, while fewer shares
+// reveal nothing about the key.
+type ThresholdKeyShare struct {
+	Index     uint32        `json:"index"`
+	Threshold uint32        `json:"threshold"`
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Secret    []byte        `json:"secret"`
+
+	filePath string
+}
+
+// Save persists the ThresholdKeyShare to its filePath.
+func (share ThresholdKeyShare) Save() {
+	if share.filePath == "" {
+		panic("cannot save ThresholdKeyShare: filePath not set")
+	}
+	jsonBytes, err := tmjson.MarshalIndent(share, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	err = tempfile.WriteFileAtomic(share.filePath, jsonBytes, 0600)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// SaveAs sets the filePath of the ThresholdKeyShare and persists it.
+func (share *ThresholdKeyShare) SaveAs(filePath string) {
+	share.filePath = filePath
+	share.Save()
+}
+
+// LoadThresholdKeyShare loads a ThresholdKeyShare from the given filePath.
+func LoadThresholdKeyShare(filePath string) (ThresholdKeyShare, error) {
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return ThresholdKeyShare{}, err
+	}
+	share := ThresholdKeyShare{}
+	if err := tmjson.Unmarshal(jsonBytes, &share); err != nil {
+		return ThresholdKeyShare{}, fmt.Errorf("error reading threshold key share from %v: %w", filePath, err)
+	}
+	if _, err := new(edwards25519.Scalar).SetCanonicalBytes(share.Secret); err != nil {
+		return ThresholdKeyShare{}, fmt.Errorf("invalid threshold key share in %v: %w", filePath, err)
+	}
+	share.filePath = filePath
+	return share, nil
+}
+
+// ThresholdGroup is the public description of a key split with
+// SplitThresholdKey, used by the ThresholdSigner to check the signature shares.
+type ThresholdGroup struct {
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Threshold uint32        `json:"threshold"`
+	// ShareKeys[i-1] is the public key of the share with index i.
+	ShareKeys [][]byte `json:"share_keys"`
+
+	filePath string
+}
+
+// Save persists the ThresholdGroup to its filePath.
+func (group ThresholdGroup) Save() {
+	if group.filePath == "" {
+		panic("cannot save ThresholdGroup: filePath not set")
+	}
+	jsonBytes, err := tmjson.MarshalIndent(group, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	err = tempfile.WriteFileAtomic(group.filePath, jsonBytes, 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// SaveAs sets the filePath of the ThresholdGroup and persists it.
+func (group *ThresholdGroup) SaveAs(filePath string) {
+	group.filePath = filePath
+	group.Save()
+}
+
+// LoadThresholdGroup loads a ThresholdGroup from the given filePath.
+func LoadThresholdGroup(filePath string) (ThresholdGroup, error) {
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return ThresholdGroup{}, err
+	}
+	group := ThresholdGroup{}
+	if err := tmjson.Unmarshal(jsonBytes, &group); err != nil {
+		return ThresholdGroup{}, fmt.Errorf("error reading threshold group from %v: %w", filePath, err)
+	}
+	group.filePath = filePath
+	return group, nil
+}
+
+// SplitThresholdKey splits an ed25519 private key into n shares, any threshold
+// of which can sign for the key's public key. The key should be discarded once
+// the shares are distributed to their signers.
+func SplitThresholdKey(privKey crypto.PrivKey, threshold, n int) (ThresholdGroup, []ThresholdKeyShare, error) {
+	edKey, ok := privKey.(ed25519.PrivKey)
+	if !ok || len(edKey) != ed25519.PrivateKeySize {
+		return ThresholdGroup{}, nil, fmt.Errorf("key type: %s is not supported", privKey.Type())
+	}
+	if err := checkThreshold(threshold, n); err != nil {
+		return ThresholdGroup{}, nil, err
+	}
+
+	// Shamir secret sharing of the ed25519 secret scalar: the shares are the
+	// points 1..n of a random polynomial of degree threshold-1 whose constant
+	// term is the secret scalar.
+	h := sha512.Sum512(edKey[:ed25519.SeedSize])
+	coeffs := make([]*edwards25519.Scalar, threshold)
+	coeffs[0] = edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	for i := 1; i < threshold; i++ {
+		coeffs[i] = randomScalar()
+	}
+
+	pubKey := edKey.PubKey()
+	group := ThresholdGroup{
+		PubKey:    pubKey,
+		Threshold: uint32(threshold),
+		ShareKeys: make([][]byte, n),
+	}
+	shares := make([]ThresholdKeyShare, n)
+	for i := 1; i <= n; i++ {
+		x := scalarFromIndex(uint32(i))
+		secret := edwards25519.NewScalar()
+		for k := threshold - 1; k >= 0; k-- {
+			secret.MultiplyAdd(secret, x, coeffs[k])
+		}
+		shares[i-1] = ThresholdKeyShare{
+			Index:     uint32(i),
+			Threshold: uint32(threshold),
+			PubKey:    pubKey,
+			Secret:    secret.Bytes(),
+		}
+		group.ShareKeys[i-1] = new(edwards25519.Point).ScalarBaseMult(secret).Bytes()
+	}
+	return group, shares, nil
+}
+
+// checkThreshold checks that any two sets of threshold signers among n have a
+// signer in common. The signers persist what they commit to, so that signer
+// refuses to take part in a conflicting signature.
+func checkThreshold(threshold, n int) error {
+	if threshold < 1 || threshold > n {
+		return fmt.Errorf("invalid threshold %d for %d shares", threshold, n)
+	}
+	if 2*threshold <= n {
+		return fmt.Errorf("threshold %d is too low for %d shares: two sets of signers could sign conflicting data, "+
+			"need more than %d", threshold, n, n/2)
+	}
+	return nil
+}
+
+//-------------------------------------------------------------------------------
+
+// LocalShareSigner implements ThresholdShareSigner with a key share held in
+// memory. Like FilePV, it persists the height, round and step it last
+// committed to, to prevent double signing. Since any two sets of threshold
+// signers overlap, a ThresholdSigner can only get a signature for conflicting
+// data if one of its signers is compromised or lost its state.
+type LocalShareSigner struct {
+	Key           ThresholdKeyShare
+	LastSignState FilePVLastSignState
+
+	mtx     sync.Mutex
+	secret  *edwards25519.Scalar
+	pending *shareSession
+}
+
+// shareSession holds the nonces a signer committed to, until they are used to
+// sign or replaced by the next commitment. Nonces are never used twice.
+type shareSession struct {
+	id        []byte
+	signBytes []byte
+	hiding    *edwards25519.Scalar
+	binding   *edwards25519.Scalar
+}
+
+var _ ThresholdShareSigner = (*LocalShareSigner)(nil)
+
+// NewLocalShareSigner returns a LocalShareSigner for the given key share,
+// persisting its last sign state to stateFilePath.
+func NewLocalShareSigner(share ThresholdKeyShare, stateFilePath string) (*LocalShareSigner, error) {
+	return newLocalShareSigner(share, FilePVLastSignState{Step: stepNone, filePath: stateFilePath})
+}
+
+// LoadLocalShareSigner loads a LocalShareSigner from the given key share and
+// state files.
+func LoadLocalShareSigner(keyFilePath, stateFilePath string) (*LocalShareSigner, error) {
+	share, err := LoadThresholdKeyShare(keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+	stateJSONBytes, err := ioutil.ReadFile(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	state := FilePVLastSignState{}
+	if err := tmjson.Unmarshal(stateJSONBytes, &state); err != nil {
+		return nil, fmt.Errorf("error reading share signer state from %v: %w", stateFilePath, err)
+	}
+	state.filePath = stateFilePath
+	return newLocalShareSigner(share, state)
+}
+
+func newLocalShareSigner(share ThresholdKeyShare, state FilePVLastSignState) (*LocalShareSigner, error) {
+	secret, err := new(edwards25519.Scalar).SetCanonicalBytes(share.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold key share: %w", err)
+	}
+	if share.Index == 0 || share.Threshold == 0 {
+		return nil, errors.New("invalid threshold key share: zero index or threshold")
+	}
+	return &LocalShareSigner{
+		Key:           share,
+		LastSignState: state,
+		secret:        secret,
+	}, nil
+}
+
+// Commit checks that the request does not conflict with what the signer
+// already signed, persists it as the last sign state and returns commitments
+// to fresh nonces for it.
+func (ls *LocalShareSigner) Commit(ctx context.Context, req ThresholdSignRequest) (ThresholdCommitment, error) {
+	ls.mtx.Lock()
+	defer ls.mtx.Unlock()
+
+	signBytes, err := ls.checkRequest(req)
+	if err != nil {
+		return ThresholdCommitment{}, err
+	}
+
+	// Lock the height, round and step on the sign bytes before returning the
+	// nonces, so that the signer refuses conflicting data even if it's not
+	// selected to sign, or the ThresholdSigner never asks for its share.
+	height, round, step, _ := req.hrs()
+	ls.saveSigned(height, round, step, signBytes, nil)
+
+	session := &shareSession{
+		id:        req.SessionID,
+		signBytes: signBytes,
+		hiding:    randomScalar(),
+		binding:   randomScalar(),
+	}
+	ls.pending = session
+
+	return ThresholdCommitment{
+		Index:         ls.Key.Index,
+		SignBytesHash: tmhash.Sum(signBytes),
+		Hiding:        new(edwards25519.Point).ScalarBaseMult(session.hiding).Bytes(),
+		Binding:       new(edwards25519.Point).ScalarBaseMult(session.binding).Bytes(),
+	}, nil
+}
+
+// SignShare signs the request with the nonces committed to for its session.
+// The signer's last sign state is persisted before the share is returned.
+func (ls *LocalShareSigner) SignShare(
+	ctx context.Context,
+	req ThresholdSignRequest,
+	commitments []ThresholdCommitment,
+) (ThresholdSignatureShare, error) {
+	ls.mtx.Lock()
+	defer ls.mtx.Unlock()
+
+	session := ls.pending
+	if session == nil || !bytes.Equal(session.id, req.SessionID) {
+		return ThresholdSignatureShare{}, errors.New("no commitment for signing session")
+	}
+	// whatever happens next, the nonces must not be used again
+	ls.pending = nil
+
+	signBytes, err := ls.checkRequest(req)
+	if err != nil {
+		return ThresholdSignatureShare{}, err
+	}
+	if !bytes.Equal(signBytes, session.signBytes) {
+		return ThresholdSignatureShare{}, errors.New("sign bytes differ from the committed ones")
+	}
+
+	parsed, err := parseCommitments(commitments, ls.Key.Threshold, tmhash.Sum(signBytes))
+	if err != nil {
+		return ThresholdSignatureShare{}, err
+	}
+	var own *frostCommitment
+	for i := range parsed {
+		if parsed[i].index == ls.Key.Index {
+			own = &parsed[i]
+		}
+	}
+	if own == nil {
+		return ThresholdSignatureShare{}, errors.New("signer is not a participant of the signing session")
+	}
+	if own.hiding.Equal(new(edwards25519.Point).ScalarBaseMult(session.hiding)) != 1 ||
+		own.binding.Equal(new(edwards25519.Point).ScalarBaseMult(session.binding)) != 1 {
+		return ThresholdSignatureShare{}, errors.New("commitment of the signer was altered")
+	}
+
+	rhos := bindingFactors(signBytes, commitments)
+	r := groupCommitment(parsed, rhos)
+	c := challenge(r, ls.Key.PubKey.Bytes(), signBytes)
+	lambda := lagrangeCoefficient(ls.Key.Index, parsed)
+
+	// z = hiding + binding*rho + lambda*secret*c
+	z := edwards25519.NewScalar().Multiply(lambda, ls.secret)
+	z.Multiply(z, c)
+	z.MultiplyAdd(session.binding, rhos[ls.Key.Index], z)
+	z.Add(z, session.hiding)
+
+	height, round, step, _ := req.hrs()
+	ls.saveSigned(height, round, step, signBytes, z.Bytes())
+
+	return ThresholdSignatureShare{Index: ls.Key.Index, Share: z.Bytes()}, nil
+}
+
+// checkRequest returns the sign bytes of the request if they don't conflict
+// with the last sign state. Unlike FilePV, the signer can't reuse the last
+// signature, so it signs again for the same height, round and step as long as
+// the sign bytes are the same or only differ by their timestamp.
+func (ls *LocalShareSigner) checkRequest(req ThresholdSignRequest) ([]byte, error) {
+	height, round, step, err := req.hrs()
+	if err != nil {
+		return nil, err
+	}
+	signBytes := req.signBytes()
+
+	lss := ls.LastSignState
+	if lss.SignBytes != nil && lss.Signature == nil {
+		// locked by Commit, but no share was signed yet
+		lss.Signature = []byte{}
+	}
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return nil, err
+	}
+	if sameHRS && !bytes.Equal(signBytes, lss.SignBytes) {
+		var onlyTimestamp bool
+		if req.Vote != nil {
+			_, onlyTimestamp = checkVotesOnlyDifferByTimestamp(lss.SignBytes, signBytes)
+		} else {
+			_, onlyTimestamp = checkProposalsOnlyDifferByTimestamp(lss.SignBytes, signBytes)
+		}
+		if !onlyTimestamp {
+			return nil, errors.New("conflicting data")
+		}
+	}
+	return signBytes, nil
+}
+
+// Persist height/round/step, sign bytes and signature share.
+func (ls *LocalShareSigner) saveSigned(height int64, round int32, step int8, signBytes []byte, share []byte) {
+	ls.LastSignState.Height = height
+	ls.LastSignState.Round = round
+	ls.LastSignState.Step = step
+	ls.LastSignState.Signature = share
+	ls.LastSignState.SignBytes = signBytes
+	ls.LastSignState.Save()
+}
+
+//-------------------------------------------------------------------------------
+// FROST (RFC 9591) over edwards25519, producing plain ed25519 signatures.
+
+type frostCommitment struct {
+	index   uint32
+	hiding  *edwards25519.Point
+	binding *edwards25519.Point
+}
+
+// parseCommitments checks that there are at least threshold commitments,
+// sorted by index, all for the given sign bytes, and decodes their points.
+func parseCommitments(commitments []ThresholdCommitment, threshold uint32, signBytesHash []byte) ([]frostCommitment, error) {
+	if len(commitments) < int(threshold) {
+		return nil, fmt.Errorf("got %d commitments, need %d", len(commitments), threshold)
+	}
+	identity := edwards25519.NewIdentityPoint()
+	parsed := make([]frostCommitment, len(commitments))
+	for i, c := range commitments {
+		if c.Index == 0 || (i > 0 && c.Index <= commitments[i-1].Index) {
+			return nil, errors.New("commitments are not sorted by index")
+		}
+		if !bytes.Equal(c.SignBytesHash, signBytesHash) {
+			return nil, fmt.Errorf("signer %d committed to different sign bytes", c.Index)
+		}
+		hiding, err := new(edwards25519.Point).SetBytes(c.Hiding)
+		if err != nil || hiding.Equal(identity) == 1 {
+			return nil, fmt.Errorf("invalid hiding commitment of signer %d", c.Index)
+		}
+		binding, err := new(edwards25519.Point).SetBytes(c.Binding)
+		if err != nil || binding.Equal(identity) == 1 {
+			return nil, fmt.Errorf("invalid binding commitment of signer %d", c.Index)
+		}
+		parsed[i] = frostCommitment{index: c.Index, hiding: hiding, binding: binding}
+	}
+	return parsed, nil
+}
+
+// bindingFactors binds each signer's nonces to the message and to the whole
+// set of commitments, so that commitments can't be mixed across sessions.
+func bindingFactors(signBytes []byte, commitments []ThresholdCommitment) map[uint32]*edwards25519.Scalar {
+	msgHash := sha512.Sum512(signBytes)
+	enc := sha512.New()
+	for _, c := range commitments {
+		_ = binary.Write(enc, binary.BigEndian, c.Index)
+		enc.Write(c.Hiding)
+		enc.Write(c.Binding)
+	}
+	encHash := enc.Sum(nil)
+
+	rhos := make(map[uint32]*edwards25519.Scalar, len(commitments))
+	for _, c := range commitments {
+		h := sha512.New()
+		h.Write([]byte("tendermint/threshold-ed25519/rho"))
+		_ = binary.Write(h, binary.BigEndian, c.Index)
+		h.Write(msgHash[:])
+		h.Write(encHash)
+		rhos[c.Index] = edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	}
+	return rhos
+}
+
+// groupCommitment returns R, the sum of the signers' nonce commitments.
+// NOTE: ScalarMult accumulates into its receiver, which must be the identity.
+func groupCommitment(commitments []frostCommitment, rhos map[uint32]*edwards25519.Scalar) *edwards25519.Point {
+	r := edwards25519.NewIdentityPoint()
+	for _, c := range commitments {
+		r.Add(r, c.hiding)
+		r.Add(r, edwards25519.NewIdentityPoint().ScalarMult(rhos[c.index], c.binding))
+	}
+	return r
+}
+
+// challenge is the ed25519 challenge SHA-512(R || A || M).
+func challenge(r *edwards25519.Point, pubKey, msg []byte) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write(r.Bytes())
+	h.Write(pubKey)
+	h.Write(msg)
+	return edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+}
+
+// lagrangeCoefficient returns the coefficient of the share with the given
+// index when interpolating the secret at 0 from the participants' shares.
+func lagrangeCoefficient(index uint32, participants []frostCommitment) *edwards25519.Scalar {
+	num := scalarFromIndex(1)
+	den := scalarFromIndex(1)
+	x := scalarFromIndex(index)
+	for _, p := range participants {
+		if p.index == index {
+			continue
+		}
+		xj := scalarFromIndex(p.index)
+		num.Multiply(num, xj)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(xj, x))
+	}
+	return num.Multiply(num, edwards25519.NewScalar().Invert(den))
+}
+
+func scalarFromIndex(i uint32) *edwards25519.Scalar {
+	var buf [32]byte
+	binary.LittleEndian.PutUint32(buf[:], i)
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(buf[:])
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func randomScalar() *edwards25519.Scalar {
+	return edwards25519.NewScalar().SetUniformBytes(crypto.CRandBytes(64))
+}
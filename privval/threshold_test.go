@@ -0,0 +1,259 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/tmhash"
+	tmjson "github.com/klyed/tendermint/libs/json"
+	tmrand "github.com/klyed/tendermint/libs/rand"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/types"
+)
+
+func newLocalShareSigners(t *testing.T, threshold, n int) (ed25519.PrivKey, ThresholdGroup, []*LocalShareSigner) {
+	privKey := ed25519.GenPrivKey()
+	group, shares, err := SplitThresholdKey(privKey, threshold, n)
+	require.NoError(t, err)
+
+	signers := make([]*LocalShareSigner, n)
+	for i, share := range shares {
+		tempStateFile, err := ioutil.TempFile("", "threshold_signer_state_")
+		require.NoError(t, err)
+		signers[i], err = NewLocalShareSigner(share, tempStateFile.Name())
+		require.NoError(t, err)
+	}
+	return privKey, group, signers
+}
+
+func newThresholdSigner(t *testing.T, group ThresholdGroup, signers ...ThresholdShareSigner) *ThresholdSigner {
+	ts, err := NewThresholdSigner(group, signers)
+	require.NoError(t, err)
+	return ts
+}
+
+func shareSigners(signers []*LocalShareSigner) []ThresholdShareSigner {
+	res := make([]ThresholdShareSigner, len(signers))
+	for i, s := range signers {
+		res[i] = s
+	}
+	return res
+}
+
+func randBlockID() types.BlockID {
+	randbytes := tmrand.Bytes(tmhash.Size)
+	return types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+}
+
+// unavailableShareSigner fails to commit, like a signer that is down.
+type unavailableShareSigner struct{}
+
+func (unavailableShareSigner) Commit(context.Context, ThresholdSignRequest) (ThresholdCommitment, error) {
+	return ThresholdCommitment{}, errors.New("unavailable")
+}
+
+func (unavailableShareSigner) SignShare(
+	context.Context, ThresholdSignRequest, []ThresholdCommitment) (ThresholdSignatureShare, error) {
+	return ThresholdSignatureShare{}, errors.New("unavailable")
+}
+
+// corruptShareSigner returns invalid signature shares.
+type corruptShareSigner struct {
+	*LocalShareSigner
+}
+
+func (s corruptShareSigner) SignShare(
+	ctx context.Context, req ThresholdSignRequest, commitments []ThresholdCommitment) (ThresholdSignatureShare, error) {
+	share, err := s.LocalShareSigner.SignShare(ctx, req, commitments)
+	share.Share = randomScalar().Bytes()
+	return share, err
+}
+
+func TestSplitThresholdKey(t *testing.T) {
+	_, _, err := SplitThresholdKey(ed25519.GenPrivKey(), 4, 3)
+	assert.Error(t, err)
+	_, _, err = SplitThresholdKey(ed25519.GenPrivKey(), 0, 3)
+	assert.Error(t, err)
+	// two disjoint sets of 2 signers among 4 could sign conflicting votes
+	_, _, err = SplitThresholdKey(ed25519.GenPrivKey(), 2, 4)
+	assert.Error(t, err)
+
+	privKey, group, signers := newLocalShareSigners(t, 2, 3)
+	assert.Equal(t, privKey.PubKey(), group.PubKey)
+	assert.Len(t, group.ShareKeys, 3)
+
+	// a share and the state of its signer can be saved and loaded
+	tempKeyFile, err := ioutil.TempFile("", "threshold_key_share_")
+	require.NoError(t, err)
+	signers[0].Key.SaveAs(tempKeyFile.Name())
+	signers[0].saveSigned(10, 1, stepPrevote, []byte("signbytes"), []byte("share"))
+
+	loaded, err := LoadLocalShareSigner(tempKeyFile.Name(), signers[0].LastSignState.filePath)
+	require.NoError(t, err)
+	assert.Equal(t, signers[0].Key, loaded.Key)
+	assert.Equal(t, signers[0].LastSignState, loaded.LastSignState)
+
+	// and so can the group
+	tempGroupFile, err := ioutil.TempFile("", "threshold_group_")
+	require.NoError(t, err)
+	group.SaveAs(tempGroupFile.Name())
+	loadedGroup, err := LoadThresholdGroup(tempGroupFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, group, loadedGroup)
+}
+
+func TestThresholdSignerSignVote(t *testing.T) {
+	privKey, group, signers := newLocalShareSigners(t, 2, 3)
+	ts := newThresholdSigner(t, group, shareSigners(signers)...)
+
+	pubKey, err := ts.GetPubKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), pubKey)
+
+	height, round := int64(10), int32(1)
+	block1, block2 := randBlockID(), randBlockID()
+
+	vote := newVote(pubKey.Address(), 0, height, round, tmproto.PrevoteType, block1).ToProto()
+	require.NoError(t, ts.SignVote(context.Background(), "mychainid", vote))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes("mychainid", vote), vote.Signature))
+
+	// try to sign the same vote again; should be fine
+	sig := vote.Signature
+	require.NoError(t, ts.SignVote(context.Background(), "mychainid", vote))
+	assert.Equal(t, sig, vote.Signature)
+
+	// now try some bad votes
+	cases := []*tmproto.Vote{
+		newVote(pubKey.Address(), 0, height, round-1, tmproto.PrevoteType, block1).ToProto(), // round regression
+		newVote(pubKey.Address(), 0, height-1, round, tmproto.PrevoteType, block1).ToProto(), // height regression
+		newVote(pubKey.Address(), 0, height, round, tmproto.PrevoteType, block2).ToProto(),   // different block
+	}
+	for _, c := range cases {
+		assert.Error(t, ts.SignVote(context.Background(), "mychainid", c), "expected error on signing conflicting vote")
+	}
+
+	// the next step is signed
+	precommit := newVote(pubKey.Address(), 0, height, round, tmproto.PrecommitType, block1).ToProto()
+	require.NoError(t, ts.SignVote(context.Background(), "mychainid", precommit))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes("mychainid", precommit), precommit.Signature))
+}
+
+func TestThresholdSignerSignProposal(t *testing.T) {
+	_, group, signers := newLocalShareSigners(t, 3, 5)
+	ts := newThresholdSigner(t, group, shareSigners(signers)...)
+
+	proposal := newProposal(10, 1, randBlockID()).ToProto()
+	require.NoError(t, ts.SignProposal(context.Background(), "mychainid", proposal))
+	assert.True(t, group.PubKey.VerifySignature(types.ProposalSignBytes("mychainid", proposal), proposal.Signature))
+
+	conflicting := newProposal(10, 1, randBlockID()).ToProto()
+	assert.Error(t, ts.SignProposal(context.Background(), "mychainid", conflicting))
+}
+
+func TestThresholdSignerUnavailableSigners(t *testing.T) {
+	_, group, signers := newLocalShareSigners(t, 2, 3)
+
+	// one signer down: the other two still reach the threshold
+	ts := newThresholdSigner(t, group, signers[0], unavailableShareSigner{}, signers[2])
+	vote := newVote(nil, 0, 10, 0, tmproto.PrevoteType, randBlockID()).ToProto()
+	require.NoError(t, ts.SignVote(context.Background(), "mychainid", vote))
+	assert.True(t, group.PubKey.VerifySignature(types.VoteSignBytes("mychainid", vote), vote.Signature))
+
+	// two signers down: no signature
+	ts = newThresholdSigner(t, group, unavailableShareSigner{}, unavailableShareSigner{}, signers[2])
+	vote = newVote(nil, 0, 11, 0, tmproto.PrevoteType, randBlockID()).ToProto()
+	err := ts.SignVote(context.Background(), "mychainid", vote)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only 1 of 3 signers agreed to sign")
+	assert.Empty(t, vote.Signature)
+}
+
+func TestThresholdSignerDoubleSign(t *testing.T) {
+	_, group, signers := newLocalShareSigners(t, 2, 3)
+
+	vote := newVote(nil, 0, 10, 0, tmproto.PrecommitType, randBlockID()).ToProto()
+	ts := newThresholdSigner(t, group, shareSigners(signers)...)
+	require.NoError(t, ts.SignVote(context.Background(), "mychainid", vote))
+
+	// A restarted node has no memory of the last signature, but the signers
+	// still refuse a conflicting vote, even if one of them lost its state.
+	signers[0].LastSignState = FilePVLastSignState{filePath: signers[0].LastSignState.filePath}
+	conflicting := newVote(nil, 0, 10, 0, tmproto.PrecommitType, randBlockID()).ToProto()
+	ts = newThresholdSigner(t, group, shareSigners(signers)...)
+	err := ts.SignVote(context.Background(), "mychainid", conflicting)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicting data")
+
+	// the same vote with a new timestamp is signed again
+	vote.Timestamp = vote.Timestamp.Add(time.Millisecond)
+	vote.Signature = nil
+	ts = newThresholdSigner(t, group, shareSigners(signers)...)
+	require.NoError(t, ts.SignVote(context.Background(), "mychainid", vote))
+	assert.True(t, group.PubKey.VerifySignature(types.VoteSignBytes("mychainid", vote), vote.Signature))
+}
+
+func TestLocalShareSignerLocksOnCommit(t *testing.T) {
+	_, _, signers := newLocalShareSigners(t, 2, 3)
+
+	vote := newVote(nil, 0, 10, 0, tmproto.PrecommitType, randBlockID()).ToProto()
+	_, err := signers[2].Commit(context.Background(),
+		ThresholdSignRequest{SessionID: []byte("session"), ChainID: "mychainid", Vote: vote})
+	require.NoError(t, err)
+
+	// the signer never signed its share, but refuses a conflicting vote
+	conflicting := newVote(nil, 0, 10, 0, tmproto.PrecommitType, randBlockID()).ToProto()
+	_, err = signers[2].Commit(context.Background(),
+		ThresholdSignRequest{SessionID: []byte("other"), ChainID: "mychainid", Vote: conflicting})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicting data")
+
+	// the lock is persisted
+	stateJSONBytes, err := ioutil.ReadFile(signers[2].LastSignState.filePath)
+	require.NoError(t, err)
+	var state FilePVLastSignState
+	require.NoError(t, tmjson.Unmarshal(stateJSONBytes, &state))
+	assert.EqualValues(t, 10, state.Height)
+	assert.EqualValues(t, types.VoteSignBytes("mychainid", vote), state.SignBytes)
+}
+
+func TestThresholdSignerCorruptShare(t *testing.T) {
+	_, group, signers := newLocalShareSigners(t, 2, 2)
+	ts := newThresholdSigner(t, group, signers[0], corruptShareSigner{signers[1]})
+
+	vote := newVote(nil, 0, 10, 0, tmproto.PrevoteType, randBlockID()).ToProto()
+	err := ts.SignVote(context.Background(), "mychainid", vote)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid signature share of signer 2")
+}
+
+func TestLocalShareSignerNonceReuse(t *testing.T) {
+	_, _, signers := newLocalShareSigners(t, 2, 3)
+	vote := newVote(nil, 0, 10, 0, tmproto.PrevoteType, randBlockID()).ToProto()
+	req := ThresholdSignRequest{SessionID: []byte("session"), ChainID: "mychainid", Vote: vote}
+
+	var commitments []ThresholdCommitment
+	for _, s := range signers[:2] {
+		c, err := s.Commit(context.Background(), req)
+		require.NoError(t, err)
+		commitments = append(commitments, c)
+	}
+	_, err := signers[0].SignShare(context.Background(), req, commitments)
+	require.NoError(t, err)
+
+	// the nonces of a session are only used once
+	_, err = signers[0].SignShare(context.Background(), req, commitments)
+	assert.Error(t, err)
+
+	// a signer doesn't sign with commitments for other sign bytes
+	other := req
+	other.Vote = newVote(nil, 0, 10, 0, tmproto.PrevoteType, randBlockID()).ToProto()
+	_, err = signers[1].SignShare(context.Background(), other, commitments)
+	assert.Error(t, err)
+}
@@ -27,23 +27,28 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("tendermint/privval/service.proto", fileDescriptor_7afe74f9f46d3dc9) }
 
 var fileDescriptor_7afe74f9f46d3dc9 = []byte{
-	// 251 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f,
-	0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x42, 0xa8,
-	0xd0, 0x83, 0xaa, 0x90, 0x92, 0xc3, 0xa2, 0xab, 0xa4, 0xb2, 0x20, 0xb5, 0x18, 0xa2, 0xc7, 0x68,
-	0x09, 0x13, 0x97, 0x40, 0x40, 0x51, 0x66, 0x59, 0x58, 0x62, 0x4e, 0x66, 0x4a, 0x62, 0x49, 0x7e,
-	0x91, 0x63, 0x80, 0xa7, 0x50, 0x10, 0x17, 0xa7, 0x7b, 0x6a, 0x49, 0x40, 0x69, 0x92, 0x77, 0x6a,
-	0xa5, 0x90, 0xa2, 0x1e, 0xa6, 0xb1, 0x7a, 0x10, 0xb9, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
-	0x29, 0x25, 0x7c, 0x4a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xc2, 0xb9, 0x38, 0x82, 0x33,
-	0xd3, 0xf3, 0xc2, 0xf2, 0x4b, 0x52, 0x85, 0x94, 0xb1, 0xa9, 0x87, 0xc9, 0xc2, 0x0c, 0x55, 0xc3,
-	0xa5, 0x28, 0x35, 0x05, 0xa2, 0x0c, 0x6a, 0x70, 0x32, 0x17, 0x0f, 0x48, 0x34, 0xa0, 0x28, 0xbf,
-	0x20, 0xbf, 0x38, 0x31, 0x47, 0x48, 0x1d, 0x97, 0x3e, 0x98, 0x0a, 0x98, 0x05, 0x5a, 0xb8, 0x2d,
-	0x40, 0x28, 0x85, 0x58, 0xe2, 0x14, 0x7c, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f,
-	0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c,
-	0x51, 0x96, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x48, 0x61, 0x8d,
-	0x12, 0xec, 0xf9, 0x25, 0xf9, 0xfa, 0x98, 0xf1, 0x90, 0xc4, 0x06, 0x96, 0x31, 0x06, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x42, 0x60, 0x24, 0x48, 0xda, 0x01, 0x00, 0x00,
+	// 323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x4a, 0xf3, 0x40,
+	0x14, 0xc5, 0x9b, 0x6f, 0x51, 0xbe, 0x0e, 0x2e, 0x64, 0x36, 0x42, 0x17, 0x83, 0x7f, 0xc0, 0x8a,
+	0x62, 0x02, 0xd5, 0x17, 0x50, 0x17, 0x22, 0x82, 0x84, 0x56, 0x2a, 0xe8, 0x2a, 0x6d, 0x2e, 0xc9,
+	0x60, 0x92, 0x1b, 0x67, 0x6e, 0x02, 0x7d, 0x0b, 0x1f, 0xc2, 0x87, 0x71, 0xd9, 0xa5, 0x3b, 0xa5,
+	0x7d, 0x11, 0xe9, 0xa4, 0x43, 0x91, 0x36, 0xed, 0x76, 0xce, 0xef, 0x9c, 0x1f, 0x0c, 0x97, 0xed,
+	0x13, 0x64, 0x21, 0xa8, 0x54, 0x66, 0xe4, 0xe5, 0x4a, 0x96, 0x65, 0x90, 0x78, 0x1a, 0x54, 0x29,
+	0x47, 0xe0, 0xe6, 0x0a, 0x09, 0x39, 0x5f, 0x12, 0xee, 0x82, 0x68, 0x8b, 0x35, 0x2d, 0x1a, 0xe7,
+	0xa0, 0xab, 0x4e, 0xf7, 0xe3, 0x1f, 0xdb, 0xf5, 0x95, 0x2c, 0x07, 0x41, 0x22, 0xc3, 0x80, 0x50,
+	0x5d, 0xf9, 0x77, 0xbc, 0xc7, 0x5a, 0xb7, 0x40, 0x7e, 0x31, 0xbc, 0x87, 0x31, 0x3f, 0x70, 0x57,
+	0x67, 0xdd, 0x2a, 0xeb, 0xc1, 0x5b, 0x01, 0x9a, 0xda, 0x87, 0x9b, 0x10, 0x9d, 0x63, 0xa6, 0x81,
+	0x3f, 0xb1, 0xff, 0x7d, 0x19, 0x65, 0x03, 0x24, 0xe0, 0x47, 0xeb, 0x78, 0x9b, 0xda, 0xd1, 0xe3,
+	0x3a, 0x08, 0xc2, 0x0a, 0x5b, 0x0c, 0x8f, 0xd8, 0xce, 0xfc, 0xd5, 0x57, 0x98, 0xa3, 0x0e, 0x12,
+	0xde, 0xa9, 0xeb, 0x59, 0xc2, 0x0a, 0x4e, 0xeb, 0x05, 0x4b, 0xb4, 0x92, 0x74, 0xbf, 0x1d, 0xb6,
+	0xf7, 0x18, 0x2b, 0xd0, 0x31, 0x26, 0x61, 0x3f, 0x0e, 0x14, 0x18, 0xd0, 0xfc, 0xd6, 0x0b, 0x6b,
+	0xde, 0x60, 0x9a, 0x4a, 0xe2, 0x27, 0xeb, 0x16, 0x97, 0x35, 0x19, 0x65, 0xd6, 0xdd, 0xd9, 0x48,
+	0x56, 0x73, 0x29, 0x64, 0xc4, 0x23, 0xd6, 0x9a, 0xf7, 0x8c, 0x92, 0x9f, 0x6f, 0xdd, 0x37, 0x9c,
+	0x95, 0x9c, 0x6d, 0xc5, 0x03, 0x2a, 0x14, 0x98, 0xce, 0xf5, 0xc3, 0xe7, 0x54, 0x38, 0x93, 0xa9,
+	0x70, 0x7e, 0xa6, 0xc2, 0x79, 0x9f, 0x89, 0xc6, 0x64, 0x26, 0x1a, 0x5f, 0x33, 0xd1, 0x78, 0xbe,
+	0x8c, 0x24, 0xc5, 0xc5, 0xd0, 0x1d, 0x61, 0xea, 0xbd, 0x26, 0x63, 0x08, 0xbd, 0x3f, 0x37, 0x85,
+	0x84, 0xde, 0xea, 0x91, 0x0d, 0x9b, 0x26, 0xb9, 0xf8, 0x1d, 0x00, 0xad, 0x94, 0x28, 0x18, 0xb7,
+	0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -197,3 +202,111 @@ var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/privval/service.proto",
 }
+
+// ThresholdShareSignerAPIClient is the client API for ThresholdShareSignerAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ThresholdShareSignerAPIClient interface {
+	Commit(ctx context.Context, in *ThresholdSignRequest, opts ...grpc.CallOption) (*ThresholdCommitment, error)
+	SignShare(ctx context.Context, in *ThresholdSignShareRequest, opts ...grpc.CallOption) (*ThresholdSignatureShare, error)
+}
+
+type thresholdShareSignerAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewThresholdShareSignerAPIClient(cc *grpc.ClientConn) ThresholdShareSignerAPIClient {
+	return &thresholdShareSignerAPIClient{cc}
+}
+
+func (c *thresholdShareSignerAPIClient) Commit(ctx context.Context, in *ThresholdSignRequest, opts ...grpc.CallOption) (*ThresholdCommitment, error) {
+	out := new(ThresholdCommitment)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.ThresholdShareSignerAPI/Commit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thresholdShareSignerAPIClient) SignShare(ctx context.Context, in *ThresholdSignShareRequest, opts ...grpc.CallOption) (*ThresholdSignatureShare, error) {
+	out := new(ThresholdSignatureShare)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.ThresholdShareSignerAPI/SignShare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ThresholdShareSignerAPIServer is the server API for ThresholdShareSignerAPI service.
+type ThresholdShareSignerAPIServer interface {
+	Commit(context.Context, *ThresholdSignRequest) (*ThresholdCommitment, error)
+	SignShare(context.Context, *ThresholdSignShareRequest) (*ThresholdSignatureShare, error)
+}
+
+// UnimplementedThresholdShareSignerAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedThresholdShareSignerAPIServer struct {
+}
+
+func (*UnimplementedThresholdShareSignerAPIServer) Commit(ctx context.Context, req *ThresholdSignRequest) (*ThresholdCommitment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Commit not implemented")
+}
+func (*UnimplementedThresholdShareSignerAPIServer) SignShare(ctx context.Context, req *ThresholdSignShareRequest) (*ThresholdSignatureShare, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignShare not implemented")
+}
+
+func RegisterThresholdShareSignerAPIServer(s *grpc.Server, srv ThresholdShareSignerAPIServer) {
+	s.RegisterService(&_ThresholdShareSignerAPI_serviceDesc, srv)
+}
+
+func _ThresholdShareSignerAPI_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThresholdSignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThresholdShareSignerAPIServer).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.ThresholdShareSignerAPI/Commit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThresholdShareSignerAPIServer).Commit(ctx, req.(*ThresholdSignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThresholdShareSignerAPI_SignShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThresholdSignShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThresholdShareSignerAPIServer).SignShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.ThresholdShareSignerAPI/SignShare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThresholdShareSignerAPIServer).SignShare(ctx, req.(*ThresholdSignShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ThresholdShareSignerAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.privval.ThresholdShareSignerAPI",
+	HandlerType: (*ThresholdShareSignerAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Commit",
+			Handler:    _ThresholdShareSignerAPI_Commit_Handler,
+		},
+		{
+			MethodName: "SignShare",
+			Handler:    _ThresholdShareSignerAPI_SignShare_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tendermint/privval/service.proto",
+}
@@ -424,6 +424,253 @@ func (m *SignedProposalResponse) GetError() *RemoteSignerError {
 	return nil
 }
 
+// ThresholdSignRequest asks a share signer to take part in signing a vote or
+// a proposal. Only one of vote and proposal is set.
+type ThresholdSignRequest struct {
+	SessionId []byte          `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ChainId   string          `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Vote      *types.Vote     `protobuf:"bytes,3,opt,name=vote,proto3" json:"vote,omitempty"`
+	Proposal  *types.Proposal `protobuf:"bytes,4,opt,name=proposal,proto3" json:"proposal,omitempty"`
+}
+
+func (m *ThresholdSignRequest) Reset()         { *m = ThresholdSignRequest{} }
+func (m *ThresholdSignRequest) String() string { return proto.CompactTextString(m) }
+func (*ThresholdSignRequest) ProtoMessage()    {}
+func (*ThresholdSignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{7}
+}
+func (m *ThresholdSignRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThresholdSignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThresholdSignRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThresholdSignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdSignRequest.Merge(m, src)
+}
+func (m *ThresholdSignRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThresholdSignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdSignRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdSignRequest proto.InternalMessageInfo
+
+func (m *ThresholdSignRequest) GetSessionId() []byte {
+	if m != nil {
+		return m.SessionId
+	}
+	return nil
+}
+
+func (m *ThresholdSignRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *ThresholdSignRequest) GetVote() *types.Vote {
+	if m != nil {
+		return m.Vote
+	}
+	return nil
+}
+
+func (m *ThresholdSignRequest) GetProposal() *types.Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+// ThresholdCommitment is a share signer's commitment to the nonces it will use
+// to sign in a session.
+type ThresholdCommitment struct {
+	Index         uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	SignBytesHash []byte `protobuf:"bytes,2,opt,name=sign_bytes_hash,json=signBytesHash,proto3" json:"sign_bytes_hash,omitempty"`
+	Hiding        []byte `protobuf:"bytes,3,opt,name=hiding,proto3" json:"hiding,omitempty"`
+	Binding       []byte `protobuf:"bytes,4,opt,name=binding,proto3" json:"binding,omitempty"`
+}
+
+func (m *ThresholdCommitment) Reset()         { *m = ThresholdCommitment{} }
+func (m *ThresholdCommitment) String() string { return proto.CompactTextString(m) }
+func (*ThresholdCommitment) ProtoMessage()    {}
+func (*ThresholdCommitment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{8}
+}
+func (m *ThresholdCommitment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThresholdCommitment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThresholdCommitment.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThresholdCommitment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdCommitment.Merge(m, src)
+}
+func (m *ThresholdCommitment) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThresholdCommitment) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdCommitment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdCommitment proto.InternalMessageInfo
+
+func (m *ThresholdCommitment) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ThresholdCommitment) GetSignBytesHash() []byte {
+	if m != nil {
+		return m.SignBytesHash
+	}
+	return nil
+}
+
+func (m *ThresholdCommitment) GetHiding() []byte {
+	if m != nil {
+		return m.Hiding
+	}
+	return nil
+}
+
+func (m *ThresholdCommitment) GetBinding() []byte {
+	if m != nil {
+		return m.Binding
+	}
+	return nil
+}
+
+// ThresholdSignShareRequest asks a share signer to sign in the session it
+// committed to, given the commitments of all the participants.
+type ThresholdSignShareRequest struct {
+	Request     ThresholdSignRequest  `protobuf:"bytes,1,opt,name=request,proto3" json:"request"`
+	Commitments []ThresholdCommitment `protobuf:"bytes,2,rep,name=commitments,proto3" json:"commitments"`
+}
+
+func (m *ThresholdSignShareRequest) Reset()         { *m = ThresholdSignShareRequest{} }
+func (m *ThresholdSignShareRequest) String() string { return proto.CompactTextString(m) }
+func (*ThresholdSignShareRequest) ProtoMessage()    {}
+func (*ThresholdSignShareRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+}
+func (m *ThresholdSignShareRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThresholdSignShareRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThresholdSignShareRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThresholdSignShareRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdSignShareRequest.Merge(m, src)
+}
+func (m *ThresholdSignShareRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThresholdSignShareRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdSignShareRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdSignShareRequest proto.InternalMessageInfo
+
+func (m *ThresholdSignShareRequest) GetRequest() ThresholdSignRequest {
+	if m != nil {
+		return m.Request
+	}
+	return ThresholdSignRequest{}
+}
+
+func (m *ThresholdSignShareRequest) GetCommitments() []ThresholdCommitment {
+	if m != nil {
+		return m.Commitments
+	}
+	return nil
+}
+
+// ThresholdSignatureShare is a share signer's share of the signature.
+type ThresholdSignatureShare struct {
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Share []byte `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
+}
+
+func (m *ThresholdSignatureShare) Reset()         { *m = ThresholdSignatureShare{} }
+func (m *ThresholdSignatureShare) String() string { return proto.CompactTextString(m) }
+func (*ThresholdSignatureShare) ProtoMessage()    {}
+func (*ThresholdSignatureShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+}
+func (m *ThresholdSignatureShare) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThresholdSignatureShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThresholdSignatureShare.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThresholdSignatureShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThresholdSignatureShare.Merge(m, src)
+}
+func (m *ThresholdSignatureShare) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThresholdSignatureShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThresholdSignatureShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThresholdSignatureShare proto.InternalMessageInfo
+
+func (m *ThresholdSignatureShare) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ThresholdSignatureShare) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
 // PingRequest is a request to confirm that the connection is alive.
 type PingRequest struct {
 }
@@ -432,7 +679,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{7}
+	return fileDescriptor_cb4e437a5328cf9c, []int{11}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -469,7 +716,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{8}
+	return fileDescriptor_cb4e437a5328cf9c, []int{12}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -515,7 +762,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+	return fileDescriptor_cb4e437a5328cf9c, []int{13}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -673,7 +920,7 @@ func (m *AuthSigMessage) Reset()         { *m = AuthSigMessage{} }
 func (m *AuthSigMessage) String() string { return proto.CompactTextString(m) }
 func (*AuthSigMessage) ProtoMessage()    {}
 func (*AuthSigMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+	return fileDescriptor_cb4e437a5328cf9c, []int{14}
 }
 func (m *AuthSigMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -725,6 +972,10 @@ func init() {
 	proto.RegisterType((*SignedVoteResponse)(nil), "tendermint.privval.SignedVoteResponse")
 	proto.RegisterType((*SignProposalRequest)(nil), "tendermint.privval.SignProposalRequest")
 	proto.RegisterType((*SignedProposalResponse)(nil), "tendermint.privval.SignedProposalResponse")
+	proto.RegisterType((*ThresholdSignRequest)(nil), "tendermint.privval.ThresholdSignRequest")
+	proto.RegisterType((*ThresholdCommitment)(nil), "tendermint.privval.ThresholdCommitment")
+	proto.RegisterType((*ThresholdSignShareRequest)(nil), "tendermint.privval.ThresholdSignShareRequest")
+	proto.RegisterType((*ThresholdSignatureShare)(nil), "tendermint.privval.ThresholdSignatureShare")
 	proto.RegisterType((*PingRequest)(nil), "tendermint.privval.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "tendermint.privval.PingResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.privval.Message")
@@ -734,56 +985,68 @@ func init() {
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 779 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4b, 0x4f, 0xdb, 0x4a,
-	0x14, 0xb6, 0xc9, 0x0b, 0x4e, 0x1e, 0x84, 0x81, 0xcb, 0x0d, 0x11, 0xd7, 0xe4, 0xfa, 0xea, 0xb6,
-	0x28, 0x8b, 0xa4, 0xa2, 0x52, 0xa5, 0x8a, 0x6e, 0x78, 0x58, 0x4d, 0x14, 0xe1, 0xa4, 0x93, 0x50,
-	0x10, 0x52, 0x65, 0xe5, 0x31, 0x75, 0x2c, 0x88, 0xed, 0x7a, 0x1c, 0xa4, 0xac, 0xbb, 0xeb, 0xaa,
-	0x52, 0xff, 0x44, 0xd7, 0xfd, 0x15, 0x2c, 0x59, 0x76, 0x55, 0x55, 0xf0, 0x47, 0xaa, 0x8c, 0x27,
-	0x8e, 0xf3, 0x42, 0xad, 0xd8, 0xcd, 0x9c, 0x73, 0xe6, 0x3b, 0xdf, 0x37, 0xf3, 0xd9, 0x07, 0x24,
-	0x97, 0x98, 0x1d, 0xe2, 0xf4, 0x0c, 0xd3, 0x2d, 0xda, 0x8e, 0x71, 0x7d, 0xdd, 0xbc, 0x2a, 0xba,
-	0x03, 0x9b, 0xd0, 0x82, 0xed, 0x58, 0xae, 0x85, 0xd0, 0x38, 0x5f, 0xe0, 0xf9, 0xec, 0x76, 0xe0,
-	0x4c, 0xdb, 0x19, 0xd8, 0xae, 0x55, 0xbc, 0x24, 0x03, 0x7e, 0x62, 0x22, 0xcb, 0x90, 0x82, 0x78,
-	0xd9, 0x0d, 0xdd, 0xd2, 0x2d, 0xb6, 0x2c, 0x0e, 0x57, 0x5e, 0x54, 0x2e, 0xc3, 0x1a, 0x26, 0x3d,
-	0xcb, 0x25, 0x75, 0x43, 0x37, 0x89, 0xa3, 0x38, 0x8e, 0xe5, 0x20, 0x04, 0xe1, 0xb6, 0xd5, 0x21,
-	0x19, 0x31, 0x27, 0xee, 0x46, 0x30, 0x5b, 0xa3, 0x1c, 0xc4, 0x3b, 0x84, 0xb6, 0x1d, 0xc3, 0x76,
-	0x0d, 0xcb, 0xcc, 0x2c, 0xe5, 0xc4, 0xdd, 0x15, 0x1c, 0x0c, 0xc9, 0x79, 0x48, 0xd6, 0xfa, 0xad,
-	0x0a, 0x19, 0x60, 0xf2, 0xa1, 0x4f, 0xa8, 0x8b, 0xb6, 0x60, 0xb9, 0xdd, 0x6d, 0x1a, 0xa6, 0x66,
-	0x74, 0x18, 0xd4, 0x0a, 0x8e, 0xb1, 0x7d, 0xb9, 0x23, 0x7f, 0x12, 0x21, 0x35, 0x2a, 0xa6, 0xb6,
-	0x65, 0x52, 0x82, 0xf6, 0x21, 0x66, 0xf7, 0x5b, 0xda, 0x25, 0x19, 0xb0, 0xe2, 0xf8, 0xde, 0x76,
-	0x21, 0x70, 0x03, 0x9e, 0xda, 0x42, 0xad, 0xdf, 0xba, 0x32, 0xda, 0x15, 0x32, 0x38, 0x0c, 0xdf,
-	0xfc, 0xd8, 0x11, 0x70, 0xd4, 0x66, 0x20, 0x68, 0x1f, 0x22, 0x64, 0x48, 0x9d, 0xf1, 0x8a, 0xef,
-	0xfd, 0x5f, 0x98, 0xbd, 0xbc, 0xc2, 0x8c, 0x4e, 0xec, 0x9d, 0x91, 0xcf, 0x61, 0x75, 0x18, 0x7d,
-	0x6b, 0xb9, 0x64, 0x44, 0x3d, 0x0f, 0xe1, 0x6b, 0xcb, 0x25, 0x9c, 0xc9, 0x66, 0x10, 0xce, 0xbb,
-	0x53, 0x56, 0xcc, 0x6a, 0x26, 0x64, 0x2e, 0x4d, 0xca, 0xfc, 0x28, 0x02, 0x62, 0x0d, 0x3b, 0x1e,
-	0x38, 0x97, 0xfa, 0xec, 0x77, 0xd0, 0xb9, 0x42, 0xaf, 0xc7, 0xa3, 0xf4, 0x75, 0x61, 0x7d, 0x18,
-	0xad, 0x39, 0x96, 0x6d, 0xd1, 0xe6, 0xd5, 0x48, 0xe3, 0x0b, 0x58, 0xb6, 0x79, 0x88, 0x33, 0xc9,
-	0xce, 0x32, 0xf1, 0x0f, 0xf9, 0xb5, 0x0f, 0xe9, 0xfd, 0x22, 0xc2, 0xa6, 0xa7, 0x77, 0xdc, 0x8c,
-	0x6b, 0x7e, 0xf5, 0x27, 0xdd, 0xb8, 0xf6, 0x71, 0xcf, 0x47, 0xe9, 0x4f, 0x42, 0xbc, 0x66, 0x98,
-	0x3a, 0xd7, 0x2d, 0xa7, 0x20, 0xe1, 0x6d, 0x3d, 0x66, 0xf2, 0xb7, 0x08, 0xc4, 0x4e, 0x08, 0xa5,
-	0x4d, 0x9d, 0xa0, 0x0a, 0xac, 0x72, 0x13, 0x6a, 0x8e, 0x57, 0xce, 0xc9, 0xfe, 0x3b, 0xaf, 0xe3,
-	0x84, 0xdd, 0x4b, 0x02, 0x4e, 0xda, 0x13, 0xfe, 0x57, 0x21, 0x3d, 0x06, 0xf3, 0x9a, 0x71, 0xfe,
-	0xf2, 0x43, 0x68, 0x5e, 0x65, 0x49, 0xc0, 0x29, 0x7b, 0xf2, 0x0b, 0x79, 0x03, 0x6b, 0xd4, 0xd0,
-	0x4d, 0x6d, 0xe8, 0x08, 0x9f, 0x5e, 0x88, 0x01, 0xfe, 0x37, 0x0f, 0x70, 0xca, 0xd4, 0x25, 0x01,
-	0xaf, 0xd2, 0x29, 0x9f, 0x5f, 0xc0, 0x06, 0x65, 0xef, 0x35, 0x02, 0xe5, 0x34, 0xc3, 0x0c, 0xf5,
-	0xc9, 0x22, 0xd4, 0x49, 0x3f, 0x97, 0x04, 0x8c, 0xe8, 0xac, 0xcb, 0xdf, 0xc1, 0x5f, 0x8c, 0xee,
-	0xe8, 0x11, 0x7d, 0xca, 0x11, 0x06, 0xfe, 0x74, 0x11, 0xf8, 0x94, 0x4f, 0x4b, 0x02, 0x5e, 0xa7,
-	0x73, 0xec, 0xfb, 0x1e, 0x32, 0x9c, 0x7a, 0xa0, 0x01, 0xa7, 0x1f, 0x65, 0x1d, 0xf2, 0x8b, 0xe9,
-	0x4f, 0xdb, 0xb3, 0x24, 0xe0, 0x4d, 0x3a, 0xdf, 0xb8, 0xc7, 0x90, 0xb0, 0x0d, 0x53, 0xf7, 0xd9,
-	0xc7, 0x18, 0xf6, 0xce, 0xdc, 0x17, 0x1c, 0xbb, 0xac, 0x24, 0xe0, 0xb8, 0x3d, 0xde, 0xa2, 0xd7,
-	0x90, 0xe4, 0x28, 0x9c, 0xe2, 0x32, 0x83, 0xc9, 0x2d, 0x86, 0xf1, 0x89, 0x25, 0xec, 0xc0, 0xfe,
-	0x30, 0x02, 0x21, 0xda, 0xef, 0xc9, 0x1a, 0xa4, 0x0e, 0xfa, 0x6e, 0xb7, 0x6e, 0xe8, 0x23, 0xeb,
-	0x3e, 0xea, 0xff, 0x99, 0x86, 0x10, 0x35, 0x74, 0xe6, 0xce, 0x04, 0x1e, 0x2e, 0xf3, 0x5f, 0x45,
-	0x88, 0xb2, 0xaf, 0x88, 0x22, 0x04, 0x29, 0x05, 0xe3, 0x2a, 0xae, 0x6b, 0xa7, 0x6a, 0x45, 0xad,
-	0x9e, 0xa9, 0x69, 0x01, 0x49, 0x90, 0xf5, 0x63, 0xca, 0x79, 0x4d, 0x39, 0x6a, 0x28, 0xc7, 0x1a,
-	0x56, 0xea, 0xb5, 0xaa, 0x5a, 0x57, 0xd2, 0x22, 0xca, 0xc0, 0x06, 0xcf, 0xab, 0x55, 0xed, 0xa8,
-	0xaa, 0xaa, 0xca, 0x51, 0xa3, 0x5c, 0x55, 0xd3, 0x4b, 0xe8, 0x1f, 0xd8, 0xe2, 0x99, 0x71, 0x58,
-	0x6b, 0x94, 0x4f, 0x94, 0xea, 0x69, 0x23, 0x1d, 0x42, 0x7f, 0xc3, 0x3a, 0x4f, 0x63, 0xe5, 0xe0,
-	0xd8, 0x4f, 0x84, 0x03, 0x88, 0x67, 0xb8, 0xdc, 0x50, 0xfc, 0x4c, 0xe4, 0xb0, 0x7e, 0x73, 0x27,
-	0x89, 0xb7, 0x77, 0x92, 0xf8, 0xf3, 0x4e, 0x12, 0x3f, 0xdf, 0x4b, 0xc2, 0xed, 0xbd, 0x24, 0x7c,
-	0xbf, 0x97, 0x84, 0x8b, 0x97, 0xba, 0xe1, 0x76, 0xfb, 0xad, 0x42, 0xdb, 0xea, 0x15, 0x83, 0xc3,
-	0x31, 0x38, 0x79, 0x87, 0x03, 0x71, 0x76, 0x14, 0xb7, 0xa2, 0x2c, 0xf3, 0xfc, 0x57, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x46, 0x64, 0xeb, 0xa4, 0xa7, 0x07, 0x00, 0x00,
+	// 971 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4d, 0x6f, 0xdb, 0x46,
+	0x10, 0x25, 0xad, 0x2f, 0x7b, 0xf4, 0x61, 0x65, 0xad, 0x3a, 0xb2, 0x91, 0x28, 0x2a, 0x8b, 0xa6,
+	0x86, 0x0f, 0x52, 0xe1, 0x16, 0xbd, 0xa4, 0x97, 0xd8, 0x26, 0x2a, 0xc1, 0x08, 0xa5, 0xae, 0x94,
+	0x26, 0x08, 0x50, 0x10, 0x92, 0xb8, 0x25, 0x17, 0x96, 0x48, 0x96, 0x4b, 0x19, 0xd5, 0xb9, 0xe8,
+	0xa5, 0xa7, 0x02, 0xfd, 0x13, 0x3d, 0x17, 0x45, 0x7f, 0x43, 0x8e, 0x39, 0xf6, 0x54, 0x14, 0xf6,
+	0x1f, 0x29, 0xb8, 0x5c, 0x52, 0x64, 0x24, 0x19, 0x0e, 0x7c, 0xdb, 0x79, 0xb3, 0xfb, 0xe6, 0xbd,
+	0xe1, 0xec, 0x4a, 0xd0, 0xf0, 0x89, 0x6d, 0x10, 0x6f, 0x46, 0x6d, 0xbf, 0xed, 0x7a, 0xf4, 0xea,
+	0x6a, 0x34, 0x6d, 0xfb, 0x0b, 0x97, 0xb0, 0x96, 0xeb, 0x39, 0xbe, 0x83, 0xd0, 0x32, 0xdf, 0x12,
+	0xf9, 0xc3, 0x47, 0x89, 0x33, 0x13, 0x6f, 0xe1, 0xfa, 0x4e, 0xfb, 0x92, 0x2c, 0xc4, 0x89, 0x54,
+	0x96, 0x33, 0x25, 0xf9, 0x0e, 0x6b, 0xa6, 0x63, 0x3a, 0x7c, 0xd9, 0x0e, 0x56, 0x21, 0xaa, 0x74,
+	0xe1, 0x01, 0x26, 0x33, 0xc7, 0x27, 0x03, 0x6a, 0xda, 0xc4, 0x53, 0x3d, 0xcf, 0xf1, 0x10, 0x82,
+	0xec, 0xc4, 0x31, 0x48, 0x5d, 0x6e, 0xca, 0x47, 0x39, 0xcc, 0xd7, 0xa8, 0x09, 0x45, 0x83, 0xb0,
+	0x89, 0x47, 0x5d, 0x9f, 0x3a, 0x76, 0x7d, 0xab, 0x29, 0x1f, 0xed, 0xe0, 0x24, 0xa4, 0x1c, 0x43,
+	0xb9, 0x3f, 0x1f, 0x5f, 0x90, 0x05, 0x26, 0x3f, 0xce, 0x09, 0xf3, 0xd1, 0x01, 0x6c, 0x4f, 0xac,
+	0x11, 0xb5, 0x75, 0x6a, 0x70, 0xaa, 0x1d, 0x5c, 0xe0, 0x71, 0xd7, 0x50, 0x7e, 0x95, 0xa1, 0x12,
+	0x6d, 0x66, 0xae, 0x63, 0x33, 0x82, 0x9e, 0x41, 0xc1, 0x9d, 0x8f, 0xf5, 0x4b, 0xb2, 0xe0, 0x9b,
+	0x8b, 0x27, 0x8f, 0x5a, 0x89, 0x0e, 0x84, 0x6e, 0x5b, 0xfd, 0xf9, 0x78, 0x4a, 0x27, 0x17, 0x64,
+	0x71, 0x9a, 0x7d, 0xfb, 0xef, 0x13, 0x09, 0xe7, 0x5d, 0x4e, 0x82, 0x9e, 0x41, 0x8e, 0x04, 0xd2,
+	0xb9, 0xae, 0xe2, 0xc9, 0xa7, 0xad, 0xd5, 0xe6, 0xb5, 0x56, 0x7c, 0xe2, 0xf0, 0x8c, 0xf2, 0x1a,
+	0x76, 0x03, 0xf4, 0x3b, 0xc7, 0x27, 0x91, 0xf4, 0x63, 0xc8, 0x5e, 0x39, 0x3e, 0x11, 0x4a, 0xf6,
+	0x93, 0x74, 0x61, 0x4f, 0xf9, 0x66, 0xbe, 0x27, 0x65, 0x73, 0x2b, 0x6d, 0xf3, 0x67, 0x19, 0x10,
+	0x2f, 0x68, 0x84, 0xe4, 0xc2, 0xea, 0xe7, 0x77, 0x61, 0x17, 0x0e, 0xc3, 0x1a, 0xf7, 0xf2, 0x67,
+	0xc1, 0x5e, 0x80, 0xf6, 0x3d, 0xc7, 0x75, 0xd8, 0x68, 0x1a, 0x79, 0xfc, 0x0a, 0xb6, 0x5d, 0x01,
+	0x09, 0x25, 0x87, 0xab, 0x4a, 0xe2, 0x43, 0xf1, 0xde, 0xdb, 0xfc, 0xfe, 0x2e, 0xc3, 0x7e, 0xe8,
+	0x77, 0x59, 0x4c, 0x78, 0xfe, 0xfa, 0x43, 0xaa, 0x09, 0xef, 0xcb, 0x9a, 0xf7, 0xf2, 0xff, 0x97,
+	0x0c, 0xb5, 0xa1, 0xe5, 0x11, 0x66, 0x39, 0x53, 0x23, 0xc8, 0x47, 0x1d, 0x78, 0x0c, 0xc0, 0x08,
+	0x63, 0xd4, 0x89, 0x47, 0xb4, 0x84, 0x77, 0x04, 0xd2, 0x35, 0x6e, 0x31, 0x1a, 0xcf, 0x47, 0xe6,
+	0x0e, 0xf3, 0x91, 0xec, 0x73, 0xf6, 0xee, 0x7d, 0x56, 0x7e, 0x91, 0x61, 0x2f, 0x96, 0x7d, 0xe6,
+	0xcc, 0x66, 0xd4, 0x9f, 0x11, 0xdb, 0x47, 0x35, 0xc8, 0x51, 0xdb, 0x20, 0x3f, 0x71, 0xc1, 0x65,
+	0x1c, 0x06, 0xe8, 0x29, 0xec, 0x32, 0x6a, 0xda, 0xfa, 0x78, 0xe1, 0x13, 0xa6, 0x5b, 0x23, 0x66,
+	0x71, 0xcd, 0x25, 0x5c, 0x0e, 0xe0, 0xd3, 0x00, 0xed, 0x8c, 0x98, 0x85, 0xf6, 0x21, 0x6f, 0x51,
+	0x83, 0xda, 0x26, 0xd7, 0x5e, 0xc2, 0x22, 0x42, 0x75, 0x28, 0x8c, 0xa9, 0xcd, 0x13, 0x59, 0x9e,
+	0x88, 0x42, 0xe5, 0x6f, 0x19, 0x0e, 0x52, 0xed, 0x1b, 0x58, 0x23, 0x2f, 0xbe, 0x29, 0x1d, 0x28,
+	0x78, 0xe1, 0x52, 0x7c, 0xd6, 0xa3, 0x75, 0xdf, 0x66, 0x5d, 0xfb, 0xc5, 0x47, 0x8e, 0x8e, 0xa3,
+	0x1e, 0x14, 0x27, 0xb1, 0x4b, 0x56, 0xdf, 0x6a, 0x66, 0x8e, 0x8a, 0x27, 0x9f, 0xdd, 0xca, 0xb6,
+	0xec, 0x8a, 0x20, 0x4b, 0x32, 0x28, 0x2a, 0x3c, 0x4c, 0xd5, 0x1d, 0xf9, 0x73, 0x8f, 0x70, 0xf1,
+	0x1b, 0x7a, 0x58, 0x83, 0x1c, 0x0b, 0xd2, 0xa2, 0x73, 0x61, 0xa0, 0x94, 0xa1, 0xd8, 0xa7, 0xb6,
+	0x29, 0x54, 0x2b, 0x15, 0x28, 0x85, 0x61, 0x38, 0xd8, 0xca, 0x9f, 0x39, 0x28, 0xbc, 0x20, 0x8c,
+	0x8d, 0x4c, 0x82, 0x2e, 0x60, 0x57, 0xbc, 0x61, 0x7a, 0xba, 0x29, 0x1f, 0xaf, 0xb3, 0x91, 0x7a,
+	0x2d, 0x3b, 0x12, 0x2e, 0xbb, 0x49, 0x00, 0x69, 0x50, 0x5d, 0x92, 0x85, 0xc5, 0xc4, 0xf8, 0x2b,
+	0xb7, 0xb1, 0x85, 0x3b, 0x3b, 0x12, 0xae, 0xb8, 0x29, 0x04, 0x7d, 0x0b, 0x0f, 0xf8, 0x84, 0x04,
+	0x43, 0x19, 0xcb, 0x0b, 0x07, 0xf8, 0x93, 0x75, 0x84, 0xef, 0xbd, 0x89, 0x1d, 0x09, 0xef, 0xb2,
+	0x34, 0x84, 0xde, 0x40, 0x8d, 0xf1, 0xeb, 0x1e, 0x91, 0x0a, 0x99, 0xe1, 0x98, 0x3f, 0xdd, 0xc4,
+	0x9a, 0x7e, 0x0e, 0x3b, 0x12, 0x46, 0x6c, 0x05, 0x45, 0xdf, 0xc3, 0x47, 0x5c, 0x6e, 0x74, 0x1f,
+	0x62, 0xc9, 0xb9, 0xa6, 0xbc, 0x69, 0x30, 0xd6, 0x3c, 0x73, 0x1d, 0x09, 0xef, 0xb1, 0x55, 0x18,
+	0xfd, 0x00, 0x75, 0x21, 0x3d, 0x51, 0x40, 0xc8, 0xcf, 0xf3, 0x0a, 0xc7, 0x9b, 0xe5, 0xbf, 0xff,
+	0xba, 0x75, 0x24, 0xbc, 0xcf, 0xd6, 0x66, 0xd0, 0x39, 0x94, 0x5c, 0x6a, 0x9b, 0xb1, 0xfa, 0x02,
+	0xe7, 0x7e, 0xb2, 0xf6, 0x0b, 0x2e, 0xa7, 0xac, 0x23, 0xe1, 0xa2, 0xbb, 0x0c, 0xd1, 0x37, 0x50,
+	0x16, 0x2c, 0x42, 0xe2, 0x36, 0xa7, 0x69, 0x6e, 0xa6, 0x89, 0x85, 0x95, 0xdc, 0x44, 0x7c, 0x9a,
+	0x83, 0x0c, 0x9b, 0xcf, 0x14, 0x1d, 0x2a, 0xcf, 0xe7, 0xbe, 0x35, 0xa0, 0x66, 0x34, 0xba, 0xf7,
+	0xfa, 0xf9, 0xad, 0x42, 0x86, 0x51, 0x53, 0x5c, 0x9b, 0x60, 0x79, 0xfc, 0x87, 0x0c, 0x79, 0xfe,
+	0x08, 0x33, 0x84, 0xa0, 0xa2, 0x62, 0xdc, 0xc3, 0x03, 0xfd, 0xa5, 0x76, 0xa1, 0xf5, 0x5e, 0x69,
+	0x55, 0x09, 0x35, 0xe0, 0x30, 0xc6, 0xd4, 0xd7, 0x7d, 0xf5, 0x6c, 0xa8, 0x9e, 0xeb, 0x58, 0x1d,
+	0xf4, 0x7b, 0xda, 0x40, 0xad, 0xca, 0xa8, 0x0e, 0x35, 0x91, 0xd7, 0x7a, 0xfa, 0x59, 0x4f, 0xd3,
+	0xd4, 0xb3, 0x61, 0xb7, 0xa7, 0x55, 0xb7, 0xd0, 0x63, 0x38, 0x10, 0x99, 0x25, 0xac, 0x0f, 0xbb,
+	0x2f, 0xd4, 0xde, 0xcb, 0x61, 0x35, 0x83, 0x1e, 0xc2, 0x9e, 0x48, 0x63, 0xf5, 0xf9, 0x79, 0x9c,
+	0xc8, 0x26, 0x18, 0x5f, 0xe1, 0xee, 0x50, 0x8d, 0x33, 0xb9, 0x53, 0xed, 0xed, 0x75, 0x43, 0x7e,
+	0x77, 0xdd, 0x90, 0xff, 0xbb, 0x6e, 0xc8, 0xbf, 0xdd, 0x34, 0xa4, 0x77, 0x37, 0x0d, 0xe9, 0x9f,
+	0x9b, 0x86, 0xf4, 0xe6, 0x4b, 0x93, 0xfa, 0xd6, 0x7c, 0xdc, 0x9a, 0x38, 0xb3, 0xf6, 0xe5, 0x74,
+	0x41, 0x8c, 0x76, 0xea, 0x3f, 0x5b, 0xf0, 0x57, 0x6a, 0xf5, 0x4f, 0xdc, 0x38, 0xcf, 0x33, 0x5f,
+	0xfc, 0x3f, 0x00, 0xb9, 0x4e, 0x24, 0x7a, 0xe1, 0x09, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -1070,7 +1333,7 @@ func (m *SignedProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func (m *PingRequest) Marshal() (dAtA []byte, err error) {
+func (m *ThresholdSignRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1080,20 +1343,58 @@ func (m *PingRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PingRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ThresholdSignRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PingRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ThresholdSignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if m.Proposal != nil {
+		{
+			size, err := m.Proposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Vote != nil {
+		{
+			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SessionId) > 0 {
+		i -= len(m.SessionId)
+		copy(dAtA[i:], m.SessionId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.SessionId)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PingResponse) Marshal() (dAtA []byte, err error) {
+func (m *ThresholdCommitment) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1103,20 +1404,46 @@ func (m *PingResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PingResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ThresholdCommitment) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ThresholdCommitment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if len(m.Binding) > 0 {
+		i -= len(m.Binding)
+		copy(dAtA[i:], m.Binding)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Binding)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Hiding) > 0 {
+		i -= len(m.Hiding)
+		copy(dAtA[i:], m.Hiding)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hiding)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SignBytesHash) > 0 {
+		i -= len(m.SignBytesHash)
+		copy(dAtA[i:], m.SignBytesHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.SignBytesHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
+func (m *ThresholdSignShareRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1126,55 +1453,183 @@ func (m *Message) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+func (m *ThresholdSignShareRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ThresholdSignShareRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Sum != nil {
-		{
-			size := m.Sum.Size()
-			i -= size
-			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
+	if len(m.Commitments) > 0 {
+		for iNdEx := len(m.Commitments) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Commitments[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
 			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Request.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
 		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
 	}
+	i--
+	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
 }
 
-func (m *Message_PubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ThresholdSignatureShare) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThresholdSignatureShare) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ThresholdSignatureShare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.PubKeyRequest != nil {
-		{
-			size, err := m.PubKeyRequest.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if len(m.Share) > 0 {
+		i -= len(m.Share)
+		copy(dAtA[i:], m.Share)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Share)))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *Message_PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PingRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PingRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PingRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *PingResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PingResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_PubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PubKeyRequest != nil {
+		{
+			size, err := m.PubKeyRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	if m.PubKeyResponse != nil {
 		{
@@ -1475,6 +1930,88 @@ func (m *SignedProposalResponse) Size() (n int) {
 	return n
 }
 
+func (m *ThresholdSignRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SessionId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Vote != nil {
+		l = m.Vote.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Proposal != nil {
+		l = m.Proposal.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ThresholdCommitment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.SignBytesHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Hiding)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Binding)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ThresholdSignShareRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Request.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Commitments) > 0 {
+		for _, e := range m.Commitments {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ThresholdSignatureShare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.Share)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *PingRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2398,6 +2935,585 @@ func (m *SignedProposalResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ThresholdSignRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThresholdSignRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThresholdSignRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SessionId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SessionId = append(m.SessionId[:0], dAtA[iNdEx:postIndex]...)
+			if m.SessionId == nil {
+				m.SessionId = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &types.Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proposal == nil {
+				m.Proposal = &types.Proposal{}
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThresholdCommitment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThresholdCommitment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThresholdCommitment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignBytesHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignBytesHash = append(m.SignBytesHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignBytesHash == nil {
+				m.SignBytesHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hiding", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hiding = append(m.Hiding[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hiding == nil {
+				m.Hiding = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Binding", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Binding = append(m.Binding[:0], dAtA[iNdEx:postIndex]...)
+			if m.Binding == nil {
+				m.Binding = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThresholdSignShareRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThresholdSignShareRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThresholdSignShareRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commitments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commitments = append(m.Commitments, ThresholdCommitment{})
+			if err := m.Commitments[len(m.Commitments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThresholdSignatureShare) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThresholdSignatureShare: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThresholdSignatureShare: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Share", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Share = append(m.Share[:0], dAtA[iNdEx:postIndex]...)
+			if m.Share == nil {
+				m.Share = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PingRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
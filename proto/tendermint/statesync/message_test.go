@@ -98,6 +98,12 @@ func TestValidateMsg(t *testing.T) {
 			true,
 			false,
 		},
+
+		"LightBlockRequest valid":    {&ssproto.LightBlockRequest{Height: 1}, true, true},
+		"LightBlockRequest 0 height": {&ssproto.LightBlockRequest{Height: 0}, true, false},
+
+		"LightBlockResponse valid": {&ssproto.LightBlockResponse{LightBlock: &tmproto.LightBlock{}}, true, true},
+		"LightBlockResponse empty": {&ssproto.LightBlockResponse{}, true, true},
 	}
 
 	for name, tc := range testcases {
@@ -161,6 +167,18 @@ func TestStateSyncVectors(t *testing.T) {
 			},
 			"2214080110021803220c697427732061206368756e6b",
 		},
+		{
+			"LightBlockRequest",
+			&ssproto.LightBlockRequest{
+				Height: 100,
+			},
+			"2a020864",
+		},
+		{
+			"LightBlockResponse",
+			&ssproto.LightBlockResponse{},
+			"3200",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"context"
+	"sync/atomic"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/types"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+//-----------------------------------------------------------------------------------------
+// Implements AppConnMempool over a pool of connections
+
+// appConnMempoolPool dispatches CheckTx requests round-robin over several
+// mempool connections, so that an application that handles CheckTx
+// concurrently can check several txs at once.
+//
+// Responses may arrive out of order from different connections, but the
+// callbacks are invoked in the order the requests were sent, so that txs are
+// added to the mempool (and rechecked) in the same order as with a single
+// connection.
+type appConnMempoolPool struct {
+	conns []abcicli.Client
+	next  uint32 // the next connection to use, modulo len(conns)
+
+	mtx      tmsync.Mutex
+	globalCb abcicli.Callback
+	pending  []*pendingCheckTx // requests not yet delivered, in order
+	sent     uint64            // number of requests sent
+	handled  uint64            // number of requests delivered (or failed)
+	waiters  []flushWaiter
+
+	// deliverMtx serializes the delivery of responses.
+	deliverMtx tmsync.Mutex
+}
+
+// pendingCheckTx is a CheckTx request waiting for its response, or for the
+// responses of the requests sent before it.
+type pendingCheckTx struct {
+	reqRes *abcicli.ReqRes
+	res    *types.Response
+	done   bool
+}
+
+// flushWaiter waits until the first n requests are handled.
+type flushWaiter struct {
+	n    uint64
+	done chan struct{}
+}
+
+var _ AppConnMempool = (*appConnMempoolPool)(nil)
+
+// NewAppConnMempoolPool returns a mempool connection which uses all the given
+// connections. With a single connection, use NewAppConnMempool instead.
+func NewAppConnMempoolPool(conns []abcicli.Client) AppConnMempool {
+	pool := &appConnMempoolPool{
+		conns: conns,
+	}
+	for _, conn := range conns {
+		// responses are delivered by the pool, in order.
+		conn.SetResponseCallback(func(*types.Request, *types.Response) {})
+	}
+	return pool
+}
+
+func (pool *appConnMempoolPool) SetResponseCallback(cb abcicli.Callback) {
+	pool.mtx.Lock()
+	pool.globalCb = cb
+	pool.mtx.Unlock()
+}
+
+// Error returns the error of the first failed connection, if any.
+func (pool *appConnMempoolPool) Error() error {
+	for _, conn := range pool.conns {
+		if err := conn.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pool *appConnMempoolPool) nextConn() abcicli.Client {
+	i := atomic.AddUint32(&pool.next, 1)
+	return pool.conns[int(i)%len(pool.conns)]
+}
+
+func (pool *appConnMempoolPool) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abcicli.ReqRes, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p := &pendingCheckTx{reqRes: abcicli.NewReqRes(types.ToRequestCheckTx(req))}
+	conn := pool.nextConn()
+
+	pool.mtx.Lock()
+	pool.pending = append(pool.pending, p)
+	pool.sent++
+	pool.mtx.Unlock()
+
+	// The request must not be dropped once it's queued, or the requests after
+	// it would never be delivered, so the context isn't passed on.
+	reqRes, err := conn.CheckTxAsync(context.Background(), req)
+	if err != nil {
+		pool.complete(p, nil)
+		return nil, err
+	}
+	reqRes.SetCallback(func(res *types.Response) {
+		pool.complete(p, res)
+	})
+	return p.reqRes, nil
+}
+
+func (pool *appConnMempoolPool) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	return pool.nextConn().CheckTxSync(ctx, req)
+}
+
+func (pool *appConnMempoolPool) FlushAsync(ctx context.Context) (*abcicli.ReqRes, error) {
+	for _, conn := range pool.conns {
+		if _, err := conn.FlushAsync(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	reqRes := abcicli.NewReqRes(types.ToRequestFlush())
+	done := pool.waitHandled()
+	go func() {
+		<-done
+		reqRes.Complete(types.ToResponseFlush())
+	}()
+	return reqRes, nil
+}
+
+// FlushSync flushes all the connections and waits until the responses to the
+// CheckTx requests sent before it are delivered.
+func (pool *appConnMempoolPool) FlushSync(ctx context.Context) error {
+	done := pool.waitHandled()
+	for _, conn := range pool.conns {
+		if err := conn.FlushSync(ctx); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitHandled returns a channel which is closed once all the requests sent so
+// far are handled.
+func (pool *appConnMempoolPool) waitHandled() <-chan struct{} {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	done := make(chan struct{})
+	if pool.handled >= pool.sent {
+		close(done)
+		return done
+	}
+	pool.waiters = append(pool.waiters, flushWaiter{n: pool.sent, done: done})
+	return done
+}
+
+// complete records the response to p, and delivers all the responses which
+// are no longer waiting for an earlier one. A nil response means the request
+// failed, and it's skipped.
+func (pool *appConnMempoolPool) complete(p *pendingCheckTx, res *types.Response) {
+	pool.mtx.Lock()
+	p.res = res
+	p.done = true
+	pool.mtx.Unlock()
+
+	pool.deliverMtx.Lock()
+	defer pool.deliverMtx.Unlock()
+
+	for {
+		pool.mtx.Lock()
+		if len(pool.pending) == 0 || !pool.pending[0].done {
+			pool.mtx.Unlock()
+			return
+		}
+		next := pool.pending[0]
+		pool.pending[0] = nil
+		pool.pending = pool.pending[1:]
+		globalCb := pool.globalCb
+		pool.mtx.Unlock()
+
+		if next.res != nil {
+			if globalCb != nil {
+				globalCb(next.reqRes.Request, next.res)
+			}
+			next.reqRes.Complete(next.res)
+		}
+
+		pool.mtx.Lock()
+		pool.handled++
+		waiters := pool.waiters[:0]
+		for _, w := range pool.waiters {
+			if w.n <= pool.handled {
+				close(w.done)
+			} else {
+				waiters = append(waiters, w)
+			}
+		}
+		pool.waiters = waiters
+		pool.mtx.Unlock()
+	}
+}
@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	abcimocks "github.com/klyed/tendermint/abci/client/mocks"
+	"github.com/klyed/tendermint/abci/types"
+)
+
+func TestAppConnMempoolPool_Order(t *testing.T) {
+	// two connections, which respond only when told to
+	var reqRess []*abcicli.ReqRes
+	conns := make([]abcicli.Client, 2)
+	for i := range conns {
+		clientMock := &abcimocks.Client{}
+		clientMock.On("SetResponseCallback", mock.Anything).Return()
+		clientMock.On("FlushSync", mock.Anything).Return(nil)
+		clientMock.On("CheckTxAsync", mock.Anything, mock.Anything).Return(
+			func(_ context.Context, req types.RequestCheckTx) *abcicli.ReqRes {
+				reqRes := abcicli.NewReqRes(types.ToRequestCheckTx(req))
+				reqRess = append(reqRess, reqRes)
+				return reqRes
+			}, nil)
+		conns[i] = clientMock
+	}
+	pool := NewAppConnMempoolPool(conns)
+
+	var globalTxs, txs []string
+	pool.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		globalTxs = append(globalTxs, string(req.GetCheckTx().Tx))
+	})
+
+	for _, tx := range []string{"a", "b", "c", "d"} {
+		reqRes, err := pool.CheckTxAsync(context.Background(), types.RequestCheckTx{Tx: []byte(tx)})
+		require.NoError(t, err)
+		tx := tx
+		reqRes.SetCallback(func(res *types.Response) {
+			assert.Equal(t, tx, res.GetCheckTx().Info)
+			txs = append(txs, tx)
+		})
+	}
+	require.Len(t, reqRess, 4)
+
+	respond := func(i int) {
+		reqRess[i].Complete(types.ToResponseCheckTx(types.ResponseCheckTx{
+			Info: string(reqRess[i].Request.GetCheckTx().Tx),
+		}))
+	}
+
+	// "b" and "d" can't be delivered before "a"
+	respond(3)
+	respond(1)
+	assert.Empty(t, txs)
+
+	flushed := make(chan error)
+	go func() {
+		flushed <- pool.FlushSync(context.Background())
+	}()
+
+	respond(0)
+	assert.Equal(t, []string{"a", "b"}, txs)
+	select {
+	case <-flushed:
+		t.Fatal("expected flush to wait for all responses")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	respond(2)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, txs)
+	assert.Equal(t, txs, globalTxs)
+	select {
+	case err := <-flushed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("expected flush to return")
+	}
+}
+
+func TestAppConnMempoolPool_Error(t *testing.T) {
+	var reqRes *abcicli.ReqRes
+	okMock := &abcimocks.Client{}
+	okMock.On("SetResponseCallback", mock.Anything).Return()
+	okMock.On("CheckTxAsync", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req types.RequestCheckTx) *abcicli.ReqRes {
+			reqRes = abcicli.NewReqRes(types.ToRequestCheckTx(req))
+			return reqRes
+		}, nil)
+	errMock := &abcimocks.Client{}
+	errMock.On("SetResponseCallback", mock.Anything).Return()
+	errMock.On("CheckTxAsync", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+	// the first request goes to the second connection
+	pool := NewAppConnMempoolPool([]abcicli.Client{okMock, errMock})
+
+	_, err := pool.CheckTxAsync(context.Background(), types.RequestCheckTx{Tx: []byte("a")})
+	require.Error(t, err)
+
+	// a failed request doesn't block the following ones
+	res, err := pool.CheckTxAsync(context.Background(), types.RequestCheckTx{Tx: []byte("b")})
+	require.NoError(t, err)
+	reqRes.Complete(types.ToResponseCheckTx(types.ResponseCheckTx{}))
+	res.Wait()
+	assert.NotNil(t, res.Response.GetCheckTx())
+}
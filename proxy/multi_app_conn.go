@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"os"
 	"syscall"
+	"time"
 
 	abcicli "github.com/klyed/tendermint/abci/client"
-	tmlog "github.com/klyed/tendermint/libs/log"
 	"github.com/klyed/tendermint/libs/service"
 )
 
@@ -30,18 +30,30 @@ type AppConns interface {
 	Query() AppConnQuery
 	// Snapshot connection
 	Snapshot() AppConnSnapshot
+
+	// Status returns the status of each connection.
+	Status() []ConnStatus
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+func NewAppConns(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
 }
 
+// ConsensusResumeFunc is called once the consensus connection to the
+// application is reestablished, before consensus uses it again. It must sync
+// the application with the state of the node, e.g. by running the handshake,
+// and return an error if it can't, in which case it's retried with a new
+// connection. The given connections use the new consensus connection.
+type ConsensusResumeFunc func(AppConns) error
+
 // multiAppConn implements AppConns.
 //
 // A multiAppConn is made of a few appConns and manages their underlying abci
-// clients.
-// TODO: on app restart, clients must reboot together
+// clients. When the connection to the application is lost (e.g. if it
+// restarts), the query, snapshot and mempool connections are reestablished.
+// The consensus connection is reestablished only if a ConsensusResumeFunc is
+// set, otherwise the process is killed.
 type multiAppConn struct {
 	service.BaseService
 
@@ -50,18 +62,61 @@ type multiAppConn struct {
 	queryConn     AppConnQuery
 	snapshotConn  AppConnSnapshot
 
-	consensusConnClient abcicli.Client
-	mempoolConnClient   abcicli.Client
-	queryConnClient     abcicli.Client
-	snapshotConnClient  abcicli.Client
+	consensusConnClient *supervisedClient
+	mempoolConnClients  []*supervisedClient
+	queryConnClient     *supervisedClient
+	snapshotConnClient  *supervisedClient
 
 	clientCreator ClientCreator
+
+	numMempoolConns     int
+	healthCheckInterval time.Duration
+	consensusResume     ConsensusResumeFunc
+}
+
+// MultiAppConnOption sets an optional parameter on the multiAppConn.
+type MultiAppConnOption func(*multiAppConn)
+
+// WithMempoolConnections sets the number of mempool connections to the
+// application (default 1). With more than one connection, CheckTx requests are
+// sent over all of them, see NewAppConnMempoolPool.
+func WithMempoolConnections(n int) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		if n > 0 {
+			app.numMempoolConns = n
+		}
+	}
+}
+
+// WithHealthCheckInterval sets the interval at which each connection sends an
+// echo request to the application, to measure its latency. An application
+// which doesn't answer within the interval is reported as unresponsive. The
+// health checks are disabled by default.
+func WithHealthCheckInterval(interval time.Duration) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.healthCheckInterval = interval
+	}
+}
+
+// WithConsensusResume makes the consensus connection to be reestablished when
+// it's lost between blocks, instead of killing the process. Consensus is halted
+// until then, and resume is called before it uses the new connection. The
+// process is still killed if the connection is lost while a block is executing,
+// since the application may hold a partially executed block.
+func WithConsensusResume(resume ConsensusResumeFunc) MultiAppConnOption {
+	return func(app *multiAppConn) {
+		app.consensusResume = resume
+	}
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator ClientCreator) AppConns {
+func NewMultiAppConn(clientCreator ClientCreator, options ...MultiAppConnOption) AppConns {
 	multiAppConn := &multiAppConn{
-		clientCreator: clientCreator,
+		clientCreator:   clientCreator,
+		numMempoolConns: 1,
+	}
+	for _, option := range options {
+		option(multiAppConn)
 	}
 	multiAppConn.BaseService = *service.NewBaseService(nil, "multiAppConn", multiAppConn)
 	return multiAppConn
@@ -83,15 +138,23 @@ func (app *multiAppConn) Snapshot() AppConnSnapshot {
 	return app.snapshotConn
 }
 
+func (app *multiAppConn) Status() []ConnStatus {
+	statuses := []ConnStatus{app.consensusConnClient.Status()}
+	for _, c := range app.mempoolConnClients {
+		statuses = append(statuses, c.Status())
+	}
+	return append(statuses, app.queryConnClient.Status(), app.snapshotConnClient.Status())
+}
+
 func (app *multiAppConn) OnStart() error {
-	c, err := app.abciClientFor(connQuery)
+	c, err := app.supervisedClientFor(connQuery)
 	if err != nil {
 		return err
 	}
 	app.queryConnClient = c
 	app.queryConn = NewAppConnQuery(c)
 
-	c, err = app.abciClientFor(connSnapshot)
+	c, err = app.supervisedClientFor(connSnapshot)
 	if err != nil {
 		app.stopAllClients()
 		return err
@@ -99,15 +162,29 @@ func (app *multiAppConn) OnStart() error {
 	app.snapshotConnClient = c
 	app.snapshotConn = NewAppConnSnapshot(c)
 
-	c, err = app.abciClientFor(connMempool)
-	if err != nil {
-		app.stopAllClients()
-		return err
+	for i := 0; i < app.numMempoolConns; i++ {
+		name := connMempool
+		if app.numMempoolConns > 1 {
+			name = fmt.Sprintf("%s/%d", connMempool, i)
+		}
+		c, err = app.supervisedClientFor(name)
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.mempoolConnClients = append(app.mempoolConnClients, c)
+	}
+	if len(app.mempoolConnClients) == 1 {
+		app.mempoolConn = NewAppConnMempool(app.mempoolConnClients[0])
+	} else {
+		clients := make([]abcicli.Client, len(app.mempoolConnClients))
+		for i, c := range app.mempoolConnClients {
+			clients[i] = c
+		}
+		app.mempoolConn = NewAppConnMempoolPool(clients)
 	}
-	app.mempoolConnClient = c
-	app.mempoolConn = NewAppConnMempool(c)
 
-	c, err = app.abciClientFor(connConsensus)
+	c, err = app.supervisedClientFor(connConsensus)
 	if err != nil {
 		app.stopAllClients()
 		return err
@@ -115,9 +192,6 @@ func (app *multiAppConn) OnStart() error {
 	app.consensusConnClient = c
 	app.consensusConn = NewAppConnConsensus(c)
 
-	// Kill Tendermint if the ABCI application crashes.
-	go app.killTMOnClientError()
-
 	return nil
 }
 
@@ -125,44 +199,14 @@ func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
 }
 
-func (app *multiAppConn) killTMOnClientError() {
-	killFn := func(conn string, err error, logger tmlog.Logger) {
-		logger.Error(
-			fmt.Sprintf("%s connection terminated. Did the application crash? Please restart tendermint", conn),
-			"err", err)
-		if killErr := kill(); killErr != nil {
-			logger.Error("Failed to kill this process - please do so manually", "err", killErr)
-		}
-	}
-
-	select {
-	case <-app.consensusConnClient.Quit():
-		if err := app.consensusConnClient.Error(); err != nil {
-			killFn(connConsensus, err, app.Logger)
-		}
-	case <-app.mempoolConnClient.Quit():
-		if err := app.mempoolConnClient.Error(); err != nil {
-			killFn(connMempool, err, app.Logger)
-		}
-	case <-app.queryConnClient.Quit():
-		if err := app.queryConnClient.Error(); err != nil {
-			killFn(connQuery, err, app.Logger)
-		}
-	case <-app.snapshotConnClient.Quit():
-		if err := app.snapshotConnClient.Error(); err != nil {
-			killFn(connSnapshot, err, app.Logger)
-		}
-	}
-}
-
 func (app *multiAppConn) stopAllClients() {
 	if app.consensusConnClient != nil {
 		if err := app.consensusConnClient.Stop(); err != nil {
 			app.Logger.Error("error while stopping consensus client", "error", err)
 		}
 	}
-	if app.mempoolConnClient != nil {
-		if err := app.mempoolConnClient.Stop(); err != nil {
+	for _, c := range app.mempoolConnClients {
+		if err := c.Stop(); err != nil {
 			app.Logger.Error("error while stopping mempool client", "error", err)
 		}
 	}
@@ -178,6 +222,49 @@ func (app *multiAppConn) stopAllClients() {
 	}
 }
 
+// supervisedClientFor starts a supervised client for the given connection.
+func (app *multiAppConn) supervisedClientFor(conn string) (*supervisedClient, error) {
+	client, err := app.abciClientFor(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newSupervisedClient(conn, client, func() (abcicli.Client, error) {
+		return app.abciClientFor(conn)
+	})
+	c.SetLogger(app.Logger.With("module", "abci-client", "connection", conn))
+	c.healthCheckInterval = app.healthCheckInterval
+	if conn == connConsensus {
+		// Kill Tendermint if the ABCI application crashes, unless consensus
+		// can resume between blocks.
+		c.kill = func(err error) {
+			app.Logger.Error(
+				fmt.Sprintf("%s connection terminated. Did the application crash? Please restart tendermint", conn),
+				"err", err)
+			if killErr := kill(); killErr != nil {
+				app.Logger.Error("Failed to kill this process - please do so manually", "err", killErr)
+			}
+		}
+		if app.consensusResume != nil {
+			c.lostState = ConnStateHalted
+			c.resume = func(client abcicli.Client) error {
+				return app.consensusResume(&resumeAppConns{
+					AppConns:      app,
+					consensusConn: NewAppConnConsensus(client),
+				})
+			}
+		}
+	}
+
+	if err := c.Start(); err != nil {
+		if err := client.Stop(); err != nil {
+			app.Logger.Error("error while stopping client", "error", err)
+		}
+		return nil, fmt.Errorf("error starting supervised ABCI client (%s connection): %w", conn, err)
+	}
+	return c, nil
+}
+
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	c, err := app.clientCreator.NewABCIClient()
 	if err != nil {
@@ -190,6 +277,18 @@ func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
 	return c, nil
 }
 
+// resumeAppConns are the connections given to the ConsensusResumeFunc, with
+// the new consensus connection.
+type resumeAppConns struct {
+	AppConns
+
+	consensusConn AppConnConsensus
+}
+
+func (conns *resumeAppConns) Consensus() AppConnConsensus {
+	return conns.consensusConn
+}
+
 func kill() error {
 	p, err := os.FindProcess(os.Getpid())
 	if err != nil {
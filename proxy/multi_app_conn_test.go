@@ -41,7 +41,36 @@ func TestAppConns_Start_Stop(t *testing.T) {
 	clientMock.AssertExpectations(t)
 }
 
-// Upon failure, we call tmos.Kill
+func TestAppConns_MempoolConnections(t *testing.T) {
+	quitCh := make(<-chan struct{})
+
+	clientCreatorMock := &mocks.ClientCreator{}
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("SetLogger", mock.Anything).Return().Times(6)
+	clientMock.On("SetResponseCallback", mock.Anything).Return().Times(3)
+	clientMock.On("Start").Return(nil).Times(6)
+	clientMock.On("Stop").Return(nil).Times(6)
+	clientMock.On("Quit").Return(quitCh).Times(6)
+
+	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil).Times(6)
+
+	appConns := NewAppConns(clientCreatorMock, WithMempoolConnections(3))
+
+	err := appConns.Start()
+	require.NoError(t, err)
+	require.IsType(t, &appConnMempoolPool{}, appConns.Mempool())
+
+	time.Sleep(100 * time.Millisecond)
+
+	err = appConns.Stop()
+	require.NoError(t, err)
+
+	clientMock.AssertExpectations(t)
+}
+
+// Upon failure of the consensus connection, without a ConsensusResumeFunc, we
+// call tmos.Kill
 func TestAppConns_Failure(t *testing.T) {
 	ok := make(chan struct{})
 	c := make(chan os.Signal, 1)
@@ -64,7 +93,7 @@ func TestAppConns_Failure(t *testing.T) {
 	clientMock.On("Stop").Return(nil)
 
 	clientMock.On("Quit").Return(recvQuitCh)
-	clientMock.On("Error").Return(errors.New("EOF")).Once()
+	clientMock.On("Error").Return(errors.New("EOF"))
 
 	clientCreatorMock.On("NewABCIClient").Return(clientMock, nil)
 
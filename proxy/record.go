@@ -0,0 +1,583 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"path/filepath"
+	"time"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/types"
+	auto "github.com/klyed/tendermint/libs/autofile"
+	"github.com/klyed/tendermint/libs/log"
+	tmos "github.com/klyed/tendermint/libs/os"
+	"github.com/klyed/tendermint/libs/service"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+const (
+	// maxRecordSizeBytes is the maximum size of a recorded call.
+	maxRecordSizeBytes = 100 * 1024 * 1024 // 100MB
+
+	// how often the recording is flushed to disk
+	recorderFlushInterval = 2 * time.Second
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// RecordedCall is an ABCI request and the response of the application,
+// recorded on one of the connections to the application.
+type RecordedCall struct {
+	// Conn is the index of the connection the call was made on, in the order
+	// the connections were created.
+	Conn     int
+	Request  *types.Request
+	Response *types.Response
+}
+
+//----------------------------------------------------------------------------
+// Recorder
+
+// Recorder records ABCI calls to a group of rotating files, so that they can
+// be replayed against an application with ReplayRecording, e.g. to find out
+// why it diverged.
+//
+// Calls are recorded in the order responses are received. The recording is
+// flushed to disk every 2s, after each commit and once when stopped.
+type Recorder struct {
+	service.BaseService
+
+	mtx   tmsync.Mutex
+	group *auto.Group
+	enc   *RecordEncoder
+
+	nextConn    int
+	flushTicker *time.Ticker
+}
+
+// NewRecorder returns a new recorder writing to file, which is the head of the
+// group of files.
+func NewRecorder(file string, groupOptions ...func(*auto.Group)) (*Recorder, error) {
+	if err := tmos.EnsureDir(filepath.Dir(file), 0700); err != nil {
+		return nil, fmt.Errorf("failed to ensure ABCI recording directory is in place: %w", err)
+	}
+
+	group, err := auto.OpenGroup(file, groupOptions...)
+	if err != nil {
+		return nil, err
+	}
+	rec := &Recorder{
+		group: group,
+		enc:   NewRecordEncoder(group),
+	}
+	rec.BaseService = *service.NewBaseService(nil, "ABCIRecorder", rec)
+	return rec, nil
+}
+
+func (rec *Recorder) SetLogger(l log.Logger) {
+	rec.BaseService.Logger = l
+	rec.group.SetLogger(l)
+}
+
+func (rec *Recorder) OnStart() error {
+	if err := rec.group.Start(); err != nil {
+		return err
+	}
+	rec.flushTicker = time.NewTicker(recorderFlushInterval)
+	go rec.processFlushTicks()
+	return nil
+}
+
+func (rec *Recorder) processFlushTicks() {
+	for {
+		select {
+		case <-rec.flushTicker.C:
+			if err := rec.flush(); err != nil {
+				rec.Logger.Error("Periodic ABCI recording flush failed", "err", err)
+			}
+		case <-rec.Quit():
+			return
+		}
+	}
+}
+
+// OnStop flushes and closes the recording.
+func (rec *Recorder) OnStop() {
+	rec.flushTicker.Stop()
+
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	if err := rec.group.FlushAndSync(); err != nil {
+		rec.Logger.Error("error on flush data to disk", "error", err)
+	}
+	if err := rec.group.Stop(); err != nil {
+		rec.Logger.Error("error trying to stop ABCI recording", "error", err)
+	}
+	rec.group.Close()
+}
+
+func (rec *Recorder) flush() error {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	return rec.group.FlushAndSync()
+}
+
+// Record writes a call to the recording. Calls made after the recorder is
+// stopped are ignored.
+func (rec *Recorder) Record(call *RecordedCall) error {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+
+	if !rec.IsRunning() {
+		return nil
+	}
+	if err := rec.enc.Encode(call); err != nil {
+		return err
+	}
+	if _, ok := call.Response.Value.(*types.Response_Commit); ok {
+		return rec.group.FlushAndSync()
+	}
+	return nil
+}
+
+// newConn returns the index of a new connection.
+func (rec *Recorder) newConn() int {
+	rec.mtx.Lock()
+	defer rec.mtx.Unlock()
+	conn := rec.nextConn
+	rec.nextConn++
+	return conn
+}
+
+// OpenRecording opens the group of files written by a Recorder to file, and
+// returns a reader over all of them.
+func OpenRecording(file string) (io.ReadCloser, error) {
+	group, err := auto.OpenGroup(file)
+	if err != nil {
+		return nil, err
+	}
+	rd, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		group.Close()
+		return nil, err
+	}
+	return &recordingReader{GroupReader: rd, group: group}, nil
+}
+
+type recordingReader struct {
+	*auto.GroupReader
+	group *auto.Group
+}
+
+func (rd *recordingReader) Close() error {
+	err := rd.GroupReader.Close()
+	rd.group.Close()
+	return err
+}
+
+//----------------------------------------------------------------------------
+// Encoding
+
+// RecordEncoder writes recorded calls to an output stream.
+//
+// Format: 4 bytes CRC sum + 4 bytes length + the connection index as a uvarint,
+// followed by the request and the response, each prefixed by its length as a
+// uvarint.
+type RecordEncoder struct {
+	wr io.Writer
+}
+
+// NewRecordEncoder returns a new encoder that writes to wr.
+func NewRecordEncoder(wr io.Writer) *RecordEncoder {
+	return &RecordEncoder{wr}
+}
+
+// Encode writes the encoding of call to the stream.
+func (enc *RecordEncoder) Encode(call *RecordedCall) error {
+	req, err := call.Request.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	res, err := call.Response.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	data := make([]byte, 0, 3*binary.MaxVarintLen64+len(req)+len(res))
+	data = appendUvarint(data, uint64(call.Conn))
+	data = appendUvarint(data, uint64(len(req)))
+	data = append(data, req...)
+	data = appendUvarint(data, uint64(len(res)))
+	data = append(data, res...)
+
+	length := uint32(len(data))
+	if length > maxRecordSizeBytes {
+		return fmt.Errorf("call is too big: %d bytes, max: %d bytes", length, maxRecordSizeBytes)
+	}
+
+	msg := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint32(msg[0:4], crc32.Checksum(data, crc32c))
+	binary.BigEndian.PutUint32(msg[4:8], length)
+	copy(msg[8:], data)
+
+	_, err = enc.wr.Write(msg)
+	return err
+}
+
+func appendUvarint(bz []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(bz, buf[:n]...)
+}
+
+// RecordDecoder reads recorded calls from an input stream. See RecordEncoder
+// for the format used.
+type RecordDecoder struct {
+	rd io.Reader
+}
+
+// NewRecordDecoder returns a new decoder that reads from rd.
+func NewRecordDecoder(rd io.Reader) *RecordDecoder {
+	return &RecordDecoder{bufio.NewReader(rd)}
+}
+
+// Decode reads the next call. It returns io.EOF at the end of the stream.
+func (dec *RecordDecoder) Decode() (*RecordedCall, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(dec.rd, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	crc := binary.BigEndian.Uint32(header[0:4])
+	length := binary.BigEndian.Uint32(header[4:8])
+	if length > maxRecordSizeBytes {
+		return nil, fmt.Errorf("length %d exceeded maximum possible value of %d bytes", length, maxRecordSizeBytes)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(dec.rd, data); err != nil {
+		return nil, fmt.Errorf("failed to read data: %w", err)
+	}
+	if actualCRC := crc32.Checksum(data, crc32c); actualCRC != crc {
+		return nil, fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)
+	}
+
+	conn, data, err := readUvarint(data)
+	if err != nil {
+		return nil, err
+	}
+	req, data, err := readBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := readBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &RecordedCall{
+		Conn:     int(conn),
+		Request:  &types.Request{},
+		Response: &types.Response{},
+	}
+	if err := call.Request.Unmarshal(req); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	if err := call.Response.Unmarshal(res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return call, nil
+}
+
+func readUvarint(data []byte) (uint64, []byte, error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, errors.New("failed to read uvarint")
+	}
+	return v, data[n:], nil
+}
+
+func readBytes(data []byte) ([]byte, []byte, error) {
+	length, data, err := readUvarint(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if uint64(len(data)) < length {
+		return nil, nil, fmt.Errorf("expected %d bytes, got %d", length, len(data))
+	}
+	return data[:length], data[length:], nil
+}
+
+//----------------------------------------------------------------------------
+// Recording clients
+
+type recordingClientCreator struct {
+	clientCreator ClientCreator
+	recorder      *Recorder
+}
+
+// NewRecordingClientCreator returns a ClientCreator whose clients record all
+// the calls they make to rec. The recorder must be started separately.
+func NewRecordingClientCreator(clientCreator ClientCreator, rec *Recorder) ClientCreator {
+	return &recordingClientCreator{
+		clientCreator: clientCreator,
+		recorder:      rec,
+	}
+}
+
+func (r *recordingClientCreator) NewABCIClient() (abcicli.Client, error) {
+	client, err := r.clientCreator.NewABCIClient()
+	if err != nil {
+		return nil, err
+	}
+	return &recordingClient{
+		Client:   client,
+		conn:     r.recorder.newConn(),
+		recorder: r.recorder,
+	}, nil
+}
+
+// recordingClient wraps an ABCI client and records all its calls, except
+// flushes.
+type recordingClient struct {
+	abcicli.Client
+
+	conn     int
+	recorder *Recorder
+}
+
+var _ abcicli.Client = (*recordingClient)(nil)
+
+func (cli *recordingClient) record(req *types.Request, res *types.Response) {
+	err := cli.recorder.Record(&RecordedCall{Conn: cli.conn, Request: req, Response: res})
+	if err != nil {
+		cli.recorder.Logger.Error("Failed to record ABCI call", "err", err)
+	}
+}
+
+// recordAsync returns a ReqRes which is completed once the call is recorded.
+func (cli *recordingClient) recordAsync(reqRes *abcicli.ReqRes, err error) (*abcicli.ReqRes, error) {
+	if err != nil {
+		return nil, err
+	}
+	recorded := abcicli.NewReqRes(reqRes.Request)
+	reqRes.SetCallback(func(res *types.Response) {
+		cli.record(reqRes.Request, res)
+		recorded.Complete(res)
+	})
+	return recorded, nil
+}
+
+func (cli *recordingClient) EchoAsync(ctx context.Context, msg string) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.EchoAsync(ctx, msg))
+}
+
+func (cli *recordingClient) InfoAsync(ctx context.Context, req types.RequestInfo) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.InfoAsync(ctx, req))
+}
+
+func (cli *recordingClient) DeliverTxAsync(ctx context.Context, req types.RequestDeliverTx) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.DeliverTxAsync(ctx, req))
+}
+
+func (cli *recordingClient) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.CheckTxAsync(ctx, req))
+}
+
+func (cli *recordingClient) QueryAsync(ctx context.Context, req types.RequestQuery) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.QueryAsync(ctx, req))
+}
+
+func (cli *recordingClient) CommitAsync(ctx context.Context) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.CommitAsync(ctx))
+}
+
+func (cli *recordingClient) InitChainAsync(ctx context.Context, req types.RequestInitChain) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.InitChainAsync(ctx, req))
+}
+
+func (cli *recordingClient) BeginBlockAsync(ctx context.Context, req types.RequestBeginBlock) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.BeginBlockAsync(ctx, req))
+}
+
+func (cli *recordingClient) EndBlockAsync(ctx context.Context, req types.RequestEndBlock) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.EndBlockAsync(ctx, req))
+}
+
+func (cli *recordingClient) ListSnapshotsAsync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.ListSnapshotsAsync(ctx, req))
+}
+
+func (cli *recordingClient) OfferSnapshotAsync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.OfferSnapshotAsync(ctx, req))
+}
+
+func (cli *recordingClient) LoadSnapshotChunkAsync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.LoadSnapshotChunkAsync(ctx, req))
+}
+
+func (cli *recordingClient) ApplySnapshotChunkAsync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.ApplySnapshotChunkAsync(ctx, req))
+}
+
+func (cli *recordingClient) FinalizeBlockAsync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*abcicli.ReqRes, error) {
+	return cli.recordAsync(cli.Client.FinalizeBlockAsync(ctx, req))
+}
+
+func (cli *recordingClient) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	res, err := cli.Client.EchoSync(ctx, msg)
+	if err == nil {
+		cli.record(types.ToRequestEcho(msg), types.ToResponseEcho(res.Message))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	res, err := cli.Client.InfoSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestInfo(req), types.ToResponseInfo(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) DeliverTxSync(
+	ctx context.Context,
+	req types.RequestDeliverTx,
+) (*types.ResponseDeliverTx, error) {
+	res, err := cli.Client.DeliverTxSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestDeliverTx(req), types.ToResponseDeliverTx(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	res, err := cli.Client.CheckTxSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestCheckTx(req), types.ToResponseCheckTx(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) QuerySync(ctx context.Context, req types.RequestQuery) (*types.ResponseQuery, error) {
+	res, err := cli.Client.QuerySync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestQuery(req), types.ToResponseQuery(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	res, err := cli.Client.CommitSync(ctx)
+	if err == nil {
+		cli.record(types.ToRequestCommit(), types.ToResponseCommit(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) InitChainSync(
+	ctx context.Context,
+	req types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	res, err := cli.Client.InitChainSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestInitChain(req), types.ToResponseInitChain(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) BeginBlockSync(
+	ctx context.Context,
+	req types.RequestBeginBlock,
+) (*types.ResponseBeginBlock, error) {
+	res, err := cli.Client.BeginBlockSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestBeginBlock(req), types.ToResponseBeginBlock(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) EndBlockSync(ctx context.Context, req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+	res, err := cli.Client.EndBlockSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestEndBlock(req), types.ToResponseEndBlock(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) ListSnapshotsSync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*types.ResponseListSnapshots, error) {
+	res, err := cli.Client.ListSnapshotsSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestListSnapshots(req), types.ToResponseListSnapshots(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) OfferSnapshotSync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*types.ResponseOfferSnapshot, error) {
+	res, err := cli.Client.OfferSnapshotSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestOfferSnapshot(req), types.ToResponseOfferSnapshot(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) LoadSnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*types.ResponseLoadSnapshotChunk, error) {
+	res, err := cli.Client.LoadSnapshotChunkSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestLoadSnapshotChunk(req), types.ToResponseLoadSnapshotChunk(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) ApplySnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*types.ResponseApplySnapshotChunk, error) {
+	res, err := cli.Client.ApplySnapshotChunkSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestApplySnapshotChunk(req), types.ToResponseApplySnapshotChunk(*res))
+	}
+	return res, err
+}
+
+func (cli *recordingClient) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	res, err := cli.Client.FinalizeBlockSync(ctx, req)
+	if err == nil {
+		cli.record(types.ToRequestFinalizeBlock(req), types.ToResponseFinalizeBlock(*res))
+	}
+	return res, err
+}
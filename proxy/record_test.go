@@ -0,0 +1,140 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/abci/example/kvstore"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/libs/log"
+)
+
+func TestRecordEncoderDecoder(t *testing.T) {
+	calls := []*RecordedCall{
+		{
+			Conn:     0,
+			Request:  types.ToRequestInfo(types.RequestInfo{Version: "1"}),
+			Response: types.ToResponseInfo(types.ResponseInfo{LastBlockHeight: 3}),
+		},
+		{
+			Conn:     3,
+			Request:  types.ToRequestCommit(),
+			Response: types.ToResponseCommit(types.ResponseCommit{Data: []byte("hash")}),
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	enc := NewRecordEncoder(buf)
+	for _, call := range calls {
+		require.NoError(t, enc.Encode(call))
+	}
+	bz := buf.Bytes()
+
+	dec := NewRecordDecoder(bytes.NewReader(bz))
+	for _, call := range calls {
+		decoded, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, call.Conn, decoded.Conn)
+		assert.Equal(t, call.Request.String(), decoded.Request.String())
+		assert.Equal(t, call.Response.String(), decoded.Response.String())
+	}
+	_, err := dec.Decode()
+	require.Equal(t, io.EOF, err)
+
+	// corrupted data
+	bz[len(bz)-1] ^= 0xff
+	dec = NewRecordDecoder(bytes.NewReader(bz))
+	_, err = dec.Decode()
+	require.NoError(t, err)
+	_, err = dec.Decode()
+	require.Error(t, err)
+
+	// truncated data
+	dec = NewRecordDecoder(bytes.NewReader(bz[:len(bz)-1]))
+	_, err = dec.Decode()
+	require.NoError(t, err)
+	_, err = dec.Decode()
+	require.Error(t, err)
+}
+
+// divergingApp is a kvstore app which returns a different app hash.
+type divergingApp struct {
+	*kvstore.Application
+}
+
+func (app *divergingApp) Commit() types.ResponseCommit {
+	res := app.Application.Commit()
+	res.Data = append(res.Data, 1)
+	return res
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "record")
+	rec, err := NewRecorder(file)
+	require.NoError(t, err)
+	rec.SetLogger(log.TestingLogger())
+	require.NoError(t, rec.Start())
+
+	appConns := NewAppConns(NewRecordingClientCreator(NewLocalClientCreator(kvstore.NewApplication()), rec))
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ctx := context.Background()
+	_, err = appConns.Query().InfoSync(ctx, RequestInfo)
+	require.NoError(t, err)
+	_, err = appConns.Consensus().InitChainSync(ctx, types.RequestInitChain{})
+	require.NoError(t, err)
+	_, err = appConns.Mempool().CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte("a=1")})
+	require.NoError(t, err)
+	_, err = appConns.Consensus().BeginBlockSync(ctx, types.RequestBeginBlock{})
+	require.NoError(t, err)
+	var delivered []*types.Response
+	appConns.Consensus().SetResponseCallback(func(_ *types.Request, res *types.Response) {
+		delivered = append(delivered, res)
+	})
+	reqRes, err := appConns.Consensus().DeliverTxAsync(ctx, types.RequestDeliverTx{Tx: []byte("a=1")})
+	require.NoError(t, err)
+	reqRes.Wait()
+	_, err = appConns.Consensus().EndBlockSync(ctx, types.RequestEndBlock{Height: 1})
+	require.NoError(t, err)
+	_, err = appConns.Consensus().CommitSync(ctx)
+	require.NoError(t, err)
+	_, err = appConns.Query().QuerySync(ctx, types.RequestQuery{Path: "/store", Data: []byte("a")})
+	require.NoError(t, err)
+
+	require.Len(t, delivered, 1)
+	require.NotNil(t, reqRes.Response.GetDeliverTx())
+
+	require.NoError(t, rec.Stop())
+
+	replay := func(app types.Application) (int, *Divergence) {
+		rd, err := OpenRecording(file)
+		require.NoError(t, err)
+		defer rd.Close()
+		n, div, err := ReplayRecording(ctx, rd, NewLocalClientCreator(app))
+		require.NoError(t, err)
+		return n, div
+	}
+
+	n, div := replay(kvstore.NewApplication())
+	assert.Nil(t, div)
+	assert.Equal(t, 8, n)
+
+	n, div = replay(&divergingApp{kvstore.NewApplication()})
+	require.NotNil(t, div)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, 6, div.Index)
+	assert.NotNil(t, div.Call.Request.GetCommit())
+	assert.NotEqual(t, div.Call.Response.GetCommit().Data, div.Response.GetCommit().Data)
+}
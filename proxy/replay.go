@@ -0,0 +1,170 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/types"
+)
+
+// Divergence is a response of the application which differs from the recorded
+// one.
+type Divergence struct {
+	// Index is the index of the call in the recording.
+	Index    int
+	Call     *RecordedCall
+	Response *types.Response
+}
+
+func (d *Divergence) String() string {
+	return fmt.Sprintf("call #%d on connection %d: %v\nrecorded response: %v\nactual response:   %v",
+		d.Index, d.Call.Conn, d.Call.Request, d.Call.Response, d.Response)
+}
+
+// ReplayRecording replays the calls recorded by a Recorder against an
+// application, with a new client for each recorded connection, and stops at
+// the first response which differs from the recorded one. The application
+// should be in the same state as when the recording started, e.g. a fresh
+// application if the node started from genesis.
+//
+// It returns the number of calls replayed, and the divergence, if any.
+func ReplayRecording(ctx context.Context, rd io.Reader, clientCreator ClientCreator) (int, *Divergence, error) {
+	clients := make(map[int]abcicli.Client)
+	defer func() {
+		for _, client := range clients {
+			_ = client.Stop()
+		}
+	}()
+
+	dec := NewRecordDecoder(rd)
+	for i := 0; ; i++ {
+		call, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return i, nil, nil
+		} else if err != nil {
+			return i, nil, fmt.Errorf("failed to read call #%d: %w", i, err)
+		}
+
+		client, ok := clients[call.Conn]
+		if !ok {
+			client, err = clientCreator.NewABCIClient()
+			if err != nil {
+				return i, nil, fmt.Errorf("error creating ABCI client (connection %d): %w", call.Conn, err)
+			}
+			if err := client.Start(); err != nil {
+				return i, nil, fmt.Errorf("error starting ABCI client (connection %d): %w", call.Conn, err)
+			}
+			clients[call.Conn] = client
+		}
+
+		res, err := execSync(ctx, client, call.Request)
+		if err != nil {
+			return i, nil, fmt.Errorf("failed to replay call #%d: %w", i, err)
+		}
+		if !proto.Equal(res, call.Response) {
+			return i, &Divergence{Index: i, Call: call, Response: res}, nil
+		}
+	}
+}
+
+// execSync sends a request to the application and waits for the response.
+func execSync(ctx context.Context, client abcicli.Client, req *types.Request) (*types.Response, error) {
+	switch r := req.Value.(type) {
+	case *types.Request_Echo:
+		res, err := client.EchoSync(ctx, r.Echo.Message)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseEcho(res.Message), nil
+	case *types.Request_Flush:
+		if err := client.FlushSync(ctx); err != nil {
+			return nil, err
+		}
+		return types.ToResponseFlush(), nil
+	case *types.Request_Info:
+		res, err := client.InfoSync(ctx, *r.Info)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseInfo(*res), nil
+	case *types.Request_DeliverTx:
+		res, err := client.DeliverTxSync(ctx, *r.DeliverTx)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseDeliverTx(*res), nil
+	case *types.Request_CheckTx:
+		res, err := client.CheckTxSync(ctx, *r.CheckTx)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseCheckTx(*res), nil
+	case *types.Request_Commit:
+		res, err := client.CommitSync(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseCommit(*res), nil
+	case *types.Request_Query:
+		res, err := client.QuerySync(ctx, *r.Query)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseQuery(*res), nil
+	case *types.Request_InitChain:
+		res, err := client.InitChainSync(ctx, *r.InitChain)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseInitChain(*res), nil
+	case *types.Request_BeginBlock:
+		res, err := client.BeginBlockSync(ctx, *r.BeginBlock)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseBeginBlock(*res), nil
+	case *types.Request_EndBlock:
+		res, err := client.EndBlockSync(ctx, *r.EndBlock)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseEndBlock(*res), nil
+	case *types.Request_ListSnapshots:
+		res, err := client.ListSnapshotsSync(ctx, *r.ListSnapshots)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseListSnapshots(*res), nil
+	case *types.Request_OfferSnapshot:
+		res, err := client.OfferSnapshotSync(ctx, *r.OfferSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseOfferSnapshot(*res), nil
+	case *types.Request_LoadSnapshotChunk:
+		res, err := client.LoadSnapshotChunkSync(ctx, *r.LoadSnapshotChunk)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseLoadSnapshotChunk(*res), nil
+	case *types.Request_ApplySnapshotChunk:
+		res, err := client.ApplySnapshotChunkSync(ctx, *r.ApplySnapshotChunk)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseApplySnapshotChunk(*res), nil
+	case *types.Request_FinalizeBlock:
+		res, err := client.FinalizeBlockSync(ctx, *r.FinalizeBlock)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseFinalizeBlock(*res), nil
+	default:
+		return nil, fmt.Errorf("unknown request %T", req.Value)
+	}
+}
@@ -0,0 +1,658 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/libs/service"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+const (
+	minReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff = 10 * time.Second
+)
+
+// ErrAppConnDown is returned by the requests sent on a connection to the
+// application while it is down, until it is reestablished.
+var ErrAppConnDown = errors.New("connection to the application is down")
+
+// ErrBlockNotStarted is returned, wrapping ErrAppConnDown, by the requests
+// starting the execution of a block (BeginBlock and FinalizeBlock) while the
+// consensus connection is down: the application received none of the block,
+// so it can be executed again once the connection is reestablished.
+var ErrBlockNotStarted = fmt.Errorf("block not started: %w", ErrAppConnDown)
+
+var errClientStopped = errors.New("client stopped")
+
+// ConnState is the state of a connection to the application.
+type ConnState string
+
+const (
+	// ConnStateConnected means the connection is up, and the application
+	// answered the last health check, if any.
+	ConnStateConnected ConnState = "connected"
+	// ConnStateUnresponsive means the connection is up, but the application
+	// didn't answer the last health check in time.
+	ConnStateUnresponsive ConnState = "unresponsive"
+	// ConnStateReconnecting means the connection was lost and is being
+	// reestablished.
+	ConnStateReconnecting ConnState = "reconnecting"
+	// ConnStateHalted means the consensus connection was lost: consensus is
+	// halted until the connection is reestablished and the application is
+	// synced again, see WithConsensusResume.
+	ConnStateHalted ConnState = "halted"
+)
+
+// ConnStatus is the status of a connection to the application.
+type ConnStatus struct {
+	// Name is the name of the connection, e.g. "consensus" or "mempool".
+	Name  string
+	State ConnState
+	// Latency is the time the application took to answer the last health
+	// check.
+	Latency time.Duration
+	// LastCheck is the time of the last health check, zero if none.
+	LastCheck time.Time
+	// Reconnects is the number of times the connection was reestablished.
+	Reconnects int
+	// LastError is the last error of the connection, empty if none.
+	LastError string
+}
+
+// supervisedClient is an ABCI client which watches the connection to the
+// application and, when it is lost, reestablishes it with a new client, with
+// an exponential backoff. While the connection is down, all requests fail with
+// ErrAppConnDown.
+//
+// If healthCheckInterval is positive, it also sends an echo request to the
+// application at that interval to measure its latency, and marks it as
+// unresponsive if it doesn't answer within the interval.
+type supervisedClient struct {
+	service.BaseService
+
+	name    string
+	connect func() (abcicli.Client, error) // creates and starts a new client
+
+	// resume is called with a new client before it is used, if set.
+	resume func(abcicli.Client) error
+	// lostState is the state of the connection while it is reestablished.
+	lostState ConnState
+	// kill is called instead of reestablishing the connection, if set, unless
+	// resume is set too and no block is executing.
+	kill func(error)
+	// executing is 1 from the request starting the execution of a block until
+	// it's committed. If the connection is lost in the meantime, the
+	// application may hold a partially executed block, so it can't resume.
+	executing uint32
+
+	healthCheckInterval time.Duration
+	checking            uint32 // 1 while a health check is in flight
+
+	mtx    tmsync.RWMutex
+	client abcicli.Client // nil while the connection is down
+	resCb  abcicli.Callback
+	status ConnStatus
+}
+
+var _ abcicli.Client = (*supervisedClient)(nil)
+
+// newSupervisedClient returns a supervised client for the given running
+// client. connect is used to create and start a new client when the
+// connection is lost.
+func newSupervisedClient(name string, client abcicli.Client, connect func() (abcicli.Client, error)) *supervisedClient {
+	s := &supervisedClient{
+		name:      name,
+		connect:   connect,
+		lostState: ConnStateReconnecting,
+		client:    client,
+		status: ConnStatus{
+			Name:  name,
+			State: ConnStateConnected,
+		},
+	}
+	s.BaseService = *service.NewBaseService(nil, "supervisedClient", s)
+	return s
+}
+
+// OnStart implements Service by starting to watch the connection.
+func (s *supervisedClient) OnStart() error {
+	go s.superviseRoutine()
+	return nil
+}
+
+// OnStop implements Service by stopping the current client.
+func (s *supervisedClient) OnStop() {
+	s.mtx.RLock()
+	client := s.client
+	s.mtx.RUnlock()
+
+	if client != nil {
+		if err := client.Stop(); err != nil {
+			s.Logger.Error("error while stopping client", "err", err)
+		}
+	}
+}
+
+// Status returns the status of the connection.
+func (s *supervisedClient) Status() ConnStatus {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.status
+}
+
+func (s *supervisedClient) superviseRoutine() {
+	var healthCheck <-chan time.Time
+	if s.healthCheckInterval > 0 {
+		ticker := time.NewTicker(s.healthCheckInterval)
+		defer ticker.Stop()
+		healthCheck = ticker.C
+	}
+
+	for {
+		s.mtx.RLock()
+		client := s.client
+		s.mtx.RUnlock()
+
+		quit := client.Quit()
+	WAIT:
+		for {
+			select {
+			case <-quit:
+				break WAIT
+			case <-healthCheck:
+				if atomic.CompareAndSwapUint32(&s.checking, 0, 1) {
+					go s.checkHealth(client)
+				}
+			case <-s.Quit():
+				return
+			}
+		}
+		if !s.IsRunning() {
+			return
+		}
+
+		err := client.Error()
+		if err == nil {
+			err = errClientStopped
+		}
+		executing := atomic.LoadUint32(&s.executing) == 1
+		if s.kill != nil && (s.resume == nil || executing) {
+			if executing {
+				s.Logger.Error("Lost connection to the application while executing a block", "err", err)
+			}
+			s.kill(err)
+			return
+		}
+
+		s.Logger.Error("Lost connection to the application, reconnecting", "err", err)
+		s.mtx.Lock()
+		s.client = nil
+		s.status.State = s.lostState
+		s.status.LastError = err.Error()
+		s.mtx.Unlock()
+
+		if !s.reconnect() {
+			return
+		}
+	}
+}
+
+// reconnect reestablishes the connection, retrying with an exponential
+// backoff. It returns false if the client was stopped.
+func (s *supervisedClient) reconnect() bool {
+	backoff := minReconnectBackoff
+	for {
+		select {
+		case <-time.After(backoff):
+		case <-s.Quit():
+			return false
+		}
+
+		client, err := s.connectAndResume()
+		if err == nil {
+			return s.swap(client)
+		}
+
+		backoff *= 2
+		if backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+
+		s.Logger.Error("Failed to reconnect to the application", "err", err, "retry_in", backoff)
+		s.mtx.Lock()
+		s.status.LastError = err.Error()
+		s.mtx.Unlock()
+	}
+}
+
+func (s *supervisedClient) connectAndResume() (abcicli.Client, error) {
+	client, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	if s.resume != nil {
+		if err := s.resume(client); err != nil {
+			if err := client.Stop(); err != nil {
+				s.Logger.Error("error while stopping client", "err", err)
+			}
+			return nil, fmt.Errorf("failed to resume: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// swap makes client the current client. It returns false, and stops client,
+// if the supervised client was stopped.
+func (s *supervisedClient) swap(client abcicli.Client) bool {
+	s.mtx.Lock()
+	if !s.IsRunning() {
+		s.mtx.Unlock()
+		if err := client.Stop(); err != nil {
+			s.Logger.Error("error while stopping client", "err", err)
+		}
+		return false
+	}
+	if s.resCb != nil {
+		client.SetResponseCallback(s.resCb)
+	}
+	s.client = client
+	s.status.State = ConnStateConnected
+	s.status.Reconnects++
+	s.mtx.Unlock()
+
+	s.Logger.Info("Reconnected to the application")
+	return true
+}
+
+// checkHealth sends an echo request to the application, and records its
+// latency.
+func (s *supervisedClient) checkHealth(client abcicli.Client) {
+	defer atomic.StoreUint32(&s.checking, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.healthCheckInterval)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.EchoSync(ctx, "health")
+	latency := time.Since(start)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.client != client {
+		// the connection was lost in the meantime
+		return
+	}
+	s.status.LastCheck = start
+	s.status.Latency = latency
+	if err != nil {
+		s.status.State = ConnStateUnresponsive
+		s.status.LastError = err.Error()
+		return
+	}
+	s.status.State = ConnStateConnected
+}
+
+// current returns the current client, or an error if the connection is down.
+func (s *supervisedClient) current() (abcicli.Client, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if s.client == nil {
+		return nil, fmt.Errorf("%s connection: %w", s.name, ErrAppConnDown)
+	}
+	return s.client, nil
+}
+
+// connError returns err, wrapped with ErrAppConnDown if the request failed
+// because the connection of client was lost.
+func (s *supervisedClient) connError(client abcicli.Client, err error) error {
+	if err == nil || errors.Is(err, ErrAppConnDown) || client.Error() == nil {
+		return err
+	}
+	return fmt.Errorf("%s connection: %w: %v", s.name, ErrAppConnDown, err)
+}
+
+func (s *supervisedClient) SetResponseCallback(cb abcicli.Callback) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.resCb = cb
+	if s.client != nil {
+		s.client.SetResponseCallback(cb)
+	}
+}
+
+func (s *supervisedClient) Error() error {
+	client, err := s.current()
+	if err != nil {
+		return err
+	}
+	return client.Error()
+}
+
+//----------------------------------------
+
+func (s *supervisedClient) FlushAsync(ctx context.Context) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.FlushAsync(ctx)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) EchoAsync(ctx context.Context, msg string) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.EchoAsync(ctx, msg)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) InfoAsync(ctx context.Context, req types.RequestInfo) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.InfoAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) DeliverTxAsync(ctx context.Context, req types.RequestDeliverTx) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.DeliverTxAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.CheckTxAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) QueryAsync(ctx context.Context, req types.RequestQuery) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.QueryAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) CommitAsync(ctx context.Context) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.CommitAsync(ctx)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) InitChainAsync(ctx context.Context, req types.RequestInitChain) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.InitChainAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) BeginBlockAsync(ctx context.Context, req types.RequestBeginBlock) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.BeginBlockAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) EndBlockAsync(ctx context.Context, req types.RequestEndBlock) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.EndBlockAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) ListSnapshotsAsync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ListSnapshotsAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) OfferSnapshotAsync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.OfferSnapshotAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) LoadSnapshotChunkAsync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.LoadSnapshotChunkAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) ApplySnapshotChunkAsync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ApplySnapshotChunkAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) FinalizeBlockAsync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*abcicli.ReqRes, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.FinalizeBlockAsync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+//----------------------------------------
+
+func (s *supervisedClient) FlushSync(ctx context.Context) error {
+	client, err := s.current()
+	if err != nil {
+		return err
+	}
+	return s.connError(client, client.FlushSync(ctx))
+}
+
+func (s *supervisedClient) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.EchoSync(ctx, msg)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.InfoSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) DeliverTxSync(
+	ctx context.Context,
+	req types.RequestDeliverTx,
+) (*types.ResponseDeliverTx, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.DeliverTxSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) CheckTxSync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*types.ResponseCheckTx, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.CheckTxSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) QuerySync(ctx context.Context, req types.RequestQuery) (*types.ResponseQuery, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.QuerySync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.CommitSync(ctx)
+	if err == nil {
+		atomic.StoreUint32(&s.executing, 0)
+	}
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) InitChainSync(
+	ctx context.Context,
+	req types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.InitChainSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) BeginBlockSync(
+	ctx context.Context,
+	req types.RequestBeginBlock,
+) (*types.ResponseBeginBlock, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, fmt.Errorf("%s connection: %w", s.name, ErrBlockNotStarted)
+	}
+	atomic.StoreUint32(&s.executing, 1)
+	res, err := client.BeginBlockSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) EndBlockSync(
+	ctx context.Context,
+	req types.RequestEndBlock,
+) (*types.ResponseEndBlock, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.EndBlockSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) ListSnapshotsSync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*types.ResponseListSnapshots, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ListSnapshotsSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) OfferSnapshotSync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*types.ResponseOfferSnapshot, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.OfferSnapshotSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) LoadSnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*types.ResponseLoadSnapshotChunk, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.LoadSnapshotChunkSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) ApplySnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*types.ResponseApplySnapshotChunk, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.ApplySnapshotChunkSync(ctx, req)
+	return res, s.connError(client, err)
+}
+
+func (s *supervisedClient) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	client, err := s.current()
+	if err != nil {
+		return nil, fmt.Errorf("%s connection: %w", s.name, ErrBlockNotStarted)
+	}
+	atomic.StoreUint32(&s.executing, 1)
+	res, err := client.FinalizeBlockSync(ctx, req)
+	return res, s.connError(client, err)
+}
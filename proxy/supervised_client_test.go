@@ -0,0 +1,258 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/klyed/tendermint/abci/client"
+	abcimocks "github.com/klyed/tendermint/abci/client/mocks"
+	"github.com/klyed/tendermint/abci/example/kvstore"
+	"github.com/klyed/tendermint/abci/server"
+	"github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/libs/log"
+	tmrand "github.com/klyed/tendermint/libs/rand"
+	"github.com/klyed/tendermint/libs/service"
+)
+
+func startSocketServer(t *testing.T, addr string, app types.Application) service.Service {
+	s, err := server.NewServer(addr, "socket", app)
+	require.NoError(t, err)
+	s.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, s.Start())
+	return s
+}
+
+func TestAppConns_Reconnect(t *testing.T) {
+	addr := fmt.Sprintf("unix:///tmp/test-reconnect-%s.sock", tmrand.Str(6))
+	app := kvstore.NewApplication()
+	srv := startSocketServer(t, addr, app)
+
+	resumed := make(chan struct{}, 1)
+	appConns := NewAppConns(NewRemoteClientCreator(addr, "socket", true),
+		WithMempoolConnections(2),
+		WithHealthCheckInterval(20*time.Millisecond),
+		WithConsensusResume(func(conns AppConns) error {
+			// the handshake would use the new consensus connection
+			if _, err := conns.Consensus().CommitSync(context.Background()); err != nil {
+				return err
+			}
+			resumed <- struct{}{}
+			return nil
+		}))
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	states := func() []ConnState {
+		var states []ConnState
+		for _, status := range appConns.Status() {
+			states = append(states, status.State)
+		}
+		return states
+	}
+
+	// the health checks measure the latency
+	require.Eventually(t, func() bool {
+		for _, status := range appConns.Status() {
+			if status.LastCheck.IsZero() {
+				return false
+			}
+		}
+		return true
+	}, time.Second, 10*time.Millisecond)
+	names := make([]string, 0, 5)
+	for _, status := range appConns.Status() {
+		names = append(names, status.Name)
+		assert.Equal(t, ConnStateConnected, status.State)
+		assert.Positive(t, status.Latency)
+	}
+	assert.Equal(t, []string{"consensus", "mempool/0", "mempool/1", "query", "snapshot"}, names)
+
+	// the application goes down
+	require.NoError(t, srv.Stop())
+	halted := []ConnState{ConnStateHalted, ConnStateReconnecting, ConnStateReconnecting,
+		ConnStateReconnecting, ConnStateReconnecting}
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(halted, states())
+	}, time.Second, 10*time.Millisecond)
+
+	ctx := context.Background()
+	_, err := appConns.Query().InfoSync(ctx, RequestInfo)
+	assert.True(t, errors.Is(err, ErrAppConnDown), err)
+	_, err = appConns.Consensus().CommitSync(ctx)
+	assert.True(t, errors.Is(err, ErrAppConnDown), err)
+	assert.Error(t, appConns.Mempool().Error())
+
+	// the application is back
+	srv = startSocketServer(t, addr, app)
+	t.Cleanup(func() {
+		if err := srv.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	select {
+	case <-resumed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the consensus connection to resume")
+	}
+	require.Eventually(t, func() bool {
+		for _, status := range appConns.Status() {
+			if status.State != ConnStateConnected || status.Reconnects != 1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = appConns.Query().InfoSync(ctx, RequestInfo)
+	require.NoError(t, err)
+	_, err = appConns.Mempool().CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte("a=1")})
+	require.NoError(t, err)
+	_, err = appConns.Consensus().CommitSync(ctx)
+	require.NoError(t, err)
+}
+
+func TestAppConns_ResumeFailure(t *testing.T) {
+	addr := fmt.Sprintf("unix:///tmp/test-resume-%s.sock", tmrand.Str(6))
+	srv := startSocketServer(t, addr, kvstore.NewApplication())
+	t.Cleanup(func() {
+		if err := srv.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	attempts := make(chan struct{}, 10)
+	appConns := NewAppConns(NewRemoteClientCreator(addr, "socket", true),
+		WithConsensusResume(func(conns AppConns) error {
+			attempts <- struct{}{}
+			return errors.New("app is ahead")
+		}))
+	appConns.SetLogger(log.TestingLogger())
+	require.NoError(t, appConns.Start())
+	t.Cleanup(func() {
+		if err := appConns.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// break the consensus connection only
+	client := appConns.(*multiAppConn).consensusConnClient
+	client.mtx.RLock()
+	require.NoError(t, client.client.Stop())
+	client.mtx.RUnlock()
+
+	// consensus stays halted while resuming fails, and it's retried
+	for i := 0; i < 2; i++ {
+		select {
+		case <-attempts:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a resume attempt")
+		}
+	}
+	status := appConns.Status()[0]
+	assert.Equal(t, ConnStateHalted, status.State)
+	assert.Contains(t, status.LastError, "app is ahead")
+	assert.Equal(t, 0, status.Reconnects)
+	assert.Equal(t, ConnStateConnected, appConns.Status()[1].State)
+}
+
+func TestSupervisedClient_ConnError(t *testing.T) {
+	appErr := errors.New("app failed")
+
+	// the connection is fine, so the error comes from the application
+	okMock := &abcimocks.Client{}
+	okMock.On("CommitSync", mock.Anything).Return(nil, appErr)
+	okMock.On("Error").Return(nil)
+	_, err := newSupervisedClient("consensus", okMock, nil).CommitSync(context.Background())
+	require.Equal(t, appErr, err)
+
+	// the request failed because the connection was lost
+	lostMock := &abcimocks.Client{}
+	lostMock.On("CommitSync", mock.Anything).Return(nil, appErr)
+	lostMock.On("Error").Return(errors.New("EOF"))
+	_, err = newSupervisedClient("consensus", lostMock, nil).CommitSync(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrAppConnDown))
+	assert.Contains(t, err.Error(), "app failed")
+}
+
+func TestSupervisedClient_BlockNotStarted(t *testing.T) {
+	s := newSupervisedClient("consensus", &abcimocks.Client{}, nil)
+	s.client = nil
+
+	_, err := s.BeginBlockSync(context.Background(), types.RequestBeginBlock{})
+	assert.True(t, errors.Is(err, ErrBlockNotStarted), err)
+	assert.True(t, errors.Is(err, ErrAppConnDown), err)
+	_, err = s.FinalizeBlockSync(context.Background(), types.RequestFinalizeBlock{})
+	assert.True(t, errors.Is(err, ErrBlockNotStarted), err)
+	_, err = s.CommitSync(context.Background())
+	assert.True(t, errors.Is(err, ErrAppConnDown), err)
+	assert.False(t, errors.Is(err, ErrBlockNotStarted), err)
+}
+
+func TestSupervisedClient_LostWhileExecuting(t *testing.T) {
+	testcases := map[string]struct {
+		commit     bool
+		expectKill bool
+	}{
+		"between blocks":  {true, false},
+		"executing block": {false, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			quit := make(chan struct{})
+			clientMock := &abcimocks.Client{}
+			clientMock.On("BeginBlockSync", mock.Anything, mock.Anything).Return(&types.ResponseBeginBlock{}, nil)
+			clientMock.On("CommitSync", mock.Anything).Return(&types.ResponseCommit{}, nil)
+			clientMock.On("Quit").Return((<-chan struct{})(quit))
+			clientMock.On("Error").Return(errors.New("EOF"))
+			clientMock.On("Stop").Return(nil)
+
+			killed := make(chan struct{}, 1)
+			reconnected := make(chan struct{}, 1)
+			s := newSupervisedClient("consensus", clientMock, func() (abcicli.Client, error) {
+				reconnected <- struct{}{}
+				return nil, errors.New("app is down")
+			})
+			s.SetLogger(log.TestingLogger())
+			s.resume = func(abcicli.Client) error { return nil }
+			s.kill = func(error) { killed <- struct{}{} }
+
+			_, err := s.BeginBlockSync(context.Background(), types.RequestBeginBlock{})
+			require.NoError(t, err)
+			if tc.commit {
+				_, err = s.CommitSync(context.Background())
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, s.Start())
+			t.Cleanup(func() {
+				if err := s.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+			close(quit)
+
+			select {
+			case <-killed:
+				assert.True(t, tc.expectKill, "unexpected kill")
+			case <-reconnected:
+				assert.False(t, tc.expectKill, "unexpected reconnection")
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected a kill or a reconnection")
+			}
+		})
+	}
+}
@@ -179,3 +179,52 @@ func TestHTTPReturnsErrorIfClientIsNotRunning(t *testing.T) {
 	err = c.UnsubscribeAll(context.Background(), "TestHeaderEvents")
 	assert.Error(t, err)
 }
+
+func TestEventLog(t *testing.T) {
+	ctx := context.Background()
+	one := 1
+
+	for _, c := range GetClients() {
+		c := c
+		t.Run(reflect.TypeOf(c).String(), func(t *testing.T) {
+			// find the current end of the log, if any
+			res, err := c.Events(ctx, "", &one, "", "", 0)
+			require.NoError(t, err)
+			start := res.Newest
+
+			_, _, tx := MakeTxKV()
+			txRes, err := c.BroadcastTxCommit(ctx, tx)
+			require.NoError(t, err)
+			require.True(t, txRes.DeliverTx.IsOK())
+
+			// the tx event is read from the cursor
+			filter := fmt.Sprintf("tm.event='Tx' AND tx.hash='%X'", types.Tx(tx).Hash())
+			res, err = c.Events(ctx, filter, nil, start, "", 0)
+			require.NoError(t, err)
+			require.Len(t, res.Items, 1)
+			assert.False(t, res.More)
+			assert.Equal(t, types.EventTx, res.Items[0].Event)
+			data, ok := res.Items[0].Data.(types.EventDataTx)
+			require.True(t, ok, "got %T", res.Items[0].Data)
+			assert.EqualValues(t, tx, data.Tx)
+
+			// but not after it
+			res, err = c.Events(ctx, filter, nil, res.Items[0].Cursor, "", 0)
+			require.NoError(t, err)
+			assert.Empty(t, res.Items)
+
+			// wait for the next block header after the tx
+			res, err = c.Events(ctx, "tm.event='NewBlockHeader'", &one, res.Newest, "", 5*time.Second)
+			require.NoError(t, err)
+			require.Len(t, res.Items, 1)
+			header, ok := res.Items[0].Data.(types.EventDataNewBlockHeader)
+			require.True(t, ok, "got %T", res.Items[0].Data)
+			assert.Greater(t, header.Header.Height, txRes.Height)
+
+			_, err = c.Events(ctx, "invalid query", nil, "", "", 0)
+			require.Error(t, err)
+			_, err = c.Events(ctx, "", nil, "invalid", "", 0)
+			require.Error(t, err)
+		})
+	}
+}
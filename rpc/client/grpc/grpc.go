@@ -0,0 +1,429 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	grpc "google.golang.org/grpc"
+
+	abci "github.com/klyed/tendermint/abci/types"
+	cryptoenc "github.com/klyed/tendermint/crypto/encoding"
+	"github.com/klyed/tendermint/libs/bytes"
+	tmjson "github.com/klyed/tendermint/libs/json"
+	tmnet "github.com/klyed/tendermint/libs/net"
+	"github.com/klyed/tendermint/p2p"
+	rpcclient "github.com/klyed/tendermint/rpc/client"
+	ctypes "github.com/klyed/tendermint/rpc/core/types"
+	coregrpc "github.com/klyed/tendermint/rpc/grpc"
+	"github.com/klyed/tendermint/types"
+)
+
+/*
+Client is a client for the gRPC QueryAPI of a Tendermint node, providing typed
+access to blocks, commits, validators, transactions, status and ABCI queries,
+and streaming event subscriptions. Results are returned as the same types as
+the JSON-RPC clients.
+
+Example:
+
+	c, err := New("tcp://192.168.1.10:26658")
+	if err != nil {
+		// handle error
+	}
+	defer c.Close()
+
+	res, err := c.Status(ctx)
+	if err != nil {
+		// handle error
+	}
+	// handle result
+*/
+type Client struct {
+	conn  *grpc.ClientConn
+	query coregrpc.QueryAPIClient
+}
+
+var (
+	_ rpcclient.SignClient   = (*Client)(nil)
+	_ rpcclient.StatusClient = (*Client)(nil)
+)
+
+// New dials the gRPC server at the given address, e.g. tcp://127.0.0.1:26658.
+// Additional dial options may be given, e.g. for transport security, which
+// is not used by default.
+func New(remote string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc)}, opts...)
+	conn, err := grpc.Dial(remote, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, query: coregrpc.NewQueryAPIClient(conn)}, nil
+}
+
+func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
+	return tmnet.Connect(addr)
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	res, err := c.query.Status(ctx, &coregrpc.RequestStatus{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := p2p.NodeInfoFromProto(res.NodeInfo)
+	if err != nil {
+		return nil, err
+	}
+	result := &ctypes.ResultStatus{NodeInfo: nodeInfo}
+
+	if si := res.SyncInfo; si != nil {
+		result.SyncInfo = ctypes.SyncInfo{
+			LatestBlockHash:       si.LatestBlockHash,
+			LatestAppHash:         si.LatestAppHash,
+			LatestBlockHeight:     si.LatestBlockHeight,
+			LatestBlockTime:       si.LatestBlockTime,
+			EarliestBlockHash:     si.EarliestBlockHash,
+			EarliestAppHash:       si.EarliestAppHash,
+			EarliestBlockHeight:   si.EarliestBlockHeight,
+			EarliestBlockTime:     si.EarliestBlockTime,
+			CatchingUp:            si.CatchingUp,
+			SnapshotHeight:        si.SnapshotHeight,
+			SnapshotChunksApplied: si.SnapshotChunksApplied,
+			SnapshotChunksTotal:   si.SnapshotChunksTotal,
+			SnapshotBytesApplied:  si.SnapshotBytesApplied,
+			SnapshotSyncTime:      si.SnapshotSyncTime,
+			SnapshotRemainingTime: si.SnapshotRemainingTime,
+		}
+	}
+
+	if vi := res.ValidatorInfo; vi != nil {
+		result.ValidatorInfo = ctypes.ValidatorInfo{
+			Address:     vi.Address,
+			VotingPower: vi.VotingPower,
+		}
+		if vi.PubKey != nil {
+			result.ValidatorInfo.PubKey, err = cryptoenc.PubKeyFromProto(*vi.PubKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Client) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	res, err := c.query.Block(ctx, &coregrpc.RequestBlock{Height: fromHeightPtr(height)})
+	if err != nil {
+		return nil, err
+	}
+	return toResultBlock(res)
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	res, err := c.query.BlockByHash(ctx, &coregrpc.RequestBlockByHash{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	return toResultBlock(res)
+}
+
+func (c *Client) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	res, err := c.query.BlockResults(ctx, &coregrpc.RequestBlockResults{Height: fromHeightPtr(height)})
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlockResults{
+		Height:                res.Height,
+		TxsResults:            res.TxsResults,
+		BeginBlockEvents:      res.BeginBlockEvents,
+		EndBlockEvents:        res.EndBlockEvents,
+		ValidatorUpdates:      res.ValidatorUpdates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}, nil
+}
+
+func (c *Client) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	res, err := c.query.Commit(ctx, &coregrpc.RequestCommit{Height: fromHeightPtr(height)})
+	if err != nil {
+		return nil, err
+	}
+	sh, err := types.SignedHeaderFromProto(res.SignedHeader)
+	if err != nil {
+		return nil, err
+	}
+	return ctypes.NewResultCommit(sh.Header, sh.Commit, res.Canonical), nil
+}
+
+func (c *Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*ctypes.ResultValidators, error) {
+	res, err := c.query.Validators(ctx, &coregrpc.RequestValidators{
+		Height:  fromHeightPtr(height),
+		Page:    fromIntPtr(page),
+		PerPage: fromIntPtr(perPage),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*types.Validator, 0, len(res.Validators))
+	for _, pbv := range res.Validators {
+		val, err := types.ValidatorFromProto(pbv)
+		if err != nil {
+			return nil, err
+		}
+		validators = append(validators, val)
+	}
+	return &ctypes.ResultValidators{
+		BlockHeight: res.BlockHeight,
+		Validators:  validators,
+		Count:       int(res.Count),
+		Total:       int(res.Total),
+	}, nil
+}
+
+func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	res, err := c.query.Tx(ctx, &coregrpc.RequestTx{Hash: hash, Prove: prove})
+	if err != nil {
+		return nil, err
+	}
+	return toResultTx(res)
+}
+
+func (c *Client) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearch, error) {
+	res, err := c.query.TxSearch(ctx, &coregrpc.RequestTxSearch{
+		Query:   query,
+		Prove:   prove,
+		Page:    fromIntPtr(page),
+		PerPage: fromIntPtr(perPage),
+		OrderBy: orderBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*ctypes.ResultTx, 0, len(res.Txs))
+	for _, pbtx := range res.Txs {
+		tx, err := toResultTx(pbtx)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return &ctypes.ResultTxSearch{Txs: txs, TotalCount: int(res.TotalCount)}, nil
+}
+
+func (c *Client) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	res, err := c.query.ABCIInfo(ctx, &coregrpc.RequestABCIInfo{})
+	if err != nil {
+		return nil, err
+	}
+	result := &ctypes.ResultABCIInfo{}
+	if res.Response != nil {
+		result.Response = *res.Response
+	}
+	return result, nil
+}
+
+func (c *Client) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+func (c *Client) ABCIQueryWithOptions(
+	ctx context.Context,
+	path string,
+	data bytes.HexBytes,
+	opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	res, err := c.query.ABCIQuery(ctx, &coregrpc.RequestABCIQuery{
+		Path:   path,
+		Data:   data,
+		Height: opts.Height,
+		Prove:  opts.Prove,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &ctypes.ResultABCIQuery{}
+	if res.Response != nil {
+		result.Response = *res.Response
+	}
+	return result, nil
+}
+
+// Subscribe subscribes to events matching the query, returning a channel onto
+// which they are delivered. The subscription ends, and the channel is closed,
+// when the context is canceled or the server cancels the subscription, e.g.
+// because the client was too slow to receive events.
+func (c *Client) Subscribe(ctx context.Context, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	stream, err := c.query.Subscribe(ctx, &coregrpc.RequestSubscribe{Query: query})
+	if err != nil {
+		return nil, err
+	}
+	// wait for the server to subscribe, such that no events are missed and
+	// subscription errors are returned here.
+	md, err := stream.Header()
+	if err != nil {
+		return nil, err
+	}
+	if len(md) == 0 {
+		// a trailers-only response, i.e. the server failed to subscribe.
+		_, err := stream.Recv()
+		return nil, err
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+	out := make(chan ctypes.ResultEvent, outCap)
+
+	go func() {
+		defer close(out)
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				// the stream was closed, either by the client or the server, and
+				// there's no way to report the reason other than closing the channel.
+				return
+			}
+			event, err := toResultEvent(res)
+			if err != nil {
+				return
+			}
+			select {
+			case out <- *event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func toResultBlock(res *coregrpc.ResponseBlock) (*ctypes.ResultBlock, error) {
+	if res.Block == nil {
+		return &ctypes.ResultBlock{}, nil
+	}
+	block, err := types.BlockFromProto(res.Block)
+	if err != nil {
+		return nil, err
+	}
+	blockID, err := types.BlockIDFromProto(res.BlockId)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlock{BlockID: *blockID, Block: block}, nil
+}
+
+func toResultTx(res *coregrpc.ResponseTx) (*ctypes.ResultTx, error) {
+	result := &ctypes.ResultTx{
+		Hash:   res.Hash,
+		Height: res.Height,
+		Index:  res.Index,
+		Tx:     res.Tx,
+	}
+	if res.TxResult != nil {
+		result.TxResult = *res.TxResult
+	}
+	if res.Proof != nil {
+		proof, err := types.TxProofFromProto(*res.Proof)
+		if err != nil {
+			return nil, err
+		}
+		result.Proof = proof
+	}
+	return result, nil
+}
+
+func toResultEvent(res *coregrpc.ResponseSubscribe) (*ctypes.ResultEvent, error) {
+	result := &ctypes.ResultEvent{Query: res.Query, Events: make(map[string][]string, len(res.Events))}
+	for _, attr := range res.Events {
+		result.Events[attr.Key] = attr.Values
+	}
+
+	switch data := res.Data.(type) {
+	case *coregrpc.ResponseSubscribe_NewBlock:
+		block, err := types.BlockFromProto(data.NewBlock.Block)
+		if err != nil {
+			return nil, err
+		}
+		result.Data = types.EventDataNewBlock{
+			Block:            block,
+			ResultBeginBlock: derefBeginBlock(data.NewBlock.ResultBeginBlock),
+			ResultEndBlock:   derefEndBlock(data.NewBlock.ResultEndBlock),
+		}
+	case *coregrpc.ResponseSubscribe_NewBlockHeader:
+		header, err := types.HeaderFromProto(data.NewBlockHeader.Header)
+		if err != nil {
+			return nil, err
+		}
+		result.Data = types.EventDataNewBlockHeader{
+			Header:           header,
+			NumTxs:           data.NewBlockHeader.NumTxs,
+			ResultBeginBlock: derefBeginBlock(data.NewBlockHeader.ResultBeginBlock),
+			ResultEndBlock:   derefEndBlock(data.NewBlockHeader.ResultEndBlock),
+		}
+	case *coregrpc.ResponseSubscribe_Tx:
+		result.Data = types.EventDataTx{TxResult: *data.Tx}
+	case *coregrpc.ResponseSubscribe_Vote:
+		vote, err := types.VoteFromProto(data.Vote)
+		if err != nil {
+			return nil, err
+		}
+		result.Data = types.EventDataVote{Vote: vote}
+	case *coregrpc.ResponseSubscribe_Json:
+		var eventData types.TMEventData
+		if err := tmjson.Unmarshal(data.Json, &eventData); err != nil {
+			return nil, fmt.Errorf("failed to decode event data: %w", err)
+		}
+		result.Data = eventData
+	default:
+		return nil, fmt.Errorf("unknown event data %T", data)
+	}
+
+	return result, nil
+}
+
+func derefBeginBlock(res *abci.ResponseBeginBlock) abci.ResponseBeginBlock {
+	if res == nil {
+		return abci.ResponseBeginBlock{}
+	}
+	return *res
+}
+
+func derefEndBlock(res *abci.ResponseEndBlock) abci.ResponseEndBlock {
+	if res == nil {
+		return abci.ResponseEndBlock{}
+	}
+	return *res
+}
+
+// fromHeightPtr returns the height, or 0 for the latest height.
+func fromHeightPtr(height *int64) int64 {
+	if height == nil {
+		return 0
+	}
+	return *height
+}
+
+// fromIntPtr returns the value, or 0 for the default value.
+func fromIntPtr(v *int) int32 {
+	if v == nil {
+		return 0
+	}
+	return int32(*v)
+}
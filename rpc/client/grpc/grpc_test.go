@@ -0,0 +1,140 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/abci/example/kvstore"
+	grpcclient "github.com/klyed/tendermint/rpc/client/grpc"
+	coregrpc "github.com/klyed/tendermint/rpc/grpc"
+	rpctest "github.com/klyed/tendermint/rpc/test"
+	"github.com/klyed/tendermint/types"
+)
+
+func TestMain(m *testing.M) {
+	// start a tendermint node in the background to test against
+	app := kvstore.NewApplication()
+	node := rpctest.StartTendermint(app)
+
+	code := m.Run()
+
+	// and shut down proper at the end
+	rpctest.StopTendermint(node)
+	os.Exit(code)
+}
+
+func getClient(t *testing.T) *grpcclient.Client {
+	c, err := grpcclient.New(rpctest.GetConfig().RPC.GRPCListenAddress)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestQueries(t *testing.T) {
+	ctx := context.Background()
+	c := getClient(t)
+
+	tx := []byte("name=satoshi")
+	res, err := rpctest.GetGRPCClient().BroadcastTx(ctx, &coregrpc.RequestBroadcastTx{Tx: tx})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, res.DeliverTx.Code)
+
+	status, err := c.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rpctest.GetConfig().Moniker, status.NodeInfo.Moniker)
+	assert.NotNil(t, status.ValidatorInfo.PubKey)
+	height := status.SyncInfo.LatestBlockHeight
+	require.Greater(t, height, int64(0))
+
+	block, err := c.Block(ctx, &height)
+	require.NoError(t, err)
+	assert.Equal(t, height, block.Block.Height)
+	assert.Equal(t, block.Block.Hash(), block.BlockID.Hash)
+
+	byHash, err := c.BlockByHash(ctx, block.BlockID.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, block.Block.Hash(), byHash.Block.Hash())
+
+	commit, err := c.Commit(ctx, &height)
+	require.NoError(t, err)
+	assert.Equal(t, block.Block.Hash(), commit.Commit.BlockID.Hash)
+
+	vals, err := c.Validators(ctx, &height, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, vals.Count)
+	assert.Equal(t, status.ValidatorInfo.Address, vals.Validators[0].Address)
+
+	txRes, err := c.Tx(ctx, types.Tx(tx).Hash(), true)
+	require.NoError(t, err)
+	assert.EqualValues(t, tx, txRes.Tx)
+	require.NoError(t, txRes.Proof.Validate(txRes.Proof.RootHash))
+
+	search, err := c.TxSearch(ctx, "app.key='name'", false, nil, nil, "asc")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, search.TotalCount, 1)
+	assert.EqualValues(t, tx, search.Txs[0].Tx)
+
+	results, err := c.BlockResults(ctx, &txRes.Height)
+	require.NoError(t, err)
+	assert.Len(t, results.TxsResults, 1)
+
+	query, err := c.ABCIQuery(ctx, "/key", []byte("name"))
+	require.NoError(t, err)
+	assert.EqualValues(t, "satoshi", query.Response.Value)
+
+	info, err := c.ABCIInfo(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, info.Response.LastBlockHeight, txRes.Height)
+
+	future := height + 1000
+	_, err = c.Block(ctx, &future)
+	require.Error(t, err)
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := getClient(t)
+
+	headers, err := c.Subscribe(ctx, types.EventQueryNewBlockHeader.String())
+	require.NoError(t, err)
+	txs, err := c.Subscribe(ctx, types.EventQueryTx.String())
+	require.NoError(t, err)
+
+	_, err = c.Subscribe(ctx, "invalid query")
+	require.Error(t, err)
+
+	select {
+	case event := <-headers:
+		header, ok := event.Data.(types.EventDataNewBlockHeader)
+		require.True(t, ok, "got %T", event.Data)
+		assert.Greater(t, header.Header.Height, int64(0))
+		assert.Equal(t, types.EventQueryNewBlockHeader.String(), event.Query)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a block header")
+	}
+
+	tx := []byte("subscribe=grpc")
+	_, err = rpctest.GetGRPCClient().BroadcastTx(ctx, &coregrpc.RequestBroadcastTx{Tx: tx})
+	require.NoError(t, err)
+	select {
+	case event := <-txs:
+		data, ok := event.Data.(types.EventDataTx)
+		require.True(t, ok, "got %T", event.Data)
+		assert.EqualValues(t, tx, data.Tx)
+		assert.Contains(t, event.Events["tx.hash"], fmt.Sprintf("%X", types.Tx(tx).Hash()))
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a tx")
+	}
+
+	// canceling the context ends the subscription
+	cancel()
+	for range headers {
+	}
+}
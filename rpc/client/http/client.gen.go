@@ -0,0 +1,449 @@
+// Code generated by scripts/rpcgen from rpc/openapi/openapi.yaml. DO NOT EDIT.
+
+package http
+
+import (
+	"context"
+	"time"
+
+	ctypes "github.com/klyed/tendermint/rpc/core/types"
+	"github.com/klyed/tendermint/types"
+)
+
+// ABCIInfo calls the abci_info RPC method.
+func (c *baseRPCClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	result := new(ctypes.ResultABCIInfo)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "abci_info", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Block calls the block RPC method.
+func (c *baseRPCClient) Block(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultBlock, error) {
+	result := new(ctypes.ResultBlock)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "block", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlockByHash calls the block_by_hash RPC method.
+func (c *baseRPCClient) BlockByHash(
+	ctx context.Context,
+	hash []byte,
+) (*ctypes.ResultBlock, error) {
+	result := new(ctypes.ResultBlock)
+	params := make(map[string]interface{})
+	params["hash"] = hash
+	_, err := c.caller.Call(ctx, "block_by_hash", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlockResults calls the block_results RPC method.
+func (c *baseRPCClient) BlockResults(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultBlockResults, error) {
+	result := new(ctypes.ResultBlockResults)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "block_results", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlockchainInfo calls the blockchain RPC method.
+func (c *baseRPCClient) BlockchainInfo(
+	ctx context.Context,
+	minHeight int64,
+	maxHeight int64,
+) (*ctypes.ResultBlockchainInfo, error) {
+	result := new(ctypes.ResultBlockchainInfo)
+	params := make(map[string]interface{})
+	params["minHeight"] = minHeight
+	params["maxHeight"] = maxHeight
+	_, err := c.caller.Call(ctx, "blockchain", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BroadcastEvidence calls the broadcast_evidence RPC method.
+func (c *baseRPCClient) BroadcastEvidence(
+	ctx context.Context,
+	evidence types.Evidence,
+) (*ctypes.ResultBroadcastEvidence, error) {
+	result := new(ctypes.ResultBroadcastEvidence)
+	params := make(map[string]interface{})
+	params["evidence"] = evidence
+	_, err := c.caller.Call(ctx, "broadcast_evidence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BroadcastTxAsync calls the broadcast_tx_async RPC method.
+func (c *baseRPCClient) BroadcastTxAsync(
+	ctx context.Context,
+	tx types.Tx,
+) (*ctypes.ResultBroadcastTx, error) {
+	result := new(ctypes.ResultBroadcastTx)
+	params := make(map[string]interface{})
+	params["tx"] = tx
+	_, err := c.caller.Call(ctx, "broadcast_tx_async", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BroadcastTxCommit calls the broadcast_tx_commit RPC method.
+func (c *baseRPCClient) BroadcastTxCommit(
+	ctx context.Context,
+	tx types.Tx,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	result := new(ctypes.ResultBroadcastTxCommit)
+	params := make(map[string]interface{})
+	params["tx"] = tx
+	_, err := c.caller.Call(ctx, "broadcast_tx_commit", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BroadcastTxSync calls the broadcast_tx_sync RPC method.
+func (c *baseRPCClient) BroadcastTxSync(
+	ctx context.Context,
+	tx types.Tx,
+) (*ctypes.ResultBroadcastTx, error) {
+	result := new(ctypes.ResultBroadcastTx)
+	params := make(map[string]interface{})
+	params["tx"] = tx
+	_, err := c.caller.Call(ctx, "broadcast_tx_sync", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BroadcastTxWait calls the broadcast_tx_wait RPC method.
+func (c *baseRPCClient) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	result := new(ctypes.ResultBroadcastTxCommit)
+	params := make(map[string]interface{})
+	params["tx"] = tx
+	params["timeout"] = timeout
+	_, err := c.caller.Call(ctx, "broadcast_tx_wait", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CheckTx calls the check_tx RPC method.
+func (c *baseRPCClient) CheckTx(
+	ctx context.Context,
+	tx types.Tx,
+) (*ctypes.ResultCheckTx, error) {
+	result := new(ctypes.ResultCheckTx)
+	params := make(map[string]interface{})
+	params["tx"] = tx
+	_, err := c.caller.Call(ctx, "check_tx", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Commit calls the commit RPC method.
+func (c *baseRPCClient) Commit(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultCommit, error) {
+	result := new(ctypes.ResultCommit)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "commit", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ConsensusParams calls the consensus_params RPC method.
+func (c *baseRPCClient) ConsensusParams(
+	ctx context.Context,
+	height *int64,
+) (*ctypes.ResultConsensusParams, error) {
+	result := new(ctypes.ResultConsensusParams)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "consensus_params", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ConsensusState calls the consensus_state RPC method.
+func (c *baseRPCClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
+	result := new(ctypes.ResultConsensusState)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "consensus_state", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DumpConsensusState calls the dump_consensus_state RPC method.
+func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
+	result := new(ctypes.ResultDumpConsensusState)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "dump_consensus_state", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Events calls the events RPC method.
+func (c *baseRPCClient) Events(
+	ctx context.Context,
+	filter string,
+	maxItems *int,
+	after string,
+	before string,
+	waitTime time.Duration,
+) (*ctypes.ResultEvents, error) {
+	result := new(ctypes.ResultEvents)
+	params := make(map[string]interface{})
+	params["filter"] = filter
+	if maxItems != nil {
+		params["max_items"] = maxItems
+	}
+	params["after"] = after
+	params["before"] = before
+	params["wait_time"] = waitTime
+	_, err := c.caller.Call(ctx, "events", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Genesis calls the genesis RPC method.
+func (c *baseRPCClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	result := new(ctypes.ResultGenesis)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "genesis", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Health calls the health RPC method.
+func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
+	result := new(ctypes.ResultHealth)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "health", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// NetInfo calls the net_info RPC method.
+func (c *baseRPCClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
+	result := new(ctypes.ResultNetInfo)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "net_info", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// NumUnconfirmedTxs calls the num_unconfirmed_txs RPC method.
+func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	result := new(ctypes.ResultUnconfirmedTxs)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "num_unconfirmed_txs", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Status calls the status RPC method.
+func (c *baseRPCClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	result := new(ctypes.ResultStatus)
+	params := make(map[string]interface{})
+	_, err := c.caller.Call(ctx, "status", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Tx calls the tx RPC method.
+func (c *baseRPCClient) Tx(
+	ctx context.Context,
+	hash []byte,
+	prove bool,
+) (*ctypes.ResultTx, error) {
+	result := new(ctypes.ResultTx)
+	params := make(map[string]interface{})
+	params["hash"] = hash
+	params["prove"] = prove
+	_, err := c.caller.Call(ctx, "tx", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TxSearch calls the tx_search RPC method.
+func (c *baseRPCClient) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page *int,
+	perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearch, error) {
+	result := new(ctypes.ResultTxSearch)
+	params := make(map[string]interface{})
+	params["query"] = query
+	params["prove"] = prove
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	params["order_by"] = orderBy
+	_, err := c.caller.Call(ctx, "tx_search", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnconfirmedTx calls the unconfirmed_tx RPC method.
+func (c *baseRPCClient) UnconfirmedTx(
+	ctx context.Context,
+	hash []byte,
+) (*ctypes.ResultUnconfirmedTx, error) {
+	result := new(ctypes.ResultUnconfirmedTx)
+	params := make(map[string]interface{})
+	params["hash"] = hash
+	_, err := c.caller.Call(ctx, "unconfirmed_tx", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnconfirmedTxs calls the unconfirmed_txs RPC method.
+func (c *baseRPCClient) UnconfirmedTxs(
+	ctx context.Context,
+	limit *int,
+) (*ctypes.ResultUnconfirmedTxs, error) {
+	result := new(ctypes.ResultUnconfirmedTxs)
+	params := make(map[string]interface{})
+	if limit != nil {
+		params["limit"] = limit
+	}
+	_, err := c.caller.Call(ctx, "unconfirmed_txs", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnconfirmedTxsInfo calls the unconfirmed_txs_info RPC method.
+func (c *baseRPCClient) UnconfirmedTxsInfo(
+	ctx context.Context,
+	limit *int,
+) (*ctypes.ResultUnconfirmedTxsInfo, error) {
+	result := new(ctypes.ResultUnconfirmedTxsInfo)
+	params := make(map[string]interface{})
+	if limit != nil {
+		params["limit"] = limit
+	}
+	_, err := c.caller.Call(ctx, "unconfirmed_txs_info", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RemoveTx calls the unsafe_remove_tx RPC method.
+func (c *baseRPCClient) RemoveTx(
+	ctx context.Context,
+	hash []byte,
+) (*ctypes.ResultUnsafeRemoveTx, error) {
+	result := new(ctypes.ResultUnsafeRemoveTx)
+	params := make(map[string]interface{})
+	params["hash"] = hash
+	_, err := c.caller.Call(ctx, "unsafe_remove_tx", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Validators calls the validators RPC method.
+func (c *baseRPCClient) Validators(
+	ctx context.Context,
+	height *int64,
+	page *int,
+	perPage *int,
+) (*ctypes.ResultValidators, error) {
+	result := new(ctypes.ResultValidators)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "validators", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -10,7 +10,6 @@ import (
 	rpcclient "github.com/klyed/tendermint/rpc/client"
 	ctypes "github.com/klyed/tendermint/rpc/core/types"
 	jsonrpcclient "github.com/klyed/tendermint/rpc/jsonrpc/client"
-	"github.com/klyed/tendermint/types"
 )
 
 /*
@@ -203,26 +202,9 @@ func (b *BatchHTTP) Count() int {
 
 //-----------------------------------------------------------------------------
 // baseRPCClient
-
-func (c *baseRPCClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
-	result := new(ctypes.ResultStatus)
-	_, err := c.caller.Call(ctx, "status", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
-
-func (c *baseRPCClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
-	result := new(ctypes.ResultABCIInfo)
-	_, err := c.caller.Call(ctx, "abci_info", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
+//
+// The methods calling most routes are generated from the OpenAPI spec, see
+// client.gen.go.
 
 func (c *baseRPCClient) ABCIQuery(
 	ctx context.Context,
@@ -247,283 +229,3 @@ func (c *baseRPCClient) ABCIQueryWithOptions(
 
 	return result, nil
 }
-
-func (c *baseRPCClient) BroadcastTxCommit(
-	ctx context.Context,
-	tx types.Tx,
-) (*ctypes.ResultBroadcastTxCommit, error) {
-	result := new(ctypes.ResultBroadcastTxCommit)
-	_, err := c.caller.Call(ctx, "broadcast_tx_commit", map[string]interface{}{"tx": tx}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) BroadcastTxAsync(
-	ctx context.Context,
-	tx types.Tx,
-) (*ctypes.ResultBroadcastTx, error) {
-	return c.broadcastTX(ctx, "broadcast_tx_async", tx)
-}
-
-func (c *baseRPCClient) BroadcastTxSync(
-	ctx context.Context,
-	tx types.Tx,
-) (*ctypes.ResultBroadcastTx, error) {
-	return c.broadcastTX(ctx, "broadcast_tx_sync", tx)
-}
-
-func (c *baseRPCClient) broadcastTX(
-	ctx context.Context,
-	route string,
-	tx types.Tx,
-) (*ctypes.ResultBroadcastTx, error) {
-	result := new(ctypes.ResultBroadcastTx)
-	_, err := c.caller.Call(ctx, route, map[string]interface{}{"tx": tx}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) UnconfirmedTxs(
-	ctx context.Context,
-	limit *int,
-) (*ctypes.ResultUnconfirmedTxs, error) {
-	result := new(ctypes.ResultUnconfirmedTxs)
-	params := make(map[string]interface{})
-	if limit != nil {
-		params["limit"] = limit
-	}
-	_, err := c.caller.Call(ctx, "unconfirmed_txs", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
-	result := new(ctypes.ResultUnconfirmedTxs)
-	_, err := c.caller.Call(ctx, "num_unconfirmed_txs", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
-	result := new(ctypes.ResultCheckTx)
-	_, err := c.caller.Call(ctx, "check_tx", map[string]interface{}{"tx": tx}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
-	result := new(ctypes.ResultNetInfo)
-	_, err := c.caller.Call(ctx, "net_info", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
-	result := new(ctypes.ResultDumpConsensusState)
-	_, err := c.caller.Call(ctx, "dump_consensus_state", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
-	result := new(ctypes.ResultConsensusState)
-	_, err := c.caller.Call(ctx, "consensus_state", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) ConsensusParams(
-	ctx context.Context,
-	height *int64,
-) (*ctypes.ResultConsensusParams, error) {
-	result := new(ctypes.ResultConsensusParams)
-	params := make(map[string]interface{})
-	if height != nil {
-		params["height"] = height
-	}
-	_, err := c.caller.Call(ctx, "consensus_params", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
-	result := new(ctypes.ResultHealth)
-	_, err := c.caller.Call(ctx, "health", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) BlockchainInfo(
-	ctx context.Context,
-	minHeight,
-	maxHeight int64,
-) (*ctypes.ResultBlockchainInfo, error) {
-	result := new(ctypes.ResultBlockchainInfo)
-	_, err := c.caller.Call(ctx, "blockchain",
-		map[string]interface{}{"minHeight": minHeight, "maxHeight": maxHeight},
-		result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
-	result := new(ctypes.ResultGenesis)
-	_, err := c.caller.Call(ctx, "genesis", map[string]interface{}{}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
-	result := new(ctypes.ResultBlock)
-	params := make(map[string]interface{})
-	if height != nil {
-		params["height"] = height
-	}
-	_, err := c.caller.Call(ctx, "block", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
-	result := new(ctypes.ResultBlock)
-	params := map[string]interface{}{
-		"hash": hash,
-	}
-	_, err := c.caller.Call(ctx, "block_by_hash", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) BlockResults(
-	ctx context.Context,
-	height *int64,
-) (*ctypes.ResultBlockResults, error) {
-	result := new(ctypes.ResultBlockResults)
-	params := make(map[string]interface{})
-	if height != nil {
-		params["height"] = height
-	}
-	_, err := c.caller.Call(ctx, "block_results", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
-	result := new(ctypes.ResultCommit)
-	params := make(map[string]interface{})
-	if height != nil {
-		params["height"] = height
-	}
-	_, err := c.caller.Call(ctx, "commit", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
-	result := new(ctypes.ResultTx)
-	params := map[string]interface{}{
-		"hash":  hash,
-		"prove": prove,
-	}
-	_, err := c.caller.Call(ctx, "tx", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) TxSearch(
-	ctx context.Context,
-	query string,
-	prove bool,
-	page,
-	perPage *int,
-	orderBy string,
-) (
-	*ctypes.ResultTxSearch, error) {
-	result := new(ctypes.ResultTxSearch)
-	params := map[string]interface{}{
-		"query":    query,
-		"prove":    prove,
-		"order_by": orderBy,
-	}
-	if page != nil {
-		params["page"] = page
-	}
-	if perPage != nil {
-		params["per_page"] = perPage
-	}
-	_, err := c.caller.Call(ctx, "tx_search", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) Validators(
-	ctx context.Context,
-	height *int64,
-	page,
-	perPage *int,
-) (*ctypes.ResultValidators, error) {
-	result := new(ctypes.ResultValidators)
-	params := make(map[string]interface{})
-	if page != nil {
-		params["page"] = page
-	}
-	if perPage != nil {
-		params["per_page"] = perPage
-	}
-	if height != nil {
-		params["height"] = height
-	}
-	_, err := c.caller.Call(ctx, "validators", params, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
-
-func (c *baseRPCClient) BroadcastEvidence(
-	ctx context.Context,
-	ev types.Evidence,
-) (*ctypes.ResultBroadcastEvidence, error) {
-	result := new(ctypes.ResultBroadcastEvidence)
-	_, err := c.caller.Call(ctx, "broadcast_evidence", map[string]interface{}{"evidence": ev}, result)
-	if err != nil {
-		return nil, err
-	}
-	return result, nil
-}
@@ -22,6 +22,7 @@ implementation.
 
 import (
 	"context"
+	"time"
 
 	"github.com/klyed/tendermint/libs/bytes"
 	"github.com/klyed/tendermint/libs/service"
@@ -35,6 +36,7 @@ type Client interface {
 	service.Service
 	ABCIClient
 	EventsClient
+	EventLogClient
 	HistoryClient
 	NetworkClient
 	SignClient
@@ -57,6 +59,7 @@ type ABCIClient interface {
 
 	// Writing to abci app
 	BroadcastTxCommit(context.Context, types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+	BroadcastTxWait(ctx context.Context, tx types.Tx, timeout time.Duration) (*ctypes.ResultBroadcastTxCommit, error)
 	BroadcastTxAsync(context.Context, types.Tx) (*ctypes.ResultBroadcastTx, error)
 	BroadcastTxSync(context.Context, types.Tx) (*ctypes.ResultBroadcastTx, error)
 }
@@ -112,11 +115,29 @@ type EventsClient interface {
 	UnsubscribeAll(ctx context.Context, subscriber string) error
 }
 
+// EventLogClient reads events from the node's event log, which retains recent
+// events such that clients can resume reading from a cursor after a
+// disconnect without missing events.
+type EventLogClient interface {
+	// Events returns up to maxItems events matching the filter query, with
+	// cursors after the after cursor and, if non-empty, before the before
+	// cursor. If no events are available, it waits up to waitTime for new
+	// events.
+	Events(ctx context.Context, filter string, maxItems *int, after, before string,
+		waitTime time.Duration) (*ctypes.ResultEvents, error)
+}
+
 // MempoolClient shows us data about current mempool state.
 type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
+	UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error)
+	UnconfirmedTxsInfo(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxsInfo, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
+
+	// RemoveTx removes a tx from the mempool. It requires the node's unsafe
+	// RPC routes to be enabled.
+	RemoveTx(ctx context.Context, hash []byte) (*ctypes.ResultUnsafeRemoveTx, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
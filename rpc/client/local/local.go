@@ -91,6 +91,14 @@ func (c *Local) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Res
 	return core.BroadcastTxCommit(c.ctx, tx)
 }
 
+func (c *Local) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	return core.BroadcastTxWait(c.ctx, tx, timeout)
+}
+
 func (c *Local) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return core.BroadcastTxAsync(c.ctx, tx)
 }
@@ -107,6 +115,18 @@ func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirme
 	return core.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	return core.UnconfirmedTx(c.ctx, hash)
+}
+
+func (c *Local) UnconfirmedTxsInfo(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxsInfo, error) {
+	return core.UnconfirmedTxsInfo(c.ctx, limit)
+}
+
+func (c *Local) RemoveTx(ctx context.Context, hash []byte) (*ctypes.ResultUnsafeRemoveTx, error) {
+	return core.UnsafeRemoveTx(c.ctx, hash)
+}
+
 func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
 	return core.CheckTx(c.ctx, tx)
 }
@@ -188,6 +208,17 @@ func (c *Local) TxSearch(
 	return core.TxSearch(c.ctx, query, prove, page, perPage, orderBy)
 }
 
+func (c *Local) Events(
+	ctx context.Context,
+	filter string,
+	maxItems *int,
+	after,
+	before string,
+	waitTime time.Duration,
+) (*ctypes.ResultEvents, error) {
+	return core.Events(c.ctx, filter, maxItems, after, before, waitTime)
+}
+
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(c.ctx, ev)
 }
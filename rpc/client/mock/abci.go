@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	abci "github.com/klyed/tendermint/abci/types"
 	"github.com/klyed/tendermint/libs/bytes"
@@ -60,6 +61,15 @@ func (a ABCIApp) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Re
 	return &res, nil
 }
 
+// BroadcastTxWait is the same as BroadcastTxCommit, ignoring the timeout.
+func (a ABCIApp) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	return a.BroadcastTxCommit(ctx, tx)
+}
+
 func (a ABCIApp) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	c := a.App.CheckTx(abci.RequestCheckTx{Tx: tx})
 	// and this gets written in a background thread...
@@ -133,6 +143,14 @@ func (m ABCIMock) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.R
 	return res.(*ctypes.ResultBroadcastTxCommit), nil
 }
 
+func (m ABCIMock) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	return m.BroadcastTxCommit(ctx, tx)
+}
+
 func (m ABCIMock) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	res, err := m.Broadcast.GetResponse(tx)
 	if err != nil {
@@ -218,6 +236,21 @@ func (r *ABCIRecorder) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*cty
 	return res, err
 }
 
+func (r *ABCIRecorder) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	res, err := r.Client.BroadcastTxWait(ctx, tx, timeout)
+	r.addCall(Call{
+		Name:     "broadcast_tx_wait",
+		Args:     tx,
+		Response: res,
+		Error:    err,
+	})
+	return res, err
+}
+
 func (r *ABCIRecorder) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	res, err := r.Client.BroadcastTxAsync(ctx, tx)
 	r.addCall(Call{
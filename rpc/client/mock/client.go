@@ -17,6 +17,7 @@ want to directly call a tendermint node in process, you can use the
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/klyed/tendermint/libs/bytes"
 	"github.com/klyed/tendermint/libs/service"
@@ -38,6 +39,7 @@ type Client struct {
 	client.HistoryClient
 	client.StatusClient
 	client.EventsClient
+	client.EventLogClient
 	client.EvidenceClient
 	client.MempoolClient
 	service.Service
@@ -104,6 +106,14 @@ func (c Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.Res
 	return core.BroadcastTxCommit(&rpctypes.Context{}, tx)
 }
 
+func (c Client) BroadcastTxWait(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*ctypes.ResultBroadcastTxCommit, error) {
+	return core.BroadcastTxWait(&rpctypes.Context{}, tx, timeout)
+}
+
 func (c Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return core.BroadcastTxAsync(&rpctypes.Context{}, tx)
 }
@@ -14,6 +14,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/klyed/tendermint/types"
 )
 
@@ -275,6 +277,29 @@ func (_m *Client) BroadcastTxSync(_a0 context.Context, _a1 types.Tx) (*coretypes
 	return r0, r1
 }
 
+// BroadcastTxWait provides a mock function with given fields: ctx, tx, timeout
+func (_m *Client) BroadcastTxWait(ctx context.Context, tx types.Tx, timeout time.Duration) (*coretypes.ResultBroadcastTxCommit, error) {
+	ret := _m.Called(ctx, tx, timeout)
+
+	var r0 *coretypes.ResultBroadcastTxCommit
+	if rf, ok := ret.Get(0).(func(context.Context, types.Tx, time.Duration) *coretypes.ResultBroadcastTxCommit); ok {
+		r0 = rf(ctx, tx, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastTxCommit)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Tx, time.Duration) error); ok {
+		r1 = rf(ctx, tx, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckTx provides a mock function with given fields: _a0, _a1
 func (_m *Client) CheckTx(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultCheckTx, error) {
 	ret := _m.Called(_a0, _a1)
@@ -390,6 +415,29 @@ func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDump
 	return r0, r1
 }
 
+// Events provides a mock function with given fields: ctx, filter, maxItems, after, before, waitTime
+func (_m *Client) Events(ctx context.Context, filter string, maxItems *int, after string, before string, waitTime time.Duration) (*coretypes.ResultEvents, error) {
+	ret := _m.Called(ctx, filter, maxItems, after, before, waitTime)
+
+	var r0 *coretypes.ResultEvents
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, string, string, time.Duration) *coretypes.ResultEvents); ok {
+		r0 = rf(ctx, filter, maxItems, after, before, waitTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvents)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, filter, maxItems, after, before, waitTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -545,6 +593,29 @@ func (_m *Client) Quit() <-chan struct{} {
 	return r0
 }
 
+// RemoveTx provides a mock function with given fields: ctx, hash
+func (_m *Client) RemoveTx(ctx context.Context, hash []byte) (*coretypes.ResultUnsafeRemoveTx, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultUnsafeRemoveTx
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultUnsafeRemoveTx); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultUnsafeRemoveTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Reset provides a mock function with given fields:
 func (_m *Client) Reset() error {
 	ret := _m.Called()
@@ -705,6 +776,29 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	return r0, r1
 }
 
+// UnconfirmedTx provides a mock function with given fields: ctx, hash
+func (_m *Client) UnconfirmedTx(ctx context.Context, hash []byte) (*coretypes.ResultUnconfirmedTx, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultUnconfirmedTx
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultUnconfirmedTx); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultUnconfirmedTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnconfirmedTxs provides a mock function with given fields: ctx, limit
 func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(ctx, limit)
@@ -728,6 +822,29 @@ func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.Re
 	return r0, r1
 }
 
+// UnconfirmedTxsInfo provides a mock function with given fields: ctx, limit
+func (_m *Client) UnconfirmedTxsInfo(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxsInfo, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 *coretypes.ResultUnconfirmedTxsInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *int) *coretypes.ResultUnconfirmedTxsInfo); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultUnconfirmedTxsInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unsubscribe provides a mock function with given fields: ctx, subscriber, query
 func (_m *Client) Unsubscribe(ctx context.Context, subscriber string, query string) error {
 	ret := _m.Called(ctx, subscriber, query)
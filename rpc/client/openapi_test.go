@@ -0,0 +1,265 @@
+package client_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	tmjson "github.com/klyed/tendermint/libs/json"
+	ctypes "github.com/klyed/tendermint/rpc/core/types"
+	rpctest "github.com/klyed/tendermint/rpc/test"
+	"github.com/klyed/tendermint/types"
+)
+
+// TestOpenAPIConformance calls every route documented in the OpenAPI spec on
+// the test node over URI/HTTP, and checks that the responses match the
+// documented schemas and decode into the documented result types.
+func TestOpenAPIConformance(t *testing.T) {
+	spec := loadOpenAPISpec(t, "../openapi/openapi.yaml")
+
+	// commit a tx to have a block, tx and hash to query
+	c := getHTTPClient()
+	_, _, tx := MakeTxKV()
+	bres, err := c.BroadcastTxCommit(ctx, tx)
+	require.NoError(t, err)
+	require.True(t, bres.DeliverTx.IsOK())
+	height := fmt.Sprint(bres.Height)
+	block, err := c.Block(ctx, &bres.Height)
+	require.NoError(t, err)
+
+	hexArg := func(bz []byte) string { return fmt.Sprintf("0x%X", bz) }
+	strArg := func(s string) string { return fmt.Sprintf("%q", s) }
+	newTx := func() string {
+		_, _, tx := MakeTxKV()
+		return hexArg(tx)
+	}
+	unknownHash := hexArg(types.Tx("unknown").Hash())
+
+	// the arguments to call each route with, and whether the call fails
+	testCases := map[string]struct {
+		args map[string]string
+		err  bool
+	}{
+		"abci_info":            {},
+		"abci_query":           {args: map[string]string{"path": strArg("/key"), "data": hexArg(tx)}},
+		"block":                {args: map[string]string{"height": height}},
+		"block_by_hash":        {args: map[string]string{"hash": hexArg(block.BlockID.Hash)}},
+		"block_results":        {args: map[string]string{"height": height}},
+		"blockchain":           {args: map[string]string{"minHeight": "1", "maxHeight": height}},
+		"broadcast_evidence":   {args: map[string]string{"evidence": strArg("{}")}, err: true},
+		"broadcast_tx_async":   {args: map[string]string{"tx": newTx()}},
+		"broadcast_tx_commit":  {args: map[string]string{"tx": newTx()}},
+		"broadcast_tx_sync":    {args: map[string]string{"tx": newTx()}},
+		"broadcast_tx_wait":    {args: map[string]string{"tx": newTx(), "timeout": "10000000000"}},
+		"check_tx":             {args: map[string]string{"tx": newTx()}},
+		"commit":               {args: map[string]string{"height": height}},
+		"consensus_params":     {args: map[string]string{"height": height}},
+		"consensus_state":      {},
+		"dial_peers":           {args: map[string]string{"peers": `[]`}, err: true},
+		"dial_seeds":           {args: map[string]string{"seeds": `[]`}, err: true},
+		"dump_consensus_state": {},
+		"events":               {args: map[string]string{"filter": strArg("tm.event='Tx'"), "max_items": "1"}},
+		"genesis":              {},
+		"health":               {},
+		"net_info":             {},
+		"num_unconfirmed_txs":  {},
+		"status":               {},
+		"tx":                   {args: map[string]string{"hash": hexArg(bres.Hash), "prove": "true"}},
+		"tx_search":            {args: map[string]string{"query": strArg("tx.height=" + height), "prove": "true"}},
+		"unconfirmed_tx":       {args: map[string]string{"hash": unknownHash}, err: true},
+		"unconfirmed_txs":      {args: map[string]string{"limit": "1"}},
+		"unconfirmed_txs_info": {args: map[string]string{"limit": "1"}},
+		"unsafe_flush_mempool": {},
+		"unsafe_remove_tx":     {args: map[string]string{"hash": unknownHash}, err: true},
+		"validators":           {args: map[string]string{"height": height, "page": "1", "per_page": "1"}},
+	}
+
+	methods := make([]string, 0, len(ctypes.RouteSpecs))
+	for method := range ctypes.RouteSpecs {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	remote := strings.Replace(rpctest.GetConfig().RPC.ListenAddress, "tcp", "http", -1)
+	for _, method := range methods {
+		method, route := method, ctypes.RouteSpecs[method]
+		if route.WebSocket {
+			continue
+		}
+		t.Run(method, func(t *testing.T) {
+			tc, ok := testCases[method]
+			require.True(t, ok, "missing conformance test case for %q", method)
+
+			query := url.Values{}
+			for name, arg := range tc.args {
+				query.Set(name, arg)
+			}
+			for _, p := range route.Params {
+				if p.Required {
+					require.Contains(t, tc.args, p.Name, "missing required param")
+				}
+			}
+			for name := range tc.args {
+				assert.Contains(t, route.ParamNames(), name, "undocumented param")
+			}
+
+			resp, err := http.Get(remote + "/" + method + "?" + query.Encode())
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			var res map[string]interface{}
+			dec := json.NewDecoder(strings.NewReader(string(body)))
+			dec.UseNumber()
+			require.NoError(t, dec.Decode(&res), string(body))
+
+			if tc.err {
+				require.Contains(t, res, "error", string(body))
+				spec.validate(t, "ErrorResponse", spec.ref("ErrorResponse"), res)
+				return
+			}
+			require.NotContains(t, res, "error", string(body))
+			spec.validate(t, method, spec.response(t, method), res)
+
+			var raw struct {
+				Result json.RawMessage `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(body, &raw))
+			result := reflect.New(route.Result).Interface()
+			require.NoError(t, tmjson.Unmarshal(raw.Result, result), "result doesn't decode into %v", route.Result)
+		})
+	}
+}
+
+// openAPISpec is a parsed OpenAPI spec, used to validate responses.
+type openAPISpec map[string]interface{}
+
+func loadOpenAPISpec(t *testing.T, path string) openAPISpec {
+	bz, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	var spec interface{}
+	require.NoError(t, yaml.Unmarshal(bz, &spec))
+	return normalizeYAML(spec).(map[string]interface{})
+}
+
+// normalizeYAML converts the maps decoded by yaml.v2 to JSON-like maps.
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			m[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeYAML(val)
+		}
+	}
+	return v
+}
+
+// response returns the schema of the successful response of a method.
+func (s openAPISpec) response(t *testing.T, method string) map[string]interface{} {
+	schema, ok := lookup(map[string]interface{}(s), "paths", "/"+method, "get", "responses", "200",
+		"content", "application/json", "schema").(map[string]interface{})
+	require.True(t, ok, "missing response schema")
+	return schema
+}
+
+// ref returns the schema of a component.
+func (s openAPISpec) ref(name string) map[string]interface{} {
+	return lookup(map[string]interface{}(s), "components", "schemas", name).(map[string]interface{})
+}
+
+func lookup(v interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// validate checks that value matches the schema at path. It supports the
+// subset of JSON schemas used in the spec: references, allOf, types, required
+// and nullable properties, and array items.
+func (s openAPISpec) validate(t *testing.T, path string, schema map[string]interface{}, value interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		s.validate(t, path, s.ref(strings.TrimPrefix(ref, "#/components/schemas/")), value)
+		return
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			s.validate(t, path, sub.(map[string]interface{}), value)
+		}
+	}
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); !nullable && schema["type"] != nil {
+			t.Errorf("%v: unexpected null", path)
+		}
+		return
+	}
+
+	typ, _ := schema["type"].(string)
+	if typ == "" && schema["properties"] != nil {
+		typ = "object"
+	}
+	switch typ {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			t.Errorf("%v: expected an object, got %T", path, value)
+			return
+		}
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, key := range required {
+				if _, ok := obj[key.(string)]; !ok {
+					t.Errorf("%v: missing required property %q", path, key)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for key, sub := range properties {
+			if v, ok := obj[key]; ok {
+				s.validate(t, path+"."+key, sub.(map[string]interface{}), v)
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			t.Errorf("%v: expected an array, got %T", path, value)
+			return
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, v := range arr {
+				s.validate(t, fmt.Sprintf("%v[%d]", path, i), items, v)
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			t.Errorf("%v: expected a string, got %T", path, value)
+		}
+	case "integer", "number":
+		if _, ok := value.(json.Number); !ok {
+			t.Errorf("%v: expected a number, got %T", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			t.Errorf("%v: expected a boolean, got %T", path, value)
+		}
+	}
+}
@@ -368,6 +368,24 @@ func TestBroadcastTxCommit(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxWait(t *testing.T) {
+	require := require.New(t)
+
+	for i, c := range GetClients() {
+		_, _, tx := MakeTxKV()
+		bres, err := c.BroadcastTxWait(context.Background(), tx, 5*time.Second)
+		require.Nil(err, "%d: %+v", i, err)
+		require.True(bres.CheckTx.IsOK())
+		require.True(bres.DeliverTx.IsOK())
+		require.EqualValues(types.Tx(tx).Hash(), bres.Hash)
+		require.NotZero(bres.Height)
+
+		ptx, err := c.Tx(context.Background(), bres.Hash, false)
+		require.NoError(err)
+		require.Equal(bres.Height, ptx.Height)
+	}
+}
+
 func TestUnconfirmedTxs(t *testing.T) {
 	_, _, tx := MakeTxKV()
 
@@ -398,6 +416,68 @@ func TestUnconfirmedTxs(t *testing.T) {
 	mempool.Flush()
 }
 
+func TestUnconfirmedTxAndRemoveTx(t *testing.T) {
+	mempool := node.Mempool()
+
+	// lock the mempool, so that the tx isn't committed while it's looked up,
+	// retrying if it was committed before the lock was taken.
+	var tx types.Tx
+	for attempt := 0; ; attempt++ {
+		require.Less(t, attempt, 10, "tx committed before the mempool could be locked")
+		_, _, tx = MakeTxKV()
+
+		ch := make(chan *abci.Response, 1)
+		err := mempool.CheckTx(tx, func(resp *abci.Response) { ch <- resp }, mempl.TxInfo{})
+		require.NoError(t, err)
+
+		// wait for tx to arrive in mempoool.
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for CheckTx callback")
+		}
+
+		mempool.Lock()
+		if _, ok := mempool.TxByKey(mempl.TxKey(tx)); ok {
+			break
+		}
+		mempool.Unlock()
+	}
+	hash := tx.Hash()
+
+	for i, c := range GetClients() {
+		mc := c.(client.MempoolClient)
+		res, err := mc.UnconfirmedTx(context.Background(), hash)
+		if !assert.NoError(t, err, "%d", i) {
+			continue
+		}
+		assert.EqualValues(t, hash, res.Hash, "%d", i)
+		assert.Equal(t, tx, res.Tx, "%d", i)
+		assert.Empty(t, res.Sender, "%d", i)
+	}
+
+	// the RPC takes the mempool lock, so the successful removal is tested
+	// without consensus in rpc/core.
+	assert.NoError(t, mempool.RemoveTxByKey(mempl.TxKey(tx), true))
+	mempool.Unlock()
+
+	mc := GetClients()[0].(client.MempoolClient)
+	_, err := mc.UnconfirmedTx(context.Background(), hash)
+	assert.Error(t, err)
+	_, err = mc.RemoveTx(context.Background(), hash)
+	assert.Error(t, err)
+
+	for i, c := range GetClients() {
+		mc := c.(client.MempoolClient)
+		list, err := mc.UnconfirmedTxsInfo(context.Background(), nil)
+		require.NoError(t, err, "%d", i)
+		assert.Len(t, list.Txs, list.Count, "%d", i)
+		for _, entry := range list.Txs {
+			assert.NotEqual(t, tx, entry.Tx, "%d", i)
+		}
+	}
+}
+
 func TestNumUnconfirmedTxs(t *testing.T) {
 	_, _, tx := MakeTxKV()
 
@@ -523,7 +603,7 @@ func TestTxSearch(t *testing.T) {
 	// from other tests as well
 	result, err := c.TxSearch(context.Background(), "tx.height >= 0", true, nil, nil, "asc")
 	require.NoError(t, err)
-	txCount := len(result.Txs)
+	txCount := result.TotalCount
 
 	// pick out the last tx to have something to search for in tests
 	find := result.Txs[len(result.Txs)-1]
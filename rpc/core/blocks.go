@@ -90,12 +90,26 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 		return nil, err
 	}
 
+	immutable := isImmutableHeight(height)
+	if immutable {
+		if res, ok := env.resultCache.get("block", height); ok {
+			ctx.MarkImmutable()
+			return res.(*ctypes.ResultBlock), nil
+		}
+	}
+
 	block := env.BlockStore.LoadBlock(height)
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return &ctypes.ResultBlock{BlockID: types.BlockID{}, Block: block}, nil
 	}
-	return &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}, nil
+	res := &ctypes.ResultBlock{BlockID: blockMeta.BlockID, Block: block}
+	// backfilled heights only have a header: a missing block must not be cached
+	if immutable && block != nil {
+		ctx.MarkImmutable()
+		env.resultCache.add("block", height, res)
+	}
+	return res, nil
 }
 
 // BlockByHash gets block by hash.
@@ -119,6 +133,15 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 		return nil, err
 	}
 
+	// The canonical commit is immutable, unlike the seen commit
+	immutable := isImmutableHeight(height)
+	if immutable {
+		if res, ok := env.resultCache.get("commit", height); ok {
+			ctx.MarkImmutable()
+			return res.(*ctypes.ResultCommit), nil
+		}
+	}
+
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
 		return nil, nil
@@ -134,7 +157,12 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 
 	// Return the canonical commit (comes from the block at height+1)
 	commit := env.BlockStore.LoadBlockCommit(height)
-	return ctypes.NewResultCommit(&header, commit, true), nil
+	res := ctypes.NewResultCommit(&header, commit, true)
+	if immutable && commit != nil {
+		ctx.MarkImmutable()
+		env.resultCache.add("commit", height, res)
+	}
+	return res, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
@@ -150,17 +178,29 @@ func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockR
 		return nil, err
 	}
 
+	immutable := isImmutableHeight(height)
+	if immutable {
+		ctx.MarkImmutable()
+		if res, ok := env.resultCache.get("block_results", height); ok {
+			return res.(*ctypes.ResultBlockResults), nil
+		}
+	}
+
 	results, err := env.StateStore.LoadABCIResponses(height)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ctypes.ResultBlockResults{
+	res := &ctypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.DeliverTxs,
 		BeginBlockEvents:      results.BeginBlock.Events,
 		EndBlockEvents:        results.EndBlock.Events,
 		ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
 		ConsensusParamUpdates: results.EndBlock.ConsensusParamUpdates,
-	}, nil
+	}
+	if immutable {
+		env.resultCache.add("block_results", height, res)
+	}
+	return res, nil
 }
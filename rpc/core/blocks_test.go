@@ -115,6 +115,35 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestBlockBackfilledHeight(t *testing.T) {
+	// backfilled heights only have a header
+	store := backfilledBlockStore{
+		mockBlockStore: mockBlockStore{height: 10},
+		meta:           &types.BlockMeta{Header: types.Header{Height: 5}},
+	}
+	env = &Environment{BlockStore: store, resultCache: newResultCache(10)}
+
+	height := int64(5)
+	ctx := &rpctypes.Context{}
+	res, err := Block(ctx, &height)
+	require.NoError(t, err)
+	assert.Nil(t, res.Block)
+	assert.False(t, ctx.IsImmutable())
+	_, ok := env.resultCache.get("block", height)
+	assert.False(t, ok)
+
+	// once the block is stored, it's returned and cached
+	store.block = &types.Block{Header: types.Header{Height: 5}}
+	env.BlockStore = store
+	ctx = &rpctypes.Context{}
+	res, err = Block(ctx, &height)
+	require.NoError(t, err)
+	assert.Equal(t, store.block, res.Block)
+	assert.True(t, ctx.IsImmutable())
+	_, ok = env.resultCache.get("block", height)
+	assert.True(t, ok)
+}
+
 type mockBlockStore struct {
 	height int64
 }
@@ -132,3 +161,12 @@ func (mockBlockStore) LoadSeenCommit(height int64) *types.Commit         { retur
 func (mockBlockStore) PruneBlocks(height int64) (uint64, error)          { return 0, nil }
 func (mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
+
+type backfilledBlockStore struct {
+	mockBlockStore
+	meta  *types.BlockMeta
+	block *types.Block
+}
+
+func (store backfilledBlockStore) LoadBlockMeta(height int64) *types.BlockMeta { return store.meta }
+func (store backfilledBlockStore) LoadBlock(height int64) *types.Block         { return store.block }
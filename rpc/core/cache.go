@@ -0,0 +1,84 @@
+package core
+
+import (
+	"container/list"
+
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+type resultCacheKey struct {
+	method string
+	height int64
+}
+
+type resultCacheEntry struct {
+	key    resultCacheKey
+	result interface{}
+}
+
+// resultCache is an LRU cache of the results of height-addressed methods for
+// heights below the latest height, which are immutable. Cached results are
+// shared between callers, which must not modify them. A nil cache caches
+// nothing.
+type resultCache struct {
+	mtx   tmsync.Mutex
+	size  int
+	items map[resultCacheKey]*list.Element
+	list  *list.List // front is the most recently used
+}
+
+// newResultCache returns a cache of up to size results, or nil if size is 0.
+func newResultCache(size int) *resultCache {
+	if size <= 0 {
+		return nil
+	}
+	return &resultCache{
+		size:  size,
+		items: make(map[resultCacheKey]*list.Element, size),
+		list:  list.New(),
+	}
+}
+
+// get returns the cached result of the method at the height, if any.
+func (c *resultCache) get(method string, height int64) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.items[resultCacheKey{method, height}]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToFront(e)
+	return e.Value.(*resultCacheEntry).result, true
+}
+
+// add caches the result of the method at the height, evicting the least
+// recently used result if the cache is full.
+func (c *resultCache) add(method string, height int64, result interface{}) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := resultCacheKey{method, height}
+	if e, ok := c.items[key]; ok {
+		c.list.MoveToFront(e)
+		return
+	}
+	if c.list.Len() >= c.size {
+		oldest := c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.items, oldest.Value.(*resultCacheEntry).key)
+	}
+	c.items[key] = c.list.PushFront(&resultCacheEntry{key: key, result: result})
+}
+
+// isImmutableHeight returns true if the results at the height can no longer
+// change, i.e. it's below the latest height.
+func isImmutableHeight(height int64) bool {
+	return height < env.BlockStore.Height()
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCache(t *testing.T) {
+	c := newResultCache(2)
+	c.add("block", 1, "b1")
+	c.add("commit", 1, "c1")
+
+	v, ok := c.get("block", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "b1", v)
+
+	// commit 1 is the least recently used, and evicted
+	c.add("block", 2, "b2")
+	_, ok = c.get("commit", 1)
+	assert.False(t, ok)
+	_, ok = c.get("block", 1)
+	assert.True(t, ok)
+	_, ok = c.get("block", 2)
+	assert.True(t, ok)
+
+	// a nil cache caches nothing
+	c = newResultCache(0)
+	assert.Nil(t, c)
+	c.add("block", 1, "b1")
+	_, ok = c.get("block", 1)
+	assert.False(t, ok)
+}
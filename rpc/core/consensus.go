@@ -22,9 +22,23 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 		return nil, err
 	}
 
-	validators, err := env.StateStore.LoadValidators(height)
-	if err != nil {
-		return nil, err
+	// The validator set is cached as a whole, and paginated for each call
+	var validators *types.ValidatorSet
+	immutable := isImmutableHeight(height)
+	if immutable {
+		ctx.MarkImmutable()
+		if vals, ok := env.resultCache.get("validators", height); ok {
+			validators = vals.(*types.ValidatorSet)
+		}
+	}
+	if validators == nil {
+		validators, err = env.StateStore.LoadValidators(height)
+		if err != nil {
+			return nil, err
+		}
+		if immutable {
+			env.resultCache.add("validators", height, validators)
+		}
 	}
 
 	totalCount := len(validators.Validators)
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	ctypes "github.com/klyed/tendermint/rpc/core/types"
 	rpctypes "github.com/klyed/tendermint/rpc/jsonrpc/types"
 )
@@ -10,3 +12,19 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeRemoveTx removes the transaction with the given hash from the mempool
+// and the cache, such that it may be resubmitted.
+func UnsafeRemoveTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnsafeRemoveTx, error) {
+	txKey, err := mempoolTxKey(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	env.Mempool.Lock()
+	defer env.Mempool.Unlock()
+	if err := env.Mempool.RemoveTxByKey(txKey, true); err != nil {
+		return nil, fmt.Errorf("tx %X: %w", hash, err)
+	}
+	return &ctypes.ResultUnsafeRemoveTx{}, nil
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/klyed/tendermint/proxy"
 	ctypes "github.com/klyed/tendermint/rpc/core/types"
 	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/state/eventlog"
 	"github.com/klyed/tendermint/state/txindex"
+	"github.com/klyed/tendermint/statesync"
 	"github.com/klyed/tendermint/types"
 )
 
@@ -35,6 +37,7 @@ var (
 // SetEnvironment sets up the given Environment.
 // It will race if multiple Node call SetEnvironment.
 func SetEnvironment(e *Environment) {
+	e.resultCache = newResultCache(e.Config.ResultCacheSize)
 	env = e
 }
 
@@ -55,6 +58,10 @@ type transport interface {
 	NodeInfo() p2p.NodeInfo
 }
 
+type appConnsStatus interface {
+	Status() []proxy.ConnStatus
+}
+
 type peers interface {
 	AddPersistentPeers([]string) error
 	AddUnconditionalPeerIDs([]string) error
@@ -70,6 +77,7 @@ type Environment struct {
 	// external, thread safe interfaces
 	ProxyAppQuery   proxy.AppConnQuery
 	ProxyAppMempool proxy.AppConnMempool
+	ProxyAppStatus  appConnsStatus
 
 	// interfaces defined in types and above
 	StateStore     sm.Store
@@ -84,12 +92,18 @@ type Environment struct {
 	GenDoc           *types.GenesisDoc // cache the genesis structure
 	TxIndexer        txindex.TxIndexer
 	ConsensusReactor *consensus.Reactor
+	StateSyncReactor *statesync.Reactor
 	EventBus         *types.EventBus // thread safe
+	EventLog         *eventlog.EventLog
+	TxWaiter         *TxWaiter
 	Mempool          mempl.Mempool
 
 	Logger log.Logger
 
 	Config cfg.RPCConfig
+
+	// cache of immutable results, set up by SetEnvironment
+	resultCache *resultCache
 }
 
 //----------------------------------------------
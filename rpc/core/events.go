@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 	tmquery "github.com/klyed/tendermint/libs/pubsub/query"
 	ctypes "github.com/klyed/tendermint/rpc/core/types"
 	rpctypes "github.com/klyed/tendermint/rpc/jsonrpc/types"
+	"github.com/klyed/tendermint/state/eventlog"
+	"github.com/klyed/tendermint/types"
 )
 
 const (
@@ -16,28 +19,19 @@ const (
 	subBufferSize = 100
 )
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. The overflow policy and timeout
+// default to the configured ones.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+func Subscribe(ctx *rpctypes.Context, query, overflowPolicy string,
+	overflowTimeout time.Duration) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
-	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
-	} else if env.EventBus.NumClientSubscriptions(addr) >= env.Config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
-	}
-
-	env.Logger.Info("Subscribe to query", "remote", addr, "query", query)
-
-	q, err := tmquery.New(query)
+	policy, err := subscriptionPolicy(overflowPolicy, overflowTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse query: %w", err)
+		return nil, fmt.Errorf("%w: %v", ctypes.ErrInvalidRequest, err)
 	}
 
-	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
-	defer cancel()
-
-	sub, err := env.EventBus.Subscribe(subCtx, addr, q, subBufferSize)
+	sub, err := subscribeEvents(ctx.Context(), addr, query, policy)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +48,11 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 				)
 				writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 				defer cancel()
-				if err := ctx.WSConn.WriteRPCResponse(writeCtx, resp); err != nil {
+				err := ctx.WSConn.WriteRPCEvent(writeCtx, query, resp)
+				if errors.Is(err, rpctypes.ErrEventRateLimited) {
+					env.Logger.Debug("Dropped event (rate limited client)",
+						"to", addr, "subscriptionID", subscriptionID)
+				} else if err != nil {
 					env.Logger.Info("Can't write response (slow client)",
 						"to", addr, "subscriptionID", subscriptionID, "err", err)
 				}
@@ -83,6 +81,79 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+// SubscribeEvents subscribes the given subscriber to events matching the
+// query, subject to the same limits as Subscribe. It is used by transports
+// other than WebSocket, such as gRPC, which deliver the events themselves. The
+// subscription must be canceled with UnsubscribeEvents.
+func SubscribeEvents(ctx context.Context, subscriber, query string) (types.Subscription, error) {
+	policy, err := subscriptionPolicy("", 0)
+	if err != nil {
+		return nil, err
+	}
+	return subscribeEvents(ctx, subscriber, query, policy)
+}
+
+func subscribeEvents(
+	ctx context.Context,
+	subscriber, query string,
+	policy tmpubsub.OverflowPolicy,
+) (types.Subscription, error) {
+	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	} else if env.EventBus.NumClientSubscriptions(subscriber) >= env.Config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+
+	env.Logger.Info("Subscribe to query", "remote", subscriber, "query", query)
+
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
+	defer cancel()
+
+	return env.EventBus.SubscribeWithPolicy(subCtx, subscriber, q, subBufferSize, policy)
+}
+
+// subscriptionPolicy returns the overflow policy for a subscription with the
+// given action and timeout, defaulting to the ones of the RPC config. The
+// block action is only allowed if it's the configured one, since it delays the
+// other subscribers, and its timeout is capped by the configured one.
+func subscriptionPolicy(name string, timeout time.Duration) (tmpubsub.OverflowPolicy, error) {
+	defaultAction, err := tmpubsub.ParseOverflowAction(env.Config.SubscriptionOverflowPolicy)
+	if err != nil {
+		return tmpubsub.OverflowPolicy{}, err
+	}
+	action := defaultAction
+	if name != "" {
+		if action, err = tmpubsub.ParseOverflowAction(name); err != nil {
+			return tmpubsub.OverflowPolicy{}, err
+		}
+	}
+	if action == tmpubsub.OverflowBlock && defaultAction != tmpubsub.OverflowBlock {
+		return tmpubsub.OverflowPolicy{}, errors.New("the block overflow policy is disabled")
+	}
+	if timeout < 0 {
+		return tmpubsub.OverflowPolicy{}, errors.New("negative overflow timeout")
+	}
+	if timeout == 0 || timeout > env.Config.SubscriptionOverflowTimeout {
+		timeout = env.Config.SubscriptionOverflowTimeout
+	}
+	return tmpubsub.OverflowPolicy{Action: action, Timeout: timeout}, nil
+}
+
+// UnsubscribeEvents cancels a subscription made with SubscribeEvents.
+func UnsubscribeEvents(ctx context.Context, subscriber, query string) error {
+	env.Logger.Info("Unsubscribe from query", "remote", subscriber, "query", query)
+	q, err := tmquery.New(query)
+	if err != nil {
+		return fmt.Errorf("failed to parse query: %w", err)
+	}
+	return env.EventBus.Unsubscribe(ctx, subscriber, q)
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
 func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
@@ -110,3 +181,78 @@ func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
 	}
 	return &ctypes.ResultUnsubscribe{}, nil
 }
+
+// Events reads events matching the filter query from the event log, starting
+// after the given cursor and, if given, ending before the before cursor. An
+// empty filter matches all events. If no matching events are available and
+// waitTime is positive, it waits up to waitTime (capped at the
+// broadcast_tx_commit timeout) for new events to be published.
+// More: https://docs.tendermint.com/master/rpc/#/Info/events
+func Events(ctx *rpctypes.Context, filter string, maxItemsPtr *int, after, before string,
+	waitTime time.Duration) (*ctypes.ResultEvents, error) {
+	if env.EventLog == nil {
+		return nil, errors.New("event log is disabled")
+	}
+
+	q := tmpubsub.Query(tmquery.Empty{})
+	if filter != "" {
+		var err error
+		if q, err = tmquery.New(filter); err != nil {
+			return nil, fmt.Errorf("%w: invalid filter: %v", ctypes.ErrInvalidRequest, err)
+		}
+	}
+	afterCursor, err := eventlog.ParseCursor(after)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ctypes.ErrInvalidRequest, err)
+	}
+	beforeCursor, err := eventlog.ParseCursor(before)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ctypes.ErrInvalidRequest, err)
+	}
+	maxItems := validatePerPage(maxItemsPtr)
+
+	if waitTime > env.Config.TimeoutBroadcastTxCommit {
+		waitTime = env.Config.TimeoutBroadcastTxCommit
+	}
+	timer := time.NewTimer(waitTime)
+	defer timer.Stop()
+
+	for {
+		// fetch the notification channel before scanning, to not miss events
+		added := env.EventLog.Added()
+		items, more, err := env.EventLog.Scan(q, afterCursor, beforeCursor, maxItems)
+		if err != nil {
+			return nil, err
+		}
+
+		// only wait for events that can still be returned
+		_, newest := env.EventLog.Window()
+		canWait := waitTime > 0 && (beforeCursor.IsZero() || newest.Before(beforeCursor))
+		if len(items) > 0 || !canWait {
+			oldest, newest := env.EventLog.Window()
+			result := &ctypes.ResultEvents{
+				Items:  make([]*ctypes.EventItem, 0, len(items)),
+				More:   more,
+				Oldest: oldest.String(),
+				Newest: newest.String(),
+			}
+			for _, item := range items {
+				result.Items = append(result.Items, &ctypes.EventItem{
+					Cursor: item.Cursor.String(),
+					Event:  item.Type,
+					Data:   item.Data,
+					Events: item.Events,
+				})
+			}
+			return result, nil
+		}
+
+		select {
+		case <-added:
+		case <-timer.C:
+			waitTime = 0
+		case <-ctx.Context().Done():
+			return nil, ctx.Context().Err()
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/klyed/tendermint/config"
+	tmpubsub "github.com/klyed/tendermint/libs/pubsub"
+)
+
+func TestSubscriptionPolicy(t *testing.T) {
+	config := *cfg.DefaultRPCConfig()
+	config.SubscriptionOverflowTimeout = time.Second
+	env = &Environment{Config: config}
+
+	testcases := map[string]struct {
+		configPolicy string
+		policy       string
+		timeout      time.Duration
+		expect       tmpubsub.OverflowPolicy
+		expectErr    bool
+	}{
+		"default": {"drop-newest", "", 0,
+			tmpubsub.OverflowPolicy{Action: tmpubsub.OverflowDropNewest, Timeout: time.Second}, false},
+		"chosen": {"cancel", "drop-oldest", 0,
+			tmpubsub.OverflowPolicy{Action: tmpubsub.OverflowDropOldest, Timeout: time.Second}, false},
+		"block": {"block", "block", time.Millisecond,
+			tmpubsub.OverflowPolicy{Action: tmpubsub.OverflowBlock, Timeout: time.Millisecond}, false},
+		"block timeout capped": {"block", "", time.Minute,
+			tmpubsub.OverflowPolicy{Action: tmpubsub.OverflowBlock, Timeout: time.Second}, false},
+		"block disabled":   {"cancel", "block", 0, tmpubsub.OverflowPolicy{}, true},
+		"unknown":          {"cancel", "drop-all", 0, tmpubsub.OverflowPolicy{}, true},
+		"negative timeout": {"block", "block", -time.Second, tmpubsub.OverflowPolicy{}, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			env.Config.SubscriptionOverflowPolicy = tc.configPolicy
+			policy, err := subscriptionPolicy(tc.policy, tc.timeout)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, policy)
+		})
+	}
+}
@@ -1,13 +1,30 @@
 package core
 
 import (
+	"github.com/klyed/tendermint/proxy"
 	ctypes "github.com/klyed/tendermint/rpc/core/types"
 	rpctypes "github.com/klyed/tendermint/rpc/jsonrpc/types"
 )
 
-// Health gets node health. Returns empty result (200 OK) on success, no
-// response - in case of an error.
+// Health gets node health. Returns the status of the connections to the ABCI
+// application (200 OK) on success, no response - in case of an error.
 // More: https://docs.tendermint.com/master/rpc/#/Info/health
 func Health(ctx *rpctypes.Context) (*ctypes.ResultHealth, error) {
-	return &ctypes.ResultHealth{}, nil
+	var statuses []proxy.ConnStatus
+	if env.ProxyAppStatus != nil {
+		statuses = env.ProxyAppStatus.Status()
+	}
+
+	conns := make([]ctypes.ABCIConnStatus, len(statuses))
+	for i, status := range statuses {
+		conns[i] = ctypes.ABCIConnStatus{
+			Name:       status.Name,
+			State:      string(status.State),
+			Latency:    status.Latency,
+			LastCheck:  status.LastCheck,
+			Reconnects: int64(status.Reconnects),
+			LastError:  status.LastError,
+		}
+	}
+	return &ctypes.ResultHealth{ABCIConnections: conns}, nil
 }
@@ -39,8 +39,10 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	if err != nil {
 		return nil, err
 	}
-	res := <-resCh
-	r := res.GetCheckTx()
+	r, err := checkTxResponse(<-resCh)
+	if err != nil {
+		return nil, err
+	}
 	return &ctypes.ResultBroadcastTx{
 		Code:      r.Code,
 		Data:      r.Data,
@@ -86,8 +88,11 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 		env.Logger.Error("Error on broadcastTxCommit", "err", err)
 		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
 	}
-	checkTxResMsg := <-checkTxResCh
-	checkTxRes := checkTxResMsg.GetCheckTx()
+	checkTxRes, err := checkTxResponse(<-checkTxResCh)
+	if err != nil {
+		env.Logger.Error("Error on broadcastTxCommit", "err", err)
+		return nil, fmt.Errorf("error on broadcastTxCommit: %v", err)
+	}
 	if checkTxRes.Code != abci.CodeTypeOK {
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
@@ -131,6 +136,59 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	}
 }
 
+// BroadcastTxWait returns with the responses from CheckTx and DeliverTx, like
+// BroadcastTxCommit, but without subscribing to the event bus for each call,
+// so it isn't limited by max_subscription_clients. It waits up to timeout for
+// the tx to be committed, capped at and defaulting to the broadcast_tx_commit
+// timeout.
+// More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_wait
+func BroadcastTxWait(ctx *rpctypes.Context, tx types.Tx, timeout time.Duration) (*ctypes.ResultBroadcastTxCommit, error) {
+	if env.TxWaiter == nil || !env.TxWaiter.IsRunning() {
+		return nil, errors.New("tx waiter is not running")
+	}
+	if timeout <= 0 || timeout > env.Config.TimeoutBroadcastTxCommit {
+		timeout = env.Config.TimeoutBroadcastTxCommit
+	}
+
+	// Register before broadcasting, to not miss the tx being committed.
+	hash := tx.Hash()
+	deliverTxCh, deregister := env.TxWaiter.Register(hash)
+	defer deregister()
+
+	// Broadcast tx and wait for CheckTx result
+	checkTxResCh := make(chan *abci.Response, 1)
+	err := env.Mempool.CheckTx(tx, func(res *abci.Response) {
+		checkTxResCh <- res
+	}, mempl.TxInfo{Context: ctx.Context()})
+	if err != nil {
+		return nil, fmt.Errorf("error on broadcast_tx_wait: %w", err)
+	}
+	checkTxRes, err := checkTxResponse(<-checkTxResCh)
+	if err != nil {
+		return nil, fmt.Errorf("error on broadcast_tx_wait: %w", err)
+	}
+	result := &ctypes.ResultBroadcastTxCommit{CheckTx: *checkTxRes, Hash: hash}
+	if checkTxRes.Code != abci.CodeTypeOK {
+		return result, nil
+	}
+
+	// Wait for the tx to be included in a block or timeout.
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case deliverTxRes := <-deliverTxCh:
+		result.DeliverTx = deliverTxRes.Result
+		result.Height = deliverTxRes.Height
+		return result, nil
+	case <-env.TxWaiter.Quit():
+		return result, errors.New("tx waiter stopped")
+	case <-ctx.Context().Done():
+		return result, ctx.Context().Err()
+	case <-timer.C:
+		return result, errors.New("timed out waiting for tx to be included in a block")
+	}
+}
+
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
 // including their number.
 // More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs
@@ -146,6 +204,69 @@ func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfi
 		Txs:        txs}, nil
 }
 
+// UnconfirmedTx gets an unconfirmed transaction by its hash, along with its
+// metadata.
+// More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_tx
+func UnconfirmedTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	txKey, err := mempoolTxKey(hash)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := env.Mempool.TxByKey(txKey)
+	if !ok {
+		return nil, fmt.Errorf("tx %X not found in mempool", hash)
+	}
+	return unconfirmedTxResult(entry), nil
+}
+
+// UnconfirmedTxsInfo gets unconfirmed transactions (maximum ?limit entries)
+// along with their metadata, including their number.
+// More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs_info
+func UnconfirmedTxsInfo(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxsInfo, error) {
+	// reuse per_page validator
+	limit := validatePerPage(limitPtr)
+
+	entries := env.Mempool.TxEntries(limit)
+	txs := make([]ctypes.ResultUnconfirmedTx, 0, len(entries))
+	for _, entry := range entries {
+		txs = append(txs, *unconfirmedTxResult(entry))
+	}
+	return &ctypes.ResultUnconfirmedTxsInfo{
+		Count:      len(txs),
+		Total:      env.Mempool.Size(),
+		TotalBytes: env.Mempool.TxsBytes(),
+		Txs:        txs}, nil
+}
+
+func unconfirmedTxResult(entry mempl.TxEntry) *ctypes.ResultUnconfirmedTx {
+	return &ctypes.ResultUnconfirmedTx{
+		Hash:      entry.Tx.Hash(),
+		Tx:        entry.Tx,
+		Height:    entry.Height,
+		GasWanted: entry.GasWanted,
+		Sender:    entry.Sender,
+	}
+}
+
+// mempoolTxKey converts a tx hash to a mempool tx key.
+func mempoolTxKey(hash []byte) ([mempl.TxKeySize]byte, error) {
+	var txKey [mempl.TxKeySize]byte
+	if len(hash) != mempl.TxKeySize {
+		return txKey, fmt.Errorf("invalid tx hash length %d, expected %d", len(hash), mempl.TxKeySize)
+	}
+	copy(txKey[:], hash)
+	return txKey, nil
+}
+
+// checkTxResponse returns the CheckTx response, or an error if the request
+// failed, e.g. because the connection to the application was lost.
+func checkTxResponse(res *abci.Response) (*abci.ResponseCheckTx, error) {
+	if e := res.GetException(); e != nil {
+		return nil, fmt.Errorf("failed to check tx: %s", e.Error)
+	}
+	return res.GetCheckTx(), nil
+}
+
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.tendermint.com/master/rpc/#/Info/num_unconfirmed_txs
 func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
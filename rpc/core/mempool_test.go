@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/abci/example/kvstore"
+	cfg "github.com/klyed/tendermint/config"
+	mempl "github.com/klyed/tendermint/mempool"
+	"github.com/klyed/tendermint/p2p"
+	"github.com/klyed/tendermint/proxy"
+	rpctypes "github.com/klyed/tendermint/rpc/jsonrpc/types"
+	"github.com/klyed/tendermint/types"
+)
+
+func TestUnconfirmedTxInfo(t *testing.T) {
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewApplication()))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() { _ = proxyApp.Stop() })
+
+	mempool := mempl.NewCListMempool(cfg.TestMempoolConfig(), proxyApp.Mempool(), 0)
+	env = &Environment{Mempool: mempool}
+
+	peerID := p2p.NodeID("0123456789abcdef0123456789abcdef01234567")
+	txs := types.Txs{[]byte("a=1"), []byte("b=2")}
+	require.NoError(t, mempool.CheckTx(txs[0], nil, mempl.TxInfo{}))
+	require.NoError(t, mempool.CheckTx(txs[1], nil, mempl.TxInfo{SenderID: 1, SenderP2PID: peerID}))
+
+	res, err := UnconfirmedTx(&rpctypes.Context{}, txs[1].Hash())
+	require.NoError(t, err)
+	assert.EqualValues(t, txs[1].Hash(), res.Hash)
+	assert.Equal(t, txs[1], res.Tx)
+	assert.EqualValues(t, 1, res.GasWanted)
+	assert.Equal(t, peerID, res.Sender)
+
+	limit := 1
+	list, err := UnconfirmedTxsInfo(&rpctypes.Context{}, &limit)
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Count)
+	assert.Equal(t, 2, list.Total)
+	require.Len(t, list.Txs, 1)
+	assert.Equal(t, txs[0], list.Txs[0].Tx)
+	assert.Empty(t, list.Txs[0].Sender)
+
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, txs[1].Hash())
+	require.NoError(t, err)
+	_, err = UnconfirmedTx(&rpctypes.Context{}, txs[1].Hash())
+	assert.Error(t, err)
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, txs[1].Hash())
+	assert.ErrorIs(t, err, mempl.ErrTxNotFound)
+	assert.Equal(t, 1, mempool.Size())
+
+	_, err = UnconfirmedTx(&rpctypes.Context{}, []byte{0x01})
+	assert.Error(t, err)
+}
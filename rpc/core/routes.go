@@ -1,6 +1,7 @@
 package core
 
 import (
+	ctypes "github.com/klyed/tendermint/rpc/core/types"
 	rpc "github.com/klyed/tendermint/rpc/jsonrpc/server"
 )
 
@@ -9,47 +10,65 @@ import (
 // Routes is a map of available routes.
 var Routes = map[string]*rpc.RPCFunc{
 	// subscribe/unsubscribe are reserved for websocket events.
-	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
-	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
-	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+	"subscribe":       newRPCFunc("subscribe", Subscribe),
+	"unsubscribe":     newRPCFunc("unsubscribe", Unsubscribe),
+	"unsubscribe_all": newRPCFunc("unsubscribe_all", UnsubscribeAll),
+
+	// event log API
+	"events": newRPCFunc("events", Events),
 
 	// info API
-	"health":               rpc.NewRPCFunc(Health, ""),
-	"status":               rpc.NewRPCFunc(Status, ""),
-	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
-	"genesis":              rpc.NewRPCFunc(Genesis, ""),
-	"block":                rpc.NewRPCFunc(Block, "height"),
-	"block_by_hash":        rpc.NewRPCFunc(BlockByHash, "hash"),
-	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
-	"commit":               rpc.NewRPCFunc(Commit, "height"),
-	"check_tx":             rpc.NewRPCFunc(CheckTx, "tx"),
-	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by"),
-	"validators":           rpc.NewRPCFunc(Validators, "height,page,per_page"),
-	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
-	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
-	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
-	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
-	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"health":               newRPCFunc("health", Health),
+	"status":               newRPCFunc("status", Status),
+	"net_info":             newRPCFunc("net_info", NetInfo),
+	"blockchain":           newRPCFunc("blockchain", BlockchainInfo),
+	"genesis":              newRPCFunc("genesis", Genesis),
+	"block":                newRPCFunc("block", Block),
+	"block_by_hash":        newRPCFunc("block_by_hash", BlockByHash),
+	"block_results":        newRPCFunc("block_results", BlockResults),
+	"commit":               newRPCFunc("commit", Commit),
+	"check_tx":             newRPCFunc("check_tx", CheckTx),
+	"tx":                   newRPCFunc("tx", Tx),
+	"tx_search":            newRPCFunc("tx_search", TxSearch),
+	"validators":           newRPCFunc("validators", Validators),
+	"dump_consensus_state": newRPCFunc("dump_consensus_state", DumpConsensusState),
+	"consensus_state":      newRPCFunc("consensus_state", ConsensusState),
+	"consensus_params":     newRPCFunc("consensus_params", ConsensusParams),
+	"unconfirmed_txs":      newRPCFunc("unconfirmed_txs", UnconfirmedTxs),
+	"num_unconfirmed_txs":  newRPCFunc("num_unconfirmed_txs", NumUnconfirmedTxs),
+	"unconfirmed_tx":       newRPCFunc("unconfirmed_tx", UnconfirmedTx),
+	"unconfirmed_txs_info": newRPCFunc("unconfirmed_txs_info", UnconfirmedTxsInfo),
 
 	// tx broadcast API
-	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
-	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
-	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_tx_commit": newRPCFunc("broadcast_tx_commit", BroadcastTxCommit),
+	"broadcast_tx_sync":   newRPCFunc("broadcast_tx_sync", BroadcastTxSync),
+	"broadcast_tx_async":  newRPCFunc("broadcast_tx_async", BroadcastTxAsync),
+	"broadcast_tx_wait":   newRPCFunc("broadcast_tx_wait", BroadcastTxWait),
 
 	// abci API
-	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
-	"abci_info":  rpc.NewRPCFunc(ABCIInfo, ""),
+	"abci_query": newRPCFunc("abci_query", ABCIQuery),
+	"abci_info":  newRPCFunc("abci_info", ABCIInfo),
 
 	// evidence API
-	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"broadcast_evidence": newRPCFunc("broadcast_evidence", BroadcastEvidence),
 }
 
 // AddUnsafeRoutes adds unsafe routes.
 func AddUnsafeRoutes() {
 	// control API
-	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
-	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
-	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["dial_seeds"] = newRPCFunc("dial_seeds", UnsafeDialSeeds)
+	Routes["dial_peers"] = newRPCFunc("dial_peers", UnsafeDialPeers)
+	Routes["unsafe_flush_mempool"] = newRPCFunc("unsafe_flush_mempool", UnsafeFlushMempool)
+	Routes["unsafe_remove_tx"] = newRPCFunc("unsafe_remove_tx", UnsafeRemoveTx)
+}
+
+// newRPCFunc wraps f to serve the given method, with the parameters documented
+// in the OpenAPI spec (see ctypes.RouteSpecs). It panics if f doesn't match the
+// spec.
+func newRPCFunc(method string, f interface{}) *rpc.RPCFunc {
+	spec := ctypes.MustRouteSpec(method, f)
+	if spec.WebSocket {
+		return rpc.NewWSRPCFunc(f, spec.ParamNames())
+	}
+	return rpc.NewRPCFunc(f, spec.ParamNames())
 }
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ctypes "github.com/klyed/tendermint/rpc/core/types"
+)
+
+func TestRoutesMatchSpec(t *testing.T) {
+	for method, spec := range ctypes.RouteSpecs {
+		_, ok := Routes[method]
+		assert.Equal(t, !spec.Unsafe, ok, "method %q", method)
+	}
+
+	AddUnsafeRoutes()
+	for method := range ctypes.RouteSpecs {
+		assert.Contains(t, Routes, method)
+	}
+	assert.Len(t, Routes, len(ctypes.RouteSpecs))
+}
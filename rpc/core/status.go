@@ -74,6 +74,16 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 		ValidatorInfo: validatorInfo,
 	}
 
+	if env.StateSyncReactor != nil {
+		progress := env.StateSyncReactor.Progress()
+		result.SyncInfo.SnapshotHeight = int64(progress.SnapshotHeight)
+		result.SyncInfo.SnapshotChunksApplied = int64(progress.ChunksApplied)
+		result.SyncInfo.SnapshotChunksTotal = int64(progress.ChunksTotal)
+		result.SyncInfo.SnapshotBytesApplied = int64(progress.BytesApplied)
+		result.SyncInfo.SnapshotSyncTime = progress.Elapsed
+		result.SyncInfo.SnapshotRemainingTime = progress.Remaining
+	}
+
 	return result, nil
 }
 
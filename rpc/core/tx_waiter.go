@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+
+	"github.com/klyed/tendermint/libs/service"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+	"github.com/klyed/tendermint/types"
+)
+
+const txWaiterSubscriber = "TxWaiter"
+
+// TxWaiter notifies callers waiting for txs to be committed, using a single
+// event bus subscription to all txs instead of one per caller.
+type TxWaiter struct {
+	service.BaseService
+
+	eventBus *types.EventBus
+
+	mtx     tmsync.Mutex
+	waiters map[string]map[chan types.EventDataTx]struct{} // by tx hash
+}
+
+// NewTxWaiter creates a new TxWaiter, notifying callers of the txs published
+// on the event bus once started.
+func NewTxWaiter(eventBus *types.EventBus) *TxWaiter {
+	w := &TxWaiter{
+		eventBus: eventBus,
+		waiters:  make(map[string]map[chan types.EventDataTx]struct{}),
+	}
+	w.BaseService = *service.NewBaseService(nil, "TxWaiter", w)
+	return w
+}
+
+// OnStart implements service.Service by subscribing to all txs.
+func (w *TxWaiter) OnStart() error {
+	// Use SubscribeUnbuffered, such that the subscription is never canceled and
+	// no txs are missed. Notifying waiters doesn't block.
+	sub, err := w.eventBus.SubscribeUnbuffered(context.Background(), txWaiterSubscriber, types.EventQueryTx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				if data, ok := msg.Data().(types.EventDataTx); ok {
+					w.notify(data)
+				}
+			case <-sub.Canceled():
+				return
+			case <-w.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from all txs.
+func (w *TxWaiter) OnStop() {
+	if w.eventBus.IsRunning() {
+		_ = w.eventBus.UnsubscribeAll(context.Background(), txWaiterSubscriber)
+	}
+}
+
+// Register registers a waiter for the tx with the given hash, returning a
+// channel which receives the tx result once it's committed, and a function to
+// deregister the waiter, which must be called once done. Callers should
+// register before broadcasting the tx, to not miss it being committed.
+func (w *TxWaiter) Register(hash []byte) (<-chan types.EventDataTx, func()) {
+	ch := make(chan types.EventDataTx, 1)
+	key := string(hash)
+
+	w.mtx.Lock()
+	if w.waiters[key] == nil {
+		w.waiters[key] = make(map[chan types.EventDataTx]struct{})
+	}
+	w.waiters[key][ch] = struct{}{}
+	w.mtx.Unlock()
+
+	return ch, func() {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		delete(w.waiters[key], ch)
+		if len(w.waiters[key]) == 0 {
+			delete(w.waiters, key)
+		}
+	}
+}
+
+// NumWaiters returns the number of registered waiters.
+func (w *TxWaiter) NumWaiters() int {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	n := 0
+	for _, waiters := range w.waiters {
+		n += len(waiters)
+	}
+	return n
+}
+
+func (w *TxWaiter) notify(data types.EventDataTx) {
+	key := string(types.Tx(data.Tx).Hash())
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for ch := range w.waiters[key] {
+		// channels are buffered and receive a single result
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
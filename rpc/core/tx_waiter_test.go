@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/types"
+)
+
+func TestTxWaiter(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	w := NewTxWaiter(eventBus)
+	require.NoError(t, w.Start())
+	t.Cleanup(func() { _ = w.Stop() })
+
+	tx := types.Tx("foo=bar")
+	ch1, deregister1 := w.Register(tx.Hash())
+	ch2, deregister2 := w.Register(tx.Hash())
+	_, deregisterOther := w.Register(types.Tx("other").Hash())
+	assert.Equal(t, 3, w.NumWaiters())
+
+	result := abci.TxResult{Height: 5, Tx: tx, Result: abci.ResponseDeliverTx{Data: []byte("ok")}}
+	require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: result}))
+
+	// all waiters for the tx are notified
+	for _, ch := range []<-chan types.EventDataTx{ch1, ch2} {
+		select {
+		case data := <-ch:
+			assert.EqualValues(t, 5, data.Height)
+			assert.Equal(t, []byte("ok"), data.Result.Data)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for tx")
+		}
+	}
+
+	deregister1()
+	deregister2()
+	deregisterOther()
+	assert.Zero(t, w.NumWaiters())
+}
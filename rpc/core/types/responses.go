@@ -85,6 +85,14 @@ type SyncInfo struct {
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
 	CatchingUp bool `json:"catching_up"`
+
+	// State sync progress, if the node restored a snapshot.
+	SnapshotHeight        int64         `json:"snapshot_height"`
+	SnapshotChunksApplied int64         `json:"snapshot_chunks_applied"`
+	SnapshotChunksTotal   int64         `json:"snapshot_chunks_total"`
+	SnapshotBytesApplied  int64         `json:"snapshot_bytes_applied"`
+	SnapshotSyncTime      time.Duration `json:"snapshot_sync_time"`
+	SnapshotRemainingTime time.Duration `json:"snapshot_remaining_time"`
 }
 
 // Info about the node's validator
@@ -216,6 +224,24 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// A transaction in the mempool along with its metadata
+type ResultUnconfirmedTx struct {
+	Hash      bytes.HexBytes `json:"hash"`
+	Tx        types.Tx       `json:"tx"`
+	Height    int64          `json:"height"`
+	GasWanted int64          `json:"gas_wanted"`
+	// The peer the tx was first received from, empty if submitted locally.
+	Sender p2p.NodeID `json:"sender"`
+}
+
+// List of mempool txs along with their metadata
+type ResultUnconfirmedTxsInfo struct {
+	Count      int                   `json:"n_txs"`
+	Total      int                   `json:"total"`
+	TotalBytes int64                 `json:"total_bytes"`
+	Txs        []ResultUnconfirmedTx `json:"txs"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -234,15 +260,53 @@ type ResultBroadcastEvidence struct {
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
+	ResultUnsafeRemoveTx     struct{}
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
 )
 
+// Node health
+type ResultHealth struct {
+	// status of each connection to the ABCI application
+	ABCIConnections []ABCIConnStatus `json:"abci_connections"`
+}
+
+// Status of a connection to the ABCI application
+type ABCIConnStatus struct {
+	Name string `json:"name"`
+	// connected, unresponsive, reconnecting or halted
+	State string `json:"state"`
+	// time the application took to answer the last health check
+	Latency    time.Duration `json:"latency"`
+	LastCheck  time.Time     `json:"last_check"`
+	Reconnects int64         `json:"reconnects"`
+	LastError  string        `json:"last_error"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	Query  string              `json:"query"`
 	Data   types.TMEventData   `json:"data"`
 	Events map[string][]string `json:"events"`
 }
+
+// Events read from the event log
+type ResultEvents struct {
+	// Events matching the filter, in order.
+	Items []*EventItem `json:"items"`
+	// True if more events matching the filter are available.
+	More bool `json:"more"`
+	// Cursors of the oldest and newest events in the event log. Clients
+	// resuming from a cursor before Oldest may have missed pruned events.
+	Oldest string `json:"oldest"`
+	Newest string `json:"newest"`
+}
+
+// An event in the event log
+type EventItem struct {
+	Cursor string              `json:"cursor"`
+	Event  string              `json:"event"`
+	Data   types.TMEventData   `json:"data"`
+	Events map[string][]string `json:"events"`
+}
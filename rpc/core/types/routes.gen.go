@@ -0,0 +1,223 @@
+// Code generated by scripts/rpcgen from rpc/openapi/openapi.yaml. DO NOT EDIT.
+
+package coretypes
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/klyed/tendermint/libs/bytes"
+	"github.com/klyed/tendermint/types"
+)
+
+// RouteSpecs are the RPC routes documented in the OpenAPI spec, by method.
+var RouteSpecs = map[string]RouteSpec{
+	"abci_info": {
+		Result: reflect.TypeOf(ResultABCIInfo{}),
+	},
+	"abci_query": {
+		Params: []RouteParam{
+			{Name: "path", Type: reflect.TypeOf((*string)(nil)).Elem(), Required: true},
+			{Name: "data", Type: reflect.TypeOf((*bytes.HexBytes)(nil)).Elem(), Required: true},
+			{Name: "height", Type: reflect.TypeOf((*int64)(nil)).Elem()},
+			{Name: "prove", Type: reflect.TypeOf((*bool)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultABCIQuery{}),
+	},
+	"block": {
+		Params: []RouteParam{
+			{Name: "height", Type: reflect.TypeOf((**int64)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultBlock{}),
+	},
+	"block_by_hash": {
+		Params: []RouteParam{
+			{Name: "hash", Type: reflect.TypeOf((*[]byte)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultBlock{}),
+	},
+	"block_results": {
+		Params: []RouteParam{
+			{Name: "height", Type: reflect.TypeOf((**int64)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultBlockResults{}),
+	},
+	"blockchain": {
+		Params: []RouteParam{
+			{Name: "minHeight", Type: reflect.TypeOf((*int64)(nil)).Elem()},
+			{Name: "maxHeight", Type: reflect.TypeOf((*int64)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultBlockchainInfo{}),
+	},
+	"broadcast_evidence": {
+		Params: []RouteParam{
+			{Name: "evidence", Type: reflect.TypeOf((*types.Evidence)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultBroadcastEvidence{}),
+	},
+	"broadcast_tx_async": {
+		Params: []RouteParam{
+			{Name: "tx", Type: reflect.TypeOf((*types.Tx)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultBroadcastTx{}),
+	},
+	"broadcast_tx_commit": {
+		Params: []RouteParam{
+			{Name: "tx", Type: reflect.TypeOf((*types.Tx)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultBroadcastTxCommit{}),
+	},
+	"broadcast_tx_sync": {
+		Params: []RouteParam{
+			{Name: "tx", Type: reflect.TypeOf((*types.Tx)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultBroadcastTx{}),
+	},
+	"broadcast_tx_wait": {
+		Params: []RouteParam{
+			{Name: "tx", Type: reflect.TypeOf((*types.Tx)(nil)).Elem(), Required: true},
+			{Name: "timeout", Type: reflect.TypeOf((*time.Duration)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultBroadcastTxCommit{}),
+	},
+	"check_tx": {
+		Params: []RouteParam{
+			{Name: "tx", Type: reflect.TypeOf((*types.Tx)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultCheckTx{}),
+	},
+	"commit": {
+		Params: []RouteParam{
+			{Name: "height", Type: reflect.TypeOf((**int64)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultCommit{}),
+	},
+	"consensus_params": {
+		Params: []RouteParam{
+			{Name: "height", Type: reflect.TypeOf((**int64)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultConsensusParams{}),
+	},
+	"consensus_state": {
+		Result: reflect.TypeOf(ResultConsensusState{}),
+	},
+	"dial_peers": {
+		Params: []RouteParam{
+			{Name: "peers", Type: reflect.TypeOf((*[]string)(nil)).Elem()},
+			{Name: "persistent", Type: reflect.TypeOf((*bool)(nil)).Elem()},
+			{Name: "unconditional", Type: reflect.TypeOf((*bool)(nil)).Elem()},
+			{Name: "private", Type: reflect.TypeOf((*bool)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultDialPeers{}),
+		Unsafe: true,
+	},
+	"dial_seeds": {
+		Params: []RouteParam{
+			{Name: "seeds", Type: reflect.TypeOf((*[]string)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultDialSeeds{}),
+		Unsafe: true,
+	},
+	"dump_consensus_state": {
+		Result: reflect.TypeOf(ResultDumpConsensusState{}),
+	},
+	"events": {
+		Params: []RouteParam{
+			{Name: "filter", Type: reflect.TypeOf((*string)(nil)).Elem()},
+			{Name: "max_items", Type: reflect.TypeOf((**int)(nil)).Elem()},
+			{Name: "after", Type: reflect.TypeOf((*string)(nil)).Elem()},
+			{Name: "before", Type: reflect.TypeOf((*string)(nil)).Elem()},
+			{Name: "wait_time", Type: reflect.TypeOf((*time.Duration)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultEvents{}),
+	},
+	"genesis": {
+		Result: reflect.TypeOf(ResultGenesis{}),
+	},
+	"health": {
+		Result: reflect.TypeOf(ResultHealth{}),
+	},
+	"net_info": {
+		Result: reflect.TypeOf(ResultNetInfo{}),
+	},
+	"num_unconfirmed_txs": {
+		Result: reflect.TypeOf(ResultUnconfirmedTxs{}),
+	},
+	"status": {
+		Result: reflect.TypeOf(ResultStatus{}),
+	},
+	"subscribe": {
+		Params: []RouteParam{
+			{Name: "query", Type: reflect.TypeOf((*string)(nil)).Elem(), Required: true},
+			{Name: "overflow_policy", Type: reflect.TypeOf((*string)(nil)).Elem()},
+			{Name: "overflow_timeout", Type: reflect.TypeOf((*time.Duration)(nil)).Elem()},
+		},
+		Result:    reflect.TypeOf(ResultSubscribe{}),
+		WebSocket: true,
+	},
+	"tx": {
+		Params: []RouteParam{
+			{Name: "hash", Type: reflect.TypeOf((*[]byte)(nil)).Elem(), Required: true},
+			{Name: "prove", Type: reflect.TypeOf((*bool)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultTx{}),
+	},
+	"tx_search": {
+		Params: []RouteParam{
+			{Name: "query", Type: reflect.TypeOf((*string)(nil)).Elem(), Required: true},
+			{Name: "prove", Type: reflect.TypeOf((*bool)(nil)).Elem()},
+			{Name: "page", Type: reflect.TypeOf((**int)(nil)).Elem()},
+			{Name: "per_page", Type: reflect.TypeOf((**int)(nil)).Elem()},
+			{Name: "order_by", Type: reflect.TypeOf((*string)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultTxSearch{}),
+	},
+	"unconfirmed_tx": {
+		Params: []RouteParam{
+			{Name: "hash", Type: reflect.TypeOf((*[]byte)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultUnconfirmedTx{}),
+	},
+	"unconfirmed_txs": {
+		Params: []RouteParam{
+			{Name: "limit", Type: reflect.TypeOf((**int)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultUnconfirmedTxs{}),
+	},
+	"unconfirmed_txs_info": {
+		Params: []RouteParam{
+			{Name: "limit", Type: reflect.TypeOf((**int)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultUnconfirmedTxsInfo{}),
+	},
+	"unsafe_flush_mempool": {
+		Result: reflect.TypeOf(ResultUnsafeFlushMempool{}),
+		Unsafe: true,
+	},
+	"unsafe_remove_tx": {
+		Params: []RouteParam{
+			{Name: "hash", Type: reflect.TypeOf((*[]byte)(nil)).Elem(), Required: true},
+		},
+		Result: reflect.TypeOf(ResultUnsafeRemoveTx{}),
+		Unsafe: true,
+	},
+	"unsubscribe": {
+		Params: []RouteParam{
+			{Name: "query", Type: reflect.TypeOf((*string)(nil)).Elem(), Required: true},
+		},
+		Result:    reflect.TypeOf(ResultUnsubscribe{}),
+		WebSocket: true,
+	},
+	"unsubscribe_all": {
+		Result:    reflect.TypeOf(ResultUnsubscribe{}),
+		WebSocket: true,
+	},
+	"validators": {
+		Params: []RouteParam{
+			{Name: "height", Type: reflect.TypeOf((**int64)(nil)).Elem()},
+			{Name: "page", Type: reflect.TypeOf((**int)(nil)).Elem()},
+			{Name: "per_page", Type: reflect.TypeOf((**int)(nil)).Elem()},
+		},
+		Result: reflect.TypeOf(ResultValidators{}),
+	},
+}
@@ -0,0 +1,79 @@
+package coretypes
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//go:generate go run ../../../scripts/rpcgen -spec ../../openapi/openapi.yaml -routes routes.gen.go -client ../../client/http/client.gen.go
+
+// RouteSpec describes an RPC route, as documented in rpc/openapi/openapi.yaml.
+type RouteSpec struct {
+	// Params are the route's parameters, in the order they're passed to the
+	// function serving the route.
+	Params []RouteParam
+	// Result is the type of the route's result.
+	Result reflect.Type
+	// Unsafe routes are only served if unsafe routes are enabled.
+	Unsafe bool
+	// WebSocket routes are only served over websocket connections.
+	WebSocket bool
+}
+
+// RouteParam describes a parameter of an RPC route.
+type RouteParam struct {
+	Name     string
+	Type     reflect.Type
+	Required bool
+}
+
+// ParamNames returns the comma separated names of the route's parameters, as
+// expected by rpc/jsonrpc/server.NewRPCFunc.
+func (s RouteSpec) ParamNames() string {
+	names := make([]string, 0, len(s.Params))
+	for _, p := range s.Params {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// ValidateFunc checks that f can serve the route: it must take a context
+// followed by the route's parameters, and return a pointer to the route's
+// result and an error.
+func (s RouteSpec) ValidateFunc(f interface{}) error {
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return fmt.Errorf("expected a func, got %T", f)
+	}
+
+	if ft.NumIn() != len(s.Params)+1 {
+		return fmt.Errorf("expected %d params after the context, got %d", len(s.Params), ft.NumIn()-1)
+	}
+	for i, p := range s.Params {
+		if t := ft.In(i + 1); t != p.Type {
+			return fmt.Errorf("param %q: expected type %v, got %v", p.Name, p.Type, t)
+		}
+	}
+
+	if ft.NumOut() != 2 {
+		return fmt.Errorf("expected 2 return values, got %d", ft.NumOut())
+	}
+	if t := ft.Out(0); t != reflect.PtrTo(s.Result) {
+		return fmt.Errorf("expected result type %v, got %v", reflect.PtrTo(s.Result), t)
+	}
+	return nil
+}
+
+// MustRouteSpec returns the spec of the given method, and panics if the
+// method is not documented or f can't serve it.
+func MustRouteSpec(method string, f interface{}) RouteSpec {
+	spec, ok := RouteSpecs[method]
+	if !ok {
+		panic(fmt.Sprintf("RPC method %q is not documented in the OpenAPI spec", method))
+	}
+	if err := spec.ValidateFunc(f); err != nil {
+		panic(fmt.Sprintf("RPC method %q doesn't match the OpenAPI spec: %v", method, err))
+	}
+	return spec
+}
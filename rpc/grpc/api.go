@@ -19,7 +19,7 @@ func (bapi *broadcastAPI) Ping(ctx context.Context, req *RequestPing) (*Response
 func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
 	// NOTE: there's no way to get client's remote address
 	// see https://stackoverflow.com/questions/33684570/session-and-remote-ip-address-in-grpc-go
-	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+	res, err := core.BroadcastTxWait(&rpctypes.Context{}, req.Tx, req.Timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -35,5 +35,7 @@ func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcast
 			Data: res.DeliverTx.Data,
 			Log:  res.DeliverTx.Log,
 		},
+		Hash:   res.Hash,
+		Height: res.Height,
 	}, nil
 }
@@ -32,4 +32,5 @@ func TestBroadcastTx(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
+	require.NotZero(t, res.Height)
 }
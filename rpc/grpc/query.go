@@ -0,0 +1,322 @@
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	cryptoenc "github.com/klyed/tendermint/crypto/encoding"
+	tmjson "github.com/klyed/tendermint/libs/json"
+	tmpubsub "github.com/klyed/tendermint/libs/pubsub"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	core "github.com/klyed/tendermint/rpc/core"
+	ctypes "github.com/klyed/tendermint/rpc/core/types"
+	rpctypes "github.com/klyed/tendermint/rpc/jsonrpc/types"
+	"github.com/klyed/tendermint/types"
+)
+
+// queryAPI implements QueryAPIServer on top of the rpc/core environment, like
+// the JSON-RPC API.
+type queryAPI struct {
+}
+
+func (qapi *queryAPI) Status(ctx context.Context, req *RequestStatus) (*ResponseStatus, error) {
+	res, err := core.Status(&rpctypes.Context{})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	si := res.SyncInfo
+	resp := &ResponseStatus{
+		NodeInfo: res.NodeInfo.ToProto(),
+		SyncInfo: &SyncInfo{
+			LatestBlockHash:       si.LatestBlockHash,
+			LatestAppHash:         si.LatestAppHash,
+			LatestBlockHeight:     si.LatestBlockHeight,
+			LatestBlockTime:       si.LatestBlockTime,
+			EarliestBlockHash:     si.EarliestBlockHash,
+			EarliestAppHash:       si.EarliestAppHash,
+			EarliestBlockHeight:   si.EarliestBlockHeight,
+			EarliestBlockTime:     si.EarliestBlockTime,
+			CatchingUp:            si.CatchingUp,
+			SnapshotHeight:        si.SnapshotHeight,
+			SnapshotChunksApplied: si.SnapshotChunksApplied,
+			SnapshotChunksTotal:   si.SnapshotChunksTotal,
+			SnapshotBytesApplied:  si.SnapshotBytesApplied,
+			SnapshotSyncTime:      si.SnapshotSyncTime,
+			SnapshotRemainingTime: si.SnapshotRemainingTime,
+		},
+		ValidatorInfo: &ValidatorInfo{
+			Address:     res.ValidatorInfo.Address,
+			VotingPower: res.ValidatorInfo.VotingPower,
+		},
+	}
+	if res.ValidatorInfo.PubKey != nil {
+		pk, err := cryptoenc.PubKeyToProto(res.ValidatorInfo.PubKey)
+		if err != nil {
+			return nil, toGRPCError(err)
+		}
+		resp.ValidatorInfo.PubKey = &pk
+	}
+	return resp, nil
+}
+
+func (qapi *queryAPI) Block(ctx context.Context, req *RequestBlock) (*ResponseBlock, error) {
+	res, err := core.Block(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toResponseBlock(res)
+}
+
+func (qapi *queryAPI) BlockByHash(ctx context.Context, req *RequestBlockByHash) (*ResponseBlock, error) {
+	res, err := core.BlockByHash(&rpctypes.Context{}, req.Hash)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toResponseBlock(res)
+}
+
+func (qapi *queryAPI) BlockResults(ctx context.Context, req *RequestBlockResults) (*ResponseBlockResults, error) {
+	res, err := core.BlockResults(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &ResponseBlockResults{
+		Height:                res.Height,
+		TxsResults:            res.TxsResults,
+		BeginBlockEvents:      res.BeginBlockEvents,
+		EndBlockEvents:        res.EndBlockEvents,
+		ValidatorUpdates:      res.ValidatorUpdates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+	}, nil
+}
+
+func (qapi *queryAPI) Commit(ctx context.Context, req *RequestCommit) (*ResponseCommit, error) {
+	res, err := core.Commit(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	if res == nil {
+		return nil, status.Errorf(codes.NotFound, "commit at height %v not found", req.Height)
+	}
+	return &ResponseCommit{
+		SignedHeader: res.SignedHeader.ToProto(),
+		Canonical:    res.CanonicalCommit,
+	}, nil
+}
+
+func (qapi *queryAPI) Validators(ctx context.Context, req *RequestValidators) (*ResponseValidators, error) {
+	res, err := core.Validators(&rpctypes.Context{}, heightPtr(req.Height), intPtr(req.Page), intPtr(req.PerPage))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	validators := make([]*tmproto.Validator, 0, len(res.Validators))
+	for _, val := range res.Validators {
+		pbv, err := val.ToProto()
+		if err != nil {
+			return nil, toGRPCError(err)
+		}
+		validators = append(validators, pbv)
+	}
+	return &ResponseValidators{
+		BlockHeight: res.BlockHeight,
+		Validators:  validators,
+		Count:       int32(res.Count),
+		Total:       int32(res.Total),
+	}, nil
+}
+
+func (qapi *queryAPI) Tx(ctx context.Context, req *RequestTx) (*ResponseTx, error) {
+	res, err := core.Tx(&rpctypes.Context{}, req.Hash, req.Prove)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toResponseTx(res, req.Prove), nil
+}
+
+func (qapi *queryAPI) TxSearch(ctx context.Context, req *RequestTxSearch) (*ResponseTxSearch, error) {
+	res, err := core.TxSearch(&rpctypes.Context{}, req.Query, req.Prove, intPtr(req.Page), intPtr(req.PerPage),
+		req.OrderBy)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	txs := make([]*ResponseTx, 0, len(res.Txs))
+	for _, tx := range res.Txs {
+		txs = append(txs, toResponseTx(tx, req.Prove))
+	}
+	return &ResponseTxSearch{Txs: txs, TotalCount: int32(res.TotalCount)}, nil
+}
+
+func (qapi *queryAPI) ABCIQuery(ctx context.Context, req *RequestABCIQuery) (*ResponseABCIQuery, error) {
+	res, err := core.ABCIQuery(&rpctypes.Context{}, req.Path, req.Data, req.Height, req.Prove)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &ResponseABCIQuery{Response: &res.Response}, nil
+}
+
+func (qapi *queryAPI) ABCIInfo(ctx context.Context, req *RequestABCIInfo) (*ResponseABCIInfo, error) {
+	res, err := core.ABCIInfo(&rpctypes.Context{})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &ResponseABCIInfo{Response: &res.Response}, nil
+}
+
+// Subscribe streams events matching the query until the client cancels the
+// stream or the subscription is canceled, e.g. because the client is too slow.
+func (qapi *queryAPI) Subscribe(req *RequestSubscribe, stream QueryAPI_SubscribeServer) error {
+	ctx := stream.Context()
+	subscriber := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		subscriber = p.Addr.String()
+	}
+
+	sub, err := core.SubscribeEvents(ctx, subscriber, req.Query)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer func() {
+		// the subscription may already have been canceled, so ignore errors
+		_ = core.UnsubscribeEvents(context.Background(), subscriber, req.Query)
+	}()
+	// let the client know that it's subscribed, so that it doesn't miss events.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			resp, err := toResponseSubscribe(req.Query, msg.Data(), msg.Events())
+			if err != nil {
+				return toGRPCError(err)
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+
+		case <-sub.Canceled():
+			switch {
+			case errors.Is(sub.Err(), tmpubsub.ErrUnsubscribed):
+				return nil
+			case sub.Err() == nil:
+				return status.Error(codes.Unavailable, "subscription was canceled (reason: Tendermint exited)")
+			default:
+				return status.Errorf(codes.Aborted, "subscription was canceled (reason: %v)", sub.Err())
+			}
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func toResponseBlock(res *ctypes.ResultBlock) (*ResponseBlock, error) {
+	resp := &ResponseBlock{}
+	if res.Block == nil {
+		return resp, nil
+	}
+	pbb, err := res.Block.ToProto()
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	blockID := res.BlockID.ToProto()
+	resp.BlockId = &blockID
+	resp.Block = pbb
+	return resp, nil
+}
+
+func toResponseTx(res *ctypes.ResultTx, prove bool) *ResponseTx {
+	resp := &ResponseTx{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		TxResult: &res.TxResult,
+		Tx:       res.Tx,
+	}
+	if prove {
+		proof := res.Proof.ToProto()
+		resp.Proof = &proof
+	}
+	return resp
+}
+
+func toResponseSubscribe(query string, data types.TMEventData, events map[string][]string) (
+	*ResponseSubscribe, error) {
+	resp := &ResponseSubscribe{Query: query}
+	for key, values := range events {
+		resp.Events = append(resp.Events, &EventAttribute{Key: key, Values: values})
+	}
+
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		pbb, err := data.Block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		resp.Data = &ResponseSubscribe_NewBlock{NewBlock: &EventDataNewBlock{
+			Block:            pbb,
+			ResultBeginBlock: &data.ResultBeginBlock,
+			ResultEndBlock:   &data.ResultEndBlock,
+		}}
+	case types.EventDataNewBlockHeader:
+		resp.Data = &ResponseSubscribe_NewBlockHeader{NewBlockHeader: &EventDataNewBlockHeader{
+			Header:           data.Header.ToProto(),
+			NumTxs:           data.NumTxs,
+			ResultBeginBlock: &data.ResultBeginBlock,
+			ResultEndBlock:   &data.ResultEndBlock,
+		}}
+	case types.EventDataTx:
+		resp.Data = &ResponseSubscribe_Tx{Tx: &data.TxResult}
+	case types.EventDataVote:
+		resp.Data = &ResponseSubscribe_Vote{Vote: data.Vote.ToProto()}
+	default:
+		bz, err := tmjson.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode event data: %w", err)
+		}
+		resp.Data = &ResponseSubscribe_Json{Json: bz}
+	}
+	return resp, nil
+}
+
+// toGRPCError converts an rpc/core error to a gRPC status error.
+func toGRPCError(err error) error {
+	switch {
+	case errors.Is(err, ctypes.ErrZeroOrNegativeHeight), errors.Is(err, ctypes.ErrZeroOrNegativePerPage),
+		errors.Is(err, ctypes.ErrPageOutOfRange), errors.Is(err, ctypes.ErrInvalidRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ctypes.ErrHeightExceedsChainHead):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, ctypes.ErrHeightNotAvailable):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// heightPtr returns a pointer to the height, or nil for the latest height.
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
+}
+
+// intPtr returns a pointer to the value, or nil for the default value.
+func intPtr(v int32) *int {
+	if v == 0 {
+		return nil
+	}
+	i := int(v)
+	return &i
+}
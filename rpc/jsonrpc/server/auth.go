@@ -0,0 +1,327 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// RoleAny allows any caller, authenticated or not, to call a method.
+	RoleAny = "*"
+	// RoleAuthenticated allows any authenticated caller to call a method.
+	RoleAuthenticated = "authenticated"
+)
+
+var (
+	// ErrUnauthenticated is returned when a request carries invalid or
+	// unrecognized credentials.
+	ErrUnauthenticated = errors.New("invalid credentials")
+	// ErrMethodNotAllowed is returned when the caller is not allowed to call a
+	// method.
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+// Identity is an authenticated RPC caller.
+type Identity struct {
+	Name  string
+	Roles []string
+}
+
+// Authenticator authenticates HTTP requests. It returns a nil identity if the
+// request carries no credentials it recognizes, and an error if the
+// credentials are invalid.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// bearerToken returns the bearer token in the Authorization header, if any.
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
+
+//-----------------------------------------------------------------------------
+
+// TokenAuthenticator authenticates requests with static bearer tokens.
+type TokenAuthenticator struct {
+	tokens map[string]Identity
+}
+
+var _ Authenticator = (*TokenAuthenticator)(nil)
+
+// NewTokenAuthenticator returns an authenticator for the given bearer tokens
+// and the identities they belong to.
+func NewTokenAuthenticator(tokens map[string]Identity) *TokenAuthenticator {
+	return &TokenAuthenticator{tokens: tokens}
+}
+
+// LoadTokenAuthenticator loads bearer tokens from a file, with one token per
+// line in the format "<token> <name> <role>[,<role>...]". Empty lines and
+// lines starting with # are ignored.
+func LoadTokenAuthenticator(path string) (*TokenAuthenticator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tokens := make(map[string]Identity)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected \"<token> <name> <roles>\"", path, line)
+		}
+		if _, ok := tokens[fields[0]]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicate token", path, line)
+		}
+		tokens[fields[0]] = Identity{Name: fields[1], Roles: strings.Split(fields[2], ",")}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewTokenAuthenticator(tokens), nil
+}
+
+// Authenticate implements Authenticator.
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return nil, nil
+	}
+	for t, id := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			id := id
+			return &id, nil
+		}
+	}
+	return nil, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// JWTAuthenticator authenticates requests with bearer JSON Web Tokens signed
+// with HMAC-SHA256 (HS256). The identity is given by the "sub" claim and the
+// "roles" claim, a list of strings. The "exp" and "nbf" claims are checked if
+// present.
+type JWTAuthenticator struct {
+	key []byte
+	now func() time.Time
+}
+
+var _ Authenticator = (*JWTAuthenticator)(nil)
+
+// NewJWTAuthenticator returns an authenticator for tokens signed with the
+// given HMAC key.
+func NewJWTAuthenticator(key []byte) *JWTAuthenticator {
+	return &JWTAuthenticator{key: key, now: time.Now}
+}
+
+// LoadJWTAuthenticator loads the HMAC key from a file. Leading and trailing
+// whitespace is ignored.
+func LoadJWTAuthenticator(path string) (*JWTAuthenticator, error) {
+	key, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key = bytes.TrimSpace(key)
+	if len(key) < 32 {
+		return nil, fmt.Errorf("JWT key in %s must be at least 32 bytes", path)
+	}
+	return NewJWTAuthenticator(key), nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	Roles     []string `json:"roles"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+}
+
+// Authenticate implements Authenticator. Bearer tokens which are not JWTs are
+// ignored.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT signature encoding: %w", err)
+	}
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, errors.New("invalid JWT signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	now := a.now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return nil, errors.New("JWT expired")
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, errors.New("JWT not valid yet")
+	}
+	return &Identity{Name: claims.Subject, Roles: claims.Roles}, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	bz, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("invalid JWT encoding: %w", err)
+	}
+	if err := json.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("invalid JWT: %w", err)
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// ClientCertAuthenticator authenticates requests with TLS client
+// certificates, which must have been verified by the server (see
+// Config.TLSClientCAs). The identity is given by the certificate's subject
+// common name, and its roles by the subject's organizational units.
+type ClientCertAuthenticator struct{}
+
+var _ Authenticator = ClientCertAuthenticator{}
+
+// Authenticate implements Authenticator.
+func (ClientCertAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	subject := r.TLS.VerifiedChains[0][0].Subject
+	return &Identity{Name: subject.CommonName, Roles: subject.OrganizationalUnit}, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// ACL maps RPC methods to the roles allowed to call them. Methods without a
+// rule use the "*" rule, if any, and are otherwise allowed for any caller.
+type ACL struct {
+	rules map[string][]string
+}
+
+// ParseACL parses ACL rules in the format "<method>=<role>[,<role>...]",
+// where the method may be "*" for all methods without a rule of their own.
+// The role RoleAny allows any caller, and RoleAuthenticated any authenticated
+// caller.
+func ParseACL(rules []string) (*ACL, error) {
+	acl := &ACL{rules: make(map[string][]string, len(rules))}
+	for _, rule := range rules {
+		parts := strings.SplitN(rule, "=", 2)
+		method := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || method == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid ACL rule %q, expected \"<method>=<roles>\"", rule)
+		}
+		if _, ok := acl.rules[method]; ok {
+			return nil, fmt.Errorf("duplicate ACL rule for method %q", method)
+		}
+		roles := strings.Split(parts[1], ",")
+		for i := range roles {
+			roles[i] = strings.TrimSpace(roles[i])
+		}
+		acl.rules[method] = roles
+	}
+	return acl, nil
+}
+
+// Allowed returns true if the caller with the given identity, which is nil
+// for unauthenticated callers, may call the method.
+func (acl *ACL) Allowed(method string, id *Identity) bool {
+	roles, ok := acl.rules[method]
+	if !ok {
+		if roles, ok = acl.rules["*"]; !ok {
+			return true
+		}
+	}
+	for _, role := range roles {
+		switch {
+		case role == RoleAny:
+			return true
+		case id == nil:
+		case role == RoleAuthenticated:
+			return true
+		default:
+			for _, r := range id.Roles {
+				if r == role {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+//-----------------------------------------------------------------------------
+
+// AuthHandler wraps an HTTP handler serving RPC functions (see
+// RegisterRPCFuncs and WebsocketManager), authenticating requests with the
+// given authenticators, which are tried in order, and checking the methods
+// called over HTTP, URI and websocket requests against the ACL. Requests with
+// invalid or unrecognized credentials are rejected, while requests without
+// credentials are unauthenticated.
+func AuthHandler(handler http.Handler, acl *ACL, authenticators ...Authenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id *Identity
+		for _, authenticator := range authenticators {
+			var err error
+			if id, err = authenticator.Authenticate(r); err != nil {
+				http.Error(w, fmt.Sprintf("%v: %v", ErrUnauthenticated, err), http.StatusUnauthorized)
+				return
+			} else if id != nil {
+				break
+			}
+		}
+		if id == nil && r.Header.Get("Authorization") != "" {
+			http.Error(w, ErrUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := withCallCheck(r.Context(), func(method string) error {
+			if !acl.Allowed(method, id) {
+				return fmt.Errorf("%w: %s", ErrMethodNotAllowed, method)
+			}
+			return nil
+		})
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
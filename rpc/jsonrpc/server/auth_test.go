@@ -0,0 +1,216 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/libs/log"
+	types "github.com/klyed/tendermint/rpc/jsonrpc/types"
+)
+
+var testJWTKey = []byte("0123456789abcdef0123456789abcdef")
+
+func makeJWT(t *testing.T, key []byte, header, claims interface{}) string {
+	encode := func(v interface{}) string {
+		bz, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(bz)
+	}
+	unsigned := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestACL(t *testing.T) {
+	acl, err := ParseACL([]string{
+		"*=authenticated",
+		"status=*",
+		"dial_peers=operator, admin",
+	})
+	require.NoError(t, err)
+
+	user := &Identity{Name: "user", Roles: []string{"user"}}
+	operator := &Identity{Name: "op", Roles: []string{"operator"}}
+
+	assert.True(t, acl.Allowed("status", nil))
+	assert.False(t, acl.Allowed("block", nil))
+	assert.True(t, acl.Allowed("block", user))
+	assert.False(t, acl.Allowed("dial_peers", nil))
+	assert.False(t, acl.Allowed("dial_peers", user))
+	assert.True(t, acl.Allowed("dial_peers", operator))
+
+	// without a "*" rule, methods without a rule are allowed for anyone
+	acl, err = ParseACL([]string{"dial_peers=operator"})
+	require.NoError(t, err)
+	assert.True(t, acl.Allowed("block", nil))
+	assert.False(t, acl.Allowed("dial_peers", user))
+
+	for _, rules := range [][]string{{"dial_peers"}, {"=operator"}, {"dial_peers="}, {"a=b", "a=c"}} {
+		_, err := ParseACL(rules)
+		assert.Error(t, err, "%v", rules)
+	}
+}
+
+func TestLoadTokenAuthenticator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+# operators
+secret1 alice operator,admin
+secret2 bob user
+`), 0600))
+
+	a, err := LoadTokenAuthenticator(path)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	id, err := a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Nil(t, id)
+
+	req.Header.Set("Authorization", "Bearer secret1")
+	id, err = a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &Identity{Name: "alice", Roles: []string{"operator", "admin"}}, id)
+
+	req.Header.Set("Authorization", "Bearer unknown")
+	id, err = a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Nil(t, id)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("secret1 alice\n"), 0600))
+	_, err = LoadTokenAuthenticator(path)
+	require.Error(t, err)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	now := time.Now()
+	a := NewJWTAuthenticator(testJWTKey)
+	a.now = func() time.Time { return now }
+
+	hs256 := map[string]string{"alg": "HS256", "typ": "JWT"}
+	testcases := map[string]struct {
+		token  string
+		expect *Identity
+		err    bool
+	}{
+		"valid": {makeJWT(t, testJWTKey, hs256, map[string]interface{}{
+			"sub": "alice", "roles": []string{"operator"}, "exp": now.Add(time.Minute).Unix(),
+		}), &Identity{Name: "alice", Roles: []string{"operator"}}, false},
+		"not a JWT": {"secret", nil, false},
+		"wrong key": {makeJWT(t, []byte("wrong"), hs256, map[string]interface{}{"sub": "alice"}), nil, true},
+		"expired": {makeJWT(t, testJWTKey, hs256, map[string]interface{}{
+			"sub": "alice", "exp": now.Add(-time.Minute).Unix(),
+		}), nil, true},
+		"not valid yet": {makeJWT(t, testJWTKey, hs256, map[string]interface{}{
+			"sub": "alice", "nbf": now.Add(time.Minute).Unix(),
+		}), nil, true},
+		"alg none": {makeJWT(t, testJWTKey, map[string]string{"alg": "none"},
+			map[string]interface{}{"sub": "alice"}), nil, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			id, err := a.Authenticate(req)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, id)
+		})
+	}
+}
+
+func TestAuthHandler(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"public": NewRPCFunc(func(ctx *types.Context) (string, error) { return "public", nil }, ""),
+		"admin":  NewRPCFunc(func(ctx *types.Context) (string, error) { return "admin", nil }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+	wm := NewWebsocketManager(funcMap)
+	wm.SetLogger(log.TestingLogger())
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+
+	acl, err := ParseACL([]string{"public=*", "admin=admin"})
+	require.NoError(t, err)
+	tokens := NewTokenAuthenticator(map[string]Identity{
+		"admin-token": {Name: "alice", Roles: []string{"admin"}},
+		"user-token":  {Name: "bob", Roles: []string{"user"}},
+	})
+	s := httptest.NewServer(AuthHandler(mux, acl, tokens, NewJWTAuthenticator(testJWTKey)))
+	defer s.Close()
+
+	do := func(method, path, body, token string) (int, string) {
+		req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		bz, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(bz)
+	}
+	status := func(method, path, body, token string) int {
+		code, _ := do(method, path, body, token)
+		return code
+	}
+	jwt := makeJWT(t, testJWTKey, map[string]string{"alg": "HS256"},
+		map[string]interface{}{"sub": "carol", "roles": []string{"admin"}})
+
+	// URI requests
+	assert.Equal(t, http.StatusOK, status(http.MethodGet, "/public", "", ""))
+	assert.Equal(t, http.StatusForbidden, status(http.MethodGet, "/admin", "", ""))
+	assert.Equal(t, http.StatusForbidden, status(http.MethodGet, "/admin", "", "user-token"))
+	assert.Equal(t, http.StatusOK, status(http.MethodGet, "/admin", "", "admin-token"))
+	assert.Equal(t, http.StatusOK, status(http.MethodGet, "/admin", "", jwt))
+	assert.Equal(t, http.StatusUnauthorized, status(http.MethodGet, "/public", "", "unknown"))
+	assert.Equal(t, http.StatusUnauthorized, status(http.MethodGet, "/public", "", jwt+"x"))
+
+	// JSON-RPC requests
+	adminReq := `{"jsonrpc": "2.0", "method": "admin", "id": "0"}`
+	_, body := do(http.MethodPost, "/", adminReq, "user-token")
+	assert.Contains(t, body, "Method not allowed")
+	_, body = do(http.MethodPost, "/", adminReq, "admin-token")
+	assert.NotContains(t, body, "error")
+
+	// websocket requests are authorized with the handshake credentials
+	call := func(token, method string) *types.RPCError {
+		header := http.Header{}
+		if token != "" {
+			header.Set("Authorization", "Bearer "+token)
+		}
+		c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", header)
+		require.NoError(t, err)
+		defer dialResp.Body.Close()
+		defer c.Close()
+		require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCStringID("0"), Method: method}))
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		return resp.Error
+	}
+	assert.Nil(t, call("", "public"))
+	rpcErr := call("user-token", "admin")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, -32001, rpcErr.Code)
+	assert.Nil(t, call("admin-token", "admin"))
+}
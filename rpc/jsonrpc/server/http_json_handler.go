@@ -81,6 +81,11 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				responses = append(responses, types.RPCMethodNotFoundError(request.ID))
 				continue
 			}
+			if err := checkCall(r.Context(), request.Method); err != nil {
+				e := callCheckError(err)
+				responses = append(responses, types.NewRPCErrorResponse(request.ID, e.Code, e.Message, e.Data))
+				continue
+			}
 			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
@@ -3,6 +3,11 @@ package server
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,6 +37,9 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// TLSClientCAs, if set, are used by ServeTLS to verify the certificates
+	// which clients may present to authenticate (see ClientCertAuthenticator).
+	TLSClientCAs *x509.CertPool
 }
 
 // DefaultConfig returns a default configuration.
@@ -83,6 +91,12 @@ func ServeTLS(
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
+	if config.TLSClientCAs != nil {
+		s.TLSConfig = &tls.Config{
+			ClientCAs:  config.TLSClientCAs,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+		}
+	}
 	err := s.ServeTLS(listener, certFile, keyFile)
 
 	logger.Error("RPC HTTPS server stopped", "err", err)
@@ -98,6 +112,8 @@ func ServeTLS(
 // 500	-32700	Parse error.
 // 400	-32600	Invalid Request.
 // 404	-32601	Method not found.
+// 403	-32001	Method not allowed.
+// 429	-32002	Rate limit exceeded.
 // 500	-32602	Invalid params.
 // 500	-32603	Internal error.
 // 500	-32099..-32000	Server error.
@@ -122,6 +138,10 @@ func WriteRPCResponseHTTPError(
 		httpCode = http.StatusBadRequest
 	case -32601:
 		httpCode = http.StatusNotFound
+	case -32001:
+		httpCode = http.StatusForbidden
+	case -32002:
+		httpCode = http.StatusTooManyRequests
 	default:
 		httpCode = http.StatusInternalServerError
 	}
@@ -132,6 +152,49 @@ func WriteRPCResponseHTTPError(
 	return err
 }
 
+// callCheck checks whether a call to an RPC method may proceed, returning an
+// error otherwise.
+type callCheck func(method string) error
+
+type callChecksKey struct{}
+
+// withCallCheck returns a context carrying the check in addition to those
+// already in ctx. Middleware such as AuthHandler and RateLimitHandler use it
+// to apply checks to each RPC call of a request, which can't be done at the
+// HTTP level since JSON-RPC requests may be batched and websocket connections
+// carry many calls.
+func withCallCheck(ctx context.Context, check callCheck) context.Context {
+	checks := callChecksFromContext(ctx)
+	return context.WithValue(ctx, callChecksKey{}, append(checks[:len(checks):len(checks)], check))
+}
+
+func callChecksFromContext(ctx context.Context) []callCheck {
+	checks, _ := ctx.Value(callChecksKey{}).([]callCheck)
+	return checks
+}
+
+// checkCall runs the call checks in ctx for the method.
+func checkCall(ctx context.Context, method string) error {
+	return runCallChecks(callChecksFromContext(ctx), method)
+}
+
+func runCallChecks(checks []callCheck, method string) error {
+	for _, check := range checks {
+		if err := check(method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callCheckError returns the RPC error for a call rejected by a check.
+func callCheckError(err error) *types.RPCError {
+	if errors.Is(err, ErrRateLimited) {
+		return &types.RPCError{Code: -32002, Message: "Rate limit exceeded", Data: err.Error()}
+	}
+	return &types.RPCError{Code: -32001, Message: "Method not allowed", Data: err.Error()}
+}
+
 // WriteRPCResponseHTTP marshals res as JSON (with indent) and writes it to w.
 func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error {
 	var v interface{}
@@ -151,6 +214,42 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res ...types.RPCResponse) error
 	return err
 }
 
+// writeImmutableRPCResponseHTTP writes an immutable response like
+// WriteRPCResponseHTTP, with headers allowing clients and caches to cache it
+// indefinitely. The ETag is a hash of the response, and requests for a known
+// ETag get an empty 304 Not Modified response.
+func writeImmutableRPCResponseHTTP(w http.ResponseWriter, r *http.Request, res types.RPCResponse) error {
+	jsonBytes, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	hash := sha256.Sum256(jsonBytes)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// etagMatches returns true if the If-None-Match header value matches the
+// ETag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 //-----------------------------------------------------------------------------
 
 // RecoverAndLogHandler wraps an HTTP handler, adding error logging.
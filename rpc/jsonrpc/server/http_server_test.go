@@ -179,3 +179,52 @@ func TestWriteRPCResponseHTTPError(t *testing.T) {
   }
 }`, string(body))
 }
+
+func TestImmutableResponseCaching(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"past": NewRPCFunc(func(ctx *types.Context) (string, error) {
+			ctx.MarkImmutable()
+			return "past", nil
+		}, ""),
+		"latest": NewRPCFunc(func(ctx *types.Context) (string, error) { return "latest", nil }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	get := func(path, etag string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, s.URL+path, nil)
+		require.NoError(t, err)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_, err = ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res
+	}
+
+	res := get("/latest", "")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Empty(t, res.Header.Get("Cache-Control"))
+	assert.Empty(t, res.Header.Get("ETag"))
+
+	res = get("/past", "")
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("Cache-Control"), "immutable")
+	etag := res.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	res = get("/past", etag)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+	assert.Equal(t, etag, res.Header.Get("ETag"))
+
+	res = get("/past", `"other", W/`+etag)
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	res = get("/past", `"other"`)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "rpc"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of subscription events not sent to websocket clients because
+	// they exceeded their event rate quota, by query.
+	WSDroppedEvents metrics.Counter
+	// Number of calls rejected by rate limits, by method.
+	RateLimitRejections metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		WSDroppedEvents: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "ws_dropped_events",
+			Help:      "Number of subscription events not sent to websocket clients exceeding their event rate quota.",
+		}, append(labels, "query")).With(labelsAndValues...),
+		RateLimitRejections: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limit_rejections",
+			Help:      "Number of calls rejected by rate limits.",
+		}, append(labels, "method")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		WSDroppedEvents:     discard.NewCounter(),
+		RateLimitRejections: discard.NewCounter(),
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+// rateLimitSweepInterval is how often idle buckets are discarded.
+const rateLimitSweepInterval = time.Minute
+
+// ErrRateLimited is returned when a call exceeds a rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimit is a token bucket rate limit of Rate calls per second, with bursts
+// of up to Burst calls.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseRateLimits parses rate limit rules in the format
+// "<method>=<rate>[:<burst>]", where rate is the number of calls per second
+// and burst defaults to the rate rounded up. The method "*" limits all calls
+// combined.
+func ParseRateLimits(rules []string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit, len(rules))
+	for _, rule := range rules {
+		parts := strings.SplitN(rule, "=", 2)
+		method := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || method == "" {
+			return nil, fmt.Errorf("invalid rate limit rule %q, expected \"<method>=<rate>[:<burst>]\"", rule)
+		}
+		if _, ok := limits[method]; ok {
+			return nil, fmt.Errorf("duplicate rate limit rule for method %q", method)
+		}
+
+		rateStr, burstStr := strings.TrimSpace(parts[1]), ""
+		if i := strings.Index(rateStr, ":"); i >= 0 {
+			rateStr, burstStr = rateStr[:i], rateStr[i+1:]
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("invalid rate in rate limit rule %q", rule)
+		}
+		burst := int(math.Ceil(rate))
+		if burstStr != "" {
+			if burst, err = strconv.Atoi(burstStr); err != nil || burst < 1 {
+				return nil, fmt.Errorf("invalid burst in rate limit rule %q", rule)
+			}
+		}
+		limits[method] = RateLimit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+// ParseTrustedProxies parses a list of IP addresses and CIDR ranges.
+func ParseTrustedProxies(addrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(addrs))
+	for _, addr := range addrs {
+		if !strings.Contains(addr, "/") {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address %q", addr)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %q: %w", addr, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+type rateLimitKey struct {
+	ip     string
+	method string // "*" for all calls combined
+}
+
+// RateLimiter limits the rate of calls from each client IP, for all methods
+// combined and for individual methods. It is goroutine-safe.
+type RateLimiter struct {
+	limits         map[string]RateLimit
+	trustedProxies []*net.IPNet
+	metrics        *Metrics
+
+	mtx       tmsync.Mutex
+	buckets   map[rateLimitKey]*tokenBucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter returns a rate limiter with the given limits by method (see
+// ParseRateLimits). The X-Forwarded-For header is used to find the client IP
+// of requests from the trusted proxies.
+func NewRateLimiter(limits map[string]RateLimit, trustedProxies []*net.IPNet, metrics *Metrics) *RateLimiter {
+	return &RateLimiter{
+		limits:         limits,
+		trustedProxies: trustedProxies,
+		metrics:        metrics,
+		buckets:        make(map[rateLimitKey]*tokenBucket),
+		lastSweep:      time.Now(),
+	}
+}
+
+// Allow takes a token for a call to the method by the client IP, returning
+// ErrRateLimited if the call exceeds a limit.
+func (l *RateLimiter) Allow(ip, method string) error {
+	now := time.Now()
+	var taken []*tokenBucket
+	for _, m := range [...]string{method, "*"} {
+		limit, ok := l.limits[m]
+		if !ok {
+			continue
+		}
+		b := l.bucket(rateLimitKey{ip: ip, method: m}, limit, now)
+		if !b.allow(now) {
+			// rejected calls don't count towards the other limits
+			for _, b := range taken {
+				b.refund()
+			}
+			l.metrics.RateLimitRejections.With("method", method).Add(1)
+			return fmt.Errorf("%w: %s", ErrRateLimited, method)
+		}
+		taken = append(taken, b)
+	}
+	return nil
+}
+
+// bucket returns the bucket for the key, discarding idle buckets every
+// rateLimitSweepInterval to bound memory usage.
+func (l *RateLimiter) bucket(key rateLimitKey, limit RateLimit, now time.Time) *tokenBucket {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		for k, b := range l.buckets {
+			if b.full(now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newTokenBucket(limit.Rate, limit.Burst)
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// ClientIP returns the IP of the client making the request. For requests from
+// trusted proxies, it's the rightmost address in the X-Forwarded-For header
+// which is not a trusted proxy.
+func (l *RateLimiter) ClientIP(r *http.Request) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if !l.trusted(ip) {
+		return ip
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(addr) == nil {
+			break
+		}
+		ip = addr
+		if !l.trusted(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+func (l *RateLimiter) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range l.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitHandler wraps an HTTP handler serving RPC functions (see
+// RegisterRPCFuncs and WebsocketManager), applying the rate limiter to the
+// methods called over HTTP, URI and websocket requests.
+func RateLimitHandler(handler http.Handler, limiter *RateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := limiter.ClientIP(r)
+		ctx := withCallCheck(r.Context(), func(method string) error {
+			return limiter.Allow(ip, method)
+		})
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/libs/log"
+	types "github.com/klyed/tendermint/rpc/jsonrpc/types"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits([]string{"*=50:100", "tx_search=2.5", "blockchain = 0.5:3"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]RateLimit{
+		"*":          {Rate: 50, Burst: 100},
+		"tx_search":  {Rate: 2.5, Burst: 3},
+		"blockchain": {Rate: 0.5, Burst: 3},
+	}, limits)
+
+	for _, rule := range []string{"tx_search", "=1", "a=0", "a=-1", "a=x", "a=1:0", "a=1:x"} {
+		_, err := ParseRateLimits([]string{rule})
+		assert.Error(t, err, rule)
+	}
+	_, err = ParseRateLimits([]string{"a=1", "a=2"})
+	assert.Error(t, err)
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	limits, err := ParseRateLimits([]string{"*=0.001:3", "tx_search=0.001:1"})
+	require.NoError(t, err)
+	l := NewRateLimiter(limits, nil, NopMetrics())
+
+	require.NoError(t, l.Allow("1.1.1.1", "tx_search"))
+	err = l.Allow("1.1.1.1", "tx_search")
+	require.True(t, errors.Is(err, ErrRateLimited), err)
+	// other methods and clients have their own buckets, but all calls
+	// combined are limited too
+	require.NoError(t, l.Allow("1.1.1.1", "status"))
+	require.NoError(t, l.Allow("1.1.1.1", "status"))
+	require.Error(t, l.Allow("1.1.1.1", "status"))
+	require.NoError(t, l.Allow("2.2.2.2", "tx_search"))
+
+	// calls rejected by the combined limit don't count towards the method's
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Allow("3.3.3.3", "status"))
+	}
+	require.Error(t, l.Allow("3.3.3.3", "tx_search"))
+	now := time.Now()
+	assert.True(t, l.bucket(rateLimitKey{ip: "3.3.3.3", method: "tx_search"}, limits["tx_search"], now).allow(now))
+}
+
+func TestRateLimiterClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16"})
+	require.NoError(t, err)
+	l := NewRateLimiter(nil, proxies, NopMetrics())
+
+	testcases := []struct {
+		remoteAddr string
+		forwarded  []string
+		expect     string
+	}{
+		{"1.1.1.1:1234", nil, "1.1.1.1"},
+		// untrusted clients can't spoof their address
+		{"1.1.1.1:1234", []string{"2.2.2.2"}, "1.1.1.1"},
+		{"10.0.0.1:1234", nil, "10.0.0.1"},
+		{"10.0.0.1:1234", []string{"2.2.2.2"}, "2.2.2.2"},
+		// the rightmost untrusted address is used
+		{"10.0.0.1:1234", []string{"3.3.3.3, 2.2.2.2, 192.168.1.1"}, "2.2.2.2"},
+		{"10.0.0.1:1234", []string{"3.3.3.3", "2.2.2.2"}, "2.2.2.2"},
+		{"10.0.0.1:1234", []string{"192.168.1.2, 192.168.1.1"}, "192.168.1.2"},
+		{"10.0.0.1:1234", []string{"2.2.2.2, invalid"}, "10.0.0.1"},
+	}
+	for _, tc := range testcases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = tc.remoteAddr
+		for _, f := range tc.forwarded {
+			r.Header.Add("X-Forwarded-For", f)
+		}
+		assert.Equal(t, tc.expect, l.ClientIP(r), "%v %v", tc.remoteAddr, tc.forwarded)
+	}
+
+	_, err = ParseTrustedProxies([]string{"10.0.0"})
+	require.Error(t, err)
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"tx_search": NewRPCFunc(func(ctx *types.Context) (string, error) { return "txs", nil }, ""),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.TestingLogger())
+	limits, err := ParseRateLimits([]string{"tx_search=0.001:1"})
+	require.NoError(t, err)
+	s := httptest.NewServer(RateLimitHandler(mux, NewRateLimiter(limits, nil, NopMetrics())))
+	defer s.Close()
+
+	get := func() int {
+		res, err := http.Get(s.URL + "/tx_search")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, get())
+	assert.Equal(t, http.StatusTooManyRequests, get())
+
+	res, err := http.Post(s.URL, "application/json",
+		strings.NewReader(`{"jsonrpc": "2.0", "method": "tx_search", "id": "0"}`))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	bz, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), "Rate limit exceeded")
+}
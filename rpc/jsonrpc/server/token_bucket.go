@@ -0,0 +1,72 @@
+package server
+
+import (
+	"time"
+
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+// tokenBucket is a token bucket rate limiter, which allows bursts of up to
+// burst events and is refilled at rate tokens per second. It is
+// goroutine-safe.
+type tokenBucket struct {
+	mtx    tmsync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket. A burst below 1 is raised to 1.
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// allow takes a token from the bucket at the given time, returning false if
+// the bucket is empty.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refund puts back a token taken by allow, e.g. if the call was rejected by
+// another bucket.
+func (b *tokenBucket) refund() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// full returns true if the bucket would be full at the given time, i.e. it
+// has been idle long enough to be discarded.
+func (b *tokenBucket) full(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.burst
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(2, 3)
+	now := b.last
+
+	// the bucket starts full
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.allow(now), "token %d", i)
+	}
+	assert.False(t, b.allow(now))
+
+	// it refills at the rate, up to the burst
+	assert.False(t, b.allow(now.Add(250*time.Millisecond)))
+	assert.True(t, b.allow(now.Add(500*time.Millisecond)))
+	assert.False(t, b.allow(now.Add(500*time.Millisecond)))
+
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.allow(now), "token %d", i)
+	}
+	assert.False(t, b.allow(now))
+}
+
+func TestTokenBucketRefund(t *testing.T) {
+	b := newTokenBucket(1, 1)
+	now := b.last
+
+	assert.True(t, b.allow(now))
+	assert.False(t, b.allow(now))
+	b.refund()
+	assert.True(t, b.allow(now))
+
+	// it's never filled above the burst
+	b.refund()
+	b.refund()
+	assert.True(t, b.allow(now))
+	assert.False(t, b.allow(now))
+}
+
+func TestTokenBucketFull(t *testing.T) {
+	b := newTokenBucket(1, 2)
+	now := b.last
+	assert.True(t, b.full(now))
+
+	assert.True(t, b.allow(now))
+	assert.False(t, b.full(now))
+	assert.True(t, b.full(now.Add(time.Second)))
+}
@@ -25,6 +25,10 @@ const (
 	defaultWSWriteWait         = 10 * time.Second
 	defaultWSReadWait          = 30 * time.Second
 	defaultWSPingPeriod        = (defaultWSReadWait * 9) / 10
+
+	// query label of the metrics of the queries which aren't set with
+	// EventMetricsQueries
+	otherQueries = "other"
 )
 
 // WebsocketManager provides a WS handler for incoming connections and passes a
@@ -87,6 +91,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := newWSConnection(wsConn, wm.funcMap, wm.wsConnOptions...)
+	con.checks = callChecksFromContext(r.Context())
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // BLOCKING
@@ -137,6 +142,21 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// Maximum number of events per second and burst written with
+	// WriteRPCEvent. 0 - unlimited.
+	eventRate    int
+	eventBurst   int
+	eventLimiter *tokenBucket
+
+	metrics *Metrics
+	// queries whose dropped events are counted separately, the others are
+	// counted together as otherQueries
+	metricsQueries map[string]bool
+
+	// checks applied to each call, from the handshake request (see
+	// AuthHandler and RateLimitHandler)
+	checks []callCheck
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -161,10 +181,14 @@ func newWSConnection(
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
 		readRoutineQuit:   make(chan struct{}),
+		metrics:           NopMetrics(),
 	}
 	for _, option := range options {
 		option(wsc)
 	}
+	if wsc.eventRate > 0 {
+		wsc.eventLimiter = newTokenBucket(float64(wsc.eventRate), wsc.eventBurst)
+	}
 	wsc.baseConn.SetReadLimit(wsc.readLimit)
 	wsc.BaseService = *service.NewBaseService(nil, "wsConnection", wsc)
 	return wsc
@@ -218,6 +242,39 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// EventRateLimit limits the number of subscription events written to the
+// connection to rate per second, with bursts of up to burst events. Events
+// exceeding the quota are dropped. A rate of 0 disables the limit.
+// It should only be used in the constructor - not Goroutine-safe.
+func EventRateLimit(rate, burst int) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.eventRate = rate
+		wsc.eventBurst = burst
+	}
+}
+
+// WithMetrics sets the metrics.
+// It should only be used in the constructor - not Goroutine-safe.
+func WithMetrics(metrics *Metrics) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.metrics = metrics
+	}
+}
+
+// EventMetricsQueries sets the queries whose dropped events are counted
+// separately in the metrics, to bound the number of series created by
+// arbitrary client queries. The dropped events of the other queries are
+// counted together as "other".
+// It should only be used in the constructor - not Goroutine-safe.
+func EventMetricsQueries(queries ...string) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.metricsQueries = make(map[string]bool, len(queries))
+		for _, q := range queries {
+			wsc.metricsQueries[q] = true
+		}
+	}
+}
+
 // OnStart implements service.Service by starting the read and write routines. It
 // blocks until there's some error.
 func (wsc *wsConnection) OnStart() error {
@@ -263,6 +320,22 @@ func (wsc *wsConnection) WriteRPCResponse(ctx context.Context, resp types.RPCRes
 	}
 }
 
+// WriteRPCEvent pushes an event for a subscription to the query to the
+// writeChan, and blocks until it is accepted. If the connection exceeded its
+// event rate quota, the event is dropped and types.ErrEventRateLimited is
+// returned.
+// It implements WSRPCConnection. It is Goroutine-safe.
+func (wsc *wsConnection) WriteRPCEvent(ctx context.Context, query string, resp types.RPCResponse) error {
+	if wsc.eventLimiter != nil && !wsc.eventLimiter.allow(time.Now()) {
+		if !wsc.metricsQueries[query] {
+			query = otherQueries
+		}
+		wsc.metrics.WSDroppedEvents.With("query", query).Add(1)
+		return types.ErrEventRateLimited
+	}
+	return wsc.WriteRPCResponse(ctx, resp)
+}
+
 // TryWriteRPCResponse attempts to push a response to the writeChan, but does
 // not block.
 // It implements WSRPCConnection. It is Goroutine-safe
@@ -363,6 +436,14 @@ func (wsc *wsConnection) readRoutine() {
 				}
 				continue
 			}
+			if err := runCallChecks(wsc.checks, request.Method); err != nil {
+				e := callCheckError(err)
+				res := types.NewRPCErrorResponse(request.ID, e.Code, e.Message, e.Data)
+				if err := wsc.WriteRPCResponse(writeCtx, res); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
 
 			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
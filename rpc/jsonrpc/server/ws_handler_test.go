@@ -1,10 +1,15 @@
 package server
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 
@@ -42,6 +47,81 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	dialResp.Body.Close()
 }
 
+func TestWebsocketEventRateLimit(t *testing.T) {
+	errs := make(chan []error, 1)
+	funcMap := map[string]*RPCFunc{
+		"events": NewWSRPCFunc(func(ctx *types.Context, n int) (string, error) {
+			var result []error
+			for i := 0; i < n; i++ {
+				query := "tm.event = 'Tx'"
+				if i%2 == 1 {
+					query = "tm.event = 'NewBlock'"
+				}
+				resp := types.NewRPCSuccessResponse(types.JSONRPCStringID("event"), "data")
+				result = append(result, ctx.WSConn.WriteRPCEvent(context.Background(), query, resp))
+			}
+			errs <- result
+			return "done", nil
+		}, "n"),
+	}
+	dropped := labelCounter{mtx: &sync.Mutex{}, counts: make(map[string]float64)}
+	wm := NewWebsocketManager(funcMap,
+		EventRateLimit(1, 3),
+		WithMetrics(&Metrics{WSDroppedEvents: dropped, RateLimitRejections: discard.NewCounter()}),
+		EventMetricsQueries("tm.event = 'Tx'"),
+	)
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer dialResp.Body.Close()
+	defer c.Close()
+
+	req, err := types.MapToRequest(types.JSONRPCStringID("events"), "events", map[string]interface{}{"n": 5})
+	require.NoError(t, err)
+	require.NoError(t, c.WriteJSON(req))
+
+	// the burst of 3 events is written, and the rest are dropped
+	result := <-errs
+	require.Len(t, result, 5)
+	for i, err := range result[:3] {
+		require.NoError(t, err, "event %d", i)
+	}
+	for _, err := range result[3:] {
+		require.ErrorIs(t, err, types.ErrEventRateLimited)
+	}
+	dropped.mtx.Lock()
+	require.Equal(t, map[string]float64{"query tm.event = 'Tx'": 1, "query other": 1}, dropped.counts)
+	dropped.mtx.Unlock()
+	for i := 0; i < 4; i++ {
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		require.Nil(t, resp.Error)
+	}
+}
+
+// labelCounter counts the values added to it by label values.
+type labelCounter struct {
+	lvs    []string
+	mtx    *sync.Mutex
+	counts map[string]float64
+}
+
+func (c labelCounter) With(labelValues ...string) metrics.Counter {
+	c.lvs = append(append([]string{}, c.lvs...), labelValues...)
+	return c
+}
+
+func (c labelCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.counts[strings.Join(c.lvs, " ")] += delta
+}
+
 func newWSServer() *httptest.Server {
 	funcMap := map[string]*RPCFunc{
 		"c": NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -246,12 +247,20 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 
 //----------------------------------------
 
+// ErrEventRateLimited is returned by WSRPCConnection.WriteRPCEvent when an
+// event is dropped because the connection exceeded its event rate quota.
+var ErrEventRateLimited = errors.New("event rate limit exceeded")
+
 // WSRPCConnection represents a websocket connection.
 type WSRPCConnection interface {
 	// GetRemoteAddr returns a remote address of the connection.
 	GetRemoteAddr() string
 	// WriteRPCResponse writes the response onto connection (BLOCKING).
 	WriteRPCResponse(context.Context, RPCResponse) error
+	// WriteRPCEvent writes an event for a subscription to the query onto
+	// connection (BLOCKING), unless it exceeds the connection's event rate
+	// quota, in which case it is dropped and ErrEventRateLimited is returned.
+	WriteRPCEvent(ctx context.Context, query string, resp RPCResponse) error
 	// TryWriteRPCResponse tries to write the response onto connection (NON-BLOCKING).
 	TryWriteRPCResponse(RPCResponse) bool
 	// Context returns the connection's context.
@@ -271,6 +280,9 @@ type Context struct {
 	WSConn WSRPCConnection
 	// http request
 	HTTPReq *http.Request
+
+	// set by functions returning immutable results
+	immutable bool
 }
 
 // RemoteAddr returns the remote address (usually a string "IP:port").
@@ -304,6 +316,19 @@ func (ctx *Context) Context() context.Context {
 	return context.Background()
 }
 
+// MarkImmutable marks the result of the call as immutable, allowing HTTP
+// clients and caches to cache it indefinitely.
+func (ctx *Context) MarkImmutable() {
+	if ctx != nil {
+		ctx.immutable = true
+	}
+}
+
+// IsImmutable returns true if the result of the call was marked immutable.
+func (ctx *Context) IsImmutable() bool {
+	return ctx != nil && ctx.immutable
+}
+
 //----------------------------------------
 // SOCKETS
 
@@ -99,6 +99,8 @@ func createConfig() *cfg.Config {
 	c.RPC.ListenAddress = rpc
 	c.RPC.CORSAllowedOrigins = []string{"https://tendermint.com/"}
 	c.RPC.GRPCListenAddress = grpc
+	c.RPC.EventLogWindowSize = 100
+	c.RPC.Unsafe = true
 	return c
 }
 
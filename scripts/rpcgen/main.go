@@ -0,0 +1,285 @@
+/*
+	rpcgen generates the RPC route specs and the HTTP client methods from the
+	OpenAPI spec of the RPC server.
+
+	Usage:
+			rpcgen -spec <openapi.yaml> -routes <routes.gen.go> -client <client.gen.go>
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	tagUnsafe    = "Unsafe"
+	tagWebsocket = "Websocket"
+)
+
+// Spec is the part of the OpenAPI spec the generated code depends on.
+type Spec struct {
+	Paths map[string]map[string]Operation `yaml:"paths"`
+}
+
+// Operation is an OpenAPI operation, i.e. an RPC route.
+type Operation struct {
+	OperationID string      `yaml:"operationId"`
+	Tags        []string    `yaml:"tags"`
+	Parameters  []Parameter `yaml:"parameters"`
+	GoResult    string      `yaml:"x-go-result"`
+	GoClient    string      `yaml:"x-go-client"`
+}
+
+// Parameter is an OpenAPI operation parameter.
+type Parameter struct {
+	Name     string `yaml:"name"`
+	Required bool   `yaml:"required"`
+	GoType   string `yaml:"x-go-type"`
+}
+
+func (op Operation) hasTag(tag string) bool {
+	for _, t := range op.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	var (
+		specPath   = flag.String("spec", "rpc/openapi/openapi.yaml", "path to the OpenAPI spec")
+		routesPath = flag.String("routes", "rpc/core/types/routes.gen.go", "output path of the route specs")
+		clientPath = flag.String("client", "rpc/client/http/client.gen.go", "output path of the HTTP client methods")
+	)
+	flag.Parse()
+
+	if err := run(*specPath, *routesPath, *clientPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, routesPath, clientPath string) error {
+	ops, err := loadOperations(specPath)
+	if err != nil {
+		return err
+	}
+
+	routes, err := generateRoutes(ops)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(routesPath, routes, 0644); err != nil {
+		return err
+	}
+
+	client, err := generateClient(ops)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(clientPath, client, 0644)
+}
+
+// loadOperations reads the operations of the spec at path, sorted by ID.
+func loadOperations(path string) ([]Operation, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if err := yaml.Unmarshal(bz, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", path, err)
+	}
+
+	ops := make([]Operation, 0, len(spec.Paths))
+	for path, methods := range spec.Paths {
+		op, ok := methods["get"]
+		if !ok || len(methods) != 1 {
+			return nil, fmt.Errorf("%v: expected a single get operation", path)
+		}
+		if err := validateOperation(path, op); err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].OperationID < ops[j].OperationID })
+	return ops, nil
+}
+
+func validateOperation(path string, op Operation) error {
+	if "/"+op.OperationID != path {
+		return fmt.Errorf("%v: operationId %q doesn't match the path", path, op.OperationID)
+	}
+	if op.GoResult == "" {
+		return fmt.Errorf("%v: missing x-go-result", path)
+	}
+	for _, p := range op.Parameters {
+		if p.GoType == "" {
+			return fmt.Errorf("%v: parameter %q: missing x-go-type", path, p.Name)
+		}
+	}
+	return nil
+}
+
+func generateRoutes(ops []Operation) ([]byte, error) {
+	var buf bytes.Buffer
+	types := []string{}
+	for _, op := range ops {
+		for _, p := range op.Parameters {
+			types = append(types, p.GoType)
+		}
+	}
+
+	std, ext, err := imports(types)
+	if err != nil {
+		return nil, err
+	}
+	writeHeader(&buf, "coretypes", append([]string{"reflect"}, std...), ext)
+	fmt.Fprintf(&buf, "// RouteSpecs are the RPC routes documented in the OpenAPI spec, by method.\n")
+	fmt.Fprintf(&buf, "var RouteSpecs = map[string]RouteSpec{\n")
+	for _, op := range ops {
+		fmt.Fprintf(&buf, "%q: {\n", op.OperationID)
+		if len(op.Parameters) > 0 {
+			fmt.Fprintf(&buf, "Params: []RouteParam{\n")
+			for _, p := range op.Parameters {
+				fmt.Fprintf(&buf, "{Name: %q, Type: reflect.TypeOf((*%s)(nil)).Elem()", p.Name, p.GoType)
+				if p.Required {
+					fmt.Fprintf(&buf, ", Required: true")
+				}
+				fmt.Fprintf(&buf, "},\n")
+			}
+			fmt.Fprintf(&buf, "},\n")
+		}
+		fmt.Fprintf(&buf, "Result: reflect.TypeOf(%s{}),\n", op.GoResult)
+		if op.hasTag(tagUnsafe) {
+			fmt.Fprintf(&buf, "Unsafe: true,\n")
+		}
+		if op.hasTag(tagWebsocket) {
+			fmt.Fprintf(&buf, "WebSocket: true,\n")
+		}
+		fmt.Fprintf(&buf, "},\n")
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func generateClient(ops []Operation) ([]byte, error) {
+	var buf bytes.Buffer
+	types := []string{}
+	for _, op := range ops {
+		if op.GoClient == "" {
+			continue
+		}
+		for _, p := range op.Parameters {
+			types = append(types, p.GoType)
+		}
+	}
+
+	std, ext, err := imports(types)
+	if err != nil {
+		return nil, err
+	}
+	ext = append(ext, `ctypes "github.com/klyed/tendermint/rpc/core/types"`)
+	writeHeader(&buf, "http", append([]string{"context"}, std...), ext)
+	for _, op := range ops {
+		if op.GoClient == "" {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "// %s calls the %s RPC method.\n", op.GoClient, op.OperationID)
+		if len(op.Parameters) == 0 {
+			fmt.Fprintf(&buf, "func (c *baseRPCClient) %s(ctx context.Context) (*ctypes.%s, error) {\n",
+				op.GoClient, op.GoResult)
+		} else {
+			fmt.Fprintf(&buf, "func (c *baseRPCClient) %s(\nctx context.Context,\n", op.GoClient)
+			for _, p := range op.Parameters {
+				fmt.Fprintf(&buf, "%s %s,\n", argName(p.Name), p.GoType)
+			}
+			fmt.Fprintf(&buf, ") (*ctypes.%s, error) {\n", op.GoResult)
+		}
+		fmt.Fprintf(&buf, "result := new(ctypes.%s)\n", op.GoResult)
+		fmt.Fprintf(&buf, "params := make(map[string]interface{})\n")
+		for _, p := range op.Parameters {
+			if strings.HasPrefix(p.GoType, "*") {
+				fmt.Fprintf(&buf, "if %s != nil {\nparams[%q] = %s\n}\n", argName(p.Name), p.Name, argName(p.Name))
+			} else {
+				fmt.Fprintf(&buf, "params[%q] = %s\n", p.Name, argName(p.Name))
+			}
+		}
+		fmt.Fprintf(&buf, "_, err := c.caller.Call(ctx, %q, params, result)\n", op.OperationID)
+		fmt.Fprintf(&buf, "if err != nil {\nreturn nil, err\n}\n")
+		fmt.Fprintf(&buf, "return result, nil\n}\n\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func writeHeader(buf *bytes.Buffer, pkg string, std, ext []string) {
+	fmt.Fprintf(buf, "// Code generated by scripts/rpcgen from rpc/openapi/openapi.yaml. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\nimport (\n", pkg)
+	for _, imp := range std {
+		fmt.Fprintf(buf, "%q\n", imp)
+	}
+	fmt.Fprintf(buf, "\n")
+	for _, imp := range ext {
+		if !strings.Contains(imp, `"`) {
+			imp = fmt.Sprintf("%q", imp)
+		}
+		fmt.Fprintf(buf, "%s\n", imp)
+	}
+	fmt.Fprintf(buf, ")\n\n")
+}
+
+// imports returns the standard library and external packages referenced by
+// the given Go types.
+func imports(types []string) (std, ext []string, err error) {
+	known := map[string]string{
+		"bytes": "github.com/klyed/tendermint/libs/bytes",
+		"time":  "time",
+		"types": "github.com/klyed/tendermint/types",
+	}
+	seen := make(map[string]bool)
+	for _, t := range types {
+		t = strings.TrimLeft(t, "*[]")
+		if i := strings.Index(t, "."); i > 0 {
+			seen[t[:i]] = true
+		}
+	}
+
+	for pkg := range seen {
+		path, ok := known[pkg]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown package %q in x-go-type", pkg)
+		}
+		if strings.Contains(path, ".") {
+			ext = append(ext, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(ext)
+	return std, ext, nil
+}
+
+// argName converts a parameter name to a Go argument name, e.g. per_page to
+// perPage.
+func argName(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.Title(parts[i])
+	}
+	return strings.Join(parts, "")
+}
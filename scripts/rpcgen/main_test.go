@@ -0,0 +1,40 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedUpToDate checks that the generated files match the OpenAPI spec,
+// i.e. that `make rpc-gen` was run after changing it.
+func TestGeneratedUpToDate(t *testing.T) {
+	ops, err := loadOperations("../../rpc/openapi/openapi.yaml")
+	require.NoError(t, err)
+
+	routes, err := generateRoutes(ops)
+	require.NoError(t, err)
+	existing, err := ioutil.ReadFile("../../rpc/core/types/routes.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(routes), string(existing), "routes.gen.go is out of date, run make rpc-gen")
+
+	client, err := generateClient(ops)
+	require.NoError(t, err)
+	existing, err = ioutil.ReadFile("../../rpc/client/http/client.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(client), string(existing), "client.gen.go is out of date, run make rpc-gen")
+}
+
+func TestArgName(t *testing.T) {
+	testCases := map[string]string{
+		"height":    "height",
+		"per_page":  "perPage",
+		"minHeight": "minHeight",
+		"wait_time": "waitTime",
+	}
+	for name, expected := range testCases {
+		assert.Equal(t, expected, argName(name))
+	}
+}
@@ -0,0 +1,379 @@
+// Package eventlog implements a bounded, persistent log of the events
+// published on the event bus, which clients can read from a cursor.
+//
+// Unlike event bus subscriptions, which are canceled when a subscriber falls
+// behind and lose any events published while a client is disconnected, the
+// log allows clients to resume exactly where they left off, as long as the
+// events have not yet been pruned from the log.
+package eventlog
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dbm "github.com/klyed/tm-db"
+
+	tmjson "github.com/klyed/tendermint/libs/json"
+	tmpubsub "github.com/klyed/tendermint/libs/pubsub"
+	tmquery "github.com/klyed/tendermint/libs/pubsub/query"
+	"github.com/klyed/tendermint/libs/service"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+	"github.com/klyed/tendermint/types"
+)
+
+const subscriber = "EventLog"
+
+// Cursor identifies an event in the log by the height at which it was
+// published and its index among the events published at that height. The
+// zero cursor precedes all events.
+type Cursor struct {
+	Height int64
+	Index  uint32
+}
+
+// ParseCursor parses a cursor in the format returned by Cursor.String. An
+// empty string parses as the zero cursor.
+func ParseCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{}, nil
+	}
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || height < 0 {
+		return Cursor{}, fmt.Errorf("invalid cursor height %q", parts[0])
+	}
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor index %q", parts[1])
+	}
+	return Cursor{Height: height, Index: uint32(index)}, nil
+}
+
+// String returns the cursor as "<height>-<index>", or an empty string for the
+// zero cursor.
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", c.Height, c.Index)
+}
+
+// IsZero returns true if this is the zero cursor.
+func (c Cursor) IsZero() bool {
+	return c == Cursor{}
+}
+
+// Before returns true if the cursor precedes the other cursor.
+func (c Cursor) Before(other Cursor) bool {
+	return c.Height < other.Height || (c.Height == other.Height && c.Index < other.Index)
+}
+
+// key returns the database key of the event, which sorts in cursor order.
+func (c Cursor) key() []byte {
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint64(key, uint64(c.Height))
+	binary.BigEndian.PutUint32(key[8:], c.Index)
+	return key
+}
+
+func cursorFromKey(key []byte) (Cursor, error) {
+	if len(key) != 12 {
+		return Cursor{}, fmt.Errorf("invalid event log key %X", key)
+	}
+	return Cursor{
+		Height: int64(binary.BigEndian.Uint64(key)),
+		Index:  binary.BigEndian.Uint32(key[8:]),
+	}, nil
+}
+
+// Item is an event in the log.
+type Item struct {
+	Cursor Cursor
+	Type   string
+	Data   types.TMEventData
+	Events map[string][]string
+}
+
+// storedItem is the stored form of an Item, whose cursor is given by the key.
+type storedItem struct {
+	Type   string              `json:"type"`
+	Data   types.TMEventData   `json:"data"`
+	Events map[string][]string `json:"events"`
+}
+
+// EventLog is a persistent log of all the events published on the event bus.
+// Each event is assigned a cursor, whose height is the greatest height seen in
+// the events published so far and whose index increments for each event at
+// that height. Events are retained for the last windowSize heights, or
+// indefinitely if windowSize is 0.
+type EventLog struct {
+	service.BaseService
+
+	db         dbm.DB
+	eventBus   *types.EventBus
+	windowSize int64
+
+	mtx    tmsync.RWMutex
+	oldest Cursor
+	newest Cursor
+	added  chan struct{} // closed and replaced when events are added
+}
+
+// NewEventLog creates a new event log backed by the given database,
+// recording events from the event bus once started.
+func NewEventLog(db dbm.DB, eventBus *types.EventBus, windowSize int64) (*EventLog, error) {
+	l := &EventLog{
+		db:         db,
+		eventBus:   eventBus,
+		windowSize: windowSize,
+		added:      make(chan struct{}),
+	}
+	l.BaseService = *service.NewBaseService(nil, "EventLog", l)
+
+	var err error
+	if l.oldest, err = l.edge(false); err != nil {
+		return nil, err
+	}
+	if l.newest, err = l.edge(true); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// edge returns the cursor of the oldest or newest event in the database, or
+// the zero cursor if it's empty.
+func (l *EventLog) edge(newest bool) (Cursor, error) {
+	var (
+		iter dbm.Iterator
+		err  error
+	)
+	if newest {
+		iter, err = l.db.ReverseIterator(nil, nil)
+	} else {
+		iter, err = l.db.Iterator(nil, nil)
+	}
+	if err != nil {
+		return Cursor{}, err
+	}
+	defer iter.Close()
+	if !iter.Valid() {
+		return Cursor{}, iter.Error()
+	}
+	return cursorFromKey(iter.Key())
+}
+
+// OnStart implements service.Service by subscribing to all events and
+// recording them in the log.
+func (l *EventLog) OnStart() error {
+	// Use SubscribeUnbuffered, such that the subscription is never canceled and
+	// no events are missed.
+	sub, err := l.eventBus.SubscribeUnbuffered(context.Background(), subscriber, tmquery.Empty{})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				if _, err := l.Add(msg.Data(), msg.Events()); err != nil {
+					l.Logger.Error("Failed to add event to event log", "err", err)
+				}
+			case <-sub.Canceled():
+				return
+			case <-l.Quit():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// OnStop implements service.Service by unsubscribing from all events.
+func (l *EventLog) OnStop() {
+	if l.eventBus.IsRunning() {
+		_ = l.eventBus.UnsubscribeAll(context.Background(), subscriber)
+	}
+}
+
+// Add adds an event to the log, pruning events which fall outside of the
+// window, and returns its cursor.
+func (l *EventLog) Add(data types.TMEventData, events map[string][]string) (Cursor, error) {
+	var eventType string
+	if values := events[types.EventTypeKey]; len(values) > 0 {
+		eventType = values[0]
+	}
+	bz, err := tmjson.Marshal(storedItem{Type: eventType, Data: data, Events: events})
+	if err != nil {
+		return Cursor{}, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	cursor := Cursor{Height: l.newest.Height, Index: l.newest.Index + 1}
+	if height := eventHeight(data); height > cursor.Height || cursor.Height == 0 {
+		if height < 1 {
+			height = 1
+		}
+		cursor = Cursor{Height: height, Index: 0}
+	}
+
+	batch := l.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(cursor.key(), bz); err != nil {
+		return Cursor{}, err
+	}
+
+	oldest := l.oldest
+	if oldest.IsZero() {
+		oldest = cursor
+	}
+	if l.windowSize > 0 && cursor.Height-l.windowSize >= oldest.Height {
+		retain := Cursor{Height: cursor.Height - l.windowSize + 1}
+		if oldest, err = l.pruneBefore(batch, retain); err != nil {
+			return Cursor{}, err
+		}
+		if oldest.IsZero() {
+			oldest = cursor
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return Cursor{}, err
+	}
+	l.oldest = oldest
+	l.newest = cursor
+	close(l.added)
+	l.added = make(chan struct{})
+
+	return cursor, nil
+}
+
+// pruneBefore deletes the events before the given cursor in the batch,
+// returning the cursor of the oldest retained event, if any.
+func (l *EventLog) pruneBefore(batch dbm.Batch, retain Cursor) (Cursor, error) {
+	iter, err := l.db.Iterator(nil, nil)
+	if err != nil {
+		return Cursor{}, err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		cursor, err := cursorFromKey(iter.Key())
+		if err != nil {
+			return Cursor{}, err
+		}
+		if !cursor.Before(retain) {
+			return cursor, nil
+		}
+		if err := batch.Delete(iter.Key()); err != nil {
+			return Cursor{}, err
+		}
+	}
+	return Cursor{}, iter.Error()
+}
+
+// Window returns the cursors of the oldest and newest events in the log,
+// which are zero if the log is empty. Clients which are resuming from a cursor
+// before the oldest event may have missed pruned events.
+func (l *EventLog) Window() (oldest, newest Cursor) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.oldest, l.newest
+}
+
+// Added returns a channel which is closed when events are next added to the
+// log, for clients waiting for new events.
+func (l *EventLog) Added() <-chan struct{} {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.added
+}
+
+// Scan returns, in order, up to maxItems events matching the query with
+// cursors after the given cursor and, if non-zero, before the given cursor.
+// It also returns whether there are more matching events in that range.
+func (l *EventLog) Scan(query tmpubsub.Query, after, before Cursor, maxItems int) ([]*Item, bool, error) {
+	if maxItems <= 0 {
+		return nil, false, errors.New("maxItems must be positive")
+	}
+
+	var start, end []byte
+	if !after.IsZero() {
+		// the smallest key after the cursor's key
+		start = append(after.key(), 0)
+	}
+	if !before.IsZero() {
+		end = before.key()
+	}
+	iter, err := l.db.Iterator(start, end)
+	if err != nil {
+		return nil, false, err
+	}
+	defer iter.Close()
+
+	items := []*Item{}
+	for ; iter.Valid(); iter.Next() {
+		var stored storedItem
+		if err := tmjson.Unmarshal(iter.Value(), &stored); err != nil {
+			return nil, false, fmt.Errorf("failed to decode event: %w", err)
+		}
+		match, err := query.Matches(stored.Events)
+		if err != nil {
+			return nil, false, err
+		}
+		if !match {
+			continue
+		}
+		if len(items) == maxItems {
+			return items, true, nil
+		}
+
+		cursor, err := cursorFromKey(iter.Key())
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, &Item{
+			Cursor: cursor,
+			Type:   stored.Type,
+			Data:   stored.Data,
+			Events: stored.Events,
+		})
+	}
+	return items, false, iter.Error()
+}
+
+// eventHeight returns the height of the event data, or 0 if it has none.
+func eventHeight(data types.TMEventData) int64 {
+	switch data := data.(type) {
+	case types.EventDataNewBlock:
+		if data.Block != nil {
+			return data.Block.Height
+		}
+	case types.EventDataNewBlockHeader:
+		return data.Header.Height
+	case types.EventDataNewEvidence:
+		return data.Height
+	case types.EventDataTx:
+		return data.Height
+	case types.EventDataRoundState:
+		return data.Height
+	case types.EventDataNewRound:
+		return data.Height
+	case types.EventDataCompleteProposal:
+		return data.Height
+	case types.EventDataVote:
+		if data.Vote != nil {
+			return data.Vote.Height
+		}
+	}
+	return 0
+}
@@ -0,0 +1,191 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+
+	dbm "github.com/klyed/tm-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/klyed/tendermint/abci/types"
+	tmquery "github.com/klyed/tendermint/libs/pubsub/query"
+	"github.com/klyed/tendermint/types"
+)
+
+func addRoundState(t *testing.T, l *EventLog, height int64) Cursor {
+	cursor, err := l.Add(types.EventDataRoundState{Height: height, Step: "RoundStepPropose"},
+		map[string][]string{types.EventTypeKey: {types.EventNewRoundStep}})
+	require.NoError(t, err)
+	return cursor
+}
+
+func addTx(t *testing.T, l *EventLog, height int64, index uint32) Cursor {
+	cursor, err := l.Add(types.EventDataTx{TxResult: abci.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     types.Tx("tx"),
+	}}, map[string][]string{types.EventTypeKey: {types.EventTx}, types.TxHeightKey: {"1"}})
+	require.NoError(t, err)
+	return cursor
+}
+
+func TestParseCursor(t *testing.T) {
+	testcases := map[string]struct {
+		cursor Cursor
+		valid  bool
+	}{
+		"":               {Cursor{}, true},
+		"1-0":            {Cursor{1, 0}, true},
+		"100-4294967295": {Cursor{100, 4294967295}, true},
+		"1":              {Cursor{}, false},
+		"1-":             {Cursor{}, false},
+		"-1-0":           {Cursor{}, false},
+		"1-4294967296":   {Cursor{}, false},
+		"a-b":            {Cursor{}, false},
+	}
+	for s, tc := range testcases {
+		s, tc := s, tc
+		t.Run(s, func(t *testing.T) {
+			cursor, err := ParseCursor(s)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.cursor, cursor)
+			require.Equal(t, s, cursor.String())
+		})
+	}
+}
+
+func TestEventLog_Add(t *testing.T) {
+	db := dbm.NewMemDB()
+	l, err := NewEventLog(db, types.NewEventBus(), 0)
+	require.NoError(t, err)
+
+	// events without a height are assigned to the first height
+	cursor, err := l.Add(types.EventDataValidatorSetUpdates{},
+		map[string][]string{types.EventTypeKey: {types.EventValidatorSetUpdates}})
+	require.NoError(t, err)
+	assert.Equal(t, Cursor{1, 0}, cursor)
+
+	assert.Equal(t, Cursor{3, 0}, addRoundState(t, l, 3))
+	assert.Equal(t, Cursor{3, 1}, addTx(t, l, 3, 0))
+	// events for earlier heights are assigned to the current height
+	assert.Equal(t, Cursor{3, 2}, addRoundState(t, l, 2))
+	assert.Equal(t, Cursor{4, 0}, addRoundState(t, l, 4))
+
+	oldest, newest := l.Window()
+	assert.Equal(t, Cursor{1, 0}, oldest)
+	assert.Equal(t, Cursor{4, 0}, newest)
+
+	// the log is resumed after a restart
+	l, err = NewEventLog(db, types.NewEventBus(), 0)
+	require.NoError(t, err)
+	oldest, newest = l.Window()
+	assert.Equal(t, Cursor{1, 0}, oldest)
+	assert.Equal(t, Cursor{4, 0}, newest)
+	assert.Equal(t, Cursor{4, 1}, addRoundState(t, l, 4))
+}
+
+func TestEventLog_Prune(t *testing.T) {
+	l, err := NewEventLog(dbm.NewMemDB(), types.NewEventBus(), 3)
+	require.NoError(t, err)
+
+	for h := int64(1); h <= 10; h++ {
+		addRoundState(t, l, h)
+		addTx(t, l, h, 0)
+	}
+
+	oldest, newest := l.Window()
+	assert.Equal(t, Cursor{8, 0}, oldest)
+	assert.Equal(t, Cursor{10, 1}, newest)
+
+	items, more, err := l.Scan(tmquery.Empty{}, Cursor{}, Cursor{}, 100)
+	require.NoError(t, err)
+	assert.False(t, more)
+	require.Len(t, items, 6)
+	assert.Equal(t, Cursor{8, 0}, items[0].Cursor)
+}
+
+func TestEventLog_Scan(t *testing.T) {
+	l, err := NewEventLog(dbm.NewMemDB(), types.NewEventBus(), 0)
+	require.NoError(t, err)
+
+	for h := int64(1); h <= 5; h++ {
+		addRoundState(t, l, h)
+		addTx(t, l, h, 0)
+		addTx(t, l, h, 1)
+	}
+
+	txQuery := tmquery.MustParse("tm.event = 'Tx'")
+	testcases := map[string]struct {
+		query    string
+		after    Cursor
+		before   Cursor
+		maxItems int
+		expect   []Cursor
+		more     bool
+	}{
+		"all txs": {"tm.event = 'Tx'", Cursor{}, Cursor{}, 100, []Cursor{
+			{1, 1}, {1, 2}, {2, 1}, {2, 2}, {3, 1}, {3, 2}, {4, 1}, {4, 2}, {5, 1}, {5, 2}}, false},
+		"limited": {"tm.event = 'Tx'", Cursor{}, Cursor{}, 3, []Cursor{{1, 1}, {1, 2}, {2, 1}}, true},
+		"after":   {"tm.event = 'Tx'", Cursor{4, 1}, Cursor{}, 3, []Cursor{{4, 2}, {5, 1}, {5, 2}}, false},
+		"between": {"tm.event = 'Tx'", Cursor{2, 1}, Cursor{3, 2}, 10, []Cursor{{2, 2}, {3, 1}}, false},
+		"limited by filter": {"tm.event = 'NewRoundStep'", Cursor{}, Cursor{}, 5,
+			[]Cursor{{1, 0}, {2, 0}, {3, 0}, {4, 0}, {5, 0}}, false},
+		"none after": {"tm.event = 'Tx'", Cursor{5, 2}, Cursor{}, 10, []Cursor{}, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			items, more, err := l.Scan(tmquery.MustParse(tc.query), tc.after, tc.before, tc.maxItems)
+			require.NoError(t, err)
+			assert.Equal(t, tc.more, more)
+			cursors := make([]Cursor, 0, len(items))
+			for _, item := range items {
+				cursors = append(cursors, item.Cursor)
+			}
+			assert.Equal(t, tc.expect, cursors)
+		})
+	}
+
+	items, _, err := l.Scan(txQuery, Cursor{}, Cursor{}, 1)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, types.EventTx, items[0].Type)
+	data, ok := items[0].Data.(types.EventDataTx)
+	require.True(t, ok)
+	assert.EqualValues(t, "tx", data.Tx)
+
+	_, _, err = l.Scan(txQuery, Cursor{}, Cursor{}, 0)
+	require.Error(t, err)
+}
+
+func TestEventLog_Service(t *testing.T) {
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	l, err := NewEventLog(dbm.NewMemDB(), eventBus, 0)
+	require.NoError(t, err)
+	require.NoError(t, l.Start())
+	t.Cleanup(func() { _ = l.Stop() })
+
+	added := l.Added()
+	require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+		Height: 1,
+		Tx:     types.Tx("tx"),
+	}}))
+
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	items, _, err := l.Scan(tmquery.MustParse("tx.height = 1"), Cursor{}, Cursor{}, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, types.EventTx, items[0].Type)
+}
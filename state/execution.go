@@ -40,6 +40,9 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// deliver blocks to the app in a single FinalizeBlock call
+	finalizeBlock bool
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -50,6 +53,15 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithFinalizeBlock makes the executor deliver each block to the
+// app in a single FinalizeBlock call, instead of BeginBlock, a DeliverTx for
+// each tx and EndBlock.
+func BlockExecutorWithFinalizeBlock(finalizeBlock bool) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.finalizeBlock = finalizeBlock
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -138,7 +150,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, blockExec.finalizeBlock,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -179,7 +191,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	// Lock mempool, commit app state, update mempoool.
 	appHash, retainHeight, err := blockExec.Commit(state, block, abciResponses.DeliverTxs)
 	if err != nil {
-		return state, 0, fmt.Errorf("commit failed for application: %v", err)
+		return state, 0, fmt.Errorf("commit failed for application: %w", err)
 	}
 
 	// Update evpool with the latest state.
@@ -221,7 +233,8 @@ func (blockExec *BlockExecutor) Commit(
 	err := blockExec.mempool.FlushAppConn()
 	if err != nil {
 		blockExec.logger.Error("client error during mempool.FlushAppConn", "err", err)
-		return nil, 0, err
+		// not a consensus connection error: the block was executed already
+		return nil, 0, fmt.Errorf("mempool flush failed: %v", err)
 	}
 
 	// Commit block, get hash back
@@ -244,17 +257,23 @@ func (blockExec *BlockExecutor) Commit(
 		block.Height,
 		block.Txs,
 		deliverTxResponses,
+		res.Recheck,
 		TxPreCheck(state),
 		TxPostCheck(state),
 	)
+	if err != nil {
+		// not a consensus connection error: the application committed already
+		return nil, 0, fmt.Errorf("mempool update failed: %v", err)
+	}
 
-	return res.Data, res.RetainHeight, err
+	return res.Data, res.RetainHeight, nil
 }
 
 //---------------------------------------------------------
 // Helper functions for executing blocks and updating state
 
-// Executes block's transactions on proxyAppConn.
+// Executes block's transactions on proxyAppConn, either in a single
+// FinalizeBlock call or with BeginBlock, DeliverTx and EndBlock calls.
 // Returns a list of transaction results and updates to the validator set
 func execBlockOnProxyApp(
 	logger log.Logger,
@@ -262,7 +281,29 @@ func execBlockOnProxyApp(
 	block *types.Block,
 	store Store,
 	initialHeight int64,
+	finalizeBlock bool,
 ) (*tmstate.ABCIResponses, error) {
+	pbh := block.Header.ToProto()
+	if pbh == nil {
+		return nil, errors.New("nil header")
+	}
+
+	byzVals := make([]abci.Evidence, 0)
+	for _, evidence := range block.Evidence.Evidence {
+		byzVals = append(byzVals, evidence.ABCI()...)
+	}
+
+	beginBlockReq := abci.RequestBeginBlock{
+		Hash:                block.Hash(),
+		Header:              *pbh,
+		LastCommitInfo:      getBeginBlockValidatorInfo(block, store, initialHeight),
+		ByzantineValidators: byzVals,
+	}
+
+	if finalizeBlock {
+		return execFinalizeBlockOnProxyApp(logger, proxyAppConn, block, beginBlockReq)
+	}
+
 	var validTxs, invalidTxs = 0, 0
 
 	txIndex := 0
@@ -290,31 +331,11 @@ func execBlockOnProxyApp(
 	}
 	proxyAppConn.SetResponseCallback(proxyCb)
 
-	commitInfo := getBeginBlockValidatorInfo(block, store, initialHeight)
-
-	byzVals := make([]abci.Evidence, 0)
-	for _, evidence := range block.Evidence.Evidence {
-		byzVals = append(byzVals, evidence.ABCI()...)
-	}
-
 	ctx := context.Background()
 
 	// Begin block
 	var err error
-	pbh := block.Header.ToProto()
-	if pbh == nil {
-		return nil, errors.New("nil header")
-	}
-
-	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(
-		ctx,
-		abci.RequestBeginBlock{
-			Hash:                block.Hash(),
-			Header:              *pbh,
-			LastCommitInfo:      commitInfo,
-			ByzantineValidators: byzVals,
-		},
-	)
+	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(ctx, beginBlockReq)
 	if err != nil {
 		logger.Error("error in proxyAppConn.BeginBlock", "err", err)
 		return nil, err
@@ -338,6 +359,66 @@ func execBlockOnProxyApp(
 	return abciResponses, nil
 }
 
+// Executes block's transactions on proxyAppConn in a single FinalizeBlock call.
+func execFinalizeBlockOnProxyApp(
+	logger log.Logger,
+	proxyAppConn proxy.AppConnConsensus,
+	block *types.Block,
+	beginBlockReq abci.RequestBeginBlock,
+) (*tmstate.ABCIResponses, error) {
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+
+	res, err := proxyAppConn.FinalizeBlockSync(
+		context.Background(),
+		abci.RequestFinalizeBlock{
+			BeginBlock: beginBlockReq,
+			Txs:        txs,
+			EndBlock:   abci.RequestEndBlock{Height: block.Height},
+		},
+	)
+	if err != nil {
+		logger.Error("error in proxyAppConn.FinalizeBlock", "err", err)
+		return nil, err
+	}
+	if len(res.DeliverTxs) != len(block.Txs) {
+		return nil, fmt.Errorf("expected %d DeliverTx responses from FinalizeBlock, got %d",
+			len(block.Txs), len(res.DeliverTxs))
+	}
+
+	// empty messages may be decoded as nil
+	abciResponses := &tmstate.ABCIResponses{
+		BeginBlock: res.BeginBlock,
+		DeliverTxs: res.DeliverTxs,
+		EndBlock:   res.EndBlock,
+	}
+	if abciResponses.BeginBlock == nil {
+		abciResponses.BeginBlock = &abci.ResponseBeginBlock{}
+	}
+	if abciResponses.EndBlock == nil {
+		abciResponses.EndBlock = &abci.ResponseEndBlock{}
+	}
+
+	var validTxs, invalidTxs = 0, 0
+	for i, txRes := range abciResponses.DeliverTxs {
+		if txRes == nil {
+			txRes = &abci.ResponseDeliverTx{}
+			abciResponses.DeliverTxs[i] = txRes
+		}
+		if txRes.Code == abci.CodeTypeOK {
+			validTxs++
+		} else {
+			logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
+			invalidTxs++
+		}
+	}
+
+	logger.Info("executed block", "height", block.Height, "num_valid_txs", validTxs, "num_invalid_txs", invalidTxs)
+	return abciResponses, nil
+}
+
 func getBeginBlockValidatorInfo(block *types.Block, store Store,
 	initialHeight int64) abci.LastCommitInfo {
 	voteInfos := make([]abci.VoteInfo, block.LastCommit.Size())
@@ -537,8 +618,9 @@ func ExecCommitBlock(
 	logger log.Logger,
 	store Store,
 	initialHeight int64,
+	finalizeBlock bool,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight)
+	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, store, initialHeight, finalizeBlock)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -17,6 +17,7 @@ import (
 	"github.com/klyed/tendermint/libs/log"
 	mmock "github.com/klyed/tendermint/mempool/mock"
 	"github.com/klyed/tendermint/proxy"
+	proxymocks "github.com/klyed/tendermint/proxy/mocks"
 	sm "github.com/klyed/tendermint/state"
 	"github.com/klyed/tendermint/state/mocks"
 	"github.com/klyed/tendermint/types"
@@ -55,6 +56,58 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+func TestApplyBlockFinalizeBlock(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithFinalizeBlock(true))
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	state, _, err = blockExec.ApplyBlock(state, blockID, block)
+	require.Nil(t, err)
+	assert.Equal(t, 1, app.FinalizedBlocks)
+	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
+
+	abciResponses, err := stateStore.LoadABCIResponses(block.Height)
+	require.NoError(t, err)
+	assert.Len(t, abciResponses.DeliverTxs, len(block.Txs))
+	assert.NotNil(t, abciResponses.BeginBlock)
+}
+
+// TestApplyBlockFinalizeBlockMissingTxResults ensures we reject FinalizeBlock
+// responses without a DeliverTx response for each tx.
+func TestApplyBlockFinalizeBlockMissingTxResults(t *testing.T) {
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+
+	proxyApp := &proxymocks.AppConnConsensus{}
+	proxyApp.On("FinalizeBlockSync", mock.Anything, mock.Anything).Return(&abci.ResponseFinalizeBlock{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}, nil)
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp,
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, sm.BlockExecutorWithFinalizeBlock(true))
+
+	block := makeBlock(state, 1)
+	require.NotEmpty(t, block.Txs)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, _, err := blockExec.ApplyBlock(state, blockID, block)
+	require.Error(t, err)
+	proxyApp.AssertExpectations(t)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -100,7 +153,7 @@ func TestBeginBlockValidators(t *testing.T) {
 		// block for height 2
 		block, _ := state.MakeBlock(2, makeTxs(2), lastCommit, nil, state.Validators.GetProposer().Address)
 
-		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateStore, 1)
+		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateStore, 1, false)
 		require.Nil(t, err, tc.desc)
 
 		// -> app receives a list of validators with a bool indicating if they signed
@@ -17,6 +17,10 @@ const (
 type Metrics struct {
 	// Time between BeginBlock and EndBlock.
 	BlockProcessingTime metrics.Histogram
+	// Height below which blocks and states are pruned.
+	PruningRetainHeight metrics.Gauge
+	// Number of blocks pruned.
+	PrunedBlocks metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -35,6 +39,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Time between BeginBlock and EndBlock in ms.",
 			Buckets:   stdprometheus.LinearBuckets(1, 10, 10),
 		}, labels).With(labelsAndValues...),
+		PruningRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruning_retain_height",
+			Help:      "Height below which blocks and states are pruned.",
+		}, labels).With(labelsAndValues...),
+		PrunedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruned_blocks",
+			Help:      "Number of blocks pruned.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -42,5 +58,7 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 func NopMetrics() *Metrics {
 	return &Metrics{
 		BlockProcessingTime: discard.NewHistogram(),
+		PruningRetainHeight: discard.NewGauge(),
+		PrunedBlocks:        discard.NewCounter(),
 	}
 }
@@ -0,0 +1,224 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/klyed/tendermint/libs/log"
+	"github.com/klyed/tendermint/libs/service"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+const (
+	// defaultPruningInterval is the default interval between pruning batches.
+	defaultPruningInterval = time.Second
+	// defaultPruningBatchSize is the default number of heights pruned per batch.
+	defaultPruningBatchSize = 100
+)
+
+// compacter is implemented by stores which can compact the storage of pruned
+// heights.
+type compacter interface {
+	Compact(height int64) error
+}
+
+// Pruner prunes blocks and states below a retain height in the background,
+// such that large deletes do not stall consensus. Heights are pruned in
+// batches of at most batchSize every interval, and the retain height is
+// persisted as a watermark in the state store, such that pruning resumes after
+// a restart. If the stores support it, the storage of pruned heights is
+// compacted every compactionInterval pruned blocks.
+type Pruner struct {
+	service.BaseService
+
+	stateStore Store
+	blockStore BlockStore
+	metrics    *Metrics
+
+	minRetainBlocks    int64
+	interval           time.Duration
+	batchSize          int64
+	compactionInterval int64
+
+	mtx          tmsync.Mutex
+	retainHeight int64 // height below which to prune, persisted in the state store
+	pending      int64 // number of blocks pruned since the last compaction
+
+	quit chan struct{}
+}
+
+// PrunerOption sets an optional parameter on the Pruner.
+type PrunerOption func(*Pruner)
+
+// PrunerMinRetainBlocks sets the minimum number of recent blocks to retain,
+// overriding retain heights requested by the application. 0 disables it.
+func PrunerMinRetainBlocks(blocks int64) PrunerOption {
+	return func(p *Pruner) { p.minRetainBlocks = blocks }
+}
+
+// PrunerInterval sets the interval between pruning batches.
+func PrunerInterval(interval time.Duration) PrunerOption {
+	return func(p *Pruner) { p.interval = interval }
+}
+
+// PrunerBatchSize sets the maximum number of heights pruned per batch.
+func PrunerBatchSize(size int64) PrunerOption {
+	return func(p *Pruner) { p.batchSize = size }
+}
+
+// PrunerCompactionInterval sets the number of pruned blocks after which the
+// stores are compacted. 0 disables compaction.
+func PrunerCompactionInterval(blocks int64) PrunerOption {
+	return func(p *Pruner) { p.compactionInterval = blocks }
+}
+
+// PrunerWithMetrics sets the metrics.
+func PrunerWithMetrics(metrics *Metrics) PrunerOption {
+	return func(p *Pruner) { p.metrics = metrics }
+}
+
+// NewPruner creates a new background pruner for the given stores.
+func NewPruner(logger log.Logger, stateStore Store, blockStore BlockStore, options ...PrunerOption) *Pruner {
+	p := &Pruner{
+		stateStore: stateStore,
+		blockStore: blockStore,
+		metrics:    NopMetrics(),
+		interval:   defaultPruningInterval,
+		batchSize:  defaultPruningBatchSize,
+		quit:       make(chan struct{}),
+	}
+	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// OnStart implements service.Service. It loads the persisted retain height and
+// starts pruning in the background.
+func (p *Pruner) OnStart() error {
+	retainHeight, err := p.stateStore.LoadRetainHeight()
+	if err != nil {
+		return fmt.Errorf("failed to load retain height: %w", err)
+	}
+
+	p.mtx.Lock()
+	if retainHeight > p.retainHeight {
+		p.retainHeight = retainHeight
+	}
+	p.metrics.PruningRetainHeight.Set(float64(p.retainHeight))
+	p.mtx.Unlock()
+
+	go p.pruneRoutine()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (p *Pruner) OnStop() {
+	close(p.quit)
+}
+
+// RetainHeight returns the current height below which heights are pruned.
+func (p *Pruner) RetainHeight() int64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.retainHeight
+}
+
+// SetRetainHeight requests pruning of all heights below the given retain
+// height, typically as returned by the application's Commit. The height is
+// lowered to retain at least minRetainBlocks blocks, and is ignored if it is
+// lower than the current retain height. The new retain height is persisted
+// before returning, while the pruning itself happens in the background.
+func (p *Pruner) SetRetainHeight(retainHeight int64) error {
+	if retainHeight <= 0 {
+		return fmt.Errorf("retain height %v must be greater than 0", retainHeight)
+	}
+	if p.minRetainBlocks > 0 {
+		if max := p.blockStore.Height() - p.minRetainBlocks + 1; retainHeight > max {
+			retainHeight = max
+		}
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if retainHeight <= p.retainHeight {
+		return nil
+	}
+	if err := p.stateStore.SaveRetainHeight(retainHeight); err != nil {
+		return fmt.Errorf("failed to save retain height: %w", err)
+	}
+	p.retainHeight = retainHeight
+	p.metrics.PruningRetainHeight.Set(float64(retainHeight))
+	return nil
+}
+
+// pruneRoutine prunes a batch of heights every interval until stopped.
+func (p *Pruner) pruneRoutine() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := p.pruneBatch(); err != nil {
+				p.Logger.Error("failed to prune", "err", err)
+			}
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// pruneBatch prunes at most batchSize heights below the retain height,
+// compacting the stores if due. It returns the number of blocks pruned.
+// It must only be called from a single goroutine.
+func (p *Pruner) pruneBatch() (uint64, error) {
+	// don't hold the mutex while pruning, to avoid blocking SetRetainHeight
+	height := p.RetainHeight()
+	base := p.blockStore.Base()
+	if base == 0 || base >= height {
+		return 0, nil
+	}
+	if height > base+p.batchSize {
+		height = base + p.batchSize
+	}
+
+	pruned, err := p.blockStore.PruneBlocks(height)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune block store: %w", err)
+	}
+	if err := p.stateStore.PruneStates(height); err != nil {
+		return pruned, fmt.Errorf("failed to prune state store: %w", err)
+	}
+	p.metrics.PrunedBlocks.Add(float64(pruned))
+	p.Logger.Debug("pruned blocks", "pruned", pruned, "retain_height", height)
+
+	p.pending += int64(pruned)
+	if p.compactionInterval > 0 && p.pending >= p.compactionInterval {
+		if err := p.compact(height); err != nil {
+			return pruned, err
+		}
+		p.pending = 0
+	}
+
+	return pruned, nil
+}
+
+// compact compacts the storage of heights below the given height, for the
+// stores which support it.
+func (p *Pruner) compact(height int64) error {
+	start := time.Now()
+	for _, store := range []interface{}{p.blockStore, p.stateStore} {
+		c, ok := store.(compacter)
+		if !ok {
+			continue
+		}
+		if err := c.Compact(height); err != nil {
+			return fmt.Errorf("failed to compact store: %w", err)
+		}
+	}
+	p.Logger.Debug("compacted stores", "height", height, "duration", time.Since(start))
+	return nil
+}
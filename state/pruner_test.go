@@ -0,0 +1,110 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/libs/log"
+	tmsync "github.com/klyed/tendermint/libs/sync"
+	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/state/mocks"
+)
+
+// pruningBlockStore is a block store which only tracks its base and height.
+type pruningBlockStore struct {
+	sm.BlockStore
+
+	mtx       tmsync.Mutex
+	base      int64
+	height    int64
+	compacted int64
+}
+
+func (bs *pruningBlockStore) Base() int64 {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	return bs.base
+}
+
+func (bs *pruningBlockStore) Height() int64 {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	return bs.height
+}
+
+func (bs *pruningBlockStore) PruneBlocks(height int64) (uint64, error) {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	pruned := uint64(height - bs.base)
+	bs.base = height
+	return pruned, nil
+}
+
+func (bs *pruningBlockStore) Compact(height int64) error {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.compacted = height
+	return nil
+}
+
+func (bs *pruningBlockStore) Compacted() int64 {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	return bs.compacted
+}
+
+func TestPruner_SetRetainHeight(t *testing.T) {
+	blockStore := &pruningBlockStore{base: 1, height: 100}
+	stateStore := &mocks.Store{}
+	stateStore.On("SaveRetainHeight", int64(50)).Return(nil).Once()
+	stateStore.On("SaveRetainHeight", int64(91)).Return(nil).Once()
+
+	pruner := sm.NewPruner(log.TestingLogger(), stateStore, blockStore, sm.PrunerMinRetainBlocks(10))
+
+	require.Error(t, pruner.SetRetainHeight(0))
+
+	require.NoError(t, pruner.SetRetainHeight(50))
+	require.EqualValues(t, 50, pruner.RetainHeight())
+
+	// lower retain heights are ignored
+	require.NoError(t, pruner.SetRetainHeight(40))
+	require.EqualValues(t, 50, pruner.RetainHeight())
+
+	// the retain height is capped to retain min-retain-blocks blocks
+	require.NoError(t, pruner.SetRetainHeight(100))
+	require.EqualValues(t, 91, pruner.RetainHeight())
+
+	stateStore.AssertExpectations(t)
+}
+
+func TestPruner_Prune(t *testing.T) {
+	blockStore := &pruningBlockStore{base: 1, height: 100}
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadRetainHeight").Return(int64(25), nil)
+	stateStore.On("SaveRetainHeight", mock.Anything).Return(nil)
+	stateStore.On("PruneStates", mock.Anything).Return(nil)
+
+	pruner := sm.NewPruner(log.TestingLogger(), stateStore, blockStore,
+		sm.PrunerInterval(10*time.Millisecond),
+		sm.PrunerBatchSize(10),
+		sm.PrunerCompactionInterval(30),
+	)
+	require.NoError(t, pruner.Start())
+	t.Cleanup(func() { require.NoError(t, pruner.Stop()) })
+
+	// pruning resumes from the persisted retain height, in batches
+	require.EqualValues(t, 25, pruner.RetainHeight())
+	require.Eventually(t, func() bool { return blockStore.Base() == 25 }, time.Second, 10*time.Millisecond)
+	stateStore.AssertCalled(t, "PruneStates", int64(11))
+	stateStore.AssertCalled(t, "PruneStates", int64(21))
+	stateStore.AssertCalled(t, "PruneStates", int64(25))
+	require.Zero(t, blockStore.Compacted())
+
+	require.NoError(t, pruner.SetRetainHeight(60))
+	require.Eventually(t, func() bool { return blockStore.Base() == 60 }, time.Second, 10*time.Millisecond)
+	stateStore.AssertCalled(t, "SaveRetainHeight", int64(60))
+	require.EqualValues(t, 35, blockStore.Compacted())
+}
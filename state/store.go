@@ -2,12 +2,14 @@ package state
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
 	dbm "github.com/klyed/tm-db"
+	"github.com/syndtr/goleveldb/leveldb/util"
 
 	abci "github.com/klyed/tendermint/abci/types"
 	tmmath "github.com/klyed/tendermint/libs/math"
@@ -33,6 +35,7 @@ const (
 	prefixConsensusParams = int64(6)
 	prefixABCIResponses   = int64(7)
 	prefixState           = int64(8)
+	prefixRetainHeight    = int64(13)
 )
 
 func encodeKey(prefix int64, height int64) []byte {
@@ -55,8 +58,11 @@ func abciResponsesKey(height int64) []byte {
 	return encodeKey(prefixABCIResponses, height)
 }
 
-// stateKey should never change after being set in init()
-var stateKey []byte
+// stateKey and retainHeightKey should never change after being set in init()
+var (
+	stateKey        []byte
+	retainHeightKey []byte
+)
 
 func init() {
 	var err error
@@ -64,6 +70,10 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	retainHeightKey, err = orderedcode.Append(nil, prefixRetainHeight)
+	if err != nil {
+		panic(err)
+	}
 }
 
 //----------------------
@@ -93,10 +103,18 @@ type Store interface {
 	Save(State) error
 	// SaveABCIResponses saves ABCIResponses for a given height
 	SaveABCIResponses(int64, *tmstate.ABCIResponses) error
+	// SaveValidatorSets saves the validator set for a range of heights
+	SaveValidatorSets(int64, int64, *types.ValidatorSet) error
+	// SaveConsensusParams saves the consensus params for a range of heights
+	SaveConsensusParams(int64, int64, types.ConsensusParams) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(State) error
 	// PruneStates takes the height from which to prune up to (exclusive)
 	PruneStates(int64) error
+	// SaveRetainHeight persists the height below which blocks and states may be pruned
+	SaveRetainHeight(int64) error
+	// LoadRetainHeight loads the persisted retain height, or 0 if none
+	LoadRetainHeight() (int64, error)
 }
 
 // dbStore wraps a db (github.com/klyed/tm-db)
@@ -254,6 +272,55 @@ func (store dbStore) Bootstrap(state State) error {
 	return batch.WriteSync()
 }
 
+// SaveValidatorSets saves the validator set as being responsible for signing
+// all heights from lowerHeight to upperHeight (inclusive), e.g. when backfilling
+// history after state sync. The full set is only stored at lowerHeight (and at
+// checkpoint heights), with the heights above referring back to it.
+func (store dbStore) SaveValidatorSets(lowerHeight, upperHeight int64, vals *types.ValidatorSet) error {
+	if lowerHeight <= 0 || lowerHeight > upperHeight {
+		return fmt.Errorf("invalid height range %v-%v", lowerHeight, upperHeight)
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	for height := lowerHeight; height <= upperHeight; height++ {
+		// the full set stored at checkpoint heights must have the proposer
+		// priorities at that height, which LoadValidators otherwise derives
+		// from the ones at lowerHeight
+		heightVals := vals
+		if height != lowerHeight && height%valSetCheckpointInterval == 0 {
+			heightVals = vals.CopyIncrementProposerPriority(tmmath.SafeConvertInt32(height - lowerHeight))
+		}
+		if err := store.saveValidatorsInfo(height, lowerHeight, heightVals, batch); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
+
+// SaveConsensusParams saves the consensus params as being in effect for all
+// heights from lowerHeight to upperHeight (inclusive), e.g. when importing
+// history. The params are only stored at lowerHeight, with the heights above
+// referring back to it.
+func (store dbStore) SaveConsensusParams(lowerHeight, upperHeight int64, params types.ConsensusParams) error {
+	if lowerHeight <= 0 || lowerHeight > upperHeight {
+		return fmt.Errorf("invalid height range %v-%v", lowerHeight, upperHeight)
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	for height := lowerHeight; height <= upperHeight; height++ {
+		if err := store.saveConsensusParamsInfo(height, lowerHeight, params, batch); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
+
 // PruneStates deletes states up to the height specified (exclusive). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at retain height must also exist.
@@ -435,6 +502,55 @@ func (store dbStore) reverseBatchDelete(batch dbm.Batch, start, end []byte) ([]b
 	return start, iter.Error()
 }
 
+// SaveRetainHeight persists the height below which blocks and states may be
+// pruned, such that pruning can resume after a restart.
+func (store dbStore) SaveRetainHeight(height int64) error {
+	if height < 0 {
+		return fmt.Errorf("retain height %v cannot be negative", height)
+	}
+	bz := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(bz, height)
+	return store.db.SetSync(retainHeightKey, bz[:n])
+}
+
+// LoadRetainHeight loads the persisted retain height, or 0 if none was saved.
+func (store dbStore) LoadRetainHeight() (int64, error) {
+	bz, err := store.db.Get(retainHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 0, nil
+	}
+	height, n := binary.Varint(bz)
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid retain height %X", bz)
+	}
+	return height, nil
+}
+
+// Compact compacts the key ranges of states pruned below the given height, to
+// reclaim disk space. It is a no-op for database backends which do not
+// support range compaction.
+func (store dbStore) Compact(height int64) error {
+	for _, prefix := range []int64{prefixValidators, prefixConsensusParams, prefixABCIResponses} {
+		if err := compactRange(store.db, encodeKey(prefix, 0), encodeKey(prefix, height)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactRange compacts the underlying storage of the key range [start, end).
+func compactRange(db dbm.DB, start, end []byte) error {
+	switch db := db.(type) {
+	case *dbm.GoLevelDB:
+		return db.DB().CompactRange(util.Range{Start: start, Limit: end})
+	default:
+		return nil
+	}
+}
+
 //------------------------------------------------------------------------
 
 // ABCIResponsesResultsHash returns the root hash of a Merkle tree of
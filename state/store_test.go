@@ -94,6 +94,76 @@ func TestStoreLoadValidators(t *testing.T) {
 	require.NotEqual(t, vals.CopyIncrementProposerPriority(valSetCheckpointInterval), loadedVals)
 }
 
+func TestStoreSaveValidatorSets(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	vals, _ := types.RandValidatorSet(3, 10)
+	vals2, _ := types.RandValidatorSet(4, 10)
+
+	require.Error(t, stateStore.SaveValidatorSets(0, 5, vals))
+	require.Error(t, stateStore.SaveValidatorSets(5, 4, vals))
+
+	require.NoError(t, stateStore.SaveValidatorSets(3, 5, vals))
+	require.NoError(t, stateStore.SaveValidatorSets(6, 8, vals2))
+
+	_, err := stateStore.LoadValidators(2)
+	require.Error(t, err)
+
+	for height := int64(3); height <= 8; height++ {
+		expected := vals
+		if height > 5 {
+			expected = vals2
+		}
+		loadedVals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), loadedVals.Hash())
+	}
+
+	_, err = stateStore.LoadValidators(9)
+	require.Error(t, err)
+
+	// the proposer priorities are the same whether loaded from a checkpoint
+	// or from the lowest height
+	require.NoError(t, stateStore.SaveValidatorSets(99998, 100001, vals2))
+	for height := int64(99998); height <= 100001; height++ {
+		loadedVals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		expected := vals2
+		if height > 99998 {
+			expected = vals2.CopyIncrementProposerPriority(int32(height - 99998))
+		}
+		require.Equal(t, expected, loadedVals)
+	}
+}
+
+func TestStoreSaveConsensusParams(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	params := *types.DefaultConsensusParams()
+	params2 := *types.DefaultConsensusParams()
+	params2.Block.MaxBytes = 1000
+
+	require.Error(t, stateStore.SaveConsensusParams(0, 5, params))
+	require.Error(t, stateStore.SaveConsensusParams(5, 4, params))
+
+	require.NoError(t, stateStore.SaveConsensusParams(3, 5, params))
+	require.NoError(t, stateStore.SaveConsensusParams(6, 8, params2))
+
+	_, err := stateStore.LoadConsensusParams(2)
+	require.Error(t, err)
+
+	for height := int64(3); height <= 8; height++ {
+		expected := params
+		if height > 5 {
+			expected = params2
+		}
+		loadedParams, err := stateStore.LoadConsensusParams(height)
+		require.NoError(t, err)
+		require.Equal(t, expected, loadedParams)
+	}
+
+	_, err = stateStore.LoadConsensusParams(9)
+	require.Error(t, err)
+}
+
 // This benchmarks the speed of loading validators from different heights if there is no validator set change.
 // NOTE: This isn't too indicative of validator retrieval speed as the db is always (regardless of height) only
 // performing two operations: 1) retrieve validator info at height x, which has a last validator set change of 1
@@ -157,6 +227,21 @@ func TestStoreLoadConsensusParams(t *testing.T) {
 	require.NotEqual(t, res, differentParams)
 }
 
+func TestStoreRetainHeight(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+
+	height, err := stateStore.LoadRetainHeight()
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	require.Error(t, stateStore.SaveRetainHeight(-1))
+	require.NoError(t, stateStore.SaveRetainHeight(100))
+
+	height, err = stateStore.LoadRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 100, height)
+}
+
 func TestPruneStates(t *testing.T) {
 	testcases := map[string]struct {
 		startHeight           int64
@@ -0,0 +1,211 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tmsync "github.com/klyed/tendermint/libs/sync"
+	"github.com/klyed/tendermint/p2p"
+	ssproto "github.com/klyed/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	"github.com/klyed/tendermint/types"
+)
+
+var (
+	// errUnsolicitedResponse is returned by the dispatcher when a response was not requested.
+	errUnsolicitedResponse = errors.New("unsolicited light block response")
+	// errDispatcherClosed is returned by the dispatcher once it has been closed.
+	errDispatcherClosed = errors.New("dispatcher closed")
+	// errPeerRemoved is returned by the dispatcher when a peer is removed while a
+	// request to it is in flight.
+	errPeerRemoved = errors.New("peer removed")
+)
+
+// dispatcher multiplexes light block requests across peers and matches the
+// responses to the waiting callers. Each peer has at most one request in
+// flight at a time, such that responses can be matched by sender.
+type dispatcher struct {
+	requestCh chan<- p2p.Envelope
+	timeout   time.Duration
+
+	mtx       tmsync.Mutex
+	peers     map[p2p.NodeID]bool                   // all known peers
+	calls     map[p2p.NodeID]chan *types.LightBlock // in-flight requests, by peer
+	available chan struct{}                         // signals that a peer may have become idle
+	closed    bool
+}
+
+// newDispatcher creates a new dispatcher sending requests on requestCh, and
+// waiting at most timeout for each response.
+func newDispatcher(requestCh chan<- p2p.Envelope, timeout time.Duration) *dispatcher {
+	return &dispatcher{
+		requestCh: requestCh,
+		timeout:   timeout,
+		peers:     make(map[p2p.NodeID]bool),
+		calls:     make(map[p2p.NodeID]chan *types.LightBlock),
+		available: make(chan struct{}, 1),
+	}
+}
+
+// LightBlock requests the light block at the given height from an idle peer,
+// waiting for a peer to become idle if necessary. It returns the light block
+// and the peer which sent it. The light block is nil if the peer does not have
+// it, and it is not verified in any way.
+func (d *dispatcher) LightBlock(ctx context.Context, height int64) (*types.LightBlock, p2p.NodeID, error) {
+	peer, callCh, err := d.acquirePeer(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer d.release(peer, callCh)
+
+	select {
+	case d.requestCh <- p2p.Envelope{
+		To:      peer,
+		Message: &ssproto.LightBlockRequest{Height: uint64(height)},
+	}:
+	case <-ctx.Done():
+		return nil, peer, ctx.Err()
+	}
+
+	timer := time.NewTimer(d.timeout)
+	defer timer.Stop()
+
+	select {
+	case lb, ok := <-callCh:
+		if !ok {
+			d.mtx.Lock()
+			defer d.mtx.Unlock()
+			if d.closed {
+				return nil, peer, errDispatcherClosed
+			}
+			return nil, peer, errPeerRemoved
+		}
+		return lb, peer, nil
+
+	case <-timer.C:
+		return nil, peer, fmt.Errorf("timed out waiting for light block %v from peer %v", height, peer)
+
+	case <-ctx.Done():
+		return nil, peer, ctx.Err()
+	}
+}
+
+// acquirePeer returns an idle peer, marking it as busy, along with the channel
+// its response will be delivered on.
+func (d *dispatcher) acquirePeer(ctx context.Context) (p2p.NodeID, chan *types.LightBlock, error) {
+	for {
+		d.mtx.Lock()
+		if d.closed {
+			d.mtx.Unlock()
+			return "", nil, errDispatcherClosed
+		}
+		for peer := range d.peers {
+			if _, busy := d.calls[peer]; !busy {
+				callCh := make(chan *types.LightBlock, 1)
+				d.calls[peer] = callCh
+				d.mtx.Unlock()
+				return peer, callCh, nil
+			}
+		}
+		d.mtx.Unlock()
+
+		select {
+		case <-d.available:
+		case <-ctx.Done():
+			return "", nil, ctx.Err()
+		}
+	}
+}
+
+// release marks a peer as idle again, unless the call has already been
+// cleared by removePeer or close.
+func (d *dispatcher) release(peer p2p.NodeID, callCh chan *types.LightBlock) {
+	d.mtx.Lock()
+	if d.calls[peer] == callCh {
+		delete(d.calls, peer)
+	}
+	d.mtx.Unlock()
+
+	d.signalAvailable()
+}
+
+// signalAvailable wakes up a caller waiting for an idle peer, if any.
+func (d *dispatcher) signalAvailable() {
+	select {
+	case d.available <- struct{}{}:
+	default:
+	}
+}
+
+// respond delivers a light block response from a peer to the waiting caller.
+// It returns an error if no request is in flight for the peer.
+func (d *dispatcher) respond(pb *tmproto.LightBlock, peer p2p.NodeID) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	callCh, ok := d.calls[peer]
+	if !ok {
+		return errUnsolicitedResponse
+	}
+
+	var lb *types.LightBlock
+	if pb != nil {
+		var err error
+		lb, err = types.LightBlockFromProto(pb)
+		if err != nil {
+			return fmt.Errorf("invalid light block: %w", err)
+		}
+	}
+
+	select {
+	case callCh <- lb:
+	default:
+		return errUnsolicitedResponse
+	}
+
+	return nil
+}
+
+// addPeer makes a peer available for requests.
+func (d *dispatcher) addPeer(peer p2p.NodeID) {
+	d.mtx.Lock()
+	d.peers[peer] = true
+	d.mtx.Unlock()
+
+	d.signalAvailable()
+}
+
+// removePeer removes a peer, failing any request in flight to it.
+func (d *dispatcher) removePeer(peer p2p.NodeID) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	delete(d.peers, peer)
+	if callCh, ok := d.calls[peer]; ok {
+		close(callCh)
+		delete(d.calls, peer)
+	}
+}
+
+// close fails all in-flight requests and rejects any further requests.
+func (d *dispatcher) close() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.closed = true
+	for peer, callCh := range d.calls {
+		close(callCh)
+		delete(d.calls, peer)
+	}
+
+	d.signalAvailable()
+}
+
+// numPeers returns the number of known peers.
+func (d *dispatcher) numPeers() int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return len(d.peers)
+}
@@ -0,0 +1,105 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/p2p"
+	ssproto "github.com/klyed/tendermint/proto/tendermint/statesync"
+)
+
+func TestDispatcher_LightBlock(t *testing.T) {
+	lightBlocks := mockLightBlocks(t, "test-chain", 2, time.Now())
+	lbproto, err := lightBlocks[2].ToProto()
+	require.NoError(t, err)
+
+	requestCh := make(chan p2p.Envelope, 1)
+	d := newDispatcher(requestCh, time.Second)
+	d.addPeer("aa")
+	require.Equal(t, 1, d.numPeers())
+
+	// responses from peers without a request in flight are rejected
+	require.Equal(t, errUnsolicitedResponse, d.respond(lbproto, "aa"))
+
+	go func() {
+		envelope := <-requestCh
+		require.Equal(t, p2p.NodeID("aa"), envelope.To)
+		require.Equal(t, &ssproto.LightBlockRequest{Height: 2}, envelope.Message)
+		require.NoError(t, d.respond(lbproto, envelope.To))
+	}()
+
+	lb, peer, err := d.LightBlock(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, p2p.NodeID("aa"), peer)
+	require.Equal(t, lightBlocks[2].Hash(), lb.Hash())
+
+	// a nil response means the peer doesn't have the light block
+	go func() {
+		envelope := <-requestCh
+		require.NoError(t, d.respond(nil, envelope.To))
+	}()
+
+	lb, peer, err = d.LightBlock(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, p2p.NodeID("aa"), peer)
+	require.Nil(t, lb)
+}
+
+func TestDispatcher_Timeout(t *testing.T) {
+	requestCh := make(chan p2p.Envelope, 1)
+	d := newDispatcher(requestCh, 10*time.Millisecond)
+	d.addPeer("aa")
+
+	_, peer, err := d.LightBlock(context.Background(), 1)
+	require.Error(t, err)
+	require.Equal(t, p2p.NodeID("aa"), peer)
+
+	// the peer is available again after timing out
+	<-requestCh
+	_, _, err = d.LightBlock(context.Background(), 1)
+	require.Error(t, err)
+}
+
+func TestDispatcher_WaitForPeer(t *testing.T) {
+	requestCh := make(chan p2p.Envelope, 1)
+	d := newDispatcher(requestCh, time.Second)
+
+	// without peers, requests block until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err := d.LightBlock(ctx, 1)
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		d.addPeer("aa")
+		<-requestCh
+		d.removePeer("aa")
+	}()
+
+	// removing the peer fails the request in flight
+	_, peer, err := d.LightBlock(context.Background(), 1)
+	require.Equal(t, errPeerRemoved, err)
+	require.Equal(t, p2p.NodeID("aa"), peer)
+	require.Zero(t, d.numPeers())
+}
+
+func TestDispatcher_Close(t *testing.T) {
+	requestCh := make(chan p2p.Envelope, 1)
+	d := newDispatcher(requestCh, time.Second)
+	d.addPeer("aa")
+
+	go func() {
+		<-requestCh
+		d.close()
+	}()
+
+	_, _, err := d.LightBlock(context.Background(), 1)
+	require.Equal(t, errDispatcherClosed, err)
+
+	_, _, err = d.LightBlock(context.Background(), 1)
+	require.Equal(t, errDispatcherClosed, err)
+}
@@ -0,0 +1,109 @@
+package statesync
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "statesync"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height of the snapshot being restored.
+	SnapshotHeight metrics.Gauge
+	// Total number of chunks in the snapshot being restored.
+	SnapshotChunksTotal metrics.Gauge
+	// Number of chunks applied to the app.
+	SnapshotChunksApplied metrics.Gauge
+	// Number of chunk bytes applied to the app.
+	SnapshotBytesApplied metrics.Gauge
+	// Estimated time remaining until the snapshot is restored, in seconds.
+	SnapshotRemainingSeconds metrics.Gauge
+	// Number of chunks fetched concurrently.
+	ChunkFetchers metrics.Gauge
+	// Histogram of chunk fetch throughput, in bytes per second.
+	ChunkFetchBytesPerSecond metrics.Histogram
+	// Number of chunk requests which timed out.
+	ChunkRequestTimeouts metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SnapshotHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_height",
+			Help:      "Height of the snapshot being restored.",
+		}, labels).With(labelsAndValues...),
+		SnapshotChunksTotal: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_chunks_total",
+			Help:      "Total number of chunks in the snapshot being restored.",
+		}, labels).With(labelsAndValues...),
+		SnapshotChunksApplied: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_chunks_applied",
+			Help:      "Number of snapshot chunks applied to the app.",
+		}, labels).With(labelsAndValues...),
+		SnapshotBytesApplied: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_bytes_applied",
+			Help:      "Number of snapshot chunk bytes applied to the app.",
+		}, labels).With(labelsAndValues...),
+		SnapshotRemainingSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_remaining_seconds",
+			Help:      "Estimated time remaining until the snapshot is restored, in seconds.",
+		}, labels).With(labelsAndValues...),
+		ChunkFetchers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_fetchers",
+			Help:      "Number of snapshot chunks fetched concurrently.",
+		}, labels).With(labelsAndValues...),
+		ChunkFetchBytesPerSecond: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_fetch_bytes_per_second",
+			Help:      "Throughput of snapshot chunk fetches, in bytes per second.",
+			Buckets:   stdprometheus.ExponentialBuckets(1024, 4, 10),
+		}, labels).With(labelsAndValues...),
+		ChunkRequestTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_request_timeouts",
+			Help:      "Number of snapshot chunk requests which timed out.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SnapshotHeight:           discard.NewGauge(),
+		SnapshotChunksTotal:      discard.NewGauge(),
+		SnapshotChunksApplied:    discard.NewGauge(),
+		SnapshotBytesApplied:     discard.NewGauge(),
+		SnapshotRemainingSeconds: discard.NewGauge(),
+		ChunkFetchers:            discard.NewGauge(),
+		ChunkFetchBytesPerSecond: discard.NewHistogram(),
+		ChunkRequestTimeouts:     discard.NewCounter(),
+	}
+}
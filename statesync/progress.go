@@ -0,0 +1,121 @@
+package statesync
+
+import (
+	"time"
+
+	tmsync "github.com/klyed/tendermint/libs/sync"
+)
+
+// Progress describes the progress of a snapshot restoration.
+type Progress struct {
+	// SnapshotHeight is the height of the snapshot being restored, or 0 if no
+	// snapshot restoration has started.
+	SnapshotHeight uint64
+	// ChunksApplied and ChunksTotal are the number of chunks applied to the app
+	// and the total number of chunks in the snapshot.
+	ChunksApplied uint32
+	ChunksTotal   uint32
+	// BytesApplied is the number of chunk bytes applied to the app.
+	BytesApplied uint64
+	// Elapsed is the time spent restoring the snapshot.
+	Elapsed time.Duration
+	// Remaining is the estimated time until the snapshot is restored, or 0 if
+	// unknown or done.
+	Remaining time.Duration
+}
+
+// syncProgress tracks the progress of snapshot restorations, reporting it via
+// metrics. It is safe for concurrent use.
+type syncProgress struct {
+	metrics *Metrics
+
+	mtx           tmsync.Mutex
+	height        uint64
+	chunksApplied uint32
+	chunksTotal   uint32
+	bytesApplied  uint64
+	started       time.Time
+	finished      time.Time
+}
+
+// newSyncProgress creates a new progress tracker.
+func newSyncProgress(metrics *Metrics) *syncProgress {
+	return &syncProgress{metrics: metrics}
+}
+
+// start resets the progress for the restoration of the given snapshot.
+func (p *syncProgress) start(snapshot *snapshot) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.height = snapshot.Height
+	p.chunksTotal = snapshot.Chunks
+	p.chunksApplied = 0
+	p.bytesApplied = 0
+	p.started = time.Now()
+	p.finished = time.Time{}
+
+	p.metrics.SnapshotHeight.Set(float64(p.height))
+	p.metrics.SnapshotChunksTotal.Set(float64(p.chunksTotal))
+	p.metrics.SnapshotChunksApplied.Set(0)
+	p.metrics.SnapshotBytesApplied.Set(0)
+	p.metrics.SnapshotRemainingSeconds.Set(0)
+}
+
+// chunkApplied records a chunk of the given size as applied to the app.
+func (p *syncProgress) chunkApplied(size int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.chunksApplied < p.chunksTotal {
+		p.chunksApplied++
+	}
+	p.bytesApplied += uint64(size)
+
+	p.metrics.SnapshotChunksApplied.Set(float64(p.chunksApplied))
+	p.metrics.SnapshotBytesApplied.Set(float64(p.bytesApplied))
+	p.metrics.SnapshotRemainingSeconds.Set(p.remaining(time.Now()).Seconds())
+}
+
+// finish marks the snapshot restoration as completed.
+func (p *syncProgress) finish() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.finished = time.Now()
+	p.metrics.SnapshotRemainingSeconds.Set(0)
+}
+
+// Progress returns the current progress.
+func (p *syncProgress) Progress() Progress {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.started.IsZero() {
+		return Progress{}
+	}
+
+	now := time.Now()
+	if !p.finished.IsZero() {
+		now = p.finished
+	}
+
+	return Progress{
+		SnapshotHeight: p.height,
+		ChunksApplied:  p.chunksApplied,
+		ChunksTotal:    p.chunksTotal,
+		BytesApplied:   p.bytesApplied,
+		Elapsed:        now.Sub(p.started),
+		Remaining:      p.remaining(now),
+	}
+}
+
+// remaining estimates the remaining restoration time by extrapolating the
+// average time per chunk applied so far. The caller must hold the mutex lock.
+func (p *syncProgress) remaining(now time.Time) time.Duration {
+	if !p.finished.IsZero() || p.chunksApplied == 0 || p.chunksApplied >= p.chunksTotal {
+		return 0
+	}
+	perChunk := now.Sub(p.started) / time.Duration(p.chunksApplied)
+	return perChunk * time.Duration(p.chunksTotal-p.chunksApplied)
+}
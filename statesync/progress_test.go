@@ -0,0 +1,48 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncProgress(t *testing.T) {
+	p := newSyncProgress(NopMetrics())
+	require.Equal(t, Progress{}, p.Progress())
+
+	p.start(&snapshot{Height: 10, Format: 1, Chunks: 4})
+	progress := p.Progress()
+	require.EqualValues(t, 10, progress.SnapshotHeight)
+	require.EqualValues(t, 4, progress.ChunksTotal)
+	require.Zero(t, progress.ChunksApplied)
+	require.Zero(t, progress.Remaining)
+
+	// fake an elapsed time of 1s for the first chunk
+	p.started = time.Now().Add(-time.Second)
+	p.chunkApplied(100)
+	progress = p.Progress()
+	require.EqualValues(t, 1, progress.ChunksApplied)
+	require.EqualValues(t, 100, progress.BytesApplied)
+	require.InDelta(t, float64(3*time.Second), float64(progress.Remaining), float64(100*time.Millisecond))
+
+	for i := 0; i < 4; i++ {
+		p.chunkApplied(100)
+	}
+	progress = p.Progress()
+	require.EqualValues(t, 4, progress.ChunksApplied)
+	require.EqualValues(t, 500, progress.BytesApplied)
+	require.Zero(t, progress.Remaining)
+
+	p.finish()
+	elapsed := p.Progress().Elapsed
+	time.Sleep(5 * time.Millisecond)
+	require.Equal(t, elapsed, p.Progress().Elapsed)
+
+	// restarting resets the progress
+	p.start(&snapshot{Height: 12, Format: 1, Chunks: 2})
+	progress = p.Progress()
+	require.EqualValues(t, 12, progress.SnapshotHeight)
+	require.Zero(t, progress.ChunksApplied)
+	require.Zero(t, progress.BytesApplied)
+}
@@ -1,10 +1,12 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	abci "github.com/klyed/tendermint/abci/types"
@@ -13,8 +15,10 @@ import (
 	tmsync "github.com/klyed/tendermint/libs/sync"
 	"github.com/klyed/tendermint/p2p"
 	ssproto "github.com/klyed/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
 	"github.com/klyed/tendermint/proxy"
 	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/store"
 	"github.com/klyed/tendermint/types"
 )
 
@@ -48,6 +52,15 @@ var (
 				RecvMessageCapacity: chunkMsgSize,
 			},
 		},
+		LightBlockChannel: {
+			MsgType: new(ssproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(LightBlockChannel),
+				Priority:            1,
+				SendQueueCapacity:   10,
+				RecvMessageCapacity: lightBlockMsgSize,
+			},
+		},
 	}
 )
 
@@ -58,6 +71,9 @@ const (
 	// ChunkChannel exchanges chunk contents
 	ChunkChannel = p2p.ChannelID(0x61)
 
+	// LightBlockChannel exchanges light blocks, used to backfill block history
+	LightBlockChannel = p2p.ChannelID(0x62)
+
 	// recentSnapshots is the number of recent snapshots to send and receive per peer.
 	recentSnapshots = 10
 
@@ -66,21 +82,63 @@ const (
 
 	// chunkMsgSize is the maximum size of a chunkResponseMessage
 	chunkMsgSize = int(16e6)
+
+	// lightBlockMsgSize is the maximum size of a lightBlockResponseMessage
+	lightBlockMsgSize = int(1e7)
+
+	// lightBlockResponseTimeout is how long the dispatcher waits for a peer to
+	// return a light block
+	lightBlockResponseTimeout = 10 * time.Second
+
+	// backfillFetchers is the number of concurrent light block fetchers to run
+	// while backfilling.
+	backfillFetchers = 4
+
+	// backfillMaxPending is the maximum number of fetched light blocks waiting
+	// to be verified while backfilling.
+	backfillMaxPending = 4 * backfillFetchers
+
+	// backfillRetryDelay is how long to wait before requesting a light block
+	// again after a failed request.
+	backfillRetryDelay = 500 * time.Millisecond
+
+	// backfillRequestTimeout is how long a light block request may wait for an
+	// idle peer and its response while backfilling.
+	backfillRequestTimeout = 2 * lightBlockResponseTimeout
+
+	// backfillMaxAttempts is how many times a light block is requested, or
+	// refetched after failing verification, before backfilling gives up.
+	backfillMaxAttempts = 20
 )
 
+// errNoPeersWithBlock is returned by Backfill when no peer provided a valid
+// light block at some height within backfillMaxAttempts requests.
+var errNoPeersWithBlock = errors.New("no peers with a valid light block")
+
 // Reactor handles state sync, both restoring snapshots for the local node and
 // serving snapshots for other nodes.
 type Reactor struct {
 	service.BaseService
 
+	stateStore sm.Store
+	blockStore *store.BlockStore
+
 	conn        proxy.AppConnSnapshot
 	connQuery   proxy.AppConnQuery
 	tempDir     string
 	snapshotCh  *p2p.Channel
 	chunkCh     *p2p.Channel
+	blockCh     *p2p.Channel
 	peerUpdates *p2p.PeerUpdates
 	closeCh     chan struct{}
 
+	// dispatcher routes light block requests to peers and responses back to
+	// the backfill process.
+	dispatcher *dispatcher
+
+	metrics  *Metrics
+	progress *syncProgress
+
 	// This will only be set when a state sync is in progress. It is used to feed
 	// received snapshots and chunks into the sync.
 	mtx    tmsync.RWMutex
@@ -89,24 +147,35 @@ type Reactor struct {
 
 // NewReactor returns a reference to a new state sync reactor, which implements
 // the service.Service interface. It accepts a logger, connections for snapshots
-// and querying, references to p2p Channels and a channel to listen for peer
-// updates on. Note, the reactor will close all p2p Channels when stopping.
+// and querying, references to p2p Channels, a channel to listen for peer
+// updates on, the state and block stores used to serve and backfill light
+// blocks, and metrics. Note, the reactor will close all p2p Channels when
+// stopping.
 func NewReactor(
 	logger log.Logger,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
-	snapshotCh, chunkCh *p2p.Channel,
+	snapshotCh, chunkCh, blockCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
 	tempDir string,
+	metrics *Metrics,
 ) *Reactor {
 	r := &Reactor{
 		conn:        conn,
 		connQuery:   connQuery,
 		snapshotCh:  snapshotCh,
 		chunkCh:     chunkCh,
+		blockCh:     blockCh,
 		peerUpdates: peerUpdates,
 		closeCh:     make(chan struct{}),
 		tempDir:     tempDir,
+		stateStore:  stateStore,
+		blockStore:  blockStore,
+		dispatcher:  newDispatcher(blockCh.Out, lightBlockResponseTimeout),
+		metrics:     metrics,
+		progress:    newSyncProgress(metrics),
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "StateSync", r)
@@ -130,6 +199,8 @@ func (r *Reactor) OnStart() error {
 	// have to deal with bounding workers or pools.
 	go r.processChunkCh()
 
+	go r.processBlockCh()
+
 	go r.processPeerUpdates()
 
 	return nil
@@ -142,11 +213,15 @@ func (r *Reactor) OnStop() {
 	// p2p Channels should execute Close().
 	close(r.closeCh)
 
+	// Fail any in-flight light block requests of an ongoing backfill.
+	r.dispatcher.close()
+
 	// Wait for all p2p Channels to be closed before returning. This ensures we
 	// can easily reason about synchronization of all p2p Channels and ensure no
 	// panics will occur.
 	<-r.snapshotCh.Done()
 	<-r.chunkCh.Done()
+	<-r.blockCh.Done()
 	<-r.peerUpdates.Done()
 }
 
@@ -307,6 +382,48 @@ func (r *Reactor) handleChunkMessage(envelope p2p.Envelope) error {
 	return nil
 }
 
+// handleLightBlockMessage handles envelopes sent from peers on the
+// LightBlockChannel. It returns an error only if the Envelope.Message is unknown
+// for this channel. This should never be called outside of handleMessage.
+func (r *Reactor) handleLightBlockMessage(envelope p2p.Envelope) error {
+	switch msg := envelope.Message.(type) {
+	case *ssproto.LightBlockRequest:
+		r.Logger.Debug("received light block request", "height", msg.Height, "peer", envelope.From)
+		lb, err := r.fetchLightBlock(msg.Height)
+		if err != nil {
+			r.Logger.Error("failed to retrieve light block", "height", msg.Height, "err", err)
+			return nil
+		}
+
+		var lbproto *tmproto.LightBlock
+		if lb != nil {
+			lbproto, err = lb.ToProto()
+			if err != nil {
+				r.Logger.Error("failed to convert light block to proto", "height", msg.Height, "err", err)
+				return nil
+			}
+		}
+
+		// a nil light block tells the peer that we don't have it
+		r.blockCh.Out <- p2p.Envelope{
+			To: envelope.From,
+			Message: &ssproto.LightBlockResponse{
+				LightBlock: lbproto,
+			},
+		}
+
+	case *ssproto.LightBlockResponse:
+		if err := r.dispatcher.respond(msg.LightBlock, envelope.From); err != nil {
+			r.Logger.Error("failed to handle light block response", "peer", envelope.From, "err", err)
+		}
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+
+	return nil
+}
+
 // handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
 // It will handle errors and any possible panics gracefully. A caller can handle
 // any error returned by sending a PeerError on the respective channel.
@@ -326,6 +443,9 @@ func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err
 	case ChunkChannel:
 		err = r.handleChunkMessage(envelope)
 
+	case LightBlockChannel:
+		err = r.handleLightBlockMessage(envelope)
+
 	default:
 		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
 	}
@@ -385,11 +505,45 @@ func (r *Reactor) processChunkCh() {
 	}
 }
 
+// processBlockCh initiates a blocking process where we listen for and handle
+// envelopes on the LightBlockChannel. Any error encountered during message
+// execution will result in a PeerError being sent on the LightBlockChannel.
+// When the reactor is stopped, we will catch the signal and close the p2p
+// Channel gracefully.
+func (r *Reactor) processBlockCh() {
+	defer r.blockCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.blockCh.In:
+			if err := r.handleMessage(r.blockCh.ID, envelope); err != nil {
+				r.Logger.Error("failed to process message", "ch_id", r.blockCh.ID, "envelope", envelope, "err", err)
+				r.blockCh.Error <- p2p.PeerError{
+					NodeID: envelope.From,
+					Err:    err,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on light block channel; closing...")
+			return
+		}
+	}
+}
+
 // processPeerUpdate processes a PeerUpdate, returning an error upon failing to
 // handle the PeerUpdate or if a panic is recovered.
 func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 	r.Logger.Debug("received peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
 
+	switch peerUpdate.Status {
+	case p2p.PeerStatusUp:
+		r.dispatcher.addPeer(peerUpdate.NodeID)
+
+	case p2p.PeerStatusDown:
+		r.dispatcher.removePeer(peerUpdate.NodeID)
+	}
+
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
@@ -470,7 +624,8 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 
-	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out, r.chunkCh.Out, r.tempDir)
+	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out, r.chunkCh.Out, r.tempDir,
+		r.metrics, r.progress)
 	r.mtx.Unlock()
 
 	// request snapshots from all currently connected peers
@@ -488,3 +643,352 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 
 	return state, commit, err
 }
+
+// SyncFromSource runs a state sync from the given snapshot source instead of
+// from peers, returning the new state and last commit at the snapshot height.
+// The snapshot app hash is still verified via the state provider. The caller
+// must store the state and commit in the state database and block store.
+func (r *Reactor) SyncFromSource(stateProvider StateProvider, source SnapshotSource) (sm.State, *types.Commit, error) {
+	r.mtx.Lock()
+	if r.syncer != nil {
+		r.mtx.Unlock()
+		return sm.State{}, nil, errors.New("a state sync is already in progress")
+	}
+
+	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out, r.chunkCh.Out, r.tempDir,
+		r.metrics, r.progress)
+	r.syncer.source = source
+	r.mtx.Unlock()
+
+	r.Logger.Info("restoring snapshot from source", "source", source)
+	state, commit, err := r.syncer.SyncSource()
+
+	r.mtx.Lock()
+	r.syncer = nil
+	r.mtx.Unlock()
+
+	return state, commit, err
+}
+
+// Progress returns the progress of the current or last snapshot restoration.
+func (r *Reactor) Progress() Progress {
+	return r.progress.Progress()
+}
+
+// fetchLightBlock loads the light block at the given height from the local
+// stores. It returns nil if the block store does not have the block header.
+func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
+	h := int64(height)
+
+	blockMeta := r.blockStore.LoadBlockMeta(h)
+	if blockMeta == nil {
+		return nil, nil
+	}
+
+	commit := r.blockStore.LoadBlockCommit(h)
+	if commit == nil {
+		commit = r.blockStore.LoadSeenCommit(h)
+	}
+	if commit == nil {
+		return nil, nil
+	}
+
+	vals, err := r.stateStore.LoadValidators(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{
+			Header: &blockMeta.Header,
+			Commit: commit,
+		},
+		ValidatorSet: vals,
+	}, nil
+}
+
+// Backfill fetches, verifies and stores the headers, commits and validator sets
+// of the blocks preceding a state synced height, such that the node has enough
+// history to verify evidence and serve light clients. If depth is zero, it
+// backfills the evidence max-age window of the consensus parameters, i.e. until
+// both the height and the time limit have been passed. Otherwise, it backfills
+// depth blocks. Headers are verified by following the hash chain back from the
+// trusted last block ID of the state.
+func (r *Reactor) Backfill(state sm.State, depth int64) error {
+	params := state.ConsensusParams.Evidence
+	stopHeight := state.LastBlockHeight - params.MaxAgeNumBlocks
+	stopTime := state.LastBlockTime.Add(-params.MaxAgeDuration)
+	if depth > 0 {
+		// all blocks are older than the last block, so only the height matters
+		stopHeight = state.LastBlockHeight - depth + 1
+		stopTime = state.LastBlockTime
+	}
+
+	initialHeight := state.InitialHeight
+	if initialHeight < 1 {
+		initialHeight = 1
+	}
+	if stopHeight < initialHeight {
+		stopHeight = initialHeight
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return r.backfill(
+		ctx,
+		state.ChainID,
+		state.LastBlockHeight,
+		stopHeight,
+		initialHeight,
+		state.LastBlockID,
+		stopTime,
+	)
+}
+
+// lightBlockResult is a light block fetched from a peer while backfilling, or
+// the error fetching it.
+type lightBlockResult struct {
+	lightBlock *types.LightBlock
+	peer       p2p.NodeID
+	err        error
+}
+
+// backfill fetches light blocks from startHeight downwards, verifying each one
+// against the hash chain starting at trustedBlockID and storing it, until a
+// block at or below stopHeight with a time at or before stopTime has been
+// stored, or initialHeight is reached. Light blocks are fetched concurrently,
+// but verified and stored in descending height order. It fails with
+// errNoPeersWithBlock if a light block can't be fetched and verified within
+// backfillMaxAttempts requests.
+func (r *Reactor) backfill(
+	ctx context.Context,
+	chainID string,
+	startHeight, stopHeight, initialHeight int64,
+	trustedBlockID types.BlockID,
+	stopTime time.Time,
+) error {
+	r.Logger.Info("starting backfill process", "startHeight", startHeight,
+		"stopHeight", stopHeight, "stopTime", stopTime, "trustedBlockID", trustedBlockID)
+
+	// Workers must have exited once we return, since the channels they send on
+	// are closed when the reactor stops.
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(ctx)
+	defer wg.Wait()
+	defer cancel()
+
+	var (
+		heightCh = make(chan int64)
+		resultCh = make(chan lightBlockResult, backfillMaxPending)
+		// tokens bound the number of heights being fetched or waiting for
+		// verification, such that fetchers can't run arbitrarily far ahead.
+		tokens = make(chan struct{}, backfillMaxPending)
+	)
+	for i := 0; i < backfillMaxPending; i++ {
+		tokens <- struct{}{}
+	}
+
+	wg.Add(1 + backfillFetchers)
+	go func() {
+		defer wg.Done()
+		defer close(heightCh)
+		for height := startHeight; height >= initialHeight; height-- {
+			select {
+			case <-tokens:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case heightCh <- height:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < backfillFetchers; i++ {
+		go func() {
+			defer wg.Done()
+			for height := range heightCh {
+				lb, peer, err := r.requestLightBlock(ctx, chainID, height)
+				if ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case resultCh <- lightBlockResult{lightBlock: lb, peer: peer, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	var (
+		pending          = make(map[int64]lightBlockResult)
+		lastHeader       *types.Header
+		lastBlockID      = trustedBlockID
+		lastValidatorSet *types.ValidatorSet
+		lastChangeHeight = startHeight
+	)
+	for height := startHeight; height >= initialHeight; height-- {
+		result, ok := pending[height]
+		for !ok {
+			select {
+			case res := <-resultCh:
+				if res.err != nil {
+					return res.err
+				}
+				pending[res.lightBlock.Height] = res
+
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			result, ok = pending[height]
+		}
+		delete(pending, height)
+
+		lb := result.lightBlock
+		for attempt := 1; ; attempt++ {
+			err := verifyBackfilledBlock(chainID, lb, lastHeader, lastBlockID)
+			if err == nil {
+				break
+			}
+			if attempt >= backfillMaxAttempts {
+				return fmt.Errorf("light block at height %v failed verification %v times: %w",
+					height, attempt, errNoPeersWithBlock)
+			}
+
+			r.Logger.Info("received invalid light block; refetching", "height", height,
+				"peer", result.peer, "err", err)
+			select {
+			case r.blockCh.Error <- p2p.PeerError{NodeID: result.peer, Err: err}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			lb, result.peer, err = r.requestLightBlock(ctx, chainID, height)
+			if err != nil {
+				return err
+			}
+		}
+		tokens <- struct{}{}
+
+		if err := r.blockStore.SaveSignedHeader(lb.SignedHeader, lastBlockID); err != nil {
+			return fmt.Errorf("failed to save signed header at height %v: %w", height, err)
+		}
+
+		// Validator sets are stored once per range of heights they were active
+		// for, which we only know once the set changes (or we're done).
+		if lastValidatorSet != nil && !bytes.Equal(lb.ValidatorsHash, lastValidatorSet.Hash()) {
+			if err := r.stateStore.SaveValidatorSets(height+1, lastChangeHeight, lastValidatorSet); err != nil {
+				return fmt.Errorf("failed to save validator sets: %w", err)
+			}
+			lastChangeHeight = height
+		}
+
+		lastHeader = lb.Header
+		lastBlockID = lb.LastBlockID
+		lastValidatorSet = lb.ValidatorSet
+
+		r.Logger.Debug("backfilled block", "height", height, "time", lb.Time, "peer", result.peer)
+
+		if height <= stopHeight && !lb.Time.After(stopTime) {
+			break
+		}
+	}
+
+	if lastHeader == nil {
+		return nil
+	}
+
+	if err := r.stateStore.SaveValidatorSets(lastHeader.Height, lastChangeHeight, lastValidatorSet); err != nil {
+		return fmt.Errorf("failed to save validator sets: %w", err)
+	}
+
+	r.Logger.Info("successfully completed backfill process", "startHeight", startHeight,
+		"endHeight", lastHeader.Height)
+	return nil
+}
+
+// requestLightBlock requests the light block at the given height from peers
+// until one returns a basically valid light block, or the context is canceled.
+// It returns errNoPeersWithBlock after backfillMaxAttempts failed requests.
+func (r *Reactor) requestLightBlock(ctx context.Context, chainID string, height int64) (*types.LightBlock, p2p.NodeID, error) {
+	for attempt := 1; attempt <= backfillMaxAttempts; attempt++ {
+		reqCtx, cancel := context.WithTimeout(ctx, backfillRequestTimeout)
+		lb, peer, err := r.dispatcher.LightBlock(reqCtx, height)
+		cancel()
+		switch {
+		case ctx.Err() != nil:
+			return nil, "", ctx.Err()
+
+		case errors.Is(err, errDispatcherClosed):
+			return nil, "", err
+
+		case err != nil:
+			r.Logger.Debug("failed to fetch light block", "height", height, "peer", peer, "err", err)
+
+		case lb == nil:
+			r.Logger.Debug("peer does not have light block", "height", height, "peer", peer)
+
+		case lb.Height != height:
+			r.Logger.Info("peer sent light block for unexpected height", "height", height,
+				"received", lb.Height, "peer", peer)
+			r.blockCh.Error <- p2p.PeerError{
+				NodeID: peer,
+				Err:    fmt.Errorf("expected light block at height %v, got %v", height, lb.Height),
+			}
+
+		default:
+			if err := lb.ValidateBasic(chainID); err != nil {
+				r.Logger.Info("peer sent invalid light block", "height", height, "peer", peer, "err", err)
+				r.blockCh.Error <- p2p.PeerError{NodeID: peer, Err: err}
+				continue
+			}
+			return lb, peer, nil
+		}
+
+		select {
+		case <-time.After(backfillRetryDelay):
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+	}
+	return nil, "", fmt.Errorf("light block at height %v not received after %v attempts: %w",
+		height, backfillMaxAttempts, errNoPeersWithBlock)
+}
+
+// verifyBackfilledBlock verifies that a light block is part of the hash chain
+// leading to the last verified header. If lastHeader is nil, the light block
+// is the first one and must match trustedBlockID, with its commit verified
+// against its validator set.
+func verifyBackfilledBlock(
+	chainID string,
+	lb *types.LightBlock,
+	lastHeader *types.Header,
+	trustedBlockID types.BlockID,
+) error {
+	if !bytes.Equal(lb.Hash(), trustedBlockID.Hash) {
+		return fmt.Errorf("expected header hash %X, got %X", trustedBlockID.Hash, lb.Hash())
+	}
+
+	if lastHeader == nil {
+		return lb.ValidatorSet.VerifyCommitLight(chainID, trustedBlockID, lb.Height, lb.Commit)
+	}
+
+	if !bytes.Equal(lb.Commit.Hash(), lastHeader.LastCommitHash) {
+		return fmt.Errorf("expected commit hash %X, got %X", lastHeader.LastCommitHash, lb.Commit.Hash())
+	}
+
+	return nil
+}
@@ -7,12 +7,21 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	dbm "github.com/klyed/tm-db"
+
 	abci "github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/crypto/tmhash"
 	"github.com/klyed/tendermint/libs/log"
+	tmrand "github.com/klyed/tendermint/libs/rand"
 	"github.com/klyed/tendermint/p2p"
 	ssproto "github.com/klyed/tendermint/proto/tendermint/statesync"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
 	proxymocks "github.com/klyed/tendermint/proxy/mocks"
+	sm "github.com/klyed/tendermint/state"
 	"github.com/klyed/tendermint/statesync/mocks"
+	"github.com/klyed/tendermint/store"
+	"github.com/klyed/tendermint/types"
+	"github.com/klyed/tendermint/version"
 )
 
 type reactorTestSuite struct {
@@ -33,7 +42,16 @@ type reactorTestSuite struct {
 	chunkOutCh     chan p2p.Envelope
 	chunkPeerErrCh chan p2p.PeerError
 
-	peerUpdates *p2p.PeerUpdates
+	blockChannel   *p2p.Channel
+	blockInCh      chan p2p.Envelope
+	blockOutCh     chan p2p.Envelope
+	blockPeerErrCh chan p2p.PeerError
+
+	peerUpdateCh chan p2p.PeerUpdate
+	peerUpdates  *p2p.PeerUpdates
+
+	stateStore sm.Store
+	blockStore *store.BlockStore
 }
 
 func setup(
@@ -62,12 +80,19 @@ func setup(
 		chunkInCh:         make(chan p2p.Envelope, chBuf),
 		chunkOutCh:        make(chan p2p.Envelope, chBuf),
 		chunkPeerErrCh:    make(chan p2p.PeerError, chBuf),
-		peerUpdates:       p2p.NewPeerUpdates(make(chan p2p.PeerUpdate)),
+		blockInCh:         make(chan p2p.Envelope, chBuf),
+		blockOutCh:        make(chan p2p.Envelope, chBuf),
+		blockPeerErrCh:    make(chan p2p.PeerError, chBuf),
+		peerUpdateCh:      make(chan p2p.PeerUpdate, chBuf),
 		conn:              conn,
 		connQuery:         connQuery,
 		stateProvider:     stateProvider,
+		stateStore:        sm.NewStore(dbm.NewMemDB()),
+		blockStore:        store.NewBlockStore(dbm.NewMemDB()),
 	}
 
+	rts.peerUpdates = p2p.NewPeerUpdates(rts.peerUpdateCh)
+
 	rts.snapshotChannel = p2p.NewChannel(
 		SnapshotChannel,
 		new(ssproto.Message),
@@ -84,14 +109,26 @@ func setup(
 		rts.chunkPeerErrCh,
 	)
 
+	rts.blockChannel = p2p.NewChannel(
+		LightBlockChannel,
+		new(ssproto.Message),
+		rts.blockInCh,
+		rts.blockOutCh,
+		rts.blockPeerErrCh,
+	)
+
 	rts.reactor = NewReactor(
 		log.NewNopLogger(),
 		conn,
 		connQuery,
 		rts.snapshotChannel,
 		rts.chunkChannel,
+		rts.blockChannel,
 		rts.peerUpdates,
+		rts.stateStore,
+		rts.blockStore,
 		"",
+		NopMetrics(),
 	)
 
 	rts.syncer = newSyncer(
@@ -102,6 +139,8 @@ func setup(
 		rts.snapshotOutCh,
 		rts.chunkOutCh,
 		"",
+		NopMetrics(),
+		newSyncProgress(NopMetrics()),
 	)
 
 	require.NoError(t, rts.reactor.Start())
@@ -284,3 +323,246 @@ func retryUntil(t *testing.T, fn func() bool, timeout time.Duration) {
 		require.NoError(t, ctx.Err())
 	}
 }
+
+// mockLightBlocks generates a chain of light blocks at heights 1 to n, one
+// second apart starting at startTime. The validator set changes halfway.
+func mockLightBlocks(t *testing.T, chainID string, n int64, startTime time.Time) map[int64]*types.LightBlock {
+	t.Helper()
+
+	vals1, privVals1 := types.RandValidatorSet(3, 10)
+	vals2, privVals2 := types.RandValidatorSet(4, 10)
+
+	var (
+		lightBlocks = make(map[int64]*types.LightBlock, n)
+		lastBlockID types.BlockID
+		lastCommit  = &types.Commit{}
+	)
+	for height := int64(1); height <= n; height++ {
+		vals, privVals, nextVals := vals1, privVals1, vals1
+		if height > n/2 {
+			vals, privVals, nextVals = vals2, privVals2, vals2
+		} else if height == n/2 {
+			nextVals = vals2
+		}
+
+		header := &types.Header{
+			Version:            version.Consensus{Block: version.BlockProtocol},
+			ChainID:            chainID,
+			Height:             height,
+			Time:               startTime.Add(time.Duration(height) * time.Second),
+			LastBlockID:        lastBlockID,
+			LastCommitHash:     lastCommit.Hash(),
+			DataHash:           tmhash.Sum([]byte("data")),
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: nextVals.Hash(),
+			ConsensusHash:      tmhash.Sum([]byte("consensus")),
+			AppHash:            tmrand.Bytes(32),
+			LastResultsHash:    tmhash.Sum([]byte("results")),
+			EvidenceHash:       tmhash.Sum([]byte("evidence")),
+			ProposerAddress:    vals.Validators[0].Address,
+		}
+		blockID := types.BlockID{
+			Hash:          header.Hash(),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(32)},
+		}
+
+		voteSet := types.NewVoteSet(chainID, height, 0, tmproto.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, header.Time)
+		require.NoError(t, err)
+
+		lightBlocks[height] = &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: vals,
+		}
+		lastBlockID = blockID
+		lastCommit = commit
+	}
+
+	return lightBlocks
+}
+
+// serveLightBlocks responds to light block requests sent to the given peers
+// until the test ends. Peers not in lightBlocks respond with nil light blocks.
+func serveLightBlocks(t *testing.T, rts *reactorTestSuite, lightBlocks map[p2p.NodeID]map[int64]*types.LightBlock) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() {
+		for {
+			select {
+			case envelope := <-rts.blockOutCh:
+				msg, ok := envelope.Message.(*ssproto.LightBlockRequest)
+				require.True(t, ok)
+
+				var lbproto *tmproto.LightBlock
+				if lb, ok := lightBlocks[envelope.To][int64(msg.Height)]; ok {
+					var err error
+					lbproto, err = lb.ToProto()
+					require.NoError(t, err)
+				}
+
+				select {
+				case rts.blockInCh <- p2p.Envelope{
+					From:    envelope.To,
+					Message: &ssproto.LightBlockResponse{LightBlock: lbproto},
+				}:
+				case <-ctx.Done():
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func TestReactor_LightBlockRequest(t *testing.T) {
+	rts := setup(t, nil, nil, nil, 2)
+
+	lightBlocks := mockLightBlocks(t, "test-chain", 3, time.Now())
+	lb := lightBlocks[2]
+	require.NoError(t, rts.blockStore.SaveSignedHeader(lb.SignedHeader, lightBlocks[3].LastBlockID))
+	require.NoError(t, rts.stateStore.SaveValidatorSets(2, 2, lb.ValidatorSet))
+
+	testcases := map[string]struct {
+		height uint64
+		expect *types.LightBlock
+	}{
+		"stored":  {2, lb},
+		"missing": {3, nil},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			rts.blockInCh <- p2p.Envelope{
+				From:    p2p.NodeID("aa"),
+				Message: &ssproto.LightBlockRequest{Height: tc.height},
+			}
+
+			response := <-rts.blockOutCh
+			require.Equal(t, p2p.NodeID("aa"), response.To)
+
+			msg, ok := response.Message.(*ssproto.LightBlockResponse)
+			require.True(t, ok)
+			if tc.expect == nil {
+				require.Nil(t, msg.LightBlock)
+				return
+			}
+
+			received, err := types.LightBlockFromProto(msg.LightBlock)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect.Hash(), received.Hash())
+			require.Equal(t, tc.expect.Commit.Hash(), received.Commit.Hash())
+			require.Equal(t, tc.expect.ValidatorSet.Hash(), received.ValidatorSet.Hash())
+		})
+	}
+}
+
+func TestReactor_Backfill(t *testing.T) {
+	const chainID = "test-chain"
+	startTime := time.Now().Add(-time.Hour)
+	lightBlocks := mockLightBlocks(t, chainID, 20, startTime)
+
+	testcases := map[string]struct {
+		stopHeight int64
+		stopTime   time.Time
+		expectLow  int64
+	}{
+		"stop height":        {14, lightBlocks[20].Time, 14},
+		"stop time":          {18, lightBlocks[12].Time, 12},
+		"until initial":      {1, lightBlocks[20].Time, 1},
+		"initial before end": {1, startTime, 1},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			rts := setup(t, nil, nil, nil, 10)
+			serveLightBlocks(t, rts, map[p2p.NodeID]map[int64]*types.LightBlock{
+				"aa": lightBlocks,
+				"bb": {},
+			})
+			for _, peer := range []p2p.NodeID{"aa", "bb"} {
+				rts.peerUpdateCh <- p2p.PeerUpdate{NodeID: peer, Status: p2p.PeerStatusUp}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			trustedBlockID := lightBlocks[20].Commit.BlockID
+			err := rts.reactor.backfill(ctx, chainID, 20, tc.stopHeight, 1, trustedBlockID, tc.stopTime)
+			require.NoError(t, err)
+
+			for height := int64(20); height >= 1; height-- {
+				meta := rts.blockStore.LoadBlockMeta(height)
+				if height < tc.expectLow {
+					require.Nil(t, meta, "height %v", height)
+					continue
+				}
+				require.NotNil(t, meta, "height %v", height)
+				require.Equal(t, lightBlocks[height].Hash(), meta.Header.Hash())
+
+				commit := rts.blockStore.LoadBlockCommit(height)
+				require.NotNil(t, commit)
+				require.Equal(t, lightBlocks[height].Commit.Hash(), commit.Hash())
+
+				vals, err := rts.stateStore.LoadValidators(height)
+				require.NoError(t, err)
+				require.Equal(t, lightBlocks[height].ValidatorSet.Hash(), vals.Hash())
+			}
+		})
+	}
+}
+
+func TestReactor_Backfill_InvalidBlocks(t *testing.T) {
+	const chainID = "test-chain"
+	lightBlocks := mockLightBlocks(t, chainID, 10, time.Now())
+	forkedBlocks := mockLightBlocks(t, chainID, 10, time.Now())
+
+	rts := setup(t, nil, nil, nil, 10)
+	serveLightBlocks(t, rts, map[p2p.NodeID]map[int64]*types.LightBlock{
+		"aa": lightBlocks,
+		"bb": forkedBlocks,
+	})
+	for _, peer := range []p2p.NodeID{"aa", "bb"} {
+		rts.peerUpdateCh <- p2p.PeerUpdate{NodeID: peer, Status: p2p.PeerStatusUp}
+	}
+
+	// drain peer errors, which are expected for the peer serving the fork
+	go func() {
+		for peerErr := range rts.blockPeerErrCh {
+			require.Equal(t, p2p.NodeID("bb"), peerErr.NodeID)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err := rts.reactor.backfill(ctx, chainID, 10, 1, 1, lightBlocks[10].Commit.BlockID, time.Now())
+	require.NoError(t, err)
+
+	for height := int64(10); height >= 1; height-- {
+		meta := rts.blockStore.LoadBlockMeta(height)
+		require.NotNil(t, meta)
+		require.Equal(t, lightBlocks[height].Hash(), meta.Header.Hash())
+	}
+}
+
+func TestReactor_Backfill_NoPeersWithBlock(t *testing.T) {
+	const chainID = "test-chain"
+	lightBlocks := mockLightBlocks(t, chainID, 10, time.Now())
+
+	rts := setup(t, nil, nil, nil, 10)
+	serveLightBlocks(t, rts, map[p2p.NodeID]map[int64]*types.LightBlock{
+		"aa": {},
+	})
+	rts.peerUpdateCh <- p2p.PeerUpdate{NodeID: "aa", Status: p2p.PeerStatusUp}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the peer never has the blocks: backfill gives up instead of retrying forever
+	err := rts.reactor.backfill(ctx, chainID, 10, 1, 1, lightBlocks[10].Commit.BlockID, time.Now())
+	require.ErrorIs(t, err, errNoPeersWithBlock)
+	require.NoError(t, ctx.Err())
+}
@@ -0,0 +1,300 @@
+package statesync
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	tmsync "github.com/klyed/tendermint/libs/sync"
+	"github.com/klyed/tendermint/p2p"
+)
+
+const (
+	// minChunkFetchers is the minimum number of concurrent chunk requests.
+	minChunkFetchers = 1
+	// maxChunkFetchers is the maximum number of concurrent chunk requests.
+	maxChunkFetchers = 32
+
+	// minChunkRequestTimeout and maxChunkRequestTimeout bound the adaptive chunk
+	// request timeout, which defaults to chunkRequestTimeout for unknown peers.
+	minChunkRequestTimeout = 2 * time.Second
+	maxChunkRequestTimeout = time.Minute
+
+	// chunkTimeoutFactor is the multiple of a request's expected duration after
+	// which it times out.
+	chunkTimeoutFactor = 4
+
+	// throughputSmoothing is the weight of a new sample in the moving averages of
+	// peer throughput and chunk size.
+	throughputSmoothing = 0.3
+)
+
+// chunkRequest is an in-flight chunk request.
+type chunkRequest struct {
+	peer p2p.NodeID
+	sent time.Time
+}
+
+// peerThroughput tracks the observed throughput of a peer.
+type peerThroughput struct {
+	rate     float64 // moving average in bytes per second, 0 if unknown
+	inFlight int
+}
+
+// chunkScheduler schedules chunk requests across peers. It tracks the observed
+// throughput of each peer to prefer fast peers and size request timeouts, and
+// adapts the number of concurrent requests: the window grows additively while
+// chunks arrive in time, and is halved whenever a request times out.
+type chunkScheduler struct {
+	metrics *Metrics
+
+	mtx       tmsync.Mutex
+	peers     map[p2p.NodeID]*peerThroughput
+	requests  map[uint32]chunkRequest // in-flight requests, by chunk index
+	window    float64                 // target number of concurrent requests
+	inFlight  int                     // number of acquired request slots
+	chunkSize float64                 // moving average of chunk sizes, in bytes
+	available chan struct{}           // signals that a request slot may have been released
+}
+
+// newChunkScheduler creates a new chunk scheduler.
+func newChunkScheduler(metrics *Metrics) *chunkScheduler {
+	s := &chunkScheduler{
+		metrics:   metrics,
+		peers:     make(map[p2p.NodeID]*peerThroughput),
+		requests:  make(map[uint32]chunkRequest),
+		window:    chunkFetchers,
+		available: make(chan struct{}, 1),
+	}
+	s.metrics.ChunkFetchers.Set(s.window)
+	return s
+}
+
+// acquire blocks until a request slot is available within the current window,
+// or the context is done. Callers must call release() when done.
+func (s *chunkScheduler) acquire(ctx context.Context) error {
+	for {
+		s.mtx.Lock()
+		if s.inFlight < int(s.window) {
+			s.inFlight++
+			s.mtx.Unlock()
+			return nil
+		}
+		s.mtx.Unlock()
+
+		select {
+		case <-s.available:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release releases a request slot acquired via acquire().
+func (s *chunkScheduler) release() {
+	s.mtx.Lock()
+	s.inFlight--
+	s.mtx.Unlock()
+
+	s.signalAvailable()
+}
+
+// signalAvailable wakes up a caller waiting for a request slot, if any.
+func (s *chunkScheduler) signalAvailable() {
+	select {
+	case s.available <- struct{}{}:
+	default:
+	}
+}
+
+// selectPeer returns the candidate with the highest expected throughput for a
+// new request, taking into account its requests already in flight. Peers with
+// no observed throughput are assumed to be average, such that they are tried
+// as well. It returns an empty ID if there are no candidates.
+func (s *chunkScheduler) selectPeer(candidates []p2p.NodeID) p2p.NodeID {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	average := s.averageRate()
+
+	var (
+		best      p2p.NodeID
+		bestScore = -1.0
+	)
+	// iterate in random order, such that ties are broken randomly
+	for _, i := range rand.Perm(len(candidates)) { // nolint:gosec // G404: Use of weak random number generator
+		peer := candidates[i]
+
+		rate, inFlight := average, 0
+		if pt, ok := s.peers[peer]; ok {
+			inFlight = pt.inFlight
+			if pt.rate > 0 {
+				rate = pt.rate
+			}
+		}
+
+		if score := rate / float64(inFlight+1); score > bestScore {
+			best, bestScore = peer, score
+		}
+	}
+
+	return best
+}
+
+// averageRate returns the average observed throughput across peers, or 1 if
+// unknown. The caller must hold the mutex lock.
+func (s *chunkScheduler) averageRate() float64 {
+	var (
+		sum   float64
+		count int
+	)
+	for _, pt := range s.peers {
+		if pt.rate > 0 {
+			sum += pt.rate
+			count++
+		}
+	}
+	if count == 0 {
+		return 1
+	}
+	return sum / float64(count)
+}
+
+// requested records a chunk request sent to a peer, returning the timeout
+// after which the request should be considered failed.
+func (s *chunkScheduler) requested(peer p2p.NodeID, index uint32) time.Duration {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.clearRequest(index)
+
+	pt := s.peer(peer)
+	pt.inFlight++
+	s.requests[index] = chunkRequest{peer: peer, sent: time.Now()}
+
+	if pt.rate == 0 || s.chunkSize == 0 {
+		return chunkRequestTimeout
+	}
+
+	// The peer's bandwidth is shared by all of its requests in flight.
+	expected := s.chunkSize * float64(pt.inFlight) / pt.rate
+	timeout := time.Duration(expected * chunkTimeoutFactor * float64(time.Second))
+	switch {
+	case timeout < minChunkRequestTimeout:
+		return minChunkRequestTimeout
+	case timeout > maxChunkRequestTimeout:
+		return maxChunkRequestTimeout
+	default:
+		return timeout
+	}
+}
+
+// received records the arrival of a chunk from a peer, updating its throughput
+// and growing the request window. Chunks which weren't requested from the peer
+// are ignored.
+func (s *chunkScheduler) received(peer p2p.NodeID, index uint32, size int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	req, ok := s.requests[index]
+	if !ok || req.peer != peer {
+		return
+	}
+	s.clearRequest(index)
+
+	elapsed := time.Since(req.sent).Seconds()
+	if elapsed <= 0 {
+		elapsed = 1e-6
+	}
+	sample := float64(size) / elapsed
+	s.metrics.ChunkFetchBytesPerSecond.Observe(sample)
+
+	pt := s.peer(peer)
+	if pt.rate == 0 {
+		pt.rate = sample
+	} else {
+		pt.rate = throughputSmoothing*sample + (1-throughputSmoothing)*pt.rate
+	}
+	if s.chunkSize == 0 {
+		s.chunkSize = float64(size)
+	} else {
+		s.chunkSize = throughputSmoothing*float64(size) + (1-throughputSmoothing)*s.chunkSize
+	}
+
+	s.setWindow(s.window + 1/s.window)
+}
+
+// timedOut records a chunk request to a peer which timed out, halving both the
+// peer's throughput estimate and the request window.
+func (s *chunkScheduler) timedOut(peer p2p.NodeID, index uint32) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	req, ok := s.requests[index]
+	if !ok || req.peer != peer {
+		return
+	}
+	s.clearRequest(index)
+	s.metrics.ChunkRequestTimeouts.Add(1)
+
+	if pt := s.peer(peer); pt.rate > 0 {
+		pt.rate /= 2
+	}
+
+	s.setWindow(s.window / 2)
+}
+
+// removePeer removes a peer's throughput statistics.
+func (s *chunkScheduler) removePeer(peer p2p.NodeID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.peers, peer)
+	for index, req := range s.requests {
+		if req.peer == peer {
+			delete(s.requests, index)
+		}
+	}
+}
+
+// concurrency returns the current number of allowed concurrent requests.
+func (s *chunkScheduler) concurrency() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return int(s.window)
+}
+
+// peer returns the throughput statistics of a peer, creating them if needed.
+// The caller must hold the mutex lock.
+func (s *chunkScheduler) peer(peer p2p.NodeID) *peerThroughput {
+	pt, ok := s.peers[peer]
+	if !ok {
+		pt = &peerThroughput{}
+		s.peers[peer] = pt
+	}
+	return pt
+}
+
+// clearRequest removes an in-flight request, if any. The caller must hold the
+// mutex lock.
+func (s *chunkScheduler) clearRequest(index uint32) {
+	req, ok := s.requests[index]
+	if !ok {
+		return
+	}
+	delete(s.requests, index)
+	if pt, ok := s.peers[req.peer]; ok && pt.inFlight > 0 {
+		pt.inFlight--
+	}
+}
+
+// setWindow sets the request window within its bounds. The caller must hold
+// the mutex lock.
+func (s *chunkScheduler) setWindow(window float64) {
+	s.window = math.Max(minChunkFetchers, math.Min(maxChunkFetchers, window))
+	s.metrics.ChunkFetchers.Set(math.Floor(s.window))
+
+	// a larger window may allow waiting fetchers to proceed
+	s.signalAvailable()
+}
@@ -0,0 +1,114 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/klyed/tendermint/p2p"
+)
+
+func TestChunkScheduler_Window(t *testing.T) {
+	s := newChunkScheduler(NopMetrics())
+	require.Equal(t, chunkFetchers, s.concurrency())
+
+	// the window limits the number of acquired slots
+	for i := 0; i < chunkFetchers; i++ {
+		require.NoError(t, s.acquire(context.Background()))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, s.acquire(ctx))
+
+	// chunks arriving in time grow the window by about one per window's worth
+	// of chunks, unblocking a waiting fetcher
+	acquired := make(chan error, 1)
+	go func() { acquired <- s.acquire(context.Background()) }()
+	for i := uint32(0); i <= chunkFetchers; i++ {
+		s.requested("a", i)
+		s.received("a", i, 1024)
+	}
+	require.Equal(t, chunkFetchers+1, s.concurrency())
+	require.NoError(t, <-acquired)
+
+	// timeouts halve the window, down to the minimum
+	s.requested("a", 10)
+	s.timedOut("a", 10)
+	require.Equal(t, (chunkFetchers+1)/2, s.concurrency())
+	for i := uint32(11); i < 20; i++ {
+		s.requested("a", i)
+		s.timedOut("a", i)
+	}
+	require.Equal(t, minChunkFetchers, s.concurrency())
+
+	// the window never exceeds the maximum
+	for i := uint32(0); i < 2000; i++ {
+		s.requested("a", i)
+		s.received("a", i, 1024)
+	}
+	require.Equal(t, maxChunkFetchers, s.concurrency())
+}
+
+func TestChunkScheduler_SelectPeer(t *testing.T) {
+	s := newChunkScheduler(NopMetrics())
+	require.Equal(t, p2p.NodeID(""), s.selectPeer(nil))
+	require.Equal(t, p2p.NodeID("a"), s.selectPeer([]p2p.NodeID{"a"}))
+
+	// a fast and a slow peer
+	s.requested("fast", 0)
+	s.requests[0] = chunkRequest{peer: "fast", sent: time.Now().Add(-100 * time.Millisecond)}
+	s.received("fast", 0, 1e6)
+	s.requested("slow", 1)
+	s.requests[1] = chunkRequest{peer: "slow", sent: time.Now().Add(-10 * time.Second)}
+	s.received("slow", 1, 1e6)
+
+	require.Equal(t, p2p.NodeID("fast"), s.selectPeer([]p2p.NodeID{"slow", "fast"}))
+
+	// unknown peers are assumed to be average, so they beat slow peers
+	require.Equal(t, p2p.NodeID("new"), s.selectPeer([]p2p.NodeID{"slow", "new"}))
+
+	// requests in flight share a peer's bandwidth
+	for i := uint32(2); i < 200; i++ {
+		s.requested("fast", i)
+	}
+	require.Equal(t, p2p.NodeID("slow"), s.selectPeer([]p2p.NodeID{"slow", "fast"}))
+
+	// removed peers lose their statistics and requests
+	s.removePeer("fast")
+	require.Empty(t, s.requests)
+}
+
+func TestChunkScheduler_Timeout(t *testing.T) {
+	s := newChunkScheduler(NopMetrics())
+
+	// unknown peers get the default timeout
+	require.Equal(t, chunkRequestTimeout, s.requested("a", 0))
+
+	// 1 MB in 1s
+	s.requests[0] = chunkRequest{peer: "a", sent: time.Now().Add(-time.Second)}
+	s.received("a", 0, 1e6)
+	timeout := s.requested("a", 1)
+	require.InDelta(t, float64(chunkTimeoutFactor*time.Second), float64(timeout), float64(100*time.Millisecond))
+
+	// the timeout grows with the number of requests in flight to the peer
+	timeout = s.requested("a", 2)
+	require.InDelta(t, float64(2*chunkTimeoutFactor*time.Second), float64(timeout), float64(100*time.Millisecond))
+
+	// and is bounded
+	for i := uint32(3); i < 100; i++ {
+		timeout = s.requested("a", i)
+	}
+	require.Equal(t, maxChunkRequestTimeout, timeout)
+
+	// fast peers get the minimum timeout
+	s.requested("b", 200)
+	s.requests[200] = chunkRequest{peer: "b", sent: time.Now().Add(-time.Millisecond)}
+	s.received("b", 200, 1e6)
+	require.Equal(t, minChunkRequestTimeout, s.requested("b", 201))
+
+	// responses from other peers than the requested one are ignored
+	s.received("a", 201, 1e6)
+	require.Equal(t, p2p.NodeID("b"), s.requests[201].peer)
+}
@@ -0,0 +1,263 @@
+package statesync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	abci "github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/p2p"
+	"github.com/klyed/tendermint/proxy"
+)
+
+const (
+	// snapshotManifestFile is the name of the file holding the snapshot metadata
+	// within an exported snapshot directory.
+	snapshotManifestFile = "snapshot.json"
+
+	// snapshotChunkDir is the name of the directory holding the snapshot chunks
+	// within an exported snapshot directory.
+	snapshotChunkDir = "chunks"
+)
+
+// SnapshotSource is a source of snapshots other than the p2p network, such as
+// an exported snapshot directory on local disk or an S3-compatible object
+// store. Snapshots from a source are verified in the same way as snapshots
+// from peers, i.e. against the app hash obtained by the state provider.
+type SnapshotSource interface {
+	// ListSnapshots returns all snapshots available in the source.
+	ListSnapshots(ctx context.Context) ([]*abci.Snapshot, error)
+	// LoadChunk loads the chunk with the given index of the given snapshot.
+	LoadChunk(ctx context.Context, height uint64, format uint32, index uint32) ([]byte, error)
+	// String returns a human-readable description of the source.
+	String() string
+}
+
+// NewSnapshotSource creates a snapshot source from a URL. Supported schemes
+// are file:// (or a plain path) for exported snapshot directories and s3://
+// for S3-compatible object stores, in which case the URL host is the bucket
+// and the URL path the key prefix.
+func NewSnapshotSource(rawURL string, s3Options S3Options) (SnapshotSource, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot source %q: %w", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case "", "file":
+		dir := u.Path
+		if u.Scheme == "" {
+			dir = rawURL
+		}
+		return NewDirSource(dir)
+
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid snapshot source %q: missing bucket", rawURL)
+		}
+		return NewS3Source(u.Host, strings.TrimPrefix(u.Path, "/"), s3Options)
+
+	default:
+		return nil, fmt.Errorf("unsupported snapshot source scheme %q", u.Scheme)
+	}
+}
+
+// snapshotPath returns the relative path of a snapshot within an export,
+// using the given separator-joining function.
+func snapshotPath(join func(...string) string, height uint64, format uint32) string {
+	return join(strconv.FormatUint(height, 10), strconv.FormatUint(uint64(format), 10))
+}
+
+// chunkPath returns the relative path of a snapshot chunk within an export.
+func chunkPath(join func(...string) string, height uint64, format uint32, index uint32) string {
+	return join(snapshotPath(join, height, format), snapshotChunkDir, strconv.FormatUint(uint64(index), 10))
+}
+
+// sortSnapshots sorts snapshots by descending height and format.
+func sortSnapshots(snapshots []*abci.Snapshot) {
+	sort.Slice(snapshots, func(i, j int) bool {
+		a := snapshots[i]
+		b := snapshots[j]
+
+		switch {
+		case a.Height != b.Height:
+			return a.Height > b.Height
+		default:
+			return a.Format > b.Format
+		}
+	})
+}
+
+// DirSource is a snapshot source reading from an exported snapshot directory,
+// as written by ExportSnapshots. The directory layout is:
+//
+//	<dir>/<height>/<format>/snapshot.json
+//	<dir>/<height>/<format>/chunks/<index>
+type DirSource struct {
+	dir string
+}
+
+var _ SnapshotSource = (*DirSource)(nil)
+
+// NewDirSource creates a new snapshot source for the given directory.
+func NewDirSource(dir string) (*DirSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("snapshot source %v is not a directory", dir)
+	}
+
+	return &DirSource{dir: dir}, nil
+}
+
+// ListSnapshots implements SnapshotSource.
+func (s *DirSource) ListSnapshots(ctx context.Context) ([]*abci.Snapshot, error) {
+	manifests, err := filepath.Glob(filepath.Join(s.dir, "*", "*", snapshotManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*abci.Snapshot, 0, len(manifests))
+	for _, manifest := range manifests {
+		bz, err := ioutil.ReadFile(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot manifest %v: %w", manifest, err)
+		}
+
+		snapshot := &abci.Snapshot{}
+		if err := json.Unmarshal(bz, snapshot); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot manifest %v: %w", manifest, err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sortSnapshots(snapshots)
+	return snapshots, nil
+}
+
+// LoadChunk implements SnapshotSource.
+func (s *DirSource) LoadChunk(ctx context.Context, height uint64, format uint32, index uint32) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.dir, chunkPath(filepath.Join, height, format, index)))
+}
+
+// String implements SnapshotSource.
+func (s *DirSource) String() string {
+	return "file://" + s.dir
+}
+
+// ExportSnapshots writes snapshots taken by the application into dir, using the
+// layout read by DirSource. If height is non-zero, only snapshots at that
+// height are exported. It returns the exported snapshots.
+func ExportSnapshots(
+	ctx context.Context,
+	conn proxy.AppConnSnapshot,
+	dir string,
+	height uint64,
+) ([]*abci.Snapshot, error) {
+	resp, err := conn.ListSnapshotsSync(ctx, abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	exported := make([]*abci.Snapshot, 0, len(resp.Snapshots))
+	for _, snapshot := range resp.Snapshots {
+		if height != 0 && snapshot.Height != height {
+			continue
+		}
+
+		if err := exportSnapshot(ctx, conn, dir, snapshot); err != nil {
+			return exported, err
+		}
+		exported = append(exported, snapshot)
+	}
+
+	if len(exported) == 0 {
+		return nil, errNoSnapshots
+	}
+
+	sortSnapshots(exported)
+	return exported, nil
+}
+
+// exportSnapshot writes a single snapshot and its chunks into dir. The manifest
+// is written last, such that a partially exported snapshot is never listed.
+func exportSnapshot(ctx context.Context, conn proxy.AppConnSnapshot, dir string, snapshot *abci.Snapshot) error {
+	snapshotDir := filepath.Join(dir, snapshotPath(filepath.Join, snapshot.Height, snapshot.Format))
+	if err := os.MkdirAll(filepath.Join(snapshotDir, snapshotChunkDir), 0700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	for index := uint32(0); index < snapshot.Chunks; index++ {
+		resp, err := conn.LoadSnapshotChunkSync(ctx, abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  index,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load chunk %v of snapshot at height %v: %w", index, snapshot.Height, err)
+		}
+		if resp.Chunk == nil {
+			return fmt.Errorf("app is missing chunk %v of snapshot at height %v", index, snapshot.Height)
+		}
+
+		path := filepath.Join(dir, chunkPath(filepath.Join, snapshot.Height, snapshot.Format, index))
+		if err := ioutil.WriteFile(path, resp.Chunk, 0600); err != nil {
+			return fmt.Errorf("failed to write chunk %v to file %v: %w", index, path, err)
+		}
+	}
+
+	bz, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(snapshotDir, snapshotManifestFile), bz, 0600)
+}
+
+// loadSnapshotsFromSource lists the snapshots of a source and adds them to the
+// snapshot pool, using the source as the sending peer. It returns the number of
+// snapshots added.
+func loadSnapshotsFromSource(ctx context.Context, source SnapshotSource, pool *snapshotPool) (int, error) {
+	snapshots, err := source.ListSnapshots(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list snapshots from %v: %w", source, err)
+	}
+	if len(snapshots) == 0 {
+		return 0, errNoSnapshots
+	}
+
+	added := 0
+	for _, s := range snapshots {
+		ok, err := pool.Add(sourcePeerID(source), &snapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		})
+		if err != nil {
+			return added, fmt.Errorf("failed to add snapshot at height %v: %w", s.Height, err)
+		}
+		if ok {
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+// sourcePeerID returns the pseudo peer ID used for snapshots and chunks
+// obtained from a snapshot source.
+func sourcePeerID(source SnapshotSource) p2p.NodeID {
+	return p2p.NodeID(source.String())
+}
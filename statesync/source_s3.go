@@ -0,0 +1,277 @@
+package statesync
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	abci "github.com/klyed/tendermint/abci/types"
+)
+
+const (
+	// s3DefaultEndpoint is the endpoint used when S3Options.Endpoint is empty.
+	s3DefaultEndpoint = "https://s3.amazonaws.com"
+
+	// s3DefaultRegion is the region used when S3Options.Region is empty.
+	s3DefaultRegion = "us-east-1"
+
+	// s3MaxObjectSize is the maximum size of an object fetched from S3. It
+	// matches the maximum chunk size accepted from peers.
+	s3MaxObjectSize = int64(chunkMsgSize)
+)
+
+// S3Options configures access to an S3-compatible object store.
+type S3Options struct {
+	// Endpoint is the base URL of the object store, e.g. http://localhost:9000.
+	// Objects are addressed path-style, i.e. <endpoint>/<bucket>/<key>.
+	Endpoint string
+	// Region is the region used for request signing.
+	Region string
+	// AccessKeyID and SecretAccessKey are the credentials used to sign requests
+	// with AWS Signature Version 4. If empty, requests are sent unsigned.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Source is a snapshot source reading from an S3-compatible object store. It
+// expects the same layout as DirSource, below the given key prefix.
+type S3Source struct {
+	bucket  string
+	prefix  string
+	opts    S3Options
+	client  *http.Client
+	baseURL *url.URL
+}
+
+var _ SnapshotSource = (*S3Source)(nil)
+
+// NewS3Source creates a new snapshot source for the given bucket and key prefix.
+func NewS3Source(bucket, prefix string, opts S3Options) (*S3Source, error) {
+	if opts.Endpoint == "" {
+		opts.Endpoint = s3DefaultEndpoint
+	}
+	if opts.Region == "" {
+		opts.Region = s3DefaultRegion
+	}
+
+	baseURL, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %w", opts.Endpoint, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: scheme must be http or https", opts.Endpoint)
+	}
+
+	return &S3Source{
+		bucket:  bucket,
+		prefix:  strings.Trim(prefix, "/"),
+		opts:    opts,
+		client:  &http.Client{},
+		baseURL: baseURL,
+	}, nil
+}
+
+// s3ListResult is the subset of a ListObjectsV2 response we care about.
+type s3ListResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+// ListSnapshots implements SnapshotSource.
+func (s *S3Source) ListSnapshots(ctx context.Context) ([]*abci.Snapshot, error) {
+	var (
+		keys  []string
+		token string
+	)
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if s.prefix != "" {
+			query.Set("prefix", s.prefix+"/")
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		body, err := s.get(ctx, "", query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		result := s3ListResult{}
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode object list: %w", err)
+		}
+
+		for _, object := range result.Contents {
+			if path.Base(object.Key) == snapshotManifestFile {
+				keys = append(keys, object.Key)
+			}
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+
+	snapshots := make([]*abci.Snapshot, 0, len(keys))
+	for _, key := range keys {
+		body, err := s.get(ctx, key, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch snapshot manifest %v: %w", key, err)
+		}
+
+		snapshot := &abci.Snapshot{}
+		if err := json.Unmarshal(body, snapshot); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot manifest %v: %w", key, err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sortSnapshots(snapshots)
+	return snapshots, nil
+}
+
+// LoadChunk implements SnapshotSource.
+func (s *S3Source) LoadChunk(ctx context.Context, height uint64, format uint32, index uint32) ([]byte, error) {
+	return s.get(ctx, path.Join(s.prefix, chunkPath(path.Join, height, format, index)), nil)
+}
+
+// String implements SnapshotSource.
+func (s *S3Source) String() string {
+	if s.prefix == "" {
+		return "s3://" + s.bucket
+	}
+	return "s3://" + s.bucket + "/" + s.prefix
+}
+
+// get fetches an object, or lists the bucket if key is empty.
+func (s *S3Source) get(ctx context.Context, key string, query url.Values) ([]byte, error) {
+	u := *s.baseURL
+	u.Path = path.Join("/", s.baseURL.Path, s.bucket, key)
+	u.RawQuery = s3EncodeQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.opts.AccessKeyID != "" {
+		s.sign(req, time.Now().UTC())
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, s3MaxObjectSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > s3MaxObjectSize {
+		return nil, fmt.Errorf("object %v exceeds maximum size %v", key, s3MaxObjectSize)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("object %q not found in bucket %v", key, s.bucket)
+	default:
+		return nil, fmt.Errorf("unexpected response status %v for object %q", resp.Status, key)
+	}
+}
+
+// sign signs a request with AWS Signature Version 4, using an unsigned payload.
+func (s *S3Source) sign(req *http.Request, now time.Time) {
+	const (
+		algorithm   = "AWS4-HMAC-SHA256"
+		payloadHash = "UNSIGNED-PAYLOAD"
+	)
+
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := strings.Join([]string{date, s.opts.Region, "s3", "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), date)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%v Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		algorithm, s.opts.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 computes the HMAC-SHA256 of data using key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data)) // Hash.Write() never returns an error.
+	return mac.Sum(nil)
+}
+
+// s3EncodeQuery encodes query parameters in the canonical form required for
+// request signing: sorted by key and percent-encoded per RFC 3986.
+func s3EncodeQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes a query component per RFC 3986.
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
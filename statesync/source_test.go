@@ -0,0 +1,243 @@
+package statesync
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/proxy"
+	proxymocks "github.com/klyed/tendermint/proxy/mocks"
+	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/statesync/mocks"
+	"github.com/klyed/tendermint/types"
+)
+
+// exportTestSnapshots exports two snapshots with two chunks each into a temp dir.
+func exportTestSnapshots(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "snapshot-export")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}, Metadata: []byte("meta1")},
+			{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}, Metadata: []byte("meta2")},
+		},
+	}, nil)
+	conn.On("LoadSnapshotChunkSync", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req abci.RequestLoadSnapshotChunk) *abci.ResponseLoadSnapshotChunk {
+			return &abci.ResponseLoadSnapshotChunk{Chunk: []byte{byte(req.Height), byte(req.Format), byte(req.Chunk)}}
+		}, nil)
+
+	snapshots, err := ExportSnapshots(ctx, conn, dir, 0)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	require.EqualValues(t, 2, snapshots[0].Height)
+
+	return dir
+}
+
+func TestExportSnapshots_Height(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}},
+	}, nil)
+
+	_, err = ExportSnapshots(ctx, conn, dir, 2)
+	require.Equal(t, errNoSnapshots, err)
+}
+
+func TestDirSource(t *testing.T) {
+	dir := exportTestSnapshots(t)
+
+	source, err := NewSnapshotSource("file://"+dir, S3Options{})
+	require.NoError(t, err)
+
+	snapshots, err := source.ListSnapshots(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*abci.Snapshot{
+		{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}, Metadata: []byte("meta2")},
+		{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}, Metadata: []byte("meta1")},
+	}, snapshots)
+
+	body, err := source.LoadChunk(ctx, 2, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2, 1, 1}, body)
+
+	_, err = source.LoadChunk(ctx, 2, 1, 2)
+	require.Error(t, err)
+
+	_, err = NewDirSource(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+// newMockS3Server serves a directory as a minimal S3-compatible object store,
+// supporting path-style GetObject and ListObjectsV2 on the given bucket.
+func newMockS3Server(t *testing.T, dir, bucket string, requireAuth bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requireAuth && !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/"+bucket)
+		key = strings.TrimPrefix(key, "/")
+
+		if key == "" && r.URL.Query().Get("list-type") == "2" {
+			prefix := r.URL.Query().Get("prefix")
+			result := s3ListResult{}
+			err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				rel, err := filepath.Rel(dir, path)
+				if err != nil {
+					return err
+				}
+				if strings.HasPrefix(filepath.ToSlash(rel), prefix) {
+					result.Contents = append(result.Contents, struct {
+						Key string `xml:"Key"`
+					}{Key: filepath.ToSlash(rel)})
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			bz, err := xml.Marshal(struct {
+				XMLName xml.Name `xml:"ListBucketResult"`
+				s3ListResult
+			}{s3ListResult: result})
+			require.NoError(t, err)
+			_, _ = w.Write(bz)
+			return
+		}
+
+		body, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestS3Source(t *testing.T) {
+	// Export into a subdirectory, which is used as the key prefix.
+	dir, err := ioutil.TempDir("", "snapshot-s3")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Rename(exportTestSnapshots(t), filepath.Join(dir, "snapshots")))
+
+	testcases := map[string]struct {
+		opts        S3Options
+		requireAuth bool
+		expectErr   bool
+	}{
+		"unsigned":        {S3Options{}, false, false},
+		"signed":          {S3Options{AccessKeyID: "key", SecretAccessKey: "secret"}, true, false},
+		"missing signing": {S3Options{}, true, true},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			server := newMockS3Server(t, dir, "bucket", tc.requireAuth)
+			defer server.Close()
+
+			opts := tc.opts
+			opts.Endpoint = server.URL
+			source, err := NewSnapshotSource("s3://bucket/snapshots", opts)
+			require.NoError(t, err)
+			require.Equal(t, "s3://bucket/snapshots", source.String())
+
+			snapshots, err := source.ListSnapshots(ctx)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, snapshots, 2)
+			require.EqualValues(t, 2, snapshots[0].Height)
+			require.Equal(t, []byte("meta2"), snapshots[0].Metadata)
+
+			body, err := source.LoadChunk(ctx, 1, 1, 0)
+			require.NoError(t, err)
+			require.Equal(t, []byte{1, 1, 0}, body)
+
+			_, err = source.LoadChunk(ctx, 3, 1, 0)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewSnapshotSource_Invalid(t *testing.T) {
+	_, err := NewSnapshotSource("ftp://host/path", S3Options{})
+	require.Error(t, err)
+
+	_, err = NewSnapshotSource("s3:///prefix", S3Options{})
+	require.Error(t, err)
+
+	_, err = NewSnapshotSource("s3://bucket", S3Options{Endpoint: "localhost:9000"})
+	require.Error(t, err)
+}
+
+func TestReactor_SyncFromSource(t *testing.T) {
+	dir := exportTestSnapshots(t)
+	source, err := NewDirSource(dir)
+	require.NoError(t, err)
+
+	state := sm.State{ChainID: "chain", LastBlockHeight: 2, AppHash: []byte("app_hash")}
+	commit := &types.Commit{Height: 2}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return([]byte("app_hash_1"), nil)
+	stateProvider.On("AppHash", mock.Anything, uint64(2)).Return([]byte("app_hash"), nil)
+	stateProvider.On("State", mock.Anything, uint64(2)).Return(state, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(2)).Return(commit, nil)
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("OfferSnapshotSync", mock.Anything, abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{Height: 2, Format: 1, Chunks: 2, Hash: []byte{2}, Metadata: []byte("meta2")},
+		AppHash:  []byte("app_hash"),
+	}).Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i := byte(0); i < 2; i++ {
+		conn.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+			Index:  uint32(i),
+			Chunk:  []byte{2, 1, i},
+			Sender: source.String(),
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+
+	connQuery := &proxymocks.AppConnQuery{}
+	connQuery.On("InfoSync", mock.Anything, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       9,
+		LastBlockHeight:  2,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	rts := setup(t, conn, connQuery, stateProvider, 2)
+
+	newState, lastCommit, err := rts.reactor.SyncFromSource(stateProvider, source)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, newState.Version.Consensus.App)
+	require.Equal(t, commit, lastCommit)
+
+	// no chunks should have been requested from peers
+	require.Empty(t, rts.chunkOutCh)
+
+	conn.AssertExpectations(t)
+	connQuery.AssertExpectations(t)
+}
@@ -18,11 +18,13 @@ import (
 )
 
 const (
-	// chunkFetchers is the number of concurrent chunk fetchers to run.
+	// chunkFetchers is the initial number of concurrent chunk requests, which is
+	// adapted to observed peer throughput by the chunk scheduler.
 	chunkFetchers = 4
 	// chunkTimeout is the timeout while waiting for the next chunk from the chunk queue.
 	chunkTimeout = 2 * time.Minute
-	// requestTimeout is the timeout before rerequesting a chunk, possibly from a different peer.
+	// chunkRequestTimeout is the timeout before rerequesting a chunk, possibly from a different
+	// peer, when the peer's throughput is unknown. Otherwise, the timeout is derived from it.
 	chunkRequestTimeout = 10 * time.Second
 )
 
@@ -57,6 +59,9 @@ type syncer struct {
 	snapshotCh    chan<- p2p.Envelope
 	chunkCh       chan<- p2p.Envelope
 	tempDir       string
+	source        SnapshotSource // if set, snapshots and chunks are only fetched from here
+	scheduler     *chunkScheduler
+	progress      *syncProgress
 
 	mtx    tmsync.RWMutex
 	chunks *chunkQueue
@@ -70,6 +75,8 @@ func newSyncer(
 	stateProvider StateProvider,
 	snapshotCh, chunkCh chan<- p2p.Envelope,
 	tempDir string,
+	metrics *Metrics,
+	progress *syncProgress,
 ) *syncer {
 	return &syncer{
 		logger:        logger,
@@ -80,6 +87,8 @@ func newSyncer(
 		snapshotCh:    snapshotCh,
 		chunkCh:       chunkCh,
 		tempDir:       tempDir,
+		scheduler:     newChunkScheduler(metrics),
+		progress:      progress,
 	}
 }
 
@@ -96,6 +105,7 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, err
 	}
 	if added {
+		s.scheduler.received(chunk.Sender, chunk.Index, len(chunk.Chunk))
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
 			"chunk", chunk.Index)
 	} else {
@@ -108,6 +118,10 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 // AddSnapshot adds a snapshot to the snapshot pool. It returns true if a new, previously unseen
 // snapshot was accepted and added.
 func (s *syncer) AddSnapshot(peerID p2p.NodeID, snapshot *snapshot) (bool, error) {
+	if s.source != nil {
+		// snapshots are only restored from the configured snapshot source
+		return false, nil
+	}
 	added, err := s.snapshots.Add(peerID, snapshot)
 	if err != nil {
 		return false, err
@@ -122,6 +136,9 @@ func (s *syncer) AddSnapshot(peerID p2p.NodeID, snapshot *snapshot) (bool, error
 // AddPeer adds a peer to the pool. For now we just keep it simple and send a
 // single request to discover snapshots, later we may want to do retries and stuff.
 func (s *syncer) AddPeer(peerID p2p.NodeID) {
+	if s.source != nil {
+		return
+	}
 	s.logger.Debug("Requesting snapshots from peer", "peer", peerID)
 	s.snapshotCh <- p2p.Envelope{
 		To:      peerID,
@@ -133,6 +150,27 @@ func (s *syncer) AddPeer(peerID p2p.NodeID) {
 func (s *syncer) RemovePeer(peerID p2p.NodeID) {
 	s.logger.Debug("Removing peer from sync", "peer", peerID)
 	s.snapshots.RemovePeer(peerID)
+	s.scheduler.removePeer(peerID)
+}
+
+// SyncSource loads all snapshots from the syncer's snapshot source into the snapshot pool, and
+// then tries to sync any of them. Chunks are fetched from the source rather than from peers. It
+// returns the latest state and block commit which the caller must use to bootstrap the node.
+func (s *syncer) SyncSource() (sm.State, *types.Commit, error) {
+	if s.source == nil {
+		return sm.State{}, nil, errors.New("no snapshot source configured")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chunkTimeout)
+	defer cancel()
+
+	added, err := loadSnapshotsFromSource(ctx, s.source, s.snapshots)
+	if err != nil {
+		return sm.State{}, nil, err
+	}
+	s.logger.Info("Loaded snapshots from source", "source", s.source, "snapshots", added)
+
+	return s.SyncAny(0)
 }
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
@@ -245,10 +283,13 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, err
 	}
 
+	s.progress.start(snapshot)
+
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
+	// The scheduler limits how many of them have a request in flight at any time.
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	for i := int32(0); i < chunkFetchers; i++ {
+	for i := int32(0); i < maxChunkFetchers; i++ {
 		go s.fetchChunks(ctx, snapshot, chunks)
 	}
 
@@ -277,6 +318,7 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 		return sm.State{}, nil, err
 	}
 	state.Version.Consensus.App = appVersion
+	s.progress.finish()
 
 	// Done! 🎉
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
@@ -365,6 +407,7 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 
 		switch resp.Result {
 		case abci.ResponseApplySnapshotChunk_ACCEPT:
+			s.progress.chunkApplied(len(chunk.Chunk))
 		case abci.ResponseApplySnapshotChunk_ABORT:
 			return errAbort
 		case abci.ResponseApplySnapshotChunk_RETRY:
@@ -380,50 +423,83 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 }
 
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
-// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
+// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add(). Each request
+// occupies a slot of the chunk scheduler, and is retried until the chunk arrives.
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
 	for {
+		if err := s.scheduler.acquire(ctx); err != nil {
+			return
+		}
+
 		index, err := chunks.Allocate()
 		if err == errDone {
+			s.scheduler.release()
 			// Keep checking until the context is canceled (restore is done), in case any
 			// chunks need to be refetched.
 			select {
 			case <-ctx.Done():
 				return
-			default:
+			case <-time.After(2 * time.Second):
 			}
-			time.Sleep(2 * time.Second)
 			continue
 		}
 		if err != nil {
+			s.scheduler.release()
 			s.logger.Error("Failed to allocate chunk from queue", "err", err)
 			return
 		}
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
-		ticker := time.NewTicker(chunkRequestTimeout)
-		defer ticker.Stop()
-		s.requestChunk(snapshot, index)
+		ok := s.fetchChunk(ctx, snapshot, chunks, index)
+		s.scheduler.release()
+		if !ok {
+			return
+		}
+	}
+}
+
+// fetchChunk requests a chunk until it arrives in the queue, returning false if the context
+// was canceled first.
+func (s *syncer) fetchChunk(ctx context.Context, snapshot *snapshot, chunks *chunkQueue, index uint32) bool {
+	for {
+		peer, timeout := s.requestChunk(snapshot, index)
+
+		timer := time.NewTimer(timeout)
 		select {
 		case <-chunks.WaitFor(index):
-		case <-ticker.C:
-			s.requestChunk(snapshot, index)
+			timer.Stop()
+			return true
+
+		case <-timer.C:
+			s.logger.Debug("Timed out waiting for snapshot chunk, rerequesting", "height", snapshot.Height,
+				"format", snapshot.Format, "chunk", index, "peer", peer, "timeout", timeout)
+			s.scheduler.timedOut(peer, index)
+
 		case <-ctx.Done():
-			return
+			timer.Stop()
+			return false
 		}
-		ticker.Stop()
 	}
 }
 
-// requestChunk requests a chunk from a peer.
-func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
+// requestChunk requests a chunk from the fastest available peer, or loads it from the snapshot
+// source if any. It returns the peer and the timeout after which the request should be retried.
+func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) (p2p.NodeID, time.Duration) {
+	if s.source != nil {
+		peer := sourcePeerID(s.source)
+		timeout := s.scheduler.requested(peer, chunk)
+		go s.loadChunk(snapshot, chunk, timeout)
+		return peer, timeout
+	}
+
+	peer := s.scheduler.selectPeer(s.snapshots.GetPeers(snapshot))
 	if peer == "" {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
-		return
+		return "", chunkRequestTimeout
 	}
+	timeout := s.scheduler.requested(peer, chunk)
 
 	s.logger.Debug(
 		"Requesting snapshot chunk",
@@ -431,6 +507,7 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 		"format", snapshot.Format,
 		"chunk", chunk,
 		"peer", peer,
+		"timeout", timeout,
 	)
 
 	s.chunkCh <- p2p.Envelope{
@@ -441,6 +518,36 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 			Index:  chunk,
 		},
 	}
+
+	return peer, timeout
+}
+
+// loadChunk loads a chunk from the snapshot source and adds it to the chunk queue.
+func (s *syncer) loadChunk(snapshot *snapshot, index uint32, timeout time.Duration) {
+	s.logger.Debug("Loading snapshot chunk from source", "height", snapshot.Height,
+		"format", snapshot.Format, "chunk", index, "source", s.source)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	body, err := s.source.LoadChunk(ctx, snapshot.Height, snapshot.Format, index)
+	if err != nil {
+		s.logger.Error("Failed to load snapshot chunk from source", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "err", err)
+		return
+	}
+
+	_, err = s.AddChunk(&chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  body,
+		Sender: sourcePeerID(s.source),
+	})
+	if err != nil {
+		s.logger.Error("Failed to add snapshot chunk", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "err", err)
+	}
 }
 
 // verifyApp verifies the sync, checking the app hash and last block height. It returns the
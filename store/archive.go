@@ -0,0 +1,368 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klyed/tendermint/libs/protoio"
+	tmstate "github.com/klyed/tendermint/proto/tendermint/state"
+	tmstore "github.com/klyed/tendermint/proto/tendermint/store"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/types"
+)
+
+// maxArchiveBlockSize is the maximum size of an archived block, including its
+// commit and ABCI responses.
+const maxArchiveBlockSize = 2 * types.MaxBlockSizeBytes
+
+// ExportBlocks writes the blocks in the height range [from, to] to w, along
+// with their commits, the ABCI responses from executing them and the validator
+// sets and consensus params at their heights, as a stream of length-delimited
+// tmstore.ArchiveBlock messages. A from or to of 0 denotes the block store's
+// base or height respectively. ABCI responses are omitted for heights where
+// the state store no longer has them. It returns the number of blocks
+// exported.
+func ExportBlocks(w io.Writer, blockStore *BlockStore, stateStore sm.Store, from, to int64) (int64, error) {
+	if blockStore.Height() == 0 {
+		return 0, errors.New("block store is empty")
+	}
+	if from == 0 {
+		from = blockStore.Base()
+	}
+	if to == 0 {
+		to = blockStore.Height()
+	}
+	if from < blockStore.Base() || to > blockStore.Height() || from > to {
+		return 0, fmt.Errorf("invalid height range [%v, %v], block store has [%v, %v]",
+			from, to, blockStore.Base(), blockStore.Height())
+	}
+
+	writer := protoio.NewDelimitedWriter(w)
+	for height := from; height <= to; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return height - from, fmt.Errorf("block at height %v not found", height)
+		}
+		pbb, err := block.ToProto()
+		if err != nil {
+			return height - from, fmt.Errorf("failed to convert block at height %v: %w", height, err)
+		}
+
+		// The canonical commit is only available once the next block is
+		// stored, so we fall back to the seen commit for the last block.
+		commit := blockStore.LoadBlockCommit(height)
+		if commit == nil {
+			commit = blockStore.LoadSeenCommit(height)
+		}
+		if commit == nil {
+			return height - from, fmt.Errorf("commit at height %v not found", height)
+		}
+
+		abciResponses, err := stateStore.LoadABCIResponses(height)
+		if err != nil && !errors.As(err, &sm.ErrNoABCIResponsesForHeight{}) {
+			return height - from, fmt.Errorf("failed to load ABCI responses at height %v: %w", height, err)
+		}
+
+		vals, err := stateStore.LoadValidators(height)
+		if err != nil {
+			return height - from, fmt.Errorf("failed to load validators at height %v: %w", height, err)
+		}
+		pbvals, err := vals.ToProto()
+		if err != nil {
+			return height - from, fmt.Errorf("failed to convert validators at height %v: %w", height, err)
+		}
+		params, err := stateStore.LoadConsensusParams(height)
+		if err != nil {
+			return height - from, fmt.Errorf("failed to load consensus params at height %v: %w", height, err)
+		}
+		pbparams := params.ToProto()
+
+		_, err = writer.WriteMsg(&tmstore.ArchiveBlock{
+			Block:           pbb,
+			Commit:          commit.ToProto(),
+			AbciResponses:   abciResponses,
+			Validators:      pbvals,
+			ConsensusParams: &pbparams,
+		})
+		if err != nil {
+			return height - from, fmt.Errorf("failed to write block at height %v: %w", height, err)
+		}
+	}
+
+	return to - from + 1, nil
+}
+
+// ImportBlocks reads blocks exported by ExportBlocks from r and saves them in
+// the block store, and their ABCI responses, validator sets and consensus
+// params in the state store. Blocks must follow on from the block store's
+// height, unless it is empty, and form a hash chain: each block must belong to
+// the genesis doc's chain, match the commit for it, its validator set and
+// consensus params, and the previous block's ID and next validators, and ABCI
+// responses must match the next block's results hash. Each commit must be
+// signed by more than 2/3 of the block's validators. The first block into an
+// empty block store is anchored by its validator set, which must be the one
+// known to the state store for its height, or the genesis validators at the
+// initial height. It returns the number of blocks imported.
+//
+// The node's state is left as is.
+func ImportBlocks(
+	r io.Reader,
+	blockStore *BlockStore,
+	stateStore sm.Store,
+	genDoc *types.GenesisDoc,
+) (imported int64, err error) {
+	var (
+		reader = protoio.NewDelimitedReader(r, maxArchiveBlockSize)
+		prev   *types.BlockMeta
+		// ABCI responses of the previous block, saved once validated against
+		// the results hash of the next block.
+		pending *tmstate.ABCIResponses
+		runs    = &archiveRuns{stateStore: stateStore}
+	)
+	if height := blockStore.Height(); height > 0 {
+		prev = blockStore.LoadBlockMeta(height)
+	}
+	// the validator sets and consensus params of the imported blocks are
+	// saved once per range of heights they're unchanged for
+	defer func() {
+		if flushErr := runs.flush(); flushErr != nil && err == nil {
+			err = flushErr
+		}
+	}()
+
+	for {
+		var msg tmstore.ArchiveBlock
+		_, err := reader.ReadMsg(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return imported, fmt.Errorf("failed to read block: %w", err)
+		}
+
+		block, err := types.BlockFromProto(msg.Block)
+		if err != nil {
+			return imported, fmt.Errorf("invalid block: %w", err)
+		}
+		commit, err := types.CommitFromProto(msg.Commit)
+		if err != nil {
+			return imported, fmt.Errorf("invalid commit at height %v: %w", block.Height, err)
+		}
+		if msg.Validators == nil || msg.ConsensusParams == nil {
+			return imported, fmt.Errorf("missing validators or consensus params at height %v", block.Height)
+		}
+		vals, err := types.ValidatorSetFromProto(msg.Validators)
+		if err != nil {
+			return imported, fmt.Errorf("invalid validators at height %v: %w", block.Height, err)
+		}
+		params := types.ConsensusParamsFromProto(*msg.ConsensusParams)
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+
+		if prev == nil {
+			trusted, err := trustedValidators(stateStore, genDoc, block.Height)
+			if err != nil {
+				return imported, err
+			}
+			if !bytes.Equal(trusted.Hash(), vals.Hash()) {
+				return imported, fmt.Errorf("validators at height %v do not match the known validators %X",
+					block.Height, trusted.Hash())
+			}
+		}
+		err = validateArchiveBlock(genDoc.ChainID, block, blockID, commit, vals, params, prev, pending)
+		if err != nil {
+			return imported, err
+		}
+		if pending != nil {
+			if err := stateStore.SaveABCIResponses(prev.Header.Height, pending); err != nil {
+				return imported, fmt.Errorf("failed to save ABCI responses at height %v: %w", prev.Header.Height, err)
+			}
+		}
+
+		blockStore.SaveBlock(block, partSet, commit)
+		if err := runs.add(block.Height, vals, params); err != nil {
+			return imported, err
+		}
+		prev = types.NewBlockMeta(block, partSet)
+		pending = msg.AbciResponses
+		imported++
+	}
+
+	// The last block's ABCI responses can't be validated, since we don't
+	// have the next block.
+	if pending != nil {
+		if err := stateStore.SaveABCIResponses(prev.Header.Height, pending); err != nil {
+			return imported, fmt.Errorf("failed to save ABCI responses at height %v: %w", prev.Header.Height, err)
+		}
+	}
+	return imported, nil
+}
+
+// trustedValidators returns the validator set known for the given height,
+// from the state store or, at the initial height, the genesis doc.
+func trustedValidators(stateStore sm.Store, genDoc *types.GenesisDoc, height int64) (*types.ValidatorSet, error) {
+	if vals, err := stateStore.LoadValidators(height); err == nil {
+		return vals, nil
+	}
+	if height == genDoc.InitialHeight && len(genDoc.Validators) > 0 {
+		validators := make([]*types.Validator, len(genDoc.Validators))
+		for i, val := range genDoc.Validators {
+			validators[i] = types.NewValidator(val.PubKey, val.Power)
+		}
+		return types.NewValidatorSet(validators), nil
+	}
+	return nil, fmt.Errorf("no known validators at height %v to verify the first block against", height)
+}
+
+// validateArchiveBlock checks that an imported block is valid, belongs to the
+// chain, matches its commit, validators and consensus params, and follows on
+// from the previous block and its ABCI responses, if any.
+func validateArchiveBlock(
+	chainID string,
+	block *types.Block,
+	blockID types.BlockID,
+	commit *types.Commit,
+	vals *types.ValidatorSet,
+	params types.ConsensusParams,
+	prev *types.BlockMeta,
+	prevABCIResponses *tmstate.ABCIResponses,
+) error {
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block at height %v: %w", block.Height, err)
+	}
+	if err := commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid commit at height %v: %w", block.Height, err)
+	}
+	if block.ChainID != chainID {
+		return fmt.Errorf("block at height %v has chain ID %q, expected %q", block.Height, block.ChainID, chainID)
+	}
+	if commit.Height != block.Height {
+		return fmt.Errorf("commit height %v does not match block height %v", commit.Height, block.Height)
+	}
+	if !commit.BlockID.Equals(blockID) {
+		return fmt.Errorf("commit for block %v does not match block at height %v", commit.BlockID, block.Height)
+	}
+	if !bytes.Equal(block.ValidatorsHash, vals.Hash()) {
+		return fmt.Errorf("validators at height %v do not match validators hash %X",
+			block.Height, block.ValidatorsHash)
+	}
+	if !bytes.Equal(block.ConsensusHash, params.HashConsensusParams()) {
+		return fmt.Errorf("consensus params at height %v do not match consensus hash %X",
+			block.Height, block.ConsensusHash)
+	}
+	if err := vals.VerifyCommit(chainID, blockID, block.Height, commit); err != nil {
+		return fmt.Errorf("invalid commit signatures at height %v: %w", block.Height, err)
+	}
+
+	if prev == nil {
+		return nil
+	}
+	if block.Height != prev.Header.Height+1 {
+		return fmt.Errorf("expected block at height %v, got %v", prev.Header.Height+1, block.Height)
+	}
+	if !block.LastBlockID.Equals(prev.BlockID) {
+		return fmt.Errorf("block at height %v does not follow on from block %v", block.Height, prev.BlockID)
+	}
+	if !bytes.Equal(block.ValidatorsHash, prev.Header.NextValidatorsHash) {
+		return fmt.Errorf("validators hash %X at height %v does not match next validators hash %X",
+			block.ValidatorsHash, block.Height, prev.Header.NextValidatorsHash)
+	}
+	if prevABCIResponses != nil &&
+		!bytes.Equal(block.LastResultsHash, sm.ABCIResponsesResultsHash(prevABCIResponses)) {
+		return fmt.Errorf("ABCI responses at height %v do not match results hash %X",
+			prev.Header.Height, block.LastResultsHash)
+	}
+	return nil
+}
+
+// archiveRuns accumulates the validator sets and consensus params of imported
+// blocks, to save them once per range of heights they're unchanged for.
+type archiveRuns struct {
+	stateStore sm.Store
+
+	valsFrom, valsTo int64
+	vals, lastVals   *types.ValidatorSet // at valsFrom and valsTo
+
+	paramsFrom, paramsTo int64
+	params               *tmproto.ConsensusParams
+}
+
+// add adds the validator set and consensus params at the given height, which
+// must follow on from the last one added, saving the previous ones if they
+// changed.
+func (r *archiveRuns) add(height int64, vals *types.ValidatorSet, params types.ConsensusParams) error {
+	// the proposer priorities of an unchanged set are incremented at each
+	// height, which is how LoadValidators derives them
+	if r.vals == nil || !equalValidatorSets(vals, r.lastVals.CopyIncrementProposerPriority(1)) {
+		if err := r.flushValidators(); err != nil {
+			return err
+		}
+		r.vals, r.valsFrom = vals, height
+	}
+	r.lastVals, r.valsTo = vals, height
+
+	pbparams := params.ToProto()
+	if r.params == nil || !r.params.Equal(&pbparams) {
+		if err := r.flushParams(); err != nil {
+			return err
+		}
+		r.params, r.paramsFrom = &pbparams, height
+	}
+	r.paramsTo = height
+	return nil
+}
+
+// flush saves the validator set and consensus params added last.
+func (r *archiveRuns) flush() error {
+	if err := r.flushValidators(); err != nil {
+		return err
+	}
+	return r.flushParams()
+}
+
+func (r *archiveRuns) flushValidators() error {
+	if r.vals == nil {
+		return nil
+	}
+	if err := r.stateStore.SaveValidatorSets(r.valsFrom, r.valsTo, r.vals); err != nil {
+		return fmt.Errorf("failed to save validators at heights %v-%v: %w", r.valsFrom, r.valsTo, err)
+	}
+	r.vals, r.lastVals = nil, nil
+	return nil
+}
+
+func (r *archiveRuns) flushParams() error {
+	if r.params == nil {
+		return nil
+	}
+	err := r.stateStore.SaveConsensusParams(r.paramsFrom, r.paramsTo, types.ConsensusParamsFromProto(*r.params))
+	if err != nil {
+		return fmt.Errorf("failed to save consensus params at heights %v-%v: %w", r.paramsFrom, r.paramsTo, err)
+	}
+	r.params = nil
+	return nil
+}
+
+// equalValidatorSets returns true if the validator sets have the same
+// validators, voting powers, proposer priorities and proposer.
+func equalValidatorSets(a, b *types.ValidatorSet) bool {
+	pba, err := a.ToProto()
+	if err != nil {
+		return false
+	}
+	pbb, err := b.ToProto()
+	if err != nil {
+		return false
+	}
+	bza, err := pba.Marshal()
+	if err != nil {
+		return false
+	}
+	bzb, err := pbb.Marshal()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(bza, bzb)
+}
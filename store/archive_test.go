@@ -0,0 +1,244 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	dbm "github.com/klyed/tm-db"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/klyed/tendermint/abci/types"
+	"github.com/klyed/tendermint/libs/protoio"
+	tmrand "github.com/klyed/tendermint/libs/rand"
+	tmstate "github.com/klyed/tendermint/proto/tendermint/state"
+	tmstore "github.com/klyed/tendermint/proto/tendermint/store"
+	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
+	sm "github.com/klyed/tendermint/state"
+	"github.com/klyed/tendermint/types"
+	tmtime "github.com/klyed/tendermint/types/time"
+)
+
+// makeChain creates block and state stores containing a hash chain of blocks
+// with ABCI responses, validators and consensus params, up to the given
+// height, and the genesis doc of the chain.
+func makeChain(t *testing.T, height int64) (*BlockStore, sm.Store, *types.GenesisDoc) {
+	privVal := types.NewMockPV()
+	pubKey, err := privVal.GetPubKey(context.Background())
+	require.NoError(t, err)
+	genDoc := &types.GenesisDoc{
+		ChainID:    "archive_test",
+		Validators: []types.GenesisValidator{{PubKey: pubKey, Power: 10}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	blockStore := NewBlockStore(dbm.NewMemDB())
+
+	lastCommit := new(types.Commit)
+	for h := int64(1); h <= height; h++ {
+		block := makeBlock(h, state, lastCommit)
+		partSet := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		voteSet := types.NewVoteSet(genDoc.ChainID, h, 0, tmproto.PrecommitType, state.Validators)
+		commit, err := types.MakeCommit(blockID, h, 0, voteSet, []types.PrivValidator{privVal}, tmtime.Now())
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, commit)
+
+		abciResponses := &tmstate.ABCIResponses{
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: uint32(h)}},
+			EndBlock:   &abci.ResponseEndBlock{},
+			BeginBlock: &abci.ResponseBeginBlock{},
+		}
+		require.NoError(t, stateStore.SaveABCIResponses(h, abciResponses))
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastResultsHash = sm.ABCIResponsesResultsHash(abciResponses)
+		lastCommit = commit
+	}
+	require.NoError(t, stateStore.SaveValidatorSets(1, height, state.Validators))
+	require.NoError(t, stateStore.SaveConsensusParams(1, height, state.ConsensusParams))
+
+	return blockStore, stateStore, genDoc
+}
+
+func TestExportImportBlocks(t *testing.T) {
+	srcBlocks, srcState, genDoc := makeChain(t, 10)
+
+	var buf bytes.Buffer
+	exported, err := ExportBlocks(&buf, srcBlocks, srcState, 0, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, exported)
+
+	dstBlocks, dstState := NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB())
+	imported, err := ImportBlocks(&buf, dstBlocks, dstState, genDoc)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, imported)
+
+	require.EqualValues(t, 1, dstBlocks.Base())
+	require.EqualValues(t, 10, dstBlocks.Height())
+	for h := int64(1); h <= 10; h++ {
+		require.Equal(t, srcBlocks.LoadBlockMeta(h), dstBlocks.LoadBlockMeta(h))
+		require.Equal(t, srcBlocks.LoadBlock(h).Hash(), dstBlocks.LoadBlock(h).Hash())
+
+		abciResponses, err := dstState.LoadABCIResponses(h)
+		require.NoError(t, err)
+		require.EqualValues(t, h, abciResponses.DeliverTxs[0].Code)
+
+		srcVals, err := srcState.LoadValidators(h)
+		require.NoError(t, err)
+		dstVals, err := dstState.LoadValidators(h)
+		require.NoError(t, err)
+		require.Equal(t, srcVals, dstVals)
+
+		srcParams, err := srcState.LoadConsensusParams(h)
+		require.NoError(t, err)
+		dstParams, err := dstState.LoadConsensusParams(h)
+		require.NoError(t, err)
+		require.Equal(t, srcParams, dstParams)
+	}
+	require.NotNil(t, dstBlocks.LoadBlockCommit(9))
+	require.NotNil(t, dstBlocks.LoadSeenCommit(10))
+}
+
+func TestExportImportBlocks_Range(t *testing.T) {
+	srcBlocks, srcState, genDoc := makeChain(t, 10)
+	dstBlocks, dstState := NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB())
+
+	_, err := ExportBlocks(&bytes.Buffer{}, NewBlockStore(dbm.NewMemDB()), srcState, 0, 0)
+	require.Error(t, err)
+	_, err = ExportBlocks(&bytes.Buffer{}, srcBlocks, srcState, 5, 11)
+	require.Error(t, err)
+	_, err = ExportBlocks(&bytes.Buffer{}, srcBlocks, srcState, 6, 5)
+	require.Error(t, err)
+
+	// a range can't be imported into an empty store without knowing the
+	// validators at its first height
+	var buf bytes.Buffer
+	_, err = ExportBlocks(&buf, srcBlocks, srcState, 3, 5)
+	require.NoError(t, err)
+	archive := buf.Bytes()
+	_, err = ImportBlocks(bytes.NewReader(archive), dstBlocks, dstState, genDoc)
+	require.Error(t, err)
+	require.Zero(t, dstBlocks.Height())
+
+	// import a range into an empty store, then continue from there
+	vals, err := srcState.LoadValidators(3)
+	require.NoError(t, err)
+	require.NoError(t, dstState.SaveValidatorSets(3, 3, vals))
+	imported, err := ImportBlocks(bytes.NewReader(archive), dstBlocks, dstState, genDoc)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, imported)
+	require.EqualValues(t, 3, dstBlocks.Base())
+	require.EqualValues(t, 5, dstBlocks.Height())
+
+	// blocks which don't follow on from the store's height are rejected
+	buf.Reset()
+	_, err = ExportBlocks(&buf, srcBlocks, srcState, 7, 10)
+	require.NoError(t, err)
+	_, err = ImportBlocks(&buf, dstBlocks, dstState, genDoc)
+	require.Error(t, err)
+	require.EqualValues(t, 5, dstBlocks.Height())
+
+	buf.Reset()
+	_, err = ExportBlocks(&buf, srcBlocks, srcState, 6, 10)
+	require.NoError(t, err)
+	imported, err = ImportBlocks(&buf, dstBlocks, dstState, genDoc)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, imported)
+	require.EqualValues(t, 10, dstBlocks.Height())
+}
+
+func TestImportBlocks_Invalid(t *testing.T) {
+	testcases := map[string]struct {
+		height int64 // height of the archived block to modify
+		modify func(*tmstore.ArchiveBlock)
+	}{
+		"modified tx": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.Block.Data.Txs[0] = []byte("modified")
+		}},
+		"modified header": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.Block.Header.AppHash = []byte("modified")
+		}},
+		"mismatched commit": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.Commit.BlockID.Hash = tmrand.Bytes(32)
+		}},
+		"modified ABCI responses": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.AbciResponses.DeliverTxs[0].Code = 100
+		}},
+		"forged signature": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.Commit.Signatures[0].Signature = tmrand.Bytes(64)
+		}},
+		"modified validators": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.Validators.Validators[0].VotingPower++
+			msg.Validators.Proposer.VotingPower++
+		}},
+		"modified consensus params": {4, func(msg *tmstore.ArchiveBlock) {
+			msg.ConsensusParams.Block.MaxBytes++
+		}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			srcBlocks, srcState, genDoc := makeChain(t, 10)
+
+			var buf bytes.Buffer
+			_, err := ExportBlocks(&buf, srcBlocks, srcState, 0, 0)
+			require.NoError(t, err)
+
+			// rewrite the archive, modifying the given block
+			var modified bytes.Buffer
+			reader := protoio.NewDelimitedReader(&buf, maxArchiveBlockSize)
+			writer := protoio.NewDelimitedWriter(&modified)
+			for h := int64(1); h <= 10; h++ {
+				var msg tmstore.ArchiveBlock
+				_, err := reader.ReadMsg(&msg)
+				require.NoError(t, err)
+				if h == tc.height {
+					tc.modify(&msg)
+				}
+				_, err = writer.WriteMsg(&msg)
+				require.NoError(t, err)
+			}
+
+			dstBlocks, dstState := NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB())
+			imported, err := ImportBlocks(&modified, dstBlocks, dstState, genDoc)
+			require.Error(t, err)
+			require.Less(t, imported, int64(tc.height+1))
+
+			// unvalidated ABCI responses must not be saved
+			_, err = dstState.LoadABCIResponses(tc.height)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestImportBlocks_Anchor(t *testing.T) {
+	srcBlocks, srcState, genDoc := makeChain(t, 5)
+	var buf bytes.Buffer
+	_, err := ExportBlocks(&buf, srcBlocks, srcState, 0, 0)
+	require.NoError(t, err)
+	archive := buf.Bytes()
+
+	// a chain with another ID is rejected
+	otherChainID := *genDoc
+	otherChainID.ChainID = "other"
+	dstBlocks, dstState := NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB())
+	_, err = ImportBlocks(bytes.NewReader(archive), dstBlocks, dstState, &otherChainID)
+	require.Error(t, err)
+	require.Zero(t, dstBlocks.Height())
+
+	// a chain forged by other validators is rejected, even though it's
+	// consistent
+	_, _, otherGenDoc := makeChain(t, 1)
+	_, err = ImportBlocks(bytes.NewReader(archive), dstBlocks, dstState, otherGenDoc)
+	require.Error(t, err)
+	require.Zero(t, dstBlocks.Height())
+
+	imported, err := ImportBlocks(bytes.NewReader(archive), dstBlocks, dstState, genDoc)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, imported)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
 	dbm "github.com/klyed/tm-db"
+	"github.com/syndtr/goleveldb/leveldb/util"
 
 	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
 	"github.com/klyed/tendermint/types"
@@ -336,6 +337,34 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+// Compact compacts the key ranges of blocks pruned below the given height, to
+// reclaim disk space. It is a no-op for database backends which do not
+// support range compaction.
+func (bs *BlockStore) Compact(height int64) error {
+	ranges := [][2][]byte{
+		{blockMetaKey(0), blockMetaKey(height)},
+		{blockPartKey(0, 0), blockPartKey(height, 0)},
+		{blockCommitKey(0), blockCommitKey(height)},
+		{seenCommitKey(0), seenCommitKey(height)},
+	}
+	for _, r := range ranges {
+		if err := compactRange(bs.db, r[0], r[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactRange compacts the underlying storage of the key range [start, end).
+func compactRange(db dbm.DB, start, end []byte) error {
+	switch db := db.(type) {
+	case *dbm.GoLevelDB:
+		return db.DB().CompactRange(util.Range{Start: start, Limit: end})
+	default:
+		return nil
+	}
+}
+
 // pruneRange is a generic function for deleting a range of values based on the lowest
 // height up to but excluding retainHeight. For each key/value pair, an optional hook can be
 // executed before the deletion itself is made. pruneRange will use batch delete to delete
@@ -519,6 +548,45 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 	return bs.db.Set(seenCommitKey(height), seenCommitBytes)
 }
 
+// SaveSignedHeader saves the header and commit of a block whose contents are
+// not available, e.g. when backfilling history after state sync. Since the
+// block itself is unknown, the block meta has a negative block size and number
+// of transactions, and LoadBlock returns nil for the height.
+func (bs *BlockStore) SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error {
+	// first check that the block store doesn't already have the block
+	bz, err := bs.db.Get(blockMetaKey(sh.Height))
+	if err != nil {
+		return err
+	}
+	if bz != nil {
+		return fmt.Errorf("block at height %d already saved", sh.Height)
+	}
+
+	blockMeta := &types.BlockMeta{
+		BlockID:   blockID,
+		BlockSize: -1,
+		Header:    *sh.Header,
+		NumTxs:    -1,
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set(blockMetaKey(sh.Height), mustEncode(blockMeta.ToProto())); err != nil {
+		return err
+	}
+
+	if err := batch.Set(blockHashKey(blockID.Hash), []byte(fmt.Sprintf("%d", sh.Height))); err != nil {
+		return err
+	}
+
+	if err := batch.Set(blockCommitKey(sh.Height), mustEncode(sh.Commit.ToProto())); err != nil {
+		return err
+	}
+
+	return batch.WriteSync()
+}
+
 //---------------------------------- KEY ENCODING -----------------------------------------
 
 // key prefixes
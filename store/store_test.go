@@ -443,6 +443,36 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestCompact(t *testing.T) {
+	config := cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	state, err := stateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	db, err := dbm.NewGoLevelDB("blockstore", config.DBDir())
+	require.NoError(t, err)
+	defer db.Close()
+	bs := NewBlockStore(db)
+
+	for h := int64(1); h <= 100; h++ {
+		block := makeBlock(h, state, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+
+	_, err = bs.PruneBlocks(50)
+	require.NoError(t, err)
+	require.NoError(t, bs.Compact(50))
+
+	// compaction must not affect retained blocks
+	assert.EqualValues(t, 50, bs.Base())
+	for h := int64(50); h <= 100; h++ {
+		require.NotNil(t, bs.LoadBlock(h))
+	}
+
+	// compaction is a no-op for other backends
+	require.NoError(t, NewBlockStore(dbm.NewMemDB()).Compact(50))
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
@@ -549,6 +579,31 @@ func TestSeenAndCanonicalCommit(t *testing.T) {
 
 }
 
+func TestSaveSignedHeader(t *testing.T) {
+	bs, _ := freshBlockStore()
+	block := makeBlock(2, state, makeTestCommit(1, tmtime.Now()))
+	commit := makeTestCommit(2, tmtime.Now())
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(2).Header()}
+	sh := &types.SignedHeader{Header: &block.Header, Commit: commit}
+
+	require.NoError(t, bs.SaveSignedHeader(sh, blockID))
+
+	meta := bs.LoadBlockMeta(2)
+	require.NotNil(t, meta)
+	require.Equal(t, blockID, meta.BlockID)
+	require.Equal(t, block.Hash(), meta.Header.Hash())
+	require.EqualValues(t, -1, meta.BlockSize)
+	require.EqualValues(t, -1, meta.NumTxs)
+
+	require.Equal(t, commit.Hash(), bs.LoadBlockCommit(2).Hash())
+
+	// the block itself was not saved
+	require.Nil(t, bs.LoadBlock(2))
+
+	// saving the same height again fails
+	require.Error(t, bs.SaveSignedHeader(sh, blockID))
+}
+
 func doFn(fn func() (interface{}, error)) (res interface{}, err error, panicErr error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -106,6 +106,11 @@ func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock
 	return resp
 }
 
+// FinalizeBlock implements ABCI.
+func (app *Application) FinalizeBlock(req abci.RequestFinalizeBlock) abci.ResponseFinalizeBlock {
+	return abci.ExecFinalizeBlock(app, req)
+}
+
 // Commit implements ABCI.
 func (app *Application) Commit() abci.ResponseCommit {
 	height, hash, err := app.state.Commit()
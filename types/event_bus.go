@@ -43,7 +43,13 @@ func NewEventBus() *EventBus {
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
-	pubsub := tmpubsub.NewServer(tmpubsub.BufferCapacity(cap))
+	return NewEventBusWithOptions(tmpubsub.BufferCapacity(cap))
+}
+
+// NewEventBusWithOptions returns a new event bus, passing the given options
+// to the underlying pubsub server.
+func NewEventBusWithOptions(options ...tmpubsub.Option) *EventBus {
+	pubsub := tmpubsub.NewServer(options...)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *service.NewBaseService(nil, "EventBus", b)
 	return b
@@ -81,6 +87,18 @@ func (b *EventBus) Subscribe(
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeWithPolicy subscribes with the given overflow policy, which
+// determines what happens when the subscription's buffer is full.
+func (b *EventBus) SubscribeWithPolicy(
+	ctx context.Context,
+	subscriber string,
+	query tmpubsub.Query,
+	outCapacity int,
+	policy tmpubsub.OverflowPolicy,
+) (Subscription, error) {
+	return b.pubsub.SubscribeWithPolicy(ctx, subscriber, query, outCapacity, policy)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(
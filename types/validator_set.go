@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/klyed/tendermint/crypto"
 	"github.com/klyed/tendermint/crypto/batch"
 	"github.com/klyed/tendermint/crypto/merkle"
 	tmmath "github.com/klyed/tendermint/libs/math"
@@ -679,56 +680,13 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 			blockID, commit.BlockID)
 	}
 
-	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
-	var (
-		talliedVotingPower int64 = 0
-		err                error
-		cacheSignBytes     = make(map[string][]byte, len(commit.Signatures))
-	)
-
-	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
-	if ok && len(commit.Signatures) > 1 {
-		for idx, commitSig := range commit.Signatures {
-			if commitSig.Absent() {
-				continue // OK, some signatures can be absent.
-			}
-
-			// The vals and commit have a 1-to-1 correspondance.
-			// This means we don't need the validator address or to do any lookup.
-			val := vals.Validators[idx]
-
-			// Validate signature.
-			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-			// cache the signBytes in case batch verification fails
-			cacheSignBytes[string(val.PubKey.Bytes())] = voteSignBytes
-			// add the key, sig and message to the verifier
-			if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-				return err
-			}
-
-			// Good!
-			if commitSig.ForBlock() {
-				talliedVotingPower += val.VotingPower
-			}
-		}
-		if !bv.Verify() {
-			talliedVotingPower, err = verifyCommitSingle(chainID, vals, commit, cacheSignBytes)
-			if err != nil {
-				return err
-			}
-		}
-	} else {
-		talliedVotingPower, err = verifyCommitSingle(chainID, vals, commit, cacheSignBytes)
-		if err != nil {
-			return err
-		}
-	}
-
-	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
-		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
-	}
+	// check all signatures except the absent ones, but only count those for
+	// the block
+	ignore := func(c CommitSig) bool { return c.Absent() }
+	count := func(c CommitSig) bool { return c.ForBlock() }
 
-	return nil
+	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	return verifyCommit(chainID, vals, commit, votingPowerNeeded, ignore, count, true, true)
 }
 
 // LIGHT CLIENT VERIFICATION METHODS
@@ -756,61 +714,12 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 			blockID, commit.BlockID)
 	}
 
-	talliedVotingPower := int64(0)
-	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
-	cacheSignBytes := make(map[string][]byte, len(commit.Signatures))
-	var err error
-
-	// need to check if batch verification is supported
-	// if batch is supported and the there are more than x key(s) run batch, otherwise run single.
-	// if batch verification fails reset tally votes to 0 and single verify until we have 2/3+
-	// check if the key supports batch verification
-	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
-	if ok && len(commit.Signatures) > 1 {
-		for idx, commitSig := range commit.Signatures {
-			// No need to verify absent or nil votes.
-			if !commitSig.ForBlock() {
-				continue
-			}
-
-			// The vals and commit have a 1-to-1 correspondance.
-			// This means we don't need the validator address or to do any lookup.
-			val := vals.Validators[idx]
-			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-			cacheSignBytes[string(val.PubKey.Bytes())] = voteSignBytes
-			// add the key, sig and message to the verifier
-			if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-				return err
-			}
-
-			talliedVotingPower += val.VotingPower
-
-			// return as soon as +2/3 of the signatures are verified
-			if talliedVotingPower > votingPowerNeeded {
-				return nil
-			}
-		}
+	// No need to verify absent or nil votes.
+	ignore := func(c CommitSig) bool { return !c.ForBlock() }
+	count := func(c CommitSig) bool { return true }
 
-		if !bv.Verify() {
-			// reset talliedVotingPower to verify enough signatures to meet the 2/3+ threshold
-			talliedVotingPower, err = verifyCommitLightSingle(
-				chainID, vals, commit, votingPowerNeeded, cacheSignBytes)
-			if err != nil {
-				return err
-			} else if talliedVotingPower > votingPowerNeeded {
-				return nil
-			}
-		}
-	} else {
-		talliedVotingPower, err = verifyCommitLightSingle(
-			chainID, vals, commit, votingPowerNeeded, cacheSignBytes)
-		if err != nil {
-			return err
-		} else if talliedVotingPower > votingPowerNeeded {
-			return nil
-		}
-	}
-	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	return verifyCommit(chainID, vals, commit, votingPowerNeeded, ignore, count, false, true)
 }
 
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
@@ -830,13 +739,6 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 		return errors.New("nil commit")
 	}
 
-	var (
-		talliedVotingPower int64
-		seenVals           = make(map[int32]int, len(commit.Signatures)) // validator index -> commit index
-		err                error
-		cacheSignBytes     = make(map[string][]byte, len(commit.Signatures))
-	)
-
 	// Safely calculate voting power needed.
 	totalVotingPowerMulByNumerator, overflow := safeMul(vals.TotalVotingPower(), int64(trustLevel.Numerator))
 	if overflow {
@@ -844,62 +746,13 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 	}
 	votingPowerNeeded := totalVotingPowerMulByNumerator / int64(trustLevel.Denominator)
 
-	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
-	if ok && len(commit.Signatures) > 1 {
-		for idx, commitSig := range commit.Signatures {
-			// No need to verify absent or nil votes.
-			if !commitSig.ForBlock() {
-				continue
-			}
+	// No need to verify absent or nil votes.
+	ignore := func(c CommitSig) bool { return !c.ForBlock() }
+	count := func(c CommitSig) bool { return true }
 
-			// We don't know the validators that committed this block, so we have to
-			// check for each vote if its validator is already known.
-			valIdx, val := vals.GetByAddress(commitSig.ValidatorAddress)
-
-			if val != nil {
-				// check for double vote of validator on the same commit
-				if firstIndex, ok := seenVals[valIdx]; ok {
-					secondIndex := idx
-					return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
-				}
-				seenVals[valIdx] = idx
-
-				// Validate signature.
-				voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-				// cache the signed bytes in case we fail verification
-				cacheSignBytes[string(val.PubKey.Bytes())] = voteSignBytes
-				// if batch verification is supported add the key, sig and message to the verifier
-				if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-					return err
-				}
-
-				talliedVotingPower += val.VotingPower
-
-				if talliedVotingPower > votingPowerNeeded {
-					return nil
-				}
-			}
-		}
-		if !bv.Verify() {
-			talliedVotingPower, err = verifyCommitLightTrustingSingle(
-				chainID, vals, commit, votingPowerNeeded, cacheSignBytes)
-			if err != nil {
-				return err
-			} else if talliedVotingPower > votingPowerNeeded {
-				return nil
-			}
-		}
-	} else {
-		talliedVotingPower, err = verifyCommitLightTrustingSingle(
-			chainID, vals, commit, votingPowerNeeded, cacheSignBytes)
-		if err != nil {
-			return err
-		} else if talliedVotingPower > votingPowerNeeded {
-			return nil
-		}
-	}
-
-	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	// We don't know the validators that committed this block, so they're
+	// looked up by address.
+	return verifyCommit(chainID, vals, commit, votingPowerNeeded, ignore, count, false, false)
 }
 
 // findPreviousProposer reverses the compare proposer priority function to find the validator
@@ -1175,126 +1028,186 @@ func safeMul(a, b int64) (int64, bool) {
 	return a * b, false
 }
 
-// verifyCommitLightTrustingSingle single verifies commits
-// If a key does not support batch verification, or batch verification fails this will be used
-// This method is used for light clients, it only checks 2/3+ of the signatures
-func verifyCommitLightTrustingSingle(
-	chainID string, vals *ValidatorSet, commit *Commit, votingPowerNeeded int64,
-	cachedVals map[string][]byte) (int64, error) {
+// batchVerifyThreshold is the minimum number of signatures in a commit for
+// batch verification to be used.
+const batchVerifyThreshold = 2
+
+// verifyCommit verifies the signatures of commit by vals, in a batch if the
+// commit has enough signatures and the proposer's key type supports batch
+// verification, else one by one. It returns an error unless the signatures
+// counted add up to more than votingPowerNeeded.
+//
+// Signatures for which ignoreSig returns true aren't verified, and only the
+// voting power of the validators for which countSig returns true is counted.
+// If countAllSignatures is false, the verification stops as soon as enough
+// voting power is counted. If lookUpByIndex is true, the validators and the
+// commit signatures have a 1-to-1 correspondence, else the validators are
+// looked up by address and the signatures of unknown validators are skipped.
+func verifyCommit(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	countAllSignatures bool,
+	lookUpByIndex bool,
+) error {
+	// an empty set has no proposer, nor signatures to verify
+	if len(commit.Signatures) >= batchVerifyThreshold && vals.Size() > 0 {
+		if bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey); ok {
+			return verifyCommitBatch(chainID, vals, commit, votingPowerNeeded, bv,
+				ignoreSig, countSig, countAllSignatures, lookUpByIndex)
+		}
+	}
+	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+		ignoreSig, countSig, countAllSignatures, lookUpByIndex)
+}
+
+// commitValidator returns the validator of the signature at index idx of the
+// commit, or nil if it's not in the set. seenVals maps the validators looked up
+// by address to the index of their signature, to catch double votes.
+func commitValidator(vals *ValidatorSet, commit *Commit, idx int, lookUpByIndex bool,
+	seenVals map[int32]int) (*Validator, error) {
+	// If the vals and commit have a 1-to-1 correspondence, we don't need the
+	// validator address or to do any lookup.
+	if lookUpByIndex {
+		return vals.Validators[idx], nil
+	}
+
+	valIdx, val := vals.GetByAddress(commit.Signatures[idx].ValidatorAddress)
+	if val == nil {
+		return nil, nil
+	}
+	// check for double vote of validator on the same commit
+	if firstIndex, ok := seenVals[valIdx]; ok {
+		return nil, fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, idx)
+	}
+	seenVals[valIdx] = idx
+	return val, nil
+}
+
+// verifyCommitBatch verifies the signatures in a batch, with bv. If a
+// validator's key type differs from the one of the batch verifier, or the
+// batch verification fails, it falls back to verifyCommitSingle, which finds
+// the invalid signature.
+func verifyCommitBatch(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	bv crypto.BatchVerifier,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	countAllSignatures bool,
+	lookUpByIndex bool,
+) error {
 	var (
+		keyType                  = vals.GetProposer().PubKey.Type()
 		seenVals                 = make(map[int32]int, len(commit.Signatures))
 		talliedVotingPower int64 = 0
 	)
+	verifySingle := func() error {
+		return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+			ignoreSig, countSig, countAllSignatures, lookUpByIndex)
+	}
+
 	for idx, commitSig := range commit.Signatures {
-		// No need to verify absent or nil votes.
-		if !commitSig.ForBlock() {
+		if ignoreSig(commitSig) {
 			continue
 		}
 
-		var voteSignBytes []byte
-
-		// We don't know the validators that committed this block, so we have to
-		// check for each vote if its validator is already known.
-		valIdx, val := vals.GetByAddress(commitSig.ValidatorAddress)
-
-		if val != nil {
-			// check for double vote of validator on the same commit
-			if firstIndex, ok := seenVals[valIdx]; ok {
-				secondIndex := idx
-				return 0, fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
-			}
-			seenVals[valIdx] = idx
-
-			// Validate signature.
-			// voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-			if val, ok := cachedVals[string(val.PubKey.Bytes())]; !ok {
-				voteSignBytes = commit.VoteSignBytes(chainID, int32(idx))
-			} else {
-				voteSignBytes = val
-			}
-			if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-				return 0, fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-			}
-
-			talliedVotingPower += val.VotingPower
-
-			if talliedVotingPower > votingPowerNeeded {
-				return talliedVotingPower, nil
-			}
+		val, err := commitValidator(vals, commit, idx, lookUpByIndex, seenVals)
+		if err != nil {
+			return err
 		}
-	}
-	return talliedVotingPower, nil
-}
-
-// verifyCommitLightSingle single verifies commits.
-// If a key does not support batch verification, or batch verification fails this will be used
-// This method is used for light client and block sync verification, it will only check 2/3+ signatures
-func verifyCommitLightSingle(
-	chainID string, vals *ValidatorSet, commit *Commit, votingPowerNeeded int64,
-	cachedVals map[string][]byte) (int64, error) {
-	var talliedVotingPower int64 = 0
-	for idx, commitSig := range commit.Signatures {
-		// No need to verify absent or nil votes.
-		if !commitSig.ForBlock() {
+		if val == nil {
 			continue
 		}
 
-		// The vals and commit have a 1-to-1 correspondance.
-		// This means we don't need the validator address or to do any lookup.
-		var voteSignBytes []byte
-		val := vals.Validators[idx]
-
-		// Check if we have the validator in the cache
-		if val, ok := cachedVals[string(val.PubKey.Bytes())]; !ok {
-			voteSignBytes = commit.VoteSignBytes(chainID, int32(idx))
-		} else {
-			voteSignBytes = val
+		// All the keys of a batch must be of the same type. The signatures of
+		// validators with other key types are only verified one by one.
+		if val.PubKey.Type() != keyType {
+			return verifySingle()
 		}
-		// Validate signature.
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return 0, fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		// A malformed signature is reported by the single verification.
+		if err := bv.Add(val.PubKey, commit.VoteSignBytes(chainID, int32(idx)), commitSig.Signature); err != nil {
+			return verifySingle()
 		}
 
-		talliedVotingPower += val.VotingPower
+		if countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
 
-		// return as soon as +2/3 of the signatures are verified
-		if talliedVotingPower > votingPowerNeeded {
-			return talliedVotingPower, nil
+		// stop adding signatures as soon as +2/3 of the voting power is in the
+		// batch, unless all of them must be checked
+		if !countAllSignatures && talliedVotingPower > votingPowerNeeded {
+			break
 		}
 	}
-	return talliedVotingPower, nil
+
+	// no need to verify the batch if the signatures don't have enough voting
+	// power anyway
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	if !bv.Verify() {
+		// It's unknown which signature is invalid.
+		return verifySingle()
+	}
+	return nil
 }
 
-// verifyCommitSingle single verifies commits.
-// If a key does not support batch verification, or batch verification fails this will be used
-// This method is used to check all the signatures included in a commit.
-// It is used in consensus for validating a block LastCommit.
-func verifyCommitSingle(chainID string, vals *ValidatorSet, commit *Commit,
-	cachedVals map[string][]byte) (int64, error) {
-	var talliedVotingPower int64 = 0
+// verifyCommitSingle verifies the signatures one by one. It is used if the
+// keys don't support batch verification, or the batch verification failed, to
+// find the invalid signature. See verifyCommit for the arguments.
+func verifyCommitSingle(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	countAllSignatures bool,
+	lookUpByIndex bool,
+) error {
+	var (
+		seenVals                 = make(map[int32]int, len(commit.Signatures))
+		talliedVotingPower int64 = 0
+	)
 	for idx, commitSig := range commit.Signatures {
-		if commitSig.Absent() {
-			continue // OK, some signatures can be absent.
+		if ignoreSig(commitSig) {
+			continue
 		}
 
-		var voteSignBytes []byte
-		val := vals.Validators[idx]
-
-		// Check if we have the validator in the cache
-		if val, ok := cachedVals[string(val.PubKey.Bytes())]; !ok {
-			voteSignBytes = commit.VoteSignBytes(chainID, int32(idx))
-		} else {
-			voteSignBytes = val
+		val, err := commitValidator(vals, commit, idx, lookUpByIndex, seenVals)
+		if err != nil {
+			return err
+		}
+		if val == nil {
+			continue
 		}
 
+		// Validate signature.
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return talliedVotingPower, fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
 		}
 
 		// Good!
-		if commitSig.ForBlock() {
+		if countSig(commitSig) {
 			talliedVotingPower += val.VotingPower
 		}
+
+		// return as soon as +2/3 of the signatures are verified
+		if !countAllSignatures && talliedVotingPower > votingPowerNeeded {
+			return nil
+		}
 	}
-	return talliedVotingPower, nil
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+	return nil
 }
@@ -15,7 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/klyed/tendermint/crypto"
+	"github.com/klyed/tendermint/crypto/batch"
 	"github.com/klyed/tendermint/crypto/ed25519"
+	"github.com/klyed/tendermint/crypto/secp256k1"
 	tmmath "github.com/klyed/tendermint/libs/math"
 	tmrand "github.com/klyed/tendermint/libs/rand"
 	tmproto "github.com/klyed/tendermint/proto/tendermint/types"
@@ -813,6 +815,89 @@ func TestValidatorSet_VerifyCommitLightTrusting_ReturnsAsSoonAsTrustLevelOfVotin
 	assert.NoError(t, err)
 }
 
+func TestValidatorSet_VerifyCommitLight_ChecksBatchedSignatures(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// malleate 1st signature, which is part of the signatures needed
+	vote := voteSet.GetByIndex(0)
+	v := vote.ToProto()
+	err = vals[0].SignVote(context.Background(), "CentaurusA", v)
+	require.NoError(t, err)
+	vote.Signature = v.Signature
+	commit.Signatures[0] = vote.CommitSig()
+
+	err = valSet.VerifyCommitLight(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#0)")
+	}
+
+	err = valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "wrong signature (#0)")
+	}
+}
+
+func TestValidatorSet_VerifyCommit_MixedKeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	// the ed25519 validators have more voting power, so that the proposer's
+	// key supports batch verification, but not the secp256k1 one
+	privKeys := []crypto.PrivKey{
+		ed25519.GenPrivKey(), ed25519.GenPrivKey(), ed25519.GenPrivKey(), secp256k1.GenPrivKey(),
+	}
+	valz := make([]*Validator, len(privKeys))
+	for i, privKey := range privKeys {
+		power := int64(20)
+		if privKey.Type() != ed25519.KeyType {
+			power = 10
+		}
+		valz[i] = NewValidator(privKey.PubKey(), power)
+	}
+	valSet := NewValidatorSet(valz)
+	require.Equal(t, ed25519.KeyType, valSet.GetProposer().PubKey.Type())
+
+	// order the signers like the validator set
+	vals := make([]PrivValidator, len(privKeys))
+	for _, privKey := range privKeys {
+		idx, _ := valSet.GetByAddress(privKey.PubKey().Address())
+		vals[idx] = NewMockPVWithParams(privKey, false, false)
+	}
+
+	voteSet := NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	assert.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+	assert.NoError(t, valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// malleate the secp256k1 signature, which is last in the set
+	secpIdx := int32(len(vals) - 1)
+	vote := voteSet.GetByIndex(secpIdx)
+	v := vote.ToProto()
+	err = vals[secpIdx].SignVote(context.Background(), "CentaurusA", v)
+	require.NoError(t, err)
+	vote.Signature = v.Signature
+	commit.Signatures[secpIdx] = vote.CommitSig()
+
+	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("wrong signature (#%d)", secpIdx))
+	}
+}
+
 func TestEmptySet(t *testing.T) {
 
 	var valList []*Validator
@@ -1782,3 +1867,49 @@ func BenchmarkValidatorSet_VerifyCommitLightTrusting_Ed25519(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkValidatorSet_VerifyCommit150 compares the batch and the single
+// verification of a commit signed by 150 validators, checking all the
+// signatures like VerifyCommit, or 2/3+ of them like VerifyCommitLight.
+func BenchmarkValidatorSet_VerifyCommit150(b *testing.B) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 150, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(b, err)
+	votingPowerNeeded := valSet.TotalVotingPower() * 2 / 3
+
+	testCases := []struct {
+		name               string
+		ignoreSig          func(CommitSig) bool
+		countSig           func(CommitSig) bool
+		countAllSignatures bool
+	}{
+		{"all", func(c CommitSig) bool { return c.Absent() }, func(c CommitSig) bool { return c.ForBlock() }, true},
+		{"light", func(c CommitSig) bool { return !c.ForBlock() }, func(c CommitSig) bool { return true }, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		b.Run(tc.name+"/batch", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bv, ok := batch.CreateBatchVerifier(valSet.GetProposer().PubKey)
+				require.True(b, ok)
+				err := verifyCommitBatch(chainID, valSet, commit, votingPowerNeeded, bv,
+					tc.ignoreSig, tc.countSig, tc.countAllSignatures, true)
+				require.NoError(b, err)
+			}
+		})
+		b.Run(tc.name+"/single", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				err := verifyCommitSingle(chainID, valSet, commit, votingPowerNeeded,
+					tc.ignoreSig, tc.countSig, tc.countAllSignatures, true)
+				require.NoError(b, err)
+			}
+		})
+	}
+}